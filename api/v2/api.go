@@ -71,6 +71,8 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup.POST("/:changefeed_id/merge_table", keyspaceCheckerMiddleware, authenticateMiddleware, api.MergeTable)
 	changefeedGroup.GET("/:changefeed_id/get_dispatcher_count", keyspaceCheckerMiddleware, api.getDispatcherCount)
 	changefeedGroup.GET("/:changefeed_id/tables", keyspaceCheckerMiddleware, api.ListTables)
+	changefeedGroup.GET("/:changefeed_id/dead_letter_count", coordinatorMiddleware, keyspaceCheckerMiddleware, api.getDeadLetterCount)
+	changefeedGroup.GET("/:changefeed_id/worker_count", keyspaceCheckerMiddleware, api.getWorkerCount)
 
 	// capture apis
 	captureGroup := v2.Group("/captures")
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/ticdc/api/middleware"
 	"github.com/pingcap/ticdc/downstreamadapter/sink"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columntransformer"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/eventrouter"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
 	"github.com/pingcap/ticdc/logservice/schemastore"
@@ -41,9 +42,11 @@ import (
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/keyspace"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/ticdc/pkg/version"
+	"github.com/pingcap/ticdc/server/watcher"
 	tidbkv "github.com/pingcap/tidb/pkg/kv"
 	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
@@ -1343,6 +1346,92 @@ func (h *OpenAPIV2) getDispatcherCount(c *gin.Context) {
 	c.JSON(http.StatusOK, &DispatcherCount{Count: number})
 }
 
+// getDeadLetterCount returns the count of the rows written to the dead-letter destination
+// by the changefeed. The count is read from the dead-letter table or files, so it covers all
+// the captures of the changefeed and is kept across the restarts.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/dead_letter_count
+func (h *OpenAPIV2) getDeadLetterCount(c *gin.Context) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), GetKeyspaceValueWithDefault(c))
+	if err := common.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	co, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ok, err := isBootstrapped(co)
+	if err != nil || !ok {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, _, err := co.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	count, err := mysql.QueryDeadLetterRowCount(c, cfInfo.ChangefeedID, cfInfo.ToChangefeedConfig())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &DeadLetterCount{Count: count})
+}
+
+// getWorkerCount returns the count of the active mysql sink workers of the changefeed on
+// a capture, which is adjusted at runtime if the adaptive concurrency is enabled. The
+// capture handling the request is used if the capture_id is not specified. The count
+// across all captures is the sum of the txn_active_worker_count metric of the changefeed.
+// Usage:
+// curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/worker_count?capture_id=xxx
+func (h *OpenAPIV2) getWorkerCount(c *gin.Context) {
+	h.getCaptureCount(c, mysql.GetActiveWorkerCount)
+}
+
+// getCaptureCount validates the changefeed and returns the count of the changefeed on a capture
+// got by getCount, the request is forwarded to the capture if the capture_id is specified.
+func (h *OpenAPIV2) getCaptureCount(c *gin.Context, getCount func(common.ChangeFeedDisplayName) int64) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), GetKeyspaceValueWithDefault(c))
+	if err := common.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+
+	if _, err := getChangeFeed(c.Request.Host, changefeedDisplayName.Keyspace, changefeedDisplayName.Name); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	selfInfo, err := h.server.SelfInfo()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	captureID := c.Query(api.APIOpVarCaptureID)
+	if captureID != "" && captureID != selfInfo.ID.String() {
+		nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
+		target, ok := nodeManager.GetAliveNodes()[node.ID(captureID)]
+		if !ok {
+			_ = c.Error(errors.ErrCaptureNotExist.GenWithStackByArgs(captureID))
+			return
+		}
+		middleware.ForwardToServer(c, selfInfo.ID, target.AdvertiseAddr)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, &CaptureCount{
+		CaptureID: selfInfo.ID.String(),
+		Count:     getCount(changefeedDisplayName),
+	})
+}
+
 // status returns the status of a changefeed.
 // Usage:
 // curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/status
@@ -1508,11 +1597,20 @@ func getVerifiedTables(
 	if err != nil {
 		return nil, nil, err
 	}
+
+	transformers, err := columntransformer.New(replicaConfig.Sink)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = transformers.VerifyTables(tableInfos)
+	if err != nil {
+		return nil, nil, err
+	}
 	if !config.IsMQScheme(scheme) {
 		return ineligibleTables, eligibleTables, nil
 	}
 
-	eventRouter, err := eventrouter.NewEventRouter(replicaConfig.Sink, topic, config.IsPulsarScheme(protocol.String()), protocol == config.ProtocolAvro)
+	eventRouter, err := eventrouter.NewEventRouter(replicaConfig.Sink, topic, config.IsPulsarScheme(protocol.String()), protocol.IsSchemaRegistryBased())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -32,16 +32,42 @@ import (
 )
 
 func getChangeFeed(host, keyspaceName, cfName string) (ChangeFeedInfo, error) {
+	uri := fmt.Sprintf("/api/v2/changefeeds/%s?keyspace=%s", cfName, url.QueryEscape(keyspaceName))
+	log.Info("Send request to coordinator to get changefeed info",
+		zap.String("host", host),
+		zap.String("uri", uri),
+	)
+	body, statusCode, err := sendGetRequest(host, uri)
+	if err != nil {
+		log.Error("failed to get changefeed", zap.Error(err), zap.String("uri", uri))
+		return ChangeFeedInfo{}, err
+	}
+	if statusCode != http.StatusOK {
+		log.Error("failed to get changefeed", zap.Int("statusCode", statusCode),
+			zap.ByteString("body", body), zap.String("uri", uri))
+		return ChangeFeedInfo{}, errors.Errorf("failed to get changefeed %s, status: %d, body: %s", cfName, statusCode, body)
+	}
+
+	var cfInfo ChangeFeedInfo
+	if err := json.Unmarshal(body, &cfInfo); err != nil {
+		log.Error("failed to unmarshal changefeed response", zap.Error(err), zap.String("uri", uri))
+		return ChangeFeedInfo{}, err
+	}
+
+	return cfInfo, nil
+}
+
+// sendGetRequest sends a GET request of uri to host and returns the body and the status code of the response.
+func sendGetRequest(host, uri string) ([]byte, int, error) {
 	security := config.GetGlobalServerConfig().Security
 
-	uri := fmt.Sprintf("/api/v2/changefeeds/%s?keyspace=%s", cfName, url.QueryEscape(keyspaceName))
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(
 		ctx, "GET", uri, nil)
 	if err != nil {
-		return ChangeFeedInfo{}, err
+		return nil, 0, err
 	}
 	req.URL.Host = host
 
@@ -53,35 +79,20 @@ func getChangeFeed(host, keyspaceName, cfName string) (ChangeFeedInfo, error) {
 
 	client, err := httputil.NewClient(security)
 	if err != nil {
-		return ChangeFeedInfo{}, err
+		return nil, 0, err
 	}
 
-	log.Info("Send request to coordinator to get changefeed info",
-		zap.String("host", host),
-		zap.String("uri", uri),
-		zap.String("schema", req.URL.Scheme),
-	)
-
 	resp, err := client.Do(req)
 	if err != nil {
-		log.Error("failed to get changefeed", zap.Error(err), zap.String("uri", uri))
-		return ChangeFeedInfo{}, err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Error("failed to read changefeed response", zap.Error(err), zap.String("uri", uri))
-		return ChangeFeedInfo{}, err
+		return nil, 0, err
 	}
-
-	var cfInfo ChangeFeedInfo
-	if err := json.Unmarshal(body, &cfInfo); err != nil {
-		log.Error("failed to unmarshal changefeed response", zap.Error(err), zap.String("uri", uri))
-		return ChangeFeedInfo{}, err
-	}
-
-	return cfInfo, nil
+	return body, resp.StatusCode, nil
 }
 
 // isFromV1API checks if the request comes from TiCDC API v1
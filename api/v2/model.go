@@ -245,6 +245,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			Rules:            c.Filter.Rules,
 			IgnoreTxnStartTs: c.Filter.IgnoreTxnStartTs,
 			EventFilters:     efs,
+			OnlineDDL:        c.Filter.OnlineDDL,
 		}
 	}
 	if c.Consistent != nil {
@@ -258,6 +259,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			Storage:               c.Consistent.Storage,
 			UseFileBackend:        c.Consistent.UseFileBackend,
 			Compression:           c.Consistent.Compression,
+			CompressionLevel:      c.Consistent.CompressionLevel,
 			FlushConcurrency:      c.Consistent.FlushConcurrency,
 		}
 		if c.Consistent.MemoryUsage != nil {
@@ -285,6 +287,26 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				Columns: selector.Columns,
 			})
 		}
+		var columnTransformers []*config.ColumnTransformer
+		for _, transformer := range c.Sink.ColumnTransformers {
+			columnTransformers = append(columnTransformers, &config.ColumnTransformer{
+				Matcher: transformer.Matcher,
+				Columns: transformer.Columns,
+				Action:  transformer.Action,
+				Salt:    transformer.Salt,
+				Length:  transformer.Length,
+				Value:   transformer.Value,
+			})
+		}
+		var metadataColumns *config.MetadataColumnsConfig
+		if c.Sink.MetadataColumns != nil {
+			metadataColumns = &config.MetadataColumnsConfig{
+				CommitTs:         c.Sink.MetadataColumns.CommitTs,
+				Op:               c.Sink.MetadataColumns.Op,
+				SourceChangefeed: c.Sink.MetadataColumns.SourceChangefeed,
+				AppendOnly:       c.Sink.MetadataColumns.AppendOnly,
+			}
+		}
 		var csvConfig *config.CSVConfig
 		if c.Sink.CSVConfig != nil {
 			csvConfig = &config.CSVConfig{
@@ -410,6 +432,14 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		}
 		var mysqlConfig *config.MySQLConfig
 		if c.Sink.MySQLConfig != nil {
+			var routingRules []*config.RoutingRule
+			for _, rule := range c.Sink.MySQLConfig.RoutingRules {
+				routingRules = append(routingRules, &config.RoutingRule{
+					Matcher:      rule.Matcher,
+					TargetSchema: rule.TargetSchema,
+					TargetTable:  rule.TargetTable,
+				})
+			}
 			mysqlConfig = &config.MySQLConfig{
 				WorkerCount:                  c.Sink.MySQLConfig.WorkerCount,
 				MaxTxnRow:                    c.Sink.MySQLConfig.MaxTxnRow,
@@ -426,6 +456,36 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				EnableBatchDML:               c.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         c.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: c.Sink.MySQLConfig.EnableCachePreparedStatement,
+				RoutingRules:                 routingRules,
+				SharedTargetDDLPolicy:        c.Sink.MySQLConfig.SharedTargetDDLPolicy,
+				ErrorPolicy:                  c.Sink.MySQLConfig.ErrorPolicy,
+			}
+			if c.Sink.MySQLConfig.DeadLetter != nil {
+				mysqlConfig.DeadLetter = &config.DeadLetterConfig{
+					Table:      c.Sink.MySQLConfig.DeadLetter.Table,
+					StorageURI: c.Sink.MySQLConfig.DeadLetter.StorageURI,
+				}
+			}
+			if c.Sink.MySQLConfig.ConflictResolution != nil {
+				mysqlConfig.ConflictResolution = &config.ConflictResolutionConfig{
+					Policy:          c.Sink.MySQLConfig.ConflictResolution.Policy,
+					CompareBy:       c.Sink.MySQLConfig.ConflictResolution.CompareBy,
+					TimestampColumn: c.Sink.MySQLConfig.ConflictResolution.TimestampColumn,
+				}
+			}
+			if c.Sink.MySQLConfig.CatchUp != nil {
+				mysqlConfig.CatchUp = &config.CatchUpConfig{
+					LagThreshold: c.Sink.MySQLConfig.CatchUp.LagThreshold,
+					MaxTxnRow:    c.Sink.MySQLConfig.CatchUp.MaxTxnRow,
+				}
+			}
+			if c.Sink.MySQLConfig.AdaptiveConcurrency != nil {
+				mysqlConfig.AdaptiveConcurrency = &config.AdaptiveConcurrencyConfig{
+					Enable:         c.Sink.MySQLConfig.AdaptiveConcurrency.Enable,
+					MinWorkerCount: c.Sink.MySQLConfig.AdaptiveConcurrency.MinWorkerCount,
+					MaxWorkerCount: c.Sink.MySQLConfig.AdaptiveConcurrency.MaxWorkerCount,
+					TargetLatency:  c.Sink.MySQLConfig.AdaptiveConcurrency.TargetLatency,
+				}
 			}
 		}
 		var cloudStorageConfig *config.CloudStorageConfig
@@ -439,6 +499,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				FileCleanupCronSpec:  c.Sink.CloudStorageConfig.FileCleanupCronSpec,
 				FlushConcurrency:     c.Sink.CloudStorageConfig.FlushConcurrency,
 				OutputRawChangeEvent: c.Sink.CloudStorageConfig.OutputRawChangeEvent,
+				Compression:          c.Sink.CloudStorageConfig.Compression,
+				CompressionLevel:     c.Sink.CloudStorageConfig.CompressionLevel,
 			}
 		}
 		var debeziumConfig *config.DebeziumConfig
@@ -459,6 +521,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			Protocol:                         c.Sink.Protocol,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
+			ColumnTransformers:               columnTransformers,
+			MetadataColumns:                  metadataColumns,
 			SchemaRegistry:                   c.Sink.SchemaRegistry,
 			EncoderConcurrency:               c.Sink.EncoderConcurrency,
 			Terminator:                       c.Sink.Terminator,
@@ -575,6 +639,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			Rules:            cloned.Filter.Rules,
 			IgnoreTxnStartTs: cloned.Filter.IgnoreTxnStartTs,
 			EventFilters:     efs,
+			OnlineDDL:        cloned.Filter.OnlineDDL,
 		}
 	}
 	if cloned.Sink != nil {
@@ -595,6 +660,26 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				Columns: selector.Columns,
 			})
 		}
+		var columnTransformers []*ColumnTransformer
+		for _, transformer := range cloned.Sink.ColumnTransformers {
+			columnTransformers = append(columnTransformers, &ColumnTransformer{
+				Matcher: transformer.Matcher,
+				Columns: transformer.Columns,
+				Action:  transformer.Action,
+				Salt:    transformer.Salt,
+				Length:  transformer.Length,
+				Value:   transformer.Value,
+			})
+		}
+		var metadataColumns *MetadataColumnsConfig
+		if cloned.Sink.MetadataColumns != nil {
+			metadataColumns = &MetadataColumnsConfig{
+				CommitTs:         cloned.Sink.MetadataColumns.CommitTs,
+				Op:               cloned.Sink.MetadataColumns.Op,
+				SourceChangefeed: cloned.Sink.MetadataColumns.SourceChangefeed,
+				AppendOnly:       cloned.Sink.MetadataColumns.AppendOnly,
+			}
+		}
 		var csvConfig *CSVConfig
 		if cloned.Sink.CSVConfig != nil {
 			csvConfig = &CSVConfig{
@@ -687,6 +772,14 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		}
 		var mysqlConfig *MySQLConfig
 		if cloned.Sink.MySQLConfig != nil {
+			var routingRules []*RoutingRule
+			for _, rule := range cloned.Sink.MySQLConfig.RoutingRules {
+				routingRules = append(routingRules, &RoutingRule{
+					Matcher:      rule.Matcher,
+					TargetSchema: rule.TargetSchema,
+					TargetTable:  rule.TargetTable,
+				})
+			}
 			mysqlConfig = &MySQLConfig{
 				WorkerCount:                  cloned.Sink.MySQLConfig.WorkerCount,
 				MaxTxnRow:                    cloned.Sink.MySQLConfig.MaxTxnRow,
@@ -703,6 +796,36 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				EnableBatchDML:               cloned.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         cloned.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: cloned.Sink.MySQLConfig.EnableCachePreparedStatement,
+				RoutingRules:                 routingRules,
+				SharedTargetDDLPolicy:        cloned.Sink.MySQLConfig.SharedTargetDDLPolicy,
+				ErrorPolicy:                  cloned.Sink.MySQLConfig.ErrorPolicy,
+			}
+			if cloned.Sink.MySQLConfig.DeadLetter != nil {
+				mysqlConfig.DeadLetter = &DeadLetterConfig{
+					Table:      cloned.Sink.MySQLConfig.DeadLetter.Table,
+					StorageURI: cloned.Sink.MySQLConfig.DeadLetter.StorageURI,
+				}
+			}
+			if cloned.Sink.MySQLConfig.ConflictResolution != nil {
+				mysqlConfig.ConflictResolution = &ConflictResolutionConfig{
+					Policy:          cloned.Sink.MySQLConfig.ConflictResolution.Policy,
+					CompareBy:       cloned.Sink.MySQLConfig.ConflictResolution.CompareBy,
+					TimestampColumn: cloned.Sink.MySQLConfig.ConflictResolution.TimestampColumn,
+				}
+			}
+			if cloned.Sink.MySQLConfig.CatchUp != nil {
+				mysqlConfig.CatchUp = &CatchUpConfig{
+					LagThreshold: cloned.Sink.MySQLConfig.CatchUp.LagThreshold,
+					MaxTxnRow:    cloned.Sink.MySQLConfig.CatchUp.MaxTxnRow,
+				}
+			}
+			if cloned.Sink.MySQLConfig.AdaptiveConcurrency != nil {
+				mysqlConfig.AdaptiveConcurrency = &AdaptiveConcurrencyConfig{
+					Enable:         cloned.Sink.MySQLConfig.AdaptiveConcurrency.Enable,
+					MinWorkerCount: cloned.Sink.MySQLConfig.AdaptiveConcurrency.MinWorkerCount,
+					MaxWorkerCount: cloned.Sink.MySQLConfig.AdaptiveConcurrency.MaxWorkerCount,
+					TargetLatency:  cloned.Sink.MySQLConfig.AdaptiveConcurrency.TargetLatency,
+				}
 			}
 		}
 		var pulsarConfig *PulsarConfig
@@ -748,6 +871,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				FileCleanupCronSpec:  cloned.Sink.CloudStorageConfig.FileCleanupCronSpec,
 				FlushConcurrency:     cloned.Sink.CloudStorageConfig.FlushConcurrency,
 				OutputRawChangeEvent: cloned.Sink.CloudStorageConfig.OutputRawChangeEvent,
+				Compression:          cloned.Sink.CloudStorageConfig.Compression,
+				CompressionLevel:     cloned.Sink.CloudStorageConfig.CompressionLevel,
 			}
 		}
 		var debeziumConfig *DebeziumConfig
@@ -768,6 +893,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			DispatchRules:                    dispatchRules,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
+			ColumnTransformers:               columnTransformers,
+			MetadataColumns:                  metadataColumns,
 			EncoderConcurrency:               cloned.Sink.EncoderConcurrency,
 			Terminator:                       cloned.Sink.Terminator,
 			DateSeparator:                    cloned.Sink.DateSeparator,
@@ -823,6 +950,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			Storage:               cloned.Consistent.Storage,
 			UseFileBackend:        cloned.Consistent.UseFileBackend,
 			Compression:           cloned.Consistent.Compression,
+			CompressionLevel:      cloned.Consistent.CompressionLevel,
 			FlushConcurrency:      cloned.Consistent.FlushConcurrency,
 		}
 		if cloned.Consistent.MemoryUsage != nil {
@@ -880,6 +1008,7 @@ type FilterConfig struct {
 	Rules            []string          `json:"rules,omitempty"`
 	IgnoreTxnStartTs []uint64          `json:"ignore_txn_start_ts,omitempty"`
 	EventFilters     []EventFilterRule `json:"event_filters,omitempty"`
+	OnlineDDL        bool              `json:"online_ddl,omitempty"`
 }
 
 // MounterConfig represents mounter config for a changefeed
@@ -955,17 +1084,19 @@ type Table struct {
 // SinkConfig represents sink config for a changefeed
 // This is a duplicate of config.SinkConfig
 type SinkConfig struct {
-	Protocol                 *string           `json:"protocol,omitempty"`
-	SchemaRegistry           *string           `json:"schema_registry,omitempty"`
-	CSVConfig                *CSVConfig        `json:"csv,omitempty"`
-	DispatchRules            []*DispatchRule   `json:"dispatchers,omitempty"`
-	ColumnSelectors          []*ColumnSelector `json:"column_selectors,omitempty"`
-	TxnAtomicity             *string           `json:"transaction_atomicity,omitempty"`
-	EncoderConcurrency       *int              `json:"encoder_concurrency,omitempty"`
-	Terminator               *string           `json:"terminator,omitempty"`
-	DateSeparator            *string           `json:"date_separator,omitempty"`
-	EnablePartitionSeparator *bool             `json:"enable_partition_separator,omitempty"`
-	FileIndexWidth           *int              `json:"file_index_width,omitempty"`
+	Protocol                 *string                `json:"protocol,omitempty"`
+	SchemaRegistry           *string                `json:"schema_registry,omitempty"`
+	CSVConfig                *CSVConfig             `json:"csv,omitempty"`
+	DispatchRules            []*DispatchRule        `json:"dispatchers,omitempty"`
+	ColumnSelectors          []*ColumnSelector      `json:"column_selectors,omitempty"`
+	ColumnTransformers       []*ColumnTransformer   `json:"column_transformers,omitempty"`
+	MetadataColumns          *MetadataColumnsConfig `json:"metadata_columns,omitempty"`
+	TxnAtomicity             *string                `json:"transaction_atomicity,omitempty"`
+	EncoderConcurrency       *int                   `json:"encoder_concurrency,omitempty"`
+	Terminator               *string                `json:"terminator,omitempty"`
+	DateSeparator            *string                `json:"date_separator,omitempty"`
+	EnablePartitionSeparator *bool                  `json:"enable_partition_separator,omitempty"`
+	FileIndexWidth           *int                   `json:"file_index_width,omitempty"`
 	// deprecated: it's become useless since v9.0.0
 	EnableKafkaSinkV2                *bool               `json:"enable_kafka_sink_v2,omitempty"`
 	OnlyOutputUpdatedColumns         *bool               `json:"only_output_updated_columns,omitempty"`
@@ -1025,6 +1156,26 @@ type ColumnSelector struct {
 	Columns []string `json:"columns,omitempty"`
 }
 
+// ColumnTransformer represents a column transformer for a table.
+// This is a duplicate of config.ColumnTransformer
+type ColumnTransformer struct {
+	Matcher []string `json:"matcher,omitempty"`
+	Columns []string `json:"columns,omitempty"`
+	Action  string   `json:"action,omitempty"`
+	Salt    string   `json:"salt,omitempty"`
+	Length  int      `json:"length,omitempty"`
+	Value   string   `json:"value,omitempty"`
+}
+
+// MetadataColumnsConfig represents the metadata columns appended to the rows by the sink.
+// This is a duplicate of config.MetadataColumnsConfig
+type MetadataColumnsConfig struct {
+	CommitTs         *string `json:"commit_ts,omitempty"`
+	Op               *string `json:"op,omitempty"`
+	SourceChangefeed *string `json:"source_changefeed,omitempty"`
+	AppendOnly       *bool   `json:"append_only,omitempty"`
+}
+
 // ConsistentConfig represents replication consistency config for a changefeed
 // This is a duplicate of config.ConsistentConfig
 type ConsistentConfig struct {
@@ -1037,6 +1188,7 @@ type ConsistentConfig struct {
 	Storage               string `json:"storage,omitempty"`
 	UseFileBackend        bool   `json:"use_file_backend"`
 	Compression           string `json:"compression,omitempty"`
+	CompressionLevel      int    `json:"compression_level,omitempty"`
 	FlushConcurrency      int    `json:"flush_concurrency,omitempty"`
 
 	MemoryUsage *ConsistentMemoryUsage `json:"memory_usage"`
@@ -1289,21 +1441,67 @@ type KafkaConfig struct {
 
 // MySQLConfig represents a MySQL sink configuration
 type MySQLConfig struct {
-	WorkerCount                  *int    `json:"worker_count,omitempty"`
-	MaxTxnRow                    *int    `json:"max_txn_row,omitempty"`
-	MaxMultiUpdateRowSize        *int    `json:"max_multi_update_row_size,omitempty"`
-	MaxMultiUpdateRowCount       *int    `json:"max_multi_update_row_count,omitempty"`
-	TiDBTxnMode                  *string `json:"tidb_txn_mode,omitempty"`
-	SSLCa                        *string `json:"ssl_ca,omitempty"`
-	SSLCert                      *string `json:"ssl_cert,omitempty"`
-	SSLKey                       *string `json:"ssl_key,omitempty"`
-	TimeZone                     *string `json:"time_zone,omitempty"`
-	WriteTimeout                 *string `json:"write_timeout,omitempty"`
-	ReadTimeout                  *string `json:"read_timeout,omitempty"`
-	Timeout                      *string `json:"timeout,omitempty"`
-	EnableBatchDML               *bool   `json:"enable_batch_dml,omitempty"`
-	EnableMultiStatement         *bool   `json:"enable_multi_statement,omitempty"`
-	EnableCachePreparedStatement *bool   `json:"enable_cache_prepared_statement,omitempty"`
+	WorkerCount                  *int                       `json:"worker_count,omitempty"`
+	MaxTxnRow                    *int                       `json:"max_txn_row,omitempty"`
+	MaxMultiUpdateRowSize        *int                       `json:"max_multi_update_row_size,omitempty"`
+	MaxMultiUpdateRowCount       *int                       `json:"max_multi_update_row_count,omitempty"`
+	TiDBTxnMode                  *string                    `json:"tidb_txn_mode,omitempty"`
+	SSLCa                        *string                    `json:"ssl_ca,omitempty"`
+	SSLCert                      *string                    `json:"ssl_cert,omitempty"`
+	SSLKey                       *string                    `json:"ssl_key,omitempty"`
+	TimeZone                     *string                    `json:"time_zone,omitempty"`
+	WriteTimeout                 *string                    `json:"write_timeout,omitempty"`
+	ReadTimeout                  *string                    `json:"read_timeout,omitempty"`
+	Timeout                      *string                    `json:"timeout,omitempty"`
+	EnableBatchDML               *bool                      `json:"enable_batch_dml,omitempty"`
+	EnableMultiStatement         *bool                      `json:"enable_multi_statement,omitempty"`
+	EnableCachePreparedStatement *bool                      `json:"enable_cache_prepared_statement,omitempty"`
+	RoutingRules                 []*RoutingRule             `json:"routing_rules,omitempty"`
+	SharedTargetDDLPolicy        *string                    `json:"shared_target_ddl_policy,omitempty"`
+	ErrorPolicy                  *string                    `json:"error_policy,omitempty"`
+	DeadLetter                   *DeadLetterConfig          `json:"dead_letter,omitempty"`
+	ConflictResolution           *ConflictResolutionConfig  `json:"conflict_resolution,omitempty"`
+	CatchUp                      *CatchUpConfig             `json:"catch_up,omitempty"`
+	AdaptiveConcurrency          *AdaptiveConcurrencyConfig `json:"adaptive_concurrency,omitempty"`
+}
+
+// AdaptiveConcurrencyConfig represents the adaptive worker concurrency of the mysql sink
+// This is a duplicate of config.AdaptiveConcurrencyConfig
+type AdaptiveConcurrencyConfig struct {
+	Enable         *bool   `json:"enable,omitempty"`
+	MinWorkerCount *int    `json:"min_worker_count,omitempty"`
+	MaxWorkerCount *int    `json:"max_worker_count,omitempty"`
+	TargetLatency  *string `json:"target_latency,omitempty"`
+}
+
+// CatchUpConfig represents the catch-up mode of the mysql sink
+// This is a duplicate of config.CatchUpConfig
+type CatchUpConfig struct {
+	LagThreshold *string `json:"lag_threshold,omitempty"`
+	MaxTxnRow    *int    `json:"max_txn_row,omitempty"`
+}
+
+// ConflictResolutionConfig represents the policy to resolve the conflicts in BDR mode
+// This is a duplicate of config.ConflictResolutionConfig
+type ConflictResolutionConfig struct {
+	Policy          *string `json:"policy,omitempty"`
+	CompareBy       *string `json:"compare_by,omitempty"`
+	TimestampColumn *string `json:"timestamp_column,omitempty"`
+}
+
+// DeadLetterConfig represents the destination of the dead-letter rows
+// This is a duplicate of config.DeadLetterConfig
+type DeadLetterConfig struct {
+	Table      *string `json:"table,omitempty"`
+	StorageURI *string `json:"storage_uri,omitempty"`
+}
+
+// RoutingRule represents a routing rule for a table
+// This is a duplicate of config.RoutingRule
+type RoutingRule struct {
+	Matcher      []string `json:"matcher,omitempty"`
+	TargetSchema string   `json:"target_schema,omitempty"`
+	TargetTable  string   `json:"target_table,omitempty"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -1316,6 +1514,8 @@ type CloudStorageConfig struct {
 	FileCleanupCronSpec  *string `json:"file_cleanup_cron_spec,omitempty"`
 	FlushConcurrency     *int    `json:"flush_concurrency,omitempty"`
 	OutputRawChangeEvent *bool   `json:"output_raw_change_event,omitempty"`
+	Compression          *string `json:"compression,omitempty"`
+	CompressionLevel     *int    `json:"compression_level,omitempty"`
 }
 
 // ChangefeedStatus holds common information of a changefeed in cdc
@@ -1354,6 +1554,18 @@ type DispatcherCount struct {
 	Count int `json:"count"`
 }
 
+// CaptureCount is a counter of a changefeed on a capture, such as the count of the active
+// mysql sink workers.
+type CaptureCount struct {
+	CaptureID string `json:"capture_id"`
+	Count     int64  `json:"count"`
+}
+
+// DeadLetterCount is the count of the rows written to the dead-letter destination by a changefeed.
+type DeadLetterCount struct {
+	Count int64 `json:"count"`
+}
+
 type NodeTableInfo struct {
 	NodeID   string  `json:"node_id"`
 	TableIDs []int64 `json:"table_ids"`
@@ -0,0 +1,67 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"os"
+
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// options defines flags for the `debug` command.
+type options struct {
+	dataDir  string
+	logLevel string
+}
+
+// newOptions creates new options for the `debug` command.
+func newOptions() *options {
+	return &options{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.dataDir, "data-dir", "", "the data dir of the TiCDC server, the server must be stopped")
+	cmd.PersistentFlags().StringVar(&o.logLevel, "log-level", "warn", "log level (etc: debug|info|warn|error)")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkPersistentFlagRequired("data-dir") //nolint:errcheck
+}
+
+// NewCmdDebug creates the `debug` command.
+func NewCmdDebug() *cobra.Command {
+	o := newOptions()
+
+	cmds := &cobra.Command{
+		Use:   "debug",
+		Short: "Inspect the data of a stopped TiCDC server offline",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			err := logger.InitLogger(&logger.Config{Level: o.logLevel})
+			if err != nil {
+				cmd.Printf("init logger error %v\n", errors.Trace(err))
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	o.addFlags(cmds)
+
+	// Add subcommands.
+	cmds.AddCommand(newCmdSchemaStore(o))
+	cmds.AddCommand(newCmdEventStore(o))
+
+	return cmds
+}
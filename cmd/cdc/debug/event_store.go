@@ -0,0 +1,224 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/integrity"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/spf13/cobra"
+)
+
+// newCmdEventStore creates the `debug event-store` command.
+func newCmdEventStore(opt *options) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "event-store",
+		Short: "Inspect the event store data",
+	}
+	cmds.AddCommand(newCmdEventStoreSubscriptions(opt))
+	cmds.AddCommand(newCmdEventStoreEvents(opt))
+	return cmds
+}
+
+// newCmdEventStoreSubscriptions creates the `debug event-store subscriptions` command.
+func newCmdEventStoreSubscriptions(o *options) *cobra.Command {
+	var tableID int64
+	command := &cobra.Command{
+		Use:   "subscriptions",
+		Short: "List the subscriptions with the ts range of their data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inspector, err := eventstore.NewInspector(o.dataDir)
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+			subscriptions, err := inspector.ListSubscriptions()
+			if err != nil {
+				return err
+			}
+			for _, sub := range subscriptions {
+				if tableID != 0 && sub.TableID != tableID {
+					continue
+				}
+				cmd.Printf("db:%d, subscription-id:%d, table-id:%d, min-commit-ts:%d, max-commit-ts:%d",
+					sub.DBIndex, sub.SubscriptionID, sub.TableID, sub.MinCommitTs, sub.MaxCommitTs)
+				if sub.InMeta {
+					cmd.Printf(", start-key:%s, end-key:%s, checkpoint-ts:%d, resolved-ts:%d",
+						hex.EncodeToString(sub.StartKey), hex.EncodeToString(sub.EndKey), sub.CheckpointTs, sub.ResolvedTs)
+				}
+				cmd.Println()
+			}
+			return nil
+		},
+	}
+	command.Flags().Int64Var(&tableID, "table-id", 0, "only list the subscriptions of the table")
+	return command
+}
+
+// eventRow is the json format of an event in the event store.
+type eventRow struct {
+	SubscriptionID uint64 `json:"subscription-id"`
+	CommitTs       uint64 `json:"commit-ts"`
+	StartTs        uint64 `json:"start-ts"`
+	Type           string `json:"type"`
+	Compression    string `json:"compression"`
+	RegionID       uint64 `json:"region-id"`
+	Key            string `json:"key"`
+	Handle         string `json:"handle,omitempty"`
+	// the columns are decoded only if the table info is found in the schema store,
+	// otherwise the raw values are printed in hex.
+	Columns    map[string]interface{} `json:"columns,omitempty"`
+	PreColumns map[string]interface{} `json:"pre-columns,omitempty"`
+	Value      string                 `json:"value,omitempty"`
+	OldValue   string                 `json:"old-value,omitempty"`
+}
+
+// eventDecoder decodes the events to rows with the table info in the schema store.
+type eventDecoder struct {
+	// getTableInfo is nil if the schema store is not available.
+	getTableInfo func(tableID int64, ts uint64) (*common.TableInfo, error)
+	mounter      event.Mounter
+}
+
+func (d *eventDecoder) decode(e *eventstore.Event) (*eventRow, error) {
+	rawKV := e.RawKV
+	row := &eventRow{
+		SubscriptionID: e.SubscriptionID,
+		CommitTs:       rawKV.CRTs,
+		StartTs:        rawKV.StartTs,
+		Type:           e.DMLOrder.String(),
+		Compression:    e.Compression.String(),
+		RegionID:       rawKV.RegionID,
+		Key:            hex.EncodeToString(rawKV.Key),
+	}
+	if handle, err := tablecodec.DecodeRowKey(event.RemoveKeyspacePrefix(rawKV.Key)); err == nil {
+		row.Handle = handle.String()
+	}
+
+	var tableInfo *common.TableInfo
+	if d.getTableInfo != nil {
+		// the row is encoded with the schema before the commit ts.
+		tableInfo, _ = d.getTableInfo(e.TableID, rawKV.CRTs-1)
+	}
+	if tableInfo == nil {
+		row.Value = hex.EncodeToString(rawKV.Value)
+		row.OldValue = hex.EncodeToString(rawKV.OldValue)
+		return row, nil
+	}
+
+	chk := chunk.NewChunkWithCapacity(tableInfo.GetFieldSlice(), 2)
+	count, _, err := d.mounter.DecodeToChunk(rawKV, tableInfo, chk)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	toColumns := func(r chunk.Row) map[string]interface{} {
+		columns := make(map[string]interface{})
+		for i, col := range tableInfo.GetColumns() {
+			if col == nil {
+				continue
+			}
+			columns[col.Name.O] = common.ExtractColVal(&r, col, i)
+		}
+		return columns
+	}
+	// the old value is decoded before the value.
+	idx := 0
+	if len(rawKV.OldValue) != 0 && idx < count {
+		row.PreColumns = toColumns(chk.GetRow(idx))
+		idx++
+	}
+	if len(rawKV.Value) != 0 && idx < count {
+		row.Columns = toColumns(chk.GetRow(idx))
+	}
+	return row, nil
+}
+
+// newCmdEventStoreEvents creates the `debug event-store events` command.
+func newCmdEventStoreEvents(o *options) *cobra.Command {
+	var (
+		tableID    int64
+		startTs    uint64
+		endTs      uint64
+		keyspaceID uint32
+		timezone   string
+	)
+	command := &cobra.Command{
+		Use:   "events",
+		Short: "Dump the events of a table whose commit ts is in range [start-ts, end-ts) in json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tz, err := time.LoadLocation(timezone)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			inspector, err := eventstore.NewInspector(o.dataDir)
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+
+			decoder := &eventDecoder{mounter: event.NewMounter(tz, &integrity.Config{})}
+			schemaInspector, err := schemastore.NewInspector(schemastore.GetDBPath(o.dataDir, keyspaceID))
+			if err != nil {
+				cmd.PrintErrf("the schema store is not available, the columns are not decoded: %v\n", err)
+			} else {
+				defer schemaInspector.Close()
+				decoder.getTableInfo = schemaInspector.GetTableInfo
+			}
+
+			subscriptions, err := inspector.ListSubscriptions()
+			if err != nil {
+				return err
+			}
+			for _, sub := range subscriptions {
+				if sub.TableID != tableID {
+					continue
+				}
+				err = inspector.IterateEvents(sub, startTs, endTs, func(e *eventstore.Event) error {
+					row, err := decoder.decode(e)
+					if err != nil {
+						return err
+					}
+					data, err := json.Marshal(row)
+					if err != nil {
+						return errors.Trace(err)
+					}
+					cmd.Println(string(data))
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	command.Flags().Int64Var(&tableID, "table-id", 0, "the id of the table or the physical partition")
+	command.Flags().Uint64Var(&startTs, "start-ts", 0, "the start commit ts of the events, inclusive")
+	command.Flags().Uint64Var(&endTs, "end-ts", math.MaxUint64, "the end commit ts of the events, exclusive")
+	command.Flags().Uint32Var(&keyspaceID, "keyspace-id", common.DefaultKeyspaceID, "the keyspace id of the schema store data used to decode the columns")
+	command.Flags().StringVar(&timezone, "tz", "UTC", "the time zone used to decode the timestamp columns")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	command.MarkFlagRequired("table-id") //nolint:errcheck
+	return command
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/integrity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDecoder(t *testing.T) {
+	helper := event.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	tableInfo := helper.GetTableInfo(job)
+	rawKVs := helper.DML2RawKv(tableInfo.TableName.TableID, job.BinlogInfo.FinishedTS,
+		"insert into t values (1, 'a')")
+	require.Len(t, rawKVs, 1)
+	e := &eventstore.Event{
+		SubscriptionID: 1,
+		TableID:        tableInfo.TableName.TableID,
+		DMLOrder:       eventstore.DMLOrderInsert,
+		Compression:    eventstore.CompressionNone,
+		RawKV:          rawKVs[0],
+	}
+	value := hex.EncodeToString(rawKVs[0].Value)
+
+	decoder := &eventDecoder{
+		getTableInfo: func(tableID int64, ts uint64) (*common.TableInfo, error) {
+			require.Equal(t, tableInfo.TableName.TableID, tableID)
+			require.Equal(t, rawKVs[0].CRTs-1, ts)
+			return tableInfo, nil
+		},
+		mounter: event.NewMounter(time.UTC, &integrity.Config{}),
+	}
+	row, err := decoder.decode(e)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), row.SubscriptionID)
+	require.Equal(t, rawKVs[0].CRTs, row.CommitTs)
+	require.Equal(t, "insert", row.Type)
+	require.Equal(t, "none", row.Compression)
+	require.Equal(t, "1", row.Handle)
+	require.Equal(t, map[string]interface{}{"id": int64(1), "name": "a"}, row.Columns)
+	require.Nil(t, row.PreColumns)
+	require.Empty(t, row.Value)
+
+	// the raw value is printed if the table info is not found.
+	decoder.getTableInfo = func(tableID int64, ts uint64) (*common.TableInfo, error) {
+		return nil, errors.New("table not found")
+	}
+	row, err = decoder.decode(e)
+	require.NoError(t, err)
+	require.Nil(t, row.Columns)
+	require.Equal(t, value, row.Value)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"context"
+	"math"
+
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/pingcap/ticdc/pkg/upstream"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/spf13/cobra"
+)
+
+// createTiStore creates the upstream kv storage to rebuild the schema store, it's replaced in tests.
+var createTiStore = upstream.CreateTiStore
+
+// schemaStoreOptions defines flags for the `debug schema-store` command.
+type schemaStoreOptions struct {
+	*options
+	keyspaceID uint32
+}
+
+func (o *schemaStoreOptions) dbPath() string {
+	return schemastore.GetDBPath(o.dataDir, o.keyspaceID)
+}
+
+// newCmdSchemaStore creates the `debug schema-store` command.
+func newCmdSchemaStore(opt *options) *cobra.Command {
+	o := &schemaStoreOptions{options: opt}
+	cmds := &cobra.Command{
+		Use:   "schema-store",
+		Short: "Inspect or rebuild the schema store data",
+	}
+	cmds.PersistentFlags().Uint32Var(&o.keyspaceID, "keyspace-id", common.DefaultKeyspaceID, "the keyspace id of the schema store data")
+
+	cmds.AddCommand(newCmdSchemaStoreMeta(o))
+	cmds.AddCommand(newCmdSchemaStoreDDLJobs(o))
+	cmds.AddCommand(newCmdSchemaStoreTableInfo(o))
+	cmds.AddCommand(newCmdSchemaStoreRebuild(o))
+	return cmds
+}
+
+// newCmdSchemaStoreMeta creates the `debug schema-store meta` command.
+func newCmdSchemaStoreMeta(o *schemaStoreOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "meta",
+		Short: "Print the gc ts and the upper bound of the schema store data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inspector, err := schemastore.NewInspector(o.dbPath())
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+			upperBound := inspector.UpperBound()
+			cmd.Printf("gc-ts:%d, finished-ddl-ts:%d, schema-version:%d, resolved-ts:%d\n",
+				inspector.GcTs(), upperBound.FinishedDDLTs, upperBound.SchemaVersion, upperBound.ResolvedTs)
+			return nil
+		},
+	}
+}
+
+// newCmdSchemaStoreDDLJobs creates the `debug schema-store ddl-jobs` command.
+func newCmdSchemaStoreDDLJobs(o *schemaStoreOptions) *cobra.Command {
+	var startTs, endTs uint64
+	command := &cobra.Command{
+		Use:   "ddl-jobs",
+		Short: "List the persisted ddl jobs whose finished ts is in range [start-ts, end-ts)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inspector, err := schemastore.NewInspector(o.dbPath())
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+			events, err := inspector.ListDDLJobs(startTs, endTs)
+			if err != nil {
+				return err
+			}
+			for _, event := range events {
+				cmd.Printf("finished-ts:%d, type:%s, schema-id:%d, table-id:%d, schema:%s, table:%s, query:%s\n",
+					event.FinishedTs, model.ActionType(event.Type), event.SchemaID, event.TableID,
+					event.SchemaName, event.TableName, event.Query)
+			}
+			return nil
+		},
+	}
+	command.Flags().Uint64Var(&startTs, "start-ts", 0, "the start ts of the ddl jobs, inclusive")
+	command.Flags().Uint64Var(&endTs, "end-ts", math.MaxUint64, "the end ts of the ddl jobs, exclusive")
+	return command
+}
+
+// newCmdSchemaStoreTableInfo creates the `debug schema-store table-info` command.
+func newCmdSchemaStoreTableInfo(o *schemaStoreOptions) *cobra.Command {
+	var (
+		tableID int64
+		ts      uint64
+	)
+	command := &cobra.Command{
+		Use:   "table-info",
+		Short: "Print the table info of a table at the given ts in json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inspector, err := schemastore.NewInspector(o.dbPath())
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+			if ts == 0 {
+				ts = inspector.UpperBound().ResolvedTs
+			}
+			tableInfo, err := inspector.GetTableInfo(tableID, ts)
+			if err != nil {
+				return err
+			}
+			data, err := tableInfo.Marshal()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			cmd.Println(string(data))
+			return nil
+		},
+	}
+	command.Flags().Int64Var(&tableID, "table-id", 0, "the id of the table or the physical partition")
+	command.Flags().Uint64Var(&ts, "ts", 0, "the ts of the table info, the one at the resolved ts of the data is used by default")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	command.MarkFlagRequired("table-id") //nolint:errcheck
+	return command
+}
+
+// newCmdSchemaStoreRebuild creates the `debug schema-store rebuild` command.
+func newCmdSchemaStoreRebuild(o *schemaStoreOptions) *cobra.Command {
+	var (
+		pd           string
+		keyspaceName string
+		snapTs       uint64
+		credential   security.Credential
+	)
+	command := &cobra.Command{
+		Use:   "rebuild",
+		Short: "Rebuild the schema store data from the upstream snapshot at the given ts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kvStorage, err := createTiStore(context.Background(), pd, &credential, keyspaceName)
+			if err != nil {
+				return err
+			}
+			defer kvStorage.Close()
+			if err = schemastore.RebuildFromSnapshot(o.dbPath(), kvStorage, snapTs); err != nil {
+				return err
+			}
+			cmd.Printf("schema store is rebuilt at ts %d\n", snapTs)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&pd, "pd", "http://127.0.0.1:2379", "PD address, use ',' to separate multiple PDs")
+	command.Flags().StringVar(&keyspaceName, "keyspace-name", "", "the keyspace name of the upstream")
+	command.Flags().Uint64Var(&snapTs, "ts", 0, "the ts of the upstream snapshot, it must be larger than the gc safe point")
+	command.Flags().StringVar(&credential.CAPath, "ca", "", "CA certificate path for TLS connection")
+	command.Flags().StringVar(&credential.CertPath, "cert", "", "Certificate path for TLS connection")
+	command.Flags().StringVar(&credential.KeyPath, "key", "", "Private key path for TLS connection")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	command.MarkFlagRequired("ts") //nolint:errcheck
+	return command
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/stretchr/testify/require"
+)
+
+// unclosableStorage prevents the command from closing the storage shared by the test helper.
+type unclosableStorage struct {
+	kv.Storage
+}
+
+func (s unclosableStorage) Close() error {
+	return nil
+}
+
+func runSchemaStoreCmd(dataDir string, args ...string) (string, error) {
+	cmd := newCmdSchemaStore(&options{dataDir: dataDir})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestSchemaStoreCmd(t *testing.T) {
+	helper := event.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	tableID := helper.GetTableInfo(job).TableName.TableID
+	snapTs := job.BinlogInfo.FinishedTS
+
+	original := createTiStore
+	defer func() { createTiStore = original }()
+	createTiStore = func(context.Context, string, *security.Credential, string) (kv.Storage, error) {
+		return unclosableStorage{helper.Storage()}, nil
+	}
+
+	dataDir := t.TempDir()
+	_, err := runSchemaStoreCmd(dataDir, "meta")
+	require.ErrorContains(t, err, "does not exist")
+
+	out, err := runSchemaStoreCmd(dataDir, "rebuild", "--ts", fmt.Sprint(snapTs))
+	require.NoError(t, err)
+	require.Contains(t, out, fmt.Sprintf("schema store is rebuilt at ts %d", snapTs))
+
+	out, err = runSchemaStoreCmd(dataDir, "meta")
+	require.NoError(t, err)
+	require.Contains(t, out, fmt.Sprintf("gc-ts:%d, finished-ddl-ts:0", snapTs))
+	require.Contains(t, out, fmt.Sprintf("resolved-ts:%d", snapTs))
+
+	out, err = runSchemaStoreCmd(dataDir, "ddl-jobs")
+	require.NoError(t, err)
+	require.Empty(t, out)
+
+	out, err = runSchemaStoreCmd(dataDir, "table-info", "--table-id", fmt.Sprint(tableID))
+	require.NoError(t, err)
+	require.Contains(t, out, fmt.Sprintf(`"Schema":"test","Table":"t","TableID":%d`, tableID))
+
+	// the commands refuse to run while the data is opened by the server.
+	db, err := pebble.Open(schemastore.GetDBPath(dataDir, common.DefaultKeyspaceID), &pebble.Options{})
+	require.NoError(t, err)
+	for _, args := range [][]string{
+		{"meta"},
+		{"ddl-jobs"},
+		{"table-info", "--table-id", fmt.Sprint(tableID)},
+		{"rebuild", "--ts", fmt.Sprint(snapTs)},
+	} {
+		_, err = runSchemaStoreCmd(dataDir, args...)
+		require.ErrorContains(t, err, "is being used by a running server")
+	}
+	require.NoError(t, db.Close())
+
+	_, err = runSchemaStoreCmd(dataDir, "meta")
+	require.NoError(t, err)
+}
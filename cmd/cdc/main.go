@@ -17,6 +17,7 @@ import (
 	"os"
 
 	"github.com/pingcap/ticdc/cmd/cdc/cli"
+	"github.com/pingcap/ticdc/cmd/cdc/debug"
 	"github.com/pingcap/ticdc/cmd/cdc/redo"
 	"github.com/pingcap/ticdc/cmd/cdc/server"
 	"github.com/pingcap/ticdc/cmd/cdc/version"
@@ -47,6 +48,7 @@ func main() {
 	cmd.AddCommand(cli.NewCmdCli())
 	cmd.AddCommand(version.NewCmdVersion())
 	cmd.AddCommand(redo.NewCmdRedo())
+	cmd.AddCommand(debug.NewCmdDebug())
 
 	setNewCollationEnabled()
 	if err := cmd.Execute(); err != nil {
@@ -157,7 +157,7 @@ func (o *option) Adjust(upstreamURIStr string, configFile string) {
 	}
 	o.codecConfig.TimeZone = tz
 
-	if protocol == config.ProtocolAvro {
+	if protocol.IsSchemaRegistryBased() {
 		o.codecConfig.AvroEnableWatermark = true
 	}
 	o.enableTableAcrossNodes = replicaConfig.Scheduler.EnableTableAcrossNodes
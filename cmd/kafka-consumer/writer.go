@@ -120,7 +120,7 @@ func newWriter(ctx context.Context, o *option) *writer {
 		w.progresses[i] = newPartitionProgress(int32(i), decoder)
 	}
 
-	eventRouter, err := eventrouter.NewEventRouter(o.sinkConfig, o.topic, false, o.protocol == config.ProtocolAvro)
+	eventRouter, err := eventrouter.NewEventRouter(o.sinkConfig, o.topic, false, o.protocol.IsSchemaRegistryBased())
 	if err != nil {
 		log.Panic("initialize the event router failed",
 			zap.Any("protocol", o.protocol), zap.Any("topic", o.topic),
@@ -460,7 +460,7 @@ func (w *writer) Write(ctx context.Context, messageType common.MessageType) bool
 
 func (w *writer) onDDL(ddl *commonEvent.DDLEvent) {
 	switch w.protocol {
-	case config.ProtocolCanalJSON, config.ProtocolOpen, config.ProtocolAvro:
+	case config.ProtocolCanal, config.ProtocolCanalJSON, config.ProtocolMaxwell, config.ProtocolOpen, config.ProtocolCraft, config.ProtocolAvro, config.ProtocolProtobuf:
 	default:
 		return
 	}
@@ -557,11 +557,11 @@ func (w *writer) appendRow2Group(dml *commonEvent.DMLEvent, progress *partitionP
 			zap.Stringer("eventType", dml.RowTypes[0]),
 			// zap.Any("columns", row.Columns), zap.Any("preColumns", row.PreColumns),
 			zap.Any("protocol", w.protocol), zap.Bool("IsPartition", dml.TableInfo.TableName.IsPartition))
-	case config.ProtocolCanalJSON, config.ProtocolOpen, config.ProtocolAvro:
-		// for partition table, the canal-json, avro and open-protocol message cannot assign physical table id to each dml message,
+	case config.ProtocolCanal, config.ProtocolCanalJSON, config.ProtocolMaxwell, config.ProtocolOpen, config.ProtocolCraft, config.ProtocolAvro, config.ProtocolProtobuf:
+		// for partition table, the canal, canal-json, maxwell, avro, protobuf, craft and open-protocol message cannot assign physical table id to each dml message,
 		// we cannot distinguish whether it's a real fallback event or not, still append it.
 		if w.partitionTableAccessor.IsPartitionTable(schema, table) {
-			log.Warn("DML events fallback, but it's canal-json, avro or open-protocol and the table is a partition table, still append it",
+			log.Warn("DML events fallback, but it's canal, canal-json, maxwell, avro, protobuf, craft or open-protocol and the table is a partition table, still append it",
 				zap.Int32("partition", group.Partition), zap.Any("offset", offset),
 				zap.Uint64("commitTs", commitTs), zap.Uint64("highWatermark", group.HighWatermark),
 				zap.String("schema", schema), zap.String("table", table), zap.Int64("tableID", tableID),
@@ -86,7 +86,7 @@ func (o *option) Adjust(upstreamURI *url.URL, configFile string) {
 		o.protocol = protocol
 	}
 	if !config.IsPulsarSupportedProtocols(o.protocol) {
-		log.Panic("unsupported protocol, pulsar sink currently only support these protocols: [canal-json]",
+		log.Panic("unsupported protocol, pulsar sink currently only support these protocols: [canal-json, maxwell]",
 			zap.String("protocol", s))
 	}
 
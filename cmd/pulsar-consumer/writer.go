@@ -400,7 +400,7 @@ func (w *writer) Write(ctx context.Context, messageType common.MessageType) bool
 
 func (w *writer) onDDL(ddl *commonEvent.DDLEvent) {
 	switch w.protocol {
-	case config.ProtocolCanalJSON:
+	case config.ProtocolCanalJSON, config.ProtocolMaxwell:
 	default:
 		return
 	}
@@ -451,11 +451,11 @@ func (w *writer) appendRow2Group(dml *commonEvent.DMLEvent, progress *partitionP
 		return
 	}
 	switch w.protocol {
-	case config.ProtocolCanalJSON:
-		// for partition table, the canal-json message cannot assign physical table id to each dml message,
+	case config.ProtocolCanalJSON, config.ProtocolMaxwell:
+		// for partition table, the canal-json and maxwell message cannot assign physical table id to each dml message,
 		// we cannot distinguish whether it's a real fallback event or not, still append it.
 		if w.partitionTableAccessor.IsPartitionTable(schema, table) {
-			log.Warn("DML events fallback, but it's canal-json and partition table, still append it",
+			log.Warn("DML events fallback, but it's canal-json or maxwell and partition table, still append it",
 				zap.Uint64("commitTs", commitTs), zap.Uint64("highWatermark", group.HighWatermark),
 				zap.String("schema", schema), zap.String("table", table), zap.Int64("tableID", tableID),
 				zap.Stringer("eventType", dml.RowTypes[0]))
@@ -28,11 +28,14 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
 	commonType "github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/codec/avro"
 	"github.com/pingcap/ticdc/pkg/sink/codec/canal"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/codec/csv"
+	"github.com/pingcap/ticdc/pkg/sink/codec/simple"
 	putil "github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"go.uber.org/atomic"
@@ -57,6 +60,7 @@ type consumer struct {
 	codecCfg        *common.Config
 	externalStorage storage.ExternalStorage
 	fileExtension   string
+	compression     string
 	sink            sink.Sink
 	// tableDMLIdxMap maintains a map of <dmlPathKey, max file index>
 	tableDMLIdxMap map[cloudstorage.DmlPathKey]uint64
@@ -97,6 +101,8 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 	switch putil.GetOrZero(replicaConfig.Sink.Protocol) {
 	case config.ProtocolCsv.String():
 	case config.ProtocolCanalJSON.String():
+	case config.ProtocolAvro.String():
+	case config.ProtocolSimple.String():
 	default:
 		return nil, fmt.Errorf(
 			"data encoded in protocol %s is not supported yet",
@@ -115,7 +121,12 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 		return nil, err
 	}
 
-	extension := helper.GetFileExtension(protocol)
+	// the data files are compressed as a whole, the extension of the compression is appended.
+	fileCompression := compression.None
+	if replicaConfig.Sink.CloudStorageConfig != nil {
+		fileCompression = putil.GetOrZero(replicaConfig.Sink.CloudStorageConfig.Compression)
+	}
+	extension := helper.GetFileExtension(protocol) + compression.FileExtension(fileCompression)
 
 	storage, err := putil.GetExternalStorageWithDefaultTimeout(ctx, upstreamURIStr)
 	if err != nil {
@@ -141,6 +152,7 @@ func newConsumer(ctx context.Context) (*consumer, error) {
 		codecCfg:        codecConfig,
 		externalStorage: storage,
 		fileExtension:   extension,
+		compression:     fileCompression,
 		sink:            sink,
 		errCh:           errCh,
 		tableDMLIdxMap:  make(map[cloudstorage.DmlPathKey]uint64),
@@ -279,6 +291,12 @@ func (c *consumer) emitDMLEvents(
 		c.codecCfg.EnableTiDBExtension = true
 		decoder = canal.NewTxnDecoder(c.codecCfg)
 		decoder.AddKeyValue(nil, content)
+	case config.ProtocolAvro:
+		decoder = avro.NewTxnDecoder(c.codecCfg, tableInfo)
+		decoder.AddKeyValue(nil, content)
+	case config.ProtocolSimple:
+		decoder = simple.NewTxnDecoder(c.codecCfg, tableInfo)
+		decoder.AddKeyValue(nil, content)
 	}
 
 	cnt := 0
@@ -327,6 +345,12 @@ func (c *consumer) syncExecDMLEvents(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.compression != "" && c.compression != compression.None {
+		content, err = compression.Decode(c.compression, content)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	tableID := c.tableIDGenerator.generateFakeTableID(
 		key.Schema, key.Table, key.PartitionNum)
 	err = c.emitDMLEvents(ctx, tableID, tableDef, key, content)
@@ -188,7 +188,8 @@ func (c *Changefeed) ForceUpdateStatus(newStatus *heartbeatpb.MaintainerStatus)
 
 func (c *Changefeed) NeedCheckpointTsMessage() bool {
 	switch c.sinkType {
-	case common.KafkaSinkType, common.PulsarSinkType, common.CloudStorageSinkType, common.BlackHoleSinkType:
+	case common.KafkaSinkType, common.PulsarSinkType, common.CloudStorageSinkType, common.BlackHoleSinkType,
+		common.WebhookSinkType:
 		return true
 	}
 	return false
@@ -296,5 +297,14 @@ func getSinkType(scheme string) common.SinkType {
 	if config.IsStorageScheme(scheme) {
 		return common.CloudStorageSinkType
 	}
+	if config.IsElasticsearchScheme(scheme) {
+		return common.ElasticsearchSinkType
+	}
+	if config.IsWebhookScheme(scheme) {
+		return common.WebhookSinkType
+	}
+	if config.IsPostgresScheme(scheme) {
+		return common.PostgresSinkType
+	}
 	return common.BlackHoleSinkType
 }
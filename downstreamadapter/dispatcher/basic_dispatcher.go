@@ -50,6 +50,7 @@ type DispatcherService interface {
 	GetResolvedTs() uint64
 	GetCheckpointTs() uint64
 	HandleEvents(events []DispatcherEvent, wakeCallback func()) (block bool)
+	HandleError(err error)
 	IsOutputRawChangeEvent() bool
 }
 
@@ -60,7 +61,6 @@ type Dispatcher interface {
 	DispatcherService
 	GetSchemaID() int64
 	HandleDispatcherStatus(*heartbeatpb.DispatcherStatus)
-	HandleError(err error)
 	SetSeq(seq uint64)
 	SetStartTs(startTs uint64)
 	SetCurrentPDTs(currentPDTs uint64)
@@ -261,6 +261,7 @@ func (d *BasicDispatcher) AddDMLEventsToSink(events []*commonEvent.DMLEvent) {
 		d.tableProgress.Add(event)
 	}
 	for _, event := range events {
+		d.sharedInfo.columnTransformers.Apply(event)
 		d.sink.AddDMLEvent(event)
 		failpoint.Inject("BlockAddDMLEvents", nil)
 	}
@@ -491,6 +492,13 @@ func (d *BasicDispatcher) handleEvents(dispatcherEvents []DispatcherEvent, wakeC
 					return block
 				}
 			}
+			// the table created or altered by the ddl must still satisfy the constraints of the
+			// column transformers, such as a unique key column can only be hashed.
+			tableInfos := append([]*common.TableInfo{ddl.TableInfo}, ddl.MultipleTableInfos...)
+			if err := d.sharedInfo.columnTransformers.VerifyTables(tableInfos); err != nil {
+				d.HandleError(err)
+				return block
+			}
 
 			log.Info("dispatcher receive ddl event",
 				zap.Stringer("dispatcher", d.id),
@@ -17,6 +17,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columntransformer"
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
@@ -38,6 +39,8 @@ type SharedInfo struct {
 	integrityConfig *eventpb.IntegrityConfig
 	// the config of filter
 	filterConfig *eventpb.FilterConfig
+	// columnTransformers transforms the column values of the DML events before they are sent to the sink.
+	columnTransformers *columntransformer.ColumnTransformers
 	// if syncPointInfo is not nil, means enable Sync Point feature,
 	syncPointConfig *syncpoint.SyncPointConfig
 
@@ -76,6 +79,7 @@ func NewSharedInfo(
 	outputRawChangeEvent bool,
 	integrityConfig *eventpb.IntegrityConfig,
 	filterConfig *eventpb.FilterConfig,
+	columnTransformers *columntransformer.ColumnTransformers,
 	syncPointConfig *syncpoint.SyncPointConfig,
 	txnAtomicity *config.AtomicityLevel,
 	enableSplittableCheck bool,
@@ -90,6 +94,7 @@ func NewSharedInfo(
 		outputRawChangeEvent:  outputRawChangeEvent,
 		integrityConfig:       integrityConfig,
 		filterConfig:          filterConfig,
+		columnTransformers:    columnTransformers,
 		syncPointConfig:       syncPointConfig,
 		enableSplittableCheck: enableSplittableCheck,
 		statusesChan:          statusesChan,
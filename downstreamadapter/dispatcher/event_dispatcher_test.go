@@ -74,6 +74,7 @@ func newDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan) *Eve
 		false,
 		nil,
 		nil,
+		nil,
 		&syncpoint.SyncPointConfig{
 			SyncPointInterval:  time.Duration(5 * time.Second),
 			SyncPointRetention: time.Duration(10 * time.Minute),
@@ -809,6 +810,7 @@ func TestDispatcherSplittableCheck(t *testing.T) {
 		false,
 		nil,
 		nil,
+		nil,
 		&syncpoint.SyncPointConfig{
 			SyncPointInterval:  time.Duration(5 * time.Second),
 			SyncPointRetention: time.Duration(10 * time.Minute),
@@ -918,6 +920,7 @@ func TestDispatcher_SkipDMLAsStartTs_FilterCorrectly(t *testing.T) {
 		false,
 		nil,
 		nil,
+		nil,
 		&syncpoint.SyncPointConfig{
 			SyncPointInterval:  time.Duration(5 * time.Second),
 			SyncPointRetention: time.Duration(10 * time.Minute),
@@ -997,6 +1000,7 @@ func TestDispatcher_SkipDMLAsStartTs_Disabled(t *testing.T) {
 		false,
 		nil,
 		nil,
+		nil,
 		&syncpoint.SyncPointConfig{
 			SyncPointInterval:  time.Duration(5 * time.Second),
 			SyncPointRetention: time.Duration(10 * time.Minute),
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/ticdc/downstreamadapter/sink"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columntransformer"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -43,6 +44,7 @@ func newRedoDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan)
 		false,
 		nil,
 		nil,
+		nil,
 		nil, // redo dispatcher doesn't need syncPointConfig
 		&defaultAtomicity,
 		false, // enableSplittableCheck
@@ -645,3 +647,59 @@ func TestRedoBatchDMLEventsPartialFlush(t *testing.T) {
 	// Verify that all events were actually flushed
 	require.Equal(t, 0, len(mockSink.GetDMLs()))
 }
+
+func TestRedoDispatcherColumnTransformers(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t(id int primary key, name varchar(32))")
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values(1, 'abc')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.Length = 1
+
+	transformers, err := columntransformer.New(&config.SinkConfig{
+		ColumnTransformers: []*config.ColumnTransformer{
+			{Matcher: []string{"test.t"}, Columns: []string{"name"}, Action: columntransformer.ActionRedact},
+		},
+	})
+	require.NoError(t, err)
+	mockSink := sink.NewMockSink(common.MysqlSinkType)
+	tableSpan, err := getCompleteTableSpan(getTestingKeyspaceID())
+	require.NoError(t, err)
+	dispatcher := newRedoDispatcherForTest(mockSink, tableSpan)
+	// the redo dispatchers share the column transformers with the event dispatchers,
+	// so the redo log never contains the raw values.
+	dispatcher.sharedInfo.columnTransformers = transformers
+	errCh := make(chan error, 1)
+	dispatcher.sharedInfo.errCh = errCh
+
+	nodeID := node.NewID()
+	block := dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, dmlEvent)}, func() {})
+	require.True(t, block)
+	require.Len(t, mockSink.GetDMLs(), 1)
+	row, ok := mockSink.GetDMLs()[0].GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, "***", row.Row.GetString(1))
+	mockSink.FlushDMLs()
+
+	// the unique key on the redacted column is rejected.
+	job := helper.DDL2Job("alter table t add unique key uk(name)")
+	ddlEvent := &commonEvent.DDLEvent{
+		FinishedTs: job.BinlogInfo.FinishedTS,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+		TableInfo: helper.GetTableInfo(job),
+		Query:     job.Query,
+	}
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ddlEvent)}, func() {})
+	require.True(t, block)
+	select {
+	case err = <-errCh:
+		require.Contains(t, err.Error(), "the primary key or unique key column can only be hashed")
+	case <-time.After(time.Second):
+		require.Fail(t, "expected error to be reported within 1 second")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
 	"github.com/pingcap/ticdc/downstreamadapter/eventcollector"
 	"github.com/pingcap/ticdc/downstreamadapter/sink"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columntransformer"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/mysql"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/redo"
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
@@ -159,9 +160,10 @@ func NewDispatcherManager(
 	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
 
 	filterCfg := &eventpb.FilterConfig{
-		CaseSensitive:  cfConfig.CaseSensitive,
-		ForceReplicate: cfConfig.ForceReplicate,
-		FilterConfig:   toFilterConfigPB(cfConfig.Filter),
+		CaseSensitive:   cfConfig.CaseSensitive,
+		ForceReplicate:  cfConfig.ForceReplicate,
+		FilterConfig:    toFilterConfigPB(cfConfig.Filter),
+		ColumnSelectors: toColumnSelectorsPB(cfConfig.SinkURI, cfConfig.SinkConfig),
 	}
 	var integrityCfg *eventpb.IntegrityConfig
 	if cfConfig.SinkConfig.Integrity != nil {
@@ -206,7 +208,10 @@ func NewDispatcherManager(
 		}
 	}
 
-	var err error
+	columnTransformers, err := columntransformer.New(manager.config.SinkConfig)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
 	manager.sink, err = sink.New(ctx, manager.config, manager.changefeedID)
 	if err != nil {
 		return nil, 0, errors.Trace(err)
@@ -229,6 +234,7 @@ func NewDispatcherManager(
 		outputRawChangeEvent,
 		integrityCfg,
 		filterCfg,
+		columnTransformers,
 		syncPointConfig,
 		manager.config.SinkConfig.TxnAtomicity,
 		manager.config.EnableSplittableCheck,
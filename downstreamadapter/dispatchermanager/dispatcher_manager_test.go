@@ -52,6 +52,7 @@ func createTestDispatcher(t *testing.T, manager *DispatcherManager, id common.Di
 		nil,
 		nil,
 		nil,
+		nil,
 		&defaultAtomicity,
 		false,
 		make(chan dispatcher.TableSpanStatusWithSeq, 1),
@@ -109,6 +110,7 @@ func createTestManager(t *testing.T) *DispatcherManager {
 		false, // outputRawChangeEvent
 		nil,   // integrityConfig
 		nil,   // filterConfig
+		nil,   // columnTransformers
 		nil,   // syncPointConfig
 		&defaultAtomicity,
 		false,
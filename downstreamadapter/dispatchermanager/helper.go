@@ -15,6 +15,7 @@ package dispatchermanager
 
 import (
 	"context"
+	"net/url"
 	"sync"
 	"sync/atomic"
 
@@ -109,6 +110,7 @@ func toFilterConfigPB(filter *config.FilterConfig) *eventpb.InnerFilterConfig {
 		Rules:            filter.Rules,
 		IgnoreTxnStartTs: filter.IgnoreTxnStartTs,
 		EventFilters:     make([]*eventpb.EventFilterRule, 0),
+		OnlineDdl:        filter.OnlineDDL,
 	}
 
 	for _, eventFilterRule := range filter.EventFilters {
@@ -137,6 +139,31 @@ func toEventFilterRulePB(rule *config.EventFilterRule) *eventpb.EventFilterRule
 	return eventFilterPB
 }
 
+// toColumnSelectorsPB returns the column selectors pushed down to the event service,
+// they are only pushed down if the sink selects the columns by them.
+func toColumnSelectorsPB(sinkURI string, sinkConfig *config.SinkConfig) []*eventpb.ColumnSelector {
+	if sinkConfig == nil || len(sinkConfig.ColumnSelectors) == 0 {
+		return nil
+	}
+	uri, err := url.Parse(sinkURI)
+	if err != nil {
+		return nil
+	}
+	scheme := config.GetScheme(uri)
+	if !config.IsMQScheme(scheme) && !config.IsWebhookScheme(scheme) && !config.IsElasticsearchScheme(scheme) {
+		return nil
+	}
+
+	selectors := make([]*eventpb.ColumnSelector, 0, len(sinkConfig.ColumnSelectors))
+	for _, selector := range sinkConfig.ColumnSelectors {
+		selectors = append(selectors, &eventpb.ColumnSelector{
+			Matcher: selector.Matcher,
+			Columns: selector.Columns,
+		})
+	}
+	return selectors
+}
+
 type Watermark struct {
 	mutex sync.Mutex
 	*heartbeatpb.Watermark
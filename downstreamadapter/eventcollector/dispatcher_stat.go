@@ -131,6 +131,10 @@ type dispatcherStat struct {
 	// tableInfoVersion is the latest table info version of the dispatcher's corresponding table.
 	// It is updated by ddl event
 	tableInfoVersion atomic.Uint64
+	// evicted is set once the eviction of the dispatcher by the disk quota of the event store
+	// is reported to the changefeed, the dispatcher is not registered again until it's recreated
+	// by the restart of the changefeed.
+	evicted atomic.Bool
 }
 
 func newDispatcherStat(
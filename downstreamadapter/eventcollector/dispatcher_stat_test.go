@@ -127,6 +127,8 @@ func (m *mockDispatcher) GetIntegrityConfig() *eventpb.IntegrityConfig {
 	return &eventpb.IntegrityConfig{}
 }
 
+func (m *mockDispatcher) HandleError(err error) {}
+
 func (m *mockDispatcher) IsOutputRawChangeEvent() bool {
 	return false
 }
@@ -467,19 +467,38 @@ func (c *EventCollector) handleDispatcherHeartbeatResponse(targetMessage *messag
 
 	response := targetMessage.Message[0].(*event.DispatcherHeartbeatResponse)
 	for _, ds := range response.DispatcherStates {
+		if ds.State != event.DSStateRemoved && ds.State != event.DSStateEvicted {
+			continue
+		}
+		v, ok := c.dispatcherMap.Load(ds.DispatcherID)
+		if !ok {
+			continue
+		}
+		stat := v.(*dispatcherStat)
+		// If the serverID not match, it means the dispatcher is not registered on this server now, just ignore it the response.
+		if !stat.connState.isCurrentEventService(targetMessage.From) {
+			continue
+		}
+		// The evicted dispatcher waits for the changefeed to restart, it's neither registered
+		// again nor reported repeatedly by the following heartbeat responses.
+		if stat.evicted.Load() {
+			continue
+		}
 		// This means that the dispatcher is removed in the event service we have to reset it.
 		if ds.State == event.DSStateRemoved {
-			v, ok := c.dispatcherMap.Load(ds.DispatcherID)
-			if !ok {
-				continue
-			}
-			stat := v.(*dispatcherStat)
-			// If the serverID not match, it means the dispatcher is not registered on this server now, just ignore it the response.
-			if stat.connState.isCurrentEventService(targetMessage.From) {
-				// register the dispatcher again
-				stat.registerTo(targetMessage.From)
-			}
+			// register the dispatcher again
+			stat.registerTo(targetMessage.From)
+			continue
 		}
+		// The data of the dispatcher is evicted by the disk quota of the event store,
+		// report the error to restart the changefeed later instead of registering it again.
+		stat.evicted.Store(true)
+		log.Warn("dispatcher is evicted by the disk quota of the event store",
+			zap.Stringer("changefeedID", stat.target.GetChangefeedID()),
+			zap.Stringer("dispatcherID", ds.DispatcherID),
+			zap.Stringer("eventServiceID", targetMessage.From))
+		stat.target.HandleError(errors.ErrEventStoreDiskQuotaExceeded.GenWithStackByArgs(
+			ds.DispatcherID.String(), targetMessage.From.String()))
 	}
 }
 
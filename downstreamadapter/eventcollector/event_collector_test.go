@@ -26,6 +26,7 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,7 @@ type mockEventDispatcher struct {
 	tableSpan    *heartbeatpb.TableSpan
 	handle       func(commonEvent.Event)
 	changefeedID common.ChangeFeedID
+	errs         []error
 }
 
 func (m *mockEventDispatcher) GetId() common.DispatcherID {
@@ -111,6 +113,10 @@ func (m *mockEventDispatcher) GetBlockEventStatus() *heartbeatpb.State {
 	return &heartbeatpb.State{}
 }
 
+func (m *mockEventDispatcher) HandleError(err error) {
+	m.errs = append(m.errs, err)
+}
+
 func (m *mockEventDispatcher) IsOutputRawChangeEvent() bool {
 	return false
 }
@@ -232,3 +238,54 @@ func TestRemoveLastDispatcher(t *testing.T) {
 	_, ok = c.changefeedMap.Load(cfID2.ID())
 	require.True(t, ok, "changefeedStat for cfID2 should not be affected")
 }
+
+func TestHandleDispatcherHeartbeatResponse(t *testing.T) {
+	ctx := context.Background()
+	nodeInfo := node.NewInfo("127.0.0.1:18300", "")
+	mc := messaging.NewMessageCenter(ctx, nodeInfo.ID, config.NewDefaultMessageCenterConfig(nodeInfo.AdvertiseAddr), nil)
+	mc.Run(ctx)
+	defer mc.Close()
+	appcontext.SetService(appcontext.MessageCenter, mc)
+	c := New(nodeInfo.ID)
+
+	d := &mockEventDispatcher{id: common.NewDispatcherID(), tableSpan: &heartbeatpb.TableSpan{TableID: 1}}
+	c.AddDispatcher(d, 1024)
+	v, ok := c.dispatcherMap.Load(d.id)
+	require.True(t, ok)
+	remoteServerID := node.ID("remote-server")
+	v.(*dispatcherStat).connState.setEventServiceID(remoteServerID)
+
+	newResponse := func(state commonEvent.DSState) *commonEvent.DispatcherHeartbeatResponse {
+		response := commonEvent.NewDispatcherHeartbeatResponse()
+		response.Append(commonEvent.NewDispatcherState(d.id, state))
+		return response
+	}
+
+	// drain the register request sent when the dispatcher is added.
+	receiveRegisterRequest := func() bool {
+		select {
+		case <-c.dispatcherMessageChan.Out():
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+	require.True(t, receiveRegisterRequest())
+
+	// the response from other event service is ignored.
+	c.handleDispatcherHeartbeatResponse(newMessage("other-server", newResponse(commonEvent.DSStateEvicted)))
+	require.Empty(t, d.errs)
+
+	// the removed dispatcher is registered again.
+	c.handleDispatcherHeartbeatResponse(newMessage(remoteServerID, newResponse(commonEvent.DSStateRemoved)))
+	require.True(t, receiveRegisterRequest())
+
+	// the evicted dispatcher reports the error to the changefeed once, and it's not registered
+	// again even if it's replied as removed after the evicted data is dropped.
+	c.handleDispatcherHeartbeatResponse(newMessage(remoteServerID, newResponse(commonEvent.DSStateEvicted)))
+	c.handleDispatcherHeartbeatResponse(newMessage(remoteServerID, newResponse(commonEvent.DSStateEvicted)))
+	c.handleDispatcherHeartbeatResponse(newMessage(remoteServerID, newResponse(commonEvent.DSStateRemoved)))
+	require.Len(t, d.errs, 1)
+	require.True(t, errors.ErrEventStoreDiskQuotaExceeded.Equal(d.errs[0]))
+	require.False(t, receiveRegisterRequest())
+}
@@ -24,10 +24,12 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/metacolumn"
 	putil "github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/meta/model"
@@ -52,6 +54,8 @@ type sink struct {
 	storage              storage.ExternalStorage
 
 	dmlWriters *dmlWriters
+	// metadataColumns appends the metadata columns to the rows, it's nil if there is no metadata column.
+	metadataColumns *metacolumn.Appender
 
 	checkpointChan           chan uint64
 	lastCheckpointTs         atomic.Uint64
@@ -107,14 +111,19 @@ func New(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	// get cloud storage file extension according to the specific protocol.
-	ext := helper.GetFileExtension(protocol)
+	// get cloud storage file extension according to the specific protocol,
+	// the extension of the compression is appended if the file is compressed.
+	ext := helper.GetFileExtension(protocol) + compression.FileExtension(cfg.Compression)
 	// the last param maxMsgBytes is mainly to limit the size of a single message for
 	// batch protocols in mq scenario. In cloud storage sink, we just set it to max int.
 	encoderConfig, err := helper.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, math.MaxInt)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	metadataColumns, err := metacolumn.New(sinkConfig.MetadataColumns, changefeedID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storage, err := putil.GetExternalStorageWithDefaultTimeout(ctx, sinkURI.String())
 	if err != nil {
 		return nil, err
@@ -127,6 +136,7 @@ func New(
 		cleanupJobs:              cleanupJobs,
 		storage:                  storage,
 		dmlWriters:               newDMLWriters(changefeedID, storage, cfg, encoderConfig, ext, statistics),
+		metadataColumns:          metadataColumns,
 		checkpointChan:           make(chan uint64, 16),
 		lastSendCheckpointTsTime: time.Now(),
 		outputRawChangeEvent:     sinkConfig.CloudStorageConfig.GetOutputRawChangeEvent(),
@@ -167,6 +177,7 @@ func (s *sink) IsNormal() bool {
 }
 
 func (s *sink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	s.metadataColumns.Apply(event)
 	s.dmlWriters.AddDMLEvent(event)
 }
 
@@ -195,21 +206,26 @@ func (s *sink) writeDDLEvent(event *commonEvent.DDLEvent) error {
 	// write the previous table first
 	if event.GetDDLType() == model.ActionExchangeTablePartition {
 		var def cloudstorage.TableDefinition
-		def.FromTableInfo(event.ExtraSchemaName, event.ExtraTableName, event.TableInfo, event.FinishedTs, s.cfg.OutputColumnID)
+		def.FromTableInfo(event.ExtraSchemaName, event.ExtraTableName,
+			s.metadataColumns.TableInfo(event.TableInfo), event.FinishedTs, s.cfg.OutputColumnID)
 		def.Query = event.Query
 		def.Type = event.Type
 		if err := s.writeFile(event, def); err != nil {
 			return err
 		}
 		var sourceTableDef cloudstorage.TableDefinition
-		sourceTableDef.FromTableInfo(event.SchemaName, event.TableName, event.MultipleTableInfos[1], event.FinishedTs, s.cfg.OutputColumnID)
+		sourceTableDef.FromTableInfo(event.SchemaName, event.TableName,
+			s.metadataColumns.TableInfo(event.MultipleTableInfos[1]), event.FinishedTs, s.cfg.OutputColumnID)
 		if err := s.writeFile(event, sourceTableDef); err != nil {
 			return err
 		}
 	} else {
 		for _, e := range event.GetEvents() {
 			var def cloudstorage.TableDefinition
-			def.FromDDLEvent(e, s.cfg.OutputColumnID)
+			def.FromTableInfo(e.SchemaName, e.TableName,
+				s.metadataColumns.TableInfo(e.TableInfo), e.FinishedTs, s.cfg.OutputColumnID)
+			def.Query = e.Query
+			def.Type = e.Type
 			if err := s.writeFile(e, def); err != nil {
 				return err
 			}
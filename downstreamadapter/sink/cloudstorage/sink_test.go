@@ -19,6 +19,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/ticdc/pkg/util/s3mock"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -271,3 +273,79 @@ func TestCleanupExpiredFiles(t *testing.T) {
 	time.Sleep(5 * time.Second)
 	require.LessOrEqual(t, int64(1), count.Load())
 }
+
+func TestS3Storage(t *testing.T) {
+	s3Server := s3mock.NewServer(t, "bucket")
+	// the faults are recovered by the retryer of the external storage.
+	s3Server.InjectThrottling(s3mock.OpHeadObject, 1)
+	uri := s3Server.URI("bucket", "cdc") + "&protocol=csv&flush-interval=200ms"
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.CloudStorageConfig = &config.CloudStorageConfig{
+		FlushConcurrency: util.AddressOf(2),
+	}
+	err = replicaConfig.ValidateAndAdjust(sinkURI)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockPDClock := pdutil.NewClock4Test()
+	appcontext.SetService(appcontext.DefaultPDClock, mockPDClock)
+
+	cloudStorageSink, err := newSinkForTest(ctx, replicaConfig, sinkURI, nil)
+	require.NoError(t, err)
+	go cloudStorageSink.Run(ctx)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	helper.ApplyJob(job)
+	tableInfo := helper.GetTableInfo(job)
+
+	s3Server.InjectInternalError(s3mock.OpPutObject, 1)
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 100,
+		TableInfo:  tableInfo,
+	}
+	require.NoError(t, cloudStorageSink.WriteBlockEvent(ddlEvent))
+	var schemaKeys []string
+	for _, key := range s3Server.Keys("bucket") {
+		if strings.HasPrefix(key, "cdc/test/t/meta/schema_100_") {
+			schemaKeys = append(schemaKeys, key)
+		}
+	}
+	require.Len(t, schemaKeys, 1)
+
+	var flushed atomic.Int64
+	s3Server.InjectThrottling(s3mock.OpPutObject, 1)
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2')")
+	dmlEvent.TableInfoVersion = 100
+	dmlEvent.CommitTs = 200
+	dmlEvent.AddPostFlushFunc(func() { flushed.Add(1) })
+	cloudStorageSink.AddDMLEvent(dmlEvent)
+
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 1
+	}, 30*time.Second, 100*time.Millisecond)
+	var dataKeys []string
+	for _, key := range s3Server.Keys("bucket") {
+		if path.Ext(key) == ".csv" {
+			dataKeys = append(dataKeys, key)
+		}
+	}
+	require.Len(t, dataKeys, 1)
+	data, ok := s3Server.Object("bucket", dataKeys[0])
+	require.True(t, ok)
+	require.Contains(t, string(data), `"test"`)
+	require.Contains(t, string(data), `"test2"`)
+	require.GreaterOrEqual(t, s3Server.RequestCount(s3mock.OpPutObject), 4)
+}
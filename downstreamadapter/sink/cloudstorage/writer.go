@@ -25,11 +25,14 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/metrics"
 	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/compression"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	pmetrics "github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/parquet"
 	"github.com/pingcap/ticdc/utils/chann"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/prometheus/client_golang/prometheus"
@@ -44,6 +47,7 @@ type writer struct {
 	changeFeedID commonType.ChangeFeedID
 	storage      storage.ExternalStorage
 	config       *cloudstorage.Config
+	protocol     config.Protocol
 	// toBeFlushedCh contains a set of batchedTask waiting to be flushed to cloud storage.
 	toBeFlushedCh          chan batchedTask
 	inputCh                *chann.DrainableChann[eventFragment]
@@ -62,6 +66,7 @@ func newWriter(
 	changefeedID commonType.ChangeFeedID,
 	storage storage.ExternalStorage,
 	config *cloudstorage.Config,
+	protocol config.Protocol,
 	extension string,
 	inputCh *chann.DrainableChann[eventFragment],
 	statistics *pmetrics.Statistics,
@@ -71,6 +76,7 @@ func newWriter(
 		changeFeedID:      changefeedID,
 		storage:           storage,
 		config:            config,
+		protocol:          protocol,
 		inputCh:           inputCh,
 		toBeFlushedCh:     make(chan batchedTask, 64),
 		statistics:        statistics,
@@ -241,11 +247,31 @@ func (d *writer) writeDataFile(ctx context.Context, path string, task *singleTab
 		buf.Write(msg.Value)
 		callbacks = append(callbacks, msg.Callback)
 	}
+	data := buf.Bytes()
+	// the parquet file can not be built by concatenating the messages,
+	// all rows of the task are written into one row group.
+	if d.protocol == config.ProtocolParquet {
+		var err error
+		data, err = parquet.BuildFile(task.tableInfo, task.msgs)
+		if err != nil {
+			return err
+		}
+		bytesCnt = int64(len(data))
+	}
+	// the whole file is compressed, so that it can be decompressed by the common tools.
+	if d.config.Compression != "" && d.config.Compression != compression.None {
+		var err error
+		data, err = compression.EncodeWithLevel(d.config.Compression, d.config.CompressionLevel, data)
+		if err != nil {
+			return err
+		}
+		bytesCnt = int64(len(data))
+	}
 
 	if err := d.statistics.RecordBatchExecution(func() (int, int64, error) {
 		start := time.Now()
 		if d.config.FlushConcurrency <= 1 {
-			return rowsCnt, bytesCnt, d.storage.WriteFile(ctx, path, buf.Bytes())
+			return rowsCnt, bytesCnt, d.storage.WriteFile(ctx, path, data)
 		}
 
 		writer, inErr := d.storage.Create(ctx, path, &storage.WriterOption{
@@ -268,7 +294,7 @@ func (d *writer) writeDataFile(ctx context.Context, path string, task *singleTab
 				}
 			}
 		}()
-		if _, inErr = writer.Write(ctx, buf.Bytes()); inErr != nil {
+		if _, inErr = writer.Write(ctx, data); inErr != nil {
 			return 0, 0, inErr
 		}
 
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"path"
 	"sync"
 	"testing"
@@ -26,6 +27,7 @@ import (
 	commonType "github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/pdutil"
@@ -41,7 +43,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func testWriter(ctx context.Context, t *testing.T, dir string) *writer {
+func testWriter(ctx context.Context, t *testing.T, dir string, cc string) *writer {
 	uri := fmt.Sprintf("file:///%s?flush-interval=2s", dir)
 	storage, err := util.GetExternalStorageWithDefaultTimeout(ctx, uri)
 	require.Nil(t, err)
@@ -50,6 +52,7 @@ func testWriter(ctx context.Context, t *testing.T, dir string) *writer {
 	cfg := cloudstorage.NewConfig()
 	replicaConfig := config.GetDefaultReplicaConfig()
 	replicaConfig.Sink.DateSeparator = util.AddressOf(config.DateSeparatorNone.String())
+	replicaConfig.Sink.CloudStorageConfig = &config.CloudStorageConfig{Compression: util.AddressOf(cc)}
 	err = cfg.Apply(context.TODO(), sinkURI, replicaConfig.Sink)
 	cfg.FileIndexWidth = 6
 	require.Nil(t, err)
@@ -61,16 +64,26 @@ func testWriter(ctx context.Context, t *testing.T, dir string) *writer {
 	mockPDClock := pdutil.NewClock4Test()
 	appcontext.SetService(appcontext.DefaultPDClock, mockPDClock)
 	d := newWriter(1, changefeedID, storage,
-		cfg, ".json", chann.NewAutoDrainChann[eventFragment](), statistics)
+		cfg, config.ProtocolCanalJSON, ".json"+compression.FileExtension(cc), chann.NewAutoDrainChann[eventFragment](), statistics)
 	return d
 }
 
 func TestWriterRun(t *testing.T) {
 	t.Parallel()
 
+	testWriterRun(t, compression.None)
+}
+
+func TestWriterRunWithCompression(t *testing.T) {
+	t.Parallel()
+
+	testWriterRun(t, compression.Zstd)
+}
+
+func testWriterRun(t *testing.T, cc string) {
 	ctx, cancel := context.WithCancel(context.Background())
 	parentDir := t.TempDir()
-	d := testWriter(ctx, t, parentDir)
+	d := testWriter(ctx, t, parentDir, cc)
 	fragCh := d.inputCh
 	table1Dir := path.Join(parentDir, "test/table1/99")
 
@@ -122,7 +135,14 @@ func TestWriterRun(t *testing.T) {
 	// check whether files for table1 has been generated
 	fileNames := getTableFiles(t, table1Dir)
 	require.Len(t, fileNames, 2)
-	require.ElementsMatch(t, []string{"CDC000001.json", "CDC.index"}, fileNames)
+	dataFile := "CDC000001.json" + compression.FileExtension(cc)
+	require.ElementsMatch(t, []string{dataFile, "CDC.index"}, fileNames)
+	data, err := os.ReadFile(path.Join(table1Dir, dataFile))
+	require.NoError(t, err)
+	require.Equal(t, cc, compression.Detect(data))
+	data, err = compression.Decode(cc, data)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"data":[{"c1":"100","c2":"hello world"}]`)
 	fragCh.CloseAndDrain()
 	cancel()
 	d.close()
@@ -0,0 +1,321 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columntransformer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+const (
+	// ActionHash replaces the value with the hex encoded SHA-256 of the salt and the value.
+	ActionHash = "hash"
+	// ActionRedact replaces every character of the value with `*`.
+	ActionRedact = "redact"
+	// ActionTruncate keeps the first `length` characters of the value.
+	ActionTruncate = "truncate"
+	// ActionConstant replaces the value with a constant.
+	ActionConstant = "constant"
+	// ActionNull replaces the value with NULL.
+	ActionNull = "null"
+
+	// hashLength is the length of the hex encoded SHA-256.
+	hashLength = sha256.Size * 2
+	redactChar = '*'
+)
+
+type columnTransformer struct {
+	tableF  filter.Filter
+	columnM filter.ColumnFilter
+
+	action string
+	salt   string
+	length int
+	value  string
+}
+
+func newColumnTransformer(
+	rule *config.ColumnTransformer, caseSensitive bool,
+) (*columnTransformer, error) {
+	tableM, err := filter.Parse(rule.Matcher)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrFilterRuleInvalid, err, rule.Matcher)
+	}
+	if !caseSensitive {
+		tableM = filter.CaseInsensitive(tableM)
+	}
+	columnM, err := filter.ParseColumnFilter(rule.Columns)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrFilterRuleInvalid, err, rule.Columns)
+	}
+
+	action := strings.ToLower(rule.Action)
+	switch action {
+	case ActionHash, ActionRedact, ActionConstant, ActionNull:
+	case ActionTruncate:
+		if rule.Length <= 0 {
+			return nil, errors.ErrColumnTransformerFailed.GenWithStack(
+				"the length of the truncate action must be greater than 0, matcher: %v", rule.Matcher)
+		}
+	default:
+		return nil, errors.ErrColumnTransformerFailed.GenWithStack(
+			"invalid column transformer action %s, which must be %s, %s, %s, %s or %s, matcher: %v",
+			rule.Action, ActionHash, ActionRedact, ActionTruncate, ActionConstant, ActionNull, rule.Matcher)
+	}
+
+	return &columnTransformer{
+		tableF:  tableM,
+		columnM: columnM,
+		action:  action,
+		salt:    rule.Salt,
+		length:  rule.Length,
+		value:   rule.Value,
+	}, nil
+}
+
+func (t *columnTransformer) match(schema, table string) bool {
+	return t.tableF.MatchTable(schema, table)
+}
+
+// transform returns the transformed datum, the NULL value is kept as is.
+func (t *columnTransformer) transform(d types.Datum, ft *types.FieldType) types.Datum {
+	if d.IsNull() {
+		return d
+	}
+	switch t.action {
+	case ActionHash:
+		sum := sha256.Sum256(append([]byte(t.salt), d.GetBytes()...))
+		return newStringDatum(hex.EncodeToString(sum[:]), ft)
+	case ActionRedact:
+		return newStringDatum(strings.Repeat(string(redactChar), valueLength(d.GetBytes(), ft)), ft)
+	case ActionTruncate:
+		return newStringDatum(truncate(d.GetBytes(), t.length, ft), ft)
+	case ActionConstant:
+		return newStringDatum(t.value, ft)
+	default:
+		return types.NewDatum(nil)
+	}
+}
+
+// verify returns the error if the column can not be transformed by the transformer.
+func (t *columnTransformer) verify(table *common.TableInfo, col *model.ColumnInfo, handleKey bool) error {
+	if col.IsGenerated() {
+		return errors.ErrColumnTransformerFailed.GenWithStack(
+			"the generated column can not be transformed, table: %v, column: %s", table.TableName, col.Name)
+	}
+	if t.action == ActionNull {
+		if mysql.HasNotNullFlag(col.GetFlag()) {
+			return errors.ErrColumnTransformerFailed.GenWithStack(
+				"the not null column can not be set to null, table: %v, column: %s", table.TableName, col.Name)
+		}
+	} else if !isStringType(col.GetType()) {
+		return errors.ErrColumnTransformerFailed.GenWithStack(
+			"the %s action only supports the string column, table: %v, column: %s",
+			t.action, table.TableName, col.Name)
+	}
+	// the hash action is the only one which keeps the values distinct.
+	if handleKey && t.action != ActionHash {
+		return errors.ErrColumnTransformerFailed.GenWithStack(
+			"the primary key or unique key column can only be hashed, table: %v, column: %s",
+			table.TableName, col.Name)
+	}
+
+	flen := col.GetFlen()
+	switch t.action {
+	case ActionHash:
+		if flen != types.UnspecifiedLength && flen < hashLength {
+			return errors.ErrColumnTransformerFailed.GenWithStack(
+				"the column is too short to store the hash value, at least %d characters are required, "+
+					"table: %v, column: %s", hashLength, table.TableName, col.Name)
+		}
+	case ActionConstant:
+		if flen != types.UnspecifiedLength && valueLength([]byte(t.value), &col.FieldType) > flen {
+			return errors.ErrColumnTransformerFailed.GenWithStack(
+				"the constant value is too long for the column, table: %v, column: %s", table.TableName, col.Name)
+		}
+	}
+	return nil
+}
+
+// ColumnTransformers manages an array of transformers, the value of a column is
+// transformed by the first transformer which matches both the table and the column.
+type ColumnTransformers struct {
+	transformers []*columnTransformer
+}
+
+// New return a column transformers
+func New(sinkConfig *config.SinkConfig) (*ColumnTransformers, error) {
+	transformers := make([]*columnTransformer, 0, len(sinkConfig.ColumnTransformers))
+	for _, r := range sinkConfig.ColumnTransformers {
+		transformer, err := newColumnTransformer(r, sinkConfig.CaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		transformers = append(transformers, transformer)
+	}
+	return &ColumnTransformers{
+		transformers: transformers,
+	}, nil
+}
+
+// get returns the transformer of every column of the table, the element is nil
+// if the column is not transformed. It returns nil if no column is transformed.
+func (c *ColumnTransformers) get(table *common.TableInfo) []*columnTransformer {
+	var result []*columnTransformer
+	for _, t := range c.transformers {
+		if !t.match(table.TableName.Schema, table.TableName.Table) {
+			continue
+		}
+		for i, col := range table.GetColumns() {
+			if col == nil || !t.columnM.MatchColumn(col.Name.O) {
+				continue
+			}
+			if result == nil {
+				result = make([]*columnTransformer, len(table.GetColumns()))
+			}
+			if result[i] == nil {
+				result[i] = t
+			}
+		}
+	}
+	return result
+}
+
+// VerifyTables return the error if any given table cannot satisfy the column transformer constraints.
+// 1. the generated column can not be transformed.
+// 2. the primary key and unique key columns can only be hashed.
+// 3. only the nullable column can be set to null, and other actions only support the string column.
+// 4. the column must be long enough to store the transformed value.
+func (c *ColumnTransformers) VerifyTables(infos []*common.TableInfo) error {
+	if c == nil || len(c.transformers) == 0 {
+		return nil
+	}
+	for _, table := range infos {
+		if table == nil {
+			continue
+		}
+		transformers := c.get(table)
+		if transformers == nil {
+			continue
+		}
+		handleKeyColumns := make(map[string]struct{})
+		for _, name := range table.GetPrimaryKeyColumnNames() {
+			handleKeyColumns[name] = struct{}{}
+		}
+		for _, index := range table.GetIndices() {
+			if !index.Unique {
+				continue
+			}
+			for _, col := range index.Columns {
+				handleKeyColumns[col.Name.O] = struct{}{}
+			}
+		}
+		for i, col := range table.GetColumns() {
+			if transformers[i] == nil {
+				continue
+			}
+			_, handleKey := handleKeyColumns[col.Name.O]
+			if err := transformers[i].verify(table, col, handleKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Apply transforms the column values of the event in place. The rows of the event
+// are copied into a new chunk since the chunk may be shared by other events.
+func (c *ColumnTransformers) Apply(event *commonEvent.DMLEvent) {
+	if c == nil || len(c.transformers) == 0 || event.Rows == nil || event.TableInfo == nil {
+		return
+	}
+	transformers := c.get(event.TableInfo)
+	if transformers == nil {
+		return
+	}
+	fieldTypes := event.TableInfo.GetFieldSlice()
+	// every update event has two physical rows and two row types.
+	rowCount := len(event.RowTypes)
+	rows := chunk.NewChunkWithCapacity(fieldTypes, rowCount)
+	for i := 0; i < rowCount; i++ {
+		row := event.Rows.GetRow(event.PreviousTotalOffset + i)
+		for j, ft := range fieldTypes {
+			d := row.GetDatum(j, ft)
+			if transformers[j] != nil {
+				d = transformers[j].transform(d, ft)
+			}
+			rows.AppendDatum(j, &d)
+		}
+	}
+	event.Rows = rows
+	event.PreviousTotalOffset = 0
+}
+
+func isStringType(tp byte) bool {
+	switch tp {
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return true
+	}
+	return false
+}
+
+func isBinary(ft *types.FieldType) bool {
+	return ft.GetCharset() == charset.CharsetBin
+}
+
+// valueLength returns the length of the value in bytes for the binary column,
+// otherwise in characters.
+func valueLength(value []byte, ft *types.FieldType) int {
+	if isBinary(ft) {
+		return len(value)
+	}
+	return utf8.RuneCount(value)
+}
+
+func truncate(value []byte, length int, ft *types.FieldType) string {
+	if isBinary(ft) {
+		if len(value) > length {
+			value = value[:length]
+		}
+		return string(value)
+	}
+	for i := range string(value) {
+		if length == 0 {
+			return string(value[:i])
+		}
+		length--
+	}
+	return string(value)
+}
+
+func newStringDatum(value string, ft *types.FieldType) types.Datum {
+	if isBinary(ft) {
+		return types.NewBytesDatum([]byte(value))
+	}
+	return types.NewStringDatum(value)
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columntransformer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewColumnTransformers(t *testing.T) {
+	replicaConfig := config.GetDefaultReplicaConfig()
+	transformers, err := New(replicaConfig.Sink)
+	require.NoError(t, err)
+	require.Len(t, transformers.transformers, 0)
+
+	replicaConfig.Sink.ColumnTransformers = []*config.ColumnTransformer{
+		{Matcher: []string{"test.*"}, Columns: []string{"email"}, Action: "HASH", Salt: "s"},
+		{Matcher: []string{"test.*"}, Columns: []string{"phone"}, Action: ActionTruncate, Length: 3},
+		{Matcher: []string{"test.*"}, Columns: []string{"name"}, Action: ActionConstant, Value: "x"},
+	}
+	transformers, err = New(replicaConfig.Sink)
+	require.NoError(t, err)
+	require.Len(t, transformers.transformers, 3)
+	require.Equal(t, ActionHash, transformers.transformers[0].action)
+
+	for _, rule := range []*config.ColumnTransformer{
+		{Matcher: []string{"test.*"}, Columns: []string{"a"}, Action: "encrypt"},
+		{Matcher: []string{"test.*"}, Columns: []string{"a"}, Action: ActionTruncate},
+		{Matcher: []string{"[test.*"}, Columns: []string{"a"}, Action: ActionNull},
+	} {
+		replicaConfig.Sink.ColumnTransformers = []*config.ColumnTransformer{rule}
+		_, err = New(replicaConfig.Sink)
+		require.Error(t, err)
+	}
+}
+
+func TestColumnTransformersVerifyTables(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	event := helper.DDL2Event("create table t (id int primary key, uk varchar(64) unique, " +
+		"email varchar(64), phone varchar(8), age int, note text not null)")
+	infos := []*common.TableInfo{event.TableInfo}
+
+	for _, c := range []struct {
+		rule  *config.ColumnTransformer
+		valid bool
+	}{
+		{rule: &config.ColumnTransformer{Columns: []string{"email"}, Action: ActionHash}, valid: true},
+		{rule: &config.ColumnTransformer{Columns: []string{"uk"}, Action: ActionHash}, valid: true},
+		{rule: &config.ColumnTransformer{Columns: []string{"age"}, Action: ActionNull}, valid: true},
+		{rule: &config.ColumnTransformer{Columns: []string{"note"}, Action: ActionRedact}, valid: true},
+		{rule: &config.ColumnTransformer{Columns: []string{"phone"}, Action: ActionConstant, Value: "12345678"}, valid: true},
+		// the hash value is too long for the column.
+		{rule: &config.ColumnTransformer{Columns: []string{"phone"}, Action: ActionHash}},
+		// the unique key column can only be hashed.
+		{rule: &config.ColumnTransformer{Columns: []string{"uk"}, Action: ActionRedact}},
+		// the int column can only be set to null.
+		{rule: &config.ColumnTransformer{Columns: []string{"age"}, Action: ActionRedact}},
+		// the not null column can not be set to null.
+		{rule: &config.ColumnTransformer{Columns: []string{"note"}, Action: ActionNull}},
+		{rule: &config.ColumnTransformer{Columns: []string{"phone"}, Action: ActionConstant, Value: "123456789"}},
+	} {
+		c.rule.Matcher = []string{"test.t"}
+		replicaConfig := config.GetDefaultReplicaConfig()
+		replicaConfig.Sink.ColumnTransformers = []*config.ColumnTransformer{c.rule}
+		transformers, err := New(replicaConfig.Sink)
+		require.NoError(t, err)
+		err = transformers.VerifyTables(infos)
+		if c.valid {
+			require.NoError(t, err, "%+v", c.rule)
+		} else {
+			require.Error(t, err, "%+v", c.rule)
+		}
+	}
+}
+
+func TestColumnTransformersApply(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, email varchar(64), phone varchar(16), " +
+		"name varchar(16), note varchar(16), age int)")
+	helper.DDL2Job("create table t1 (id int primary key, email varchar(64))")
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.ColumnTransformers = []*config.ColumnTransformer{
+		{Matcher: []string{"test.t"}, Columns: []string{"email"}, Action: ActionHash, Salt: "salt"},
+		{Matcher: []string{"test.t"}, Columns: []string{"phone"}, Action: ActionRedact},
+		{Matcher: []string{"test.t"}, Columns: []string{"name"}, Action: ActionTruncate, Length: 2},
+		{Matcher: []string{"test.t"}, Columns: []string{"note"}, Action: ActionConstant, Value: "masked"},
+		{Matcher: []string{"test.t"}, Columns: []string{"age", "note"}, Action: ActionNull},
+	}
+	transformers, err := New(replicaConfig.Sink)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("salta@b.com"))
+	hash := hex.EncodeToString(sum[:])
+
+	event := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a@b.com', '1234', '张三丰', 'n', 18)",
+		"insert into t values (2, null, null, 'ab', null, null)")
+	transformers.Apply(event)
+	row, ok := event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, int64(1), row.Row.GetInt64(0))
+	require.Equal(t, hash, row.Row.GetString(1))
+	require.Equal(t, "****", row.Row.GetString(2))
+	require.Equal(t, "张三", row.Row.GetString(3))
+	require.Equal(t, "masked", row.Row.GetString(4))
+	require.True(t, row.Row.IsNull(5))
+	row, ok = event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, int64(2), row.Row.GetInt64(0))
+	require.True(t, row.Row.IsNull(1))
+	require.True(t, row.Row.IsNull(2))
+	require.Equal(t, "ab", row.Row.GetString(3))
+	require.True(t, row.Row.IsNull(4))
+
+	// both the pre-image and the post-image of the update event are transformed.
+	event, _ = helper.DML2UpdateEvent("test", "t",
+		"insert into t values (3, 'a@b.com', '12', 'abc', 'n', 1)",
+		"update t set phone = '123' where id = 3")
+	transformers.Apply(event)
+	row, ok = event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, hash, row.PreRow.GetString(1))
+	require.Equal(t, "**", row.PreRow.GetString(2))
+	require.Equal(t, hash, row.Row.GetString(1))
+	require.Equal(t, "***", row.Row.GetString(2))
+
+	// the event of the unmatched table is not changed.
+	event = helper.DML2Event("test", "t1", "insert into t1 values (1, 'a@b.com')")
+	rows := event.Rows
+	transformers.Apply(event)
+	require.Same(t, rows, event.Rows)
+	row, ok = event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, "a@b.com", row.Row.GetString(1))
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"hash/fnv"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/eventrouter"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/elasticsearch"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultIndexRule is used if the default index is not set in the sink URI,
+// each table is written to its own index.
+const defaultIndexRule = "{schema}_{table}"
+
+// sink writes the row changes to the elasticsearch cluster by the bulk API.
+// Each table is mapped to an index by the dispatch rules, the topic expression is used as the index name.
+// The document id is the handle key of the row, so the table must have a primary key or a not null unique key.
+// DDL events are ignored since the index is schemaless.
+type sink struct {
+	changefeedID commonType.ChangeFeedID
+	cfg          *elasticsearch.Config
+	client       *elasticsearch.Client
+
+	eventRouter    *eventrouter.EventRouter
+	columnSelector *columnselector.ColumnSelectors
+	// workers write the events concurrently, the events of the same index
+	// are always dispatched to the same worker to keep the order.
+	workers    []*worker
+	statistics *metrics.Statistics
+
+	// isNormal indicate whether the sink is in the normal state.
+	isNormal *atomic.Bool
+}
+
+// Verify checks whether the elasticsearch sink can be created by the given config.
+func Verify(ctx context.Context, changefeedID commonType.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
+	cfg, _, _, err := newSinkComponents(sinkURI, sinkConfig)
+	if err != nil {
+		return err
+	}
+	return elasticsearch.NewClient(cfg).Ping(ctx)
+}
+
+// New creates an elasticsearch sink.
+func New(
+	ctx context.Context, changefeedID commonType.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (*sink, error) {
+	cfg, eventRouter, columnSelector, err := newSinkComponents(sinkURI, sinkConfig)
+	if err != nil {
+		return nil, err
+	}
+	client := elasticsearch.NewClient(cfg)
+	if err = client.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	statistics := metrics.NewStatistics(changefeedID, "elasticsearch")
+	workers := make([]*worker, cfg.WorkerCount)
+	for i := range workers {
+		workers[i] = newWorker(i, changefeedID, cfg, client, columnSelector, statistics)
+	}
+	log.Info("elasticsearch sink created",
+		zap.String("keyspace", changefeedID.Keyspace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.String("endpoint", cfg.Endpoint),
+		zap.String("defaultIndex", cfg.DefaultIndex),
+		zap.Int("workerCount", cfg.WorkerCount))
+	return &sink{
+		changefeedID:   changefeedID,
+		cfg:            cfg,
+		client:         client,
+		eventRouter:    eventRouter,
+		columnSelector: columnSelector,
+		workers:        workers,
+		statistics:     statistics,
+		isNormal:       atomic.NewBool(true),
+	}, nil
+}
+
+func newSinkComponents(
+	sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (*elasticsearch.Config, *eventrouter.EventRouter, *columnselector.ColumnSelectors, error) {
+	cfg := elasticsearch.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, nil, nil, err
+	}
+
+	routerConfig := *sinkConfig
+	if cfg.DefaultIndex == "" {
+		// the tables which do not match any rule are written to the index of their own.
+		routerConfig.DispatchRules = append(append([]*config.DispatchRule{}, sinkConfig.DispatchRules...),
+			&config.DispatchRule{Matcher: []string{"*.*"}, TopicRule: defaultIndexRule})
+	}
+	eventRouter, err := eventrouter.NewEventRouter(&routerConfig, cfg.DefaultIndex, false, false)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	columnSelector, err := columnselector.New(sinkConfig)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	return cfg, eventRouter, columnSelector, nil
+}
+
+func (s *sink) SinkType() commonType.SinkType {
+	return commonType.ElasticsearchSinkType
+}
+
+func (s *sink) IsNormal() bool {
+	return s.isNormal.Load()
+}
+
+func (s *sink) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, w := range s.workers {
+		g.Go(func() error {
+			return w.run(ctx)
+		})
+	}
+	err := g.Wait()
+	s.isNormal.Store(false)
+	return errors.Trace(err)
+}
+
+func (s *sink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	index := s.getIndex(event.TableInfo.GetSchemaName(), event.TableInfo.GetTableName())
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(index))
+	s.workers[h.Sum32()%uint32(len(s.workers))].add(&indexedEvent{index: index, event: event})
+}
+
+// getIndex returns the index of the table, the index name must be lowercase.
+func (s *sink) getIndex(schema, table string) string {
+	return strings.ToLower(s.eventRouter.GetTopicForRowChange(schema, table))
+}
+
+func (s *sink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	switch v := event.(type) {
+	case *commonEvent.DDLEvent:
+		log.Info("elasticsearch sink skip the DDL event",
+			zap.String("keyspace", s.changefeedID.Keyspace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Uint64("commitTs", v.GetCommitTs()),
+			zap.String("query", v.GetDDLQuery()))
+	case *commonEvent.SyncPointEvent:
+	default:
+		log.Error("elasticsearch sink doesn't support this type of block event",
+			zap.String("keyspace", s.changefeedID.Keyspace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.String("eventType", commonEvent.TypeToString(event.GetType())))
+		return errors.ErrInvalidEventType.GenWithStackByArgs(commonEvent.TypeToString(event.GetType()))
+	}
+	event.PostFlush()
+	return nil
+}
+
+func (s *sink) AddCheckpointTs(_ uint64) {}
+
+func (s *sink) SetTableSchemaStore(_ *commonEvent.TableSchemaStore) {}
+
+func (s *sink) Close(_ bool) {
+	s.statistics.Close()
+}
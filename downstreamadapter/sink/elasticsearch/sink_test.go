@@ -0,0 +1,263 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// mockServer is a stand-in of the elasticsearch cluster, it only supports the bulk API.
+type mockServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// indices holds the documents of each index.
+	indices map[string]map[string]map[string]interface{}
+	// failures is the number of the following bulk requests which return the status.
+	failures     int
+	failedStatus int
+	bulkRequests int
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	s := &mockServer{indices: make(map[string]map[string]map[string]interface{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *mockServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.URL.Path == "/" {
+		_, _ = w.Write([]byte(`{"version":{"number":"8.0.0"}}`))
+		return
+	}
+	s.bulkRequests++
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(s.failedStatus)
+		return
+	}
+
+	type item struct {
+		Index  string `json:"_index"`
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+	}
+	var items []map[string]item
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var meta map[string]item
+		if err := json.Unmarshal(scanner.Bytes(), &meta); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for action, m := range meta {
+			index, ok := s.indices[m.Index]
+			if !ok {
+				index = make(map[string]map[string]interface{})
+				s.indices[m.Index] = index
+			}
+			m.Status = http.StatusOK
+			switch action {
+			case "index":
+				scanner.Scan()
+				var doc map[string]interface{}
+				if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				index[m.ID] = doc
+			case "delete":
+				if _, ok := index[m.ID]; !ok {
+					m.Status = http.StatusNotFound
+				}
+				delete(index, m.ID)
+			}
+			items = append(items, map[string]item{action: m})
+		}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": false, "items": items})
+}
+
+func (s *mockServer) getDocument(index, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, ok := s.indices[index][id]
+	return doc, ok
+}
+
+func (s *mockServer) documentCount(index string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.indices[index])
+}
+
+func newSinkForTest(
+	ctx context.Context, t *testing.T, server *mockServer, path string, sinkConfig *config.SinkConfig,
+) *sink {
+	sinkURI, err := url.Parse(strings.Replace(server.URL, "http", "elasticsearch", 1) + path)
+	require.NoError(t, err)
+	s, err := New(ctx, common.NewChangefeedID4Test("test", "test"), sinkURI, sinkConfig)
+	require.NoError(t, err)
+	return s
+}
+
+func TestElasticsearchSinkWriteEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newMockServer(t)
+	s := newSinkForTest(ctx, t, server, "/?flush-interval=10ms", config.GetDefaultReplicaConfig().Sink)
+	go func() { _ = s.Run(ctx) }()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table test.t (id int primary key, name varchar(32), j json, b varbinary(8))")
+
+	var flushed atomic.Int64
+	addEvent := func(event *commonEvent.DMLEvent) {
+		event.AddPostFlushFunc(func() { flushed.Inc() })
+		s.AddDMLEvent(event)
+	}
+
+	// the helper decodes the last row of the table, so the rows are written in the order of the keys.
+	updateEvent, _ := helper.DML2UpdateEvent("test", "t",
+		`insert into test.t values (1, 'c', null, null)`, `update test.t set name = 'cc' where id = 1`)
+	deleteEvent := helper.DML2DeleteEvent("test", "t",
+		`insert into test.t values (4, 'd', null, null)`, `delete from test.t where id = 4`)
+	addEvent(helper.DML2Event("test", "t",
+		`insert into test.t values (2, 'a', '{"k": [1, 2]}', x'0102')`,
+		`insert into test.t values (3, 'b', null, null)`,
+		`insert into test.t values (4, 'd', null, null)`))
+	addEvent(updateEvent)
+	addEvent(deleteEvent)
+
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the index is named by the schema and table by default.
+	require.Equal(t, 3, server.documentCount("test_t"))
+	doc, ok := server.getDocument("test_t", "2")
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{
+		"id":   float64(2),
+		"name": "a",
+		"j":    map[string]interface{}{"k": []interface{}{float64(1), float64(2)}},
+		"b":    "AQI=",
+	}, doc)
+	doc, ok = server.getDocument("test_t", "1")
+	require.True(t, ok)
+	require.Equal(t, "cc", doc["name"])
+	_, ok = server.getDocument("test_t", "4")
+	require.False(t, ok)
+
+	// the DDL event is skipped.
+	var ddlFlushed atomic.Bool
+	ddlEvent := helper.DDL2Event("alter table test.t add column c int")
+	ddlEvent.AddPostFlushFunc(func() { ddlFlushed.Store(true) })
+	require.NoError(t, s.WriteBlockEvent(ddlEvent))
+	require.True(t, ddlFlushed.Load())
+	require.True(t, s.IsNormal())
+}
+
+func TestElasticsearchSinkIndexRouting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newMockServer(t)
+	sinkConfig := config.GetDefaultReplicaConfig().Sink
+	sinkConfig.DispatchRules = []*config.DispatchRule{
+		{Matcher: []string{"test.routed"}, TopicRule: "CDC_{table}"},
+	}
+	s := newSinkForTest(ctx, t, server, "/default?flush-interval=10ms", sinkConfig)
+	go func() { _ = s.Run(ctx) }()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table test.routed (a int, b int not null, c int not null, unique key uk(b, c))")
+	helper.DDL2Job("create table test.other (id varchar(16) primary key)")
+
+	var flushed atomic.Int64
+	for _, event := range []*commonEvent.DMLEvent{
+		helper.DML2Event("test", "routed", "insert into test.routed values (1, 2, 3)"),
+		helper.DML2Event("test", "other", "insert into test.other values ('x')"),
+	} {
+		event.AddPostFlushFunc(func() { flushed.Inc() })
+		s.AddDMLEvent(event)
+	}
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the index name is lowercase, and the id of the composite key is a JSON array.
+	_, ok := server.getDocument("cdc_routed", `["2","3"]`)
+	require.True(t, ok)
+	_, ok = server.getDocument("default", "x")
+	require.True(t, ok)
+}
+
+func TestElasticsearchSinkRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newMockServer(t)
+	s := newSinkForTest(ctx, t, server, "/index?flush-interval=10ms&max-retry=3", config.GetDefaultReplicaConfig().Sink)
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Run(ctx) }()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table test.t (id int primary key)")
+	helper.DDL2Job("create table test.nokey (id int)")
+
+	// the unavailable error is retried.
+	server.mu.Lock()
+	server.failures, server.failedStatus = 2, http.StatusServiceUnavailable
+	server.mu.Unlock()
+	var flushed atomic.Bool
+	event := helper.DML2Event("test", "t", "insert into test.t values (1)")
+	event.AddPostFlushFunc(func() { flushed.Store(true) })
+	s.AddDMLEvent(event)
+	require.Eventually(t, flushed.Load, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, 3, server.bulkRequests)
+
+	// the table without handle key can not be written.
+	s.AddDMLEvent(helper.DML2Event("test", "nokey", "insert into test.nokey values (1)"))
+	select {
+	case err := <-errCh:
+		require.True(t, cerror.ErrElasticsearchNoHandleKey.Equal(err))
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the sink is not failed")
+	}
+	require.False(t, s.IsNormal())
+}
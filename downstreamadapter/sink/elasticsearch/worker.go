@@ -0,0 +1,262 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/ticdc/pkg/sink/elasticsearch"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+const (
+	// inputChanSize is the size of the input channel of each worker.
+	inputChanSize = 128
+
+	backoffBaseDelay = 500 * time.Millisecond
+	backoffMaxDelay  = 30 * time.Second
+)
+
+type indexedEvent struct {
+	index string
+	event *commonEvent.DMLEvent
+}
+
+// worker converts the events to the bulk items, and writes them in batches.
+// The batch is flushed once it's full or the flush interval is reached.
+type worker struct {
+	id             int
+	changefeedID   commonType.ChangeFeedID
+	cfg            *elasticsearch.Config
+	client         *elasticsearch.Client
+	columnSelector *columnselector.ColumnSelectors
+	statistics     *metrics.Statistics
+
+	inputCh chan *indexedEvent
+
+	items  []*elasticsearch.BulkItem
+	events []*commonEvent.DMLEvent
+	size   int64
+}
+
+func newWorker(
+	id int,
+	changefeedID commonType.ChangeFeedID,
+	cfg *elasticsearch.Config,
+	client *elasticsearch.Client,
+	columnSelector *columnselector.ColumnSelectors,
+	statistics *metrics.Statistics,
+) *worker {
+	return &worker{
+		id:             id,
+		changefeedID:   changefeedID,
+		cfg:            cfg,
+		client:         client,
+		columnSelector: columnSelector,
+		statistics:     statistics,
+		inputCh:        make(chan *indexedEvent, inputChanSize),
+	}
+}
+
+func (w *worker) add(event *indexedEvent) {
+	w.inputCh <- event
+}
+
+func (w *worker) run(ctx context.Context) error {
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case e := <-w.inputCh:
+			if err := w.appendEvent(e); err != nil {
+				return err
+			}
+			if len(w.items) < w.cfg.MaxBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(w.events) == 0 {
+				continue
+			}
+		}
+		if err := w.flush(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// appendEvent converts each row of the event to the bulk items.
+func (w *worker) appendEvent(e *indexedEvent) error {
+	event := e.event
+	tableInfo := event.TableInfo
+	selector := w.columnSelector.Get(tableInfo.GetSchemaName(), tableInfo.GetTableName())
+	defer event.Rewind()
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		switch row.RowType {
+		case commonType.RowTypeDelete:
+			id, err := getDocumentID(tableInfo, row.PreRow)
+			if err != nil {
+				return err
+			}
+			w.appendItem(&elasticsearch.BulkItem{Action: elasticsearch.ActionDelete, Index: e.index, ID: id})
+		case commonType.RowTypeInsert, commonType.RowTypeUpdate:
+			id, err := getDocumentID(tableInfo, row.Row)
+			if err != nil {
+				return err
+			}
+			// the handle key is changed, the old document must be removed.
+			if row.RowType == commonType.RowTypeUpdate {
+				oldID, err := getDocumentID(tableInfo, row.PreRow)
+				if err != nil {
+					return err
+				}
+				if oldID != id {
+					w.appendItem(&elasticsearch.BulkItem{Action: elasticsearch.ActionDelete, Index: e.index, ID: oldID})
+				}
+			}
+			document, err := getDocument(tableInfo, row.Row, selector)
+			if err != nil {
+				return err
+			}
+			w.appendItem(&elasticsearch.BulkItem{
+				Action: elasticsearch.ActionIndex, Index: e.index, ID: id, Document: document,
+			})
+		}
+	}
+	w.events = append(w.events, event)
+	return nil
+}
+
+func (w *worker) appendItem(item *elasticsearch.BulkItem) {
+	w.items = append(w.items, item)
+	w.size += int64(len(item.ID) + len(item.Document))
+}
+
+// flush writes all the items by one bulk request, and then calls the callbacks of the events.
+func (w *worker) flush(ctx context.Context) error {
+	start := time.Now()
+	err := retry.Do(ctx, func() error {
+		return w.statistics.RecordBatchExecution(func() (int, int64, error) {
+			if err := w.client.Bulk(ctx, w.items); err != nil {
+				log.Warn("elasticsearch sink bulk request failed",
+					zap.String("keyspace", w.changefeedID.Keyspace()),
+					zap.String("changefeed", w.changefeedID.Name()),
+					zap.Int("workerID", w.id),
+					zap.Int("items", len(w.items)),
+					zap.Error(err))
+				return 0, 0, err
+			}
+			return len(w.items), w.size, nil
+		})
+	}, retry.WithBackoffBaseDelay(backoffBaseDelay.Milliseconds()),
+		retry.WithBackoffMaxDelay(backoffMaxDelay.Milliseconds()),
+		retry.WithMaxTries(w.cfg.MaxRetry),
+		retry.WithIsRetryableErr(elasticsearch.IsRetryableError))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Debug("elasticsearch sink flush events",
+		zap.Int("workerID", w.id),
+		zap.Int("events", len(w.events)),
+		zap.Int("items", len(w.items)),
+		zap.Duration("duration", time.Since(start)))
+
+	for _, event := range w.events {
+		event.PostFlush()
+	}
+	clear(w.items)
+	clear(w.events)
+	w.items = w.items[:0]
+	w.events = w.events[:0]
+	w.size = 0
+	return nil
+}
+
+// getDocumentID returns the document id by the handle key columns of the row.
+// The value is used directly if there is only one handle key column,
+// otherwise the values are encoded as a JSON array to avoid ambiguity.
+func getDocumentID(tableInfo *commonType.TableInfo, row chunk.Row) (string, error) {
+	colIDs := tableInfo.GetOrderedHandleKeyColumnIDs()
+	if len(colIDs) == 0 {
+		return "", errors.ErrElasticsearchNoHandleKey.GenWithStackByArgs(tableInfo.TableName.String())
+	}
+	values := make([]string, 0, len(colIDs))
+	for _, colID := range colIDs {
+		colInfo := tableInfo.ForceGetColumnInfo(colID)
+		offset := tableInfo.MustGetColumnOffsetByID(colID)
+		var value string
+		switch v := commonType.ExtractColVal(&row, colInfo, offset).(type) {
+		case []byte:
+			value = hex.EncodeToString(v)
+		case string:
+			// the string may refer to the memory of the chunk, clone it.
+			value = strings.Clone(v)
+		default:
+			value = fmt.Sprint(v)
+		}
+		values = append(values, value)
+	}
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	id, err := json.Marshal(values)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(id), nil
+}
+
+// getDocument returns the JSON encoded document of the row, the key is the column name.
+func getDocument(
+	tableInfo *commonType.TableInfo, row chunk.Row, selector *columnselector.ColumnSelector,
+) ([]byte, error) {
+	columns := tableInfo.GetColumns()
+	document := make(map[string]interface{}, len(columns))
+	for idx, col := range columns {
+		if col == nil || col.IsVirtualGenerated() || !selector.Select(col) {
+			continue
+		}
+		value := commonType.ExtractColVal(&row, col, idx)
+		// the JSON column is written as an object instead of a string.
+		if s, ok := value.(string); ok && col.GetType() == mysql.TypeJSON {
+			value = json.RawMessage(s)
+		}
+		document[col.Name.O] = value
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
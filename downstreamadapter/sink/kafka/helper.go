@@ -78,7 +78,7 @@ func newKafkaSinkComponentWithFactory(ctx context.Context,
 	}
 
 	kafkaComponent.eventRouter, err = eventrouter.NewEventRouter(
-		sinkConfig, topic, false, protocol == config.ProtocolAvro)
+		sinkConfig, topic, false, protocol.IsSchemaRegistryBased())
 	if err != nil {
 		return kafkaComponent, protocol, errors.Trace(err)
 	}
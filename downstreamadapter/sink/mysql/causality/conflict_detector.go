@@ -44,6 +44,9 @@ type ConflictDetector struct {
 
 	// nextCacheID is used to dispatch transactions round-robin.
 	nextCacheID atomic.Int64
+	// activeCacheCount is the count of the caches which the transactions without
+	// conflicts are dispatched to, the first activeCacheCount caches are used.
+	activeCacheCount atomic.Int64
 
 	notifiedNodes        *chann.UnlimitedChannel[func(), any]
 	notifyGuardWaitGroup util.GuardedWaitGroup
@@ -68,6 +71,7 @@ func New(
 	for i := 0; i < opt.Count; i++ {
 		ret.resolvedTxnCaches[i] = newTxnCache(opt)
 	}
+	ret.activeCacheCount.Store(int64(opt.Count))
 	log.Info("conflict detector initialized", zap.Int("cacheCount", opt.Count),
 		zap.Int("cacheSize", opt.Size), zap.String("BlockStrategy", string(opt.BlockStrategy)))
 	return ret
@@ -116,7 +120,7 @@ func (d *ConflictDetector) Add(event *commonEvent.DMLEvent) {
 		return ok
 	}
 	node.RandCacheID = func() int64 {
-		return d.nextCacheID.Add(1) % int64(len(d.resolvedTxnCaches))
+		return d.nextCacheID.Add(1) % d.activeCacheCount.Load()
 	}
 	node.OnNotified = func(callback func()) {
 		if !d.notifyGuardWaitGroup.AddIf(func() bool { return !d.notifyClosed.Load() }) {
@@ -142,6 +146,28 @@ func (d *ConflictDetector) GetOutChByCacheID(id int) *chann.UnlimitedChannel[*co
 	return d.resolvedTxnCaches[id].out()
 }
 
+// SetActiveCacheCount sets the count of the caches which the transactions without conflicts
+// are dispatched to. The transactions conflicting with the transactions in the other caches
+// are still dispatched to them, so the inactive caches are drained gradually.
+func (d *ConflictDetector) SetActiveCacheCount(count int) {
+	count = max(1, min(count, len(d.resolvedTxnCaches)))
+	d.activeCacheCount.Store(int64(count))
+}
+
+// ActiveCacheCount returns the count of the active caches.
+func (d *ConflictDetector) ActiveCacheCount() int {
+	return int(d.activeCacheCount.Load())
+}
+
+// Backlog returns the count of the transactions waiting in all the caches.
+func (d *ConflictDetector) Backlog() int {
+	var backlog int
+	for _, cache := range d.resolvedTxnCaches {
+		backlog += cache.out().Len()
+	}
+	return backlog
+}
+
 func (d *ConflictDetector) closeCache() {
 	// the unlimited channel should be closed when quit wait group, otherwise dmlWriter will be blocked
 	for _, cache := range d.resolvedTxnCaches {
@@ -0,0 +1,96 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"go.uber.org/zap"
+)
+
+// concurrencyAdjustInterval is the interval to adjust the active worker count.
+const concurrencyAdjustInterval = 10 * time.Second
+
+// concurrencyController adjusts the active worker count by the feedback of the downstream,
+// the count is increased additively and decreased multiplicatively.
+type concurrencyController struct {
+	minWorkerCount int
+	maxWorkerCount int
+	targetLatency  time.Duration
+}
+
+// next returns the active worker count of the next interval:
+//  1. it's decreased by a quarter if any error or slow query happens, or the average
+//     flush latency exceeds the target latency.
+//  2. it's increased by one if more transactions than the active workers are waiting,
+//     and the average flush latency is below half of the target latency.
+//  3. otherwise it's kept.
+func (c *concurrencyController) next(current int, stats mysql.FlushStats, backlog int) int {
+	latency := stats.AvgFlushDuration()
+	switch {
+	case stats.Errors > 0 || stats.SlowQueries > 0 || latency > c.targetLatency:
+		current -= max(1, current/4)
+	case backlog > current && latency < c.targetLatency/2:
+		current++
+	}
+	return max(c.minWorkerCount, min(current, c.maxWorkerCount))
+}
+
+// setActiveWorkerCount sets the count of the active workers. The transactions without conflicts
+// are only dispatched to the active workers, and the connection pool is shrunk to the active
+// workers and the ddl worker, so the idle connections are closed and the inactive workers,
+// which still drain the transactions conflicting with theirs, are parked on getting a
+// connection. It limits the concurrency of the downstream instead of only the routing.
+func (s *Sink) setActiveWorkerCount(count int) {
+	s.conflictDetector.SetActiveCacheCount(count)
+	count = s.conflictDetector.ActiveCacheCount()
+	s.db.SetMaxIdleConns(count + 1)
+	s.db.SetMaxOpenConns(count + 1)
+	mysql.SetActiveWorkerCount(s.changefeedID, count)
+}
+
+func (s *Sink) runConcurrencyController(ctx context.Context) error {
+	ticker := time.NewTicker(concurrencyAdjustInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+			var stats mysql.FlushStats
+			for _, writer := range s.dmlWriter {
+				stats = stats.Add(writer.TakeFlushStats())
+			}
+			current := s.conflictDetector.ActiveCacheCount()
+			backlog := s.conflictDetector.Backlog()
+			next := s.concurrency.next(current, stats, backlog)
+			if next == current {
+				continue
+			}
+			s.setActiveWorkerCount(next)
+			log.Info("adjust the active worker count of mysql sink",
+				zap.String("keyspace", s.changefeedID.Keyspace()),
+				zap.String("changefeed", s.changefeedID.Name()),
+				zap.Int("from", current), zap.Int("to", next),
+				zap.Int("backlog", backlog),
+				zap.Duration("avgFlushDuration", stats.AvgFlushDuration()),
+				zap.Int64("slowQueries", stats.SlowQueries),
+				zap.Int64("errors", stats.Errors))
+		}
+	}
+}
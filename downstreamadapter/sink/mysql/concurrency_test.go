@@ -0,0 +1,71 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyControllerNext(t *testing.T) {
+	c := &concurrencyController{minWorkerCount: 2, maxWorkerCount: 10, targetLatency: time.Second}
+	fast := mysql.FlushStats{FlushCount: 10, FlushDuration: time.Second}
+
+	// the transactions are backlogged and the downstream is fast.
+	require.Equal(t, 5, c.next(4, fast, 100))
+	require.Equal(t, 10, c.next(10, fast, 100))
+	// no backlog.
+	require.Equal(t, 4, c.next(4, fast, 2))
+	// the latency is not low enough to increase the worker count.
+	require.Equal(t, 4, c.next(4, mysql.FlushStats{FlushCount: 1, FlushDuration: 800 * time.Millisecond}, 100))
+	// the downstream is slow or returns errors.
+	require.Equal(t, 6, c.next(8, mysql.FlushStats{FlushCount: 1, FlushDuration: 2 * time.Second}, 100))
+	require.Equal(t, 3, c.next(4, mysql.FlushStats{FlushCount: 1, SlowQueries: 1}, 100))
+	require.Equal(t, 2, c.next(2, mysql.FlushStats{Errors: 1}, 100))
+}
+
+func TestMysqlSinkAdaptiveConcurrency(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	changefeedID := common.NewChangefeedID4Test("test", "adaptive")
+	cfg := mysql.New()
+	cfg.WorkerCount = 4
+	cfg.AdaptiveConcurrency = true
+	cfg.MinWorkerCount, cfg.MaxWorkerCount = 2, 8
+	cfg.TargetFlushLatency = time.Second
+
+	sink := NewMySQLSink(context.Background(), changefeedID, cfg, db, false)
+	require.Len(t, sink.dmlWriter, 8)
+	require.NotNil(t, sink.concurrency)
+	require.Equal(t, 4, sink.conflictDetector.ActiveCacheCount())
+	require.Equal(t, int64(4), mysql.GetActiveWorkerCount(changefeedID.DisplayName))
+	require.Equal(t, 5, db.Stats().MaxOpenConnections)
+
+	sink.setActiveWorkerCount(2)
+	require.Equal(t, 2, sink.conflictDetector.ActiveCacheCount())
+	require.Equal(t, 3, db.Stats().MaxOpenConnections)
+	require.Equal(t, int64(2), mysql.GetActiveWorkerCount(changefeedID.DisplayName))
+
+	sink.setActiveWorkerCount(100)
+	require.Equal(t, 8, sink.conflictDetector.ActiveCacheCount())
+	require.Equal(t, 9, db.Stats().MaxOpenConnections)
+	sink.Close(false)
+	require.Zero(t, mysql.GetActiveWorkerCount(changefeedID.DisplayName))
+}
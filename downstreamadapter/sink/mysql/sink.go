@@ -55,7 +55,13 @@ type Sink struct {
 	// isNormal indicate whether the sink is in the normal state.
 	isNormal   *atomic.Bool
 	maxTxnRows int
-	bdrMode    bool
+	// catchUpMaxTxnRows is the max rows flushed at once by a writer in the catch-up mode.
+	catchUpMaxTxnRows int
+	bdrMode           bool
+
+	// concurrency adjusts the active worker count at runtime, it's nil if the
+	// adaptive concurrency is disabled.
+	concurrency *concurrencyController
 }
 
 // Verify is used to verify the sink uri and config is valid
@@ -95,26 +101,44 @@ func NewMySQLSink(
 	bdrMode bool,
 ) *Sink {
 	stat := metrics.NewStatistics(changefeedID, "TxnSink")
+	// with the adaptive concurrency, the workers are created up to the max worker count,
+	// and only the active ones are dispatched the transactions without conflicts and
+	// get the connections, see setActiveWorkerCount.
+	writerCount := cfg.WorkerCount
+	if cfg.AdaptiveConcurrency {
+		writerCount = cfg.MaxWorkerCount
+	}
 	result := &Sink{
 		changefeedID: changefeedID,
 		db:           db,
-		dmlWriter:    make([]*mysql.Writer, cfg.WorkerCount),
+		dmlWriter:    make([]*mysql.Writer, writerCount),
 		statistics:   stat,
 		conflictDetector: causality.New(defaultConflictDetectorSlots,
 			causality.TxnCacheOption{
-				Count:         cfg.WorkerCount,
+				Count:         writerCount,
 				Size:          1024,
 				BlockStrategy: causality.BlockStrategyWaitEmpty,
 			},
 			changefeedID),
-		isNormal:   atomic.NewBool(true),
-		maxTxnRows: cfg.MaxTxnRow,
-		bdrMode:    bdrMode,
+		isNormal:          atomic.NewBool(true),
+		maxTxnRows:        cfg.MaxTxnRow,
+		catchUpMaxTxnRows: cfg.CatchUpMaxTxnRow,
+		bdrMode:           bdrMode,
 	}
 	for i := 0; i < len(result.dmlWriter); i++ {
 		result.dmlWriter[i] = mysql.NewWriter(ctx, i, db, cfg, changefeedID, stat)
 	}
 	result.ddlWriter = mysql.NewWriter(ctx, len(result.dmlWriter), db, cfg, changefeedID, stat)
+	if cfg.AdaptiveConcurrency {
+		result.concurrency = &concurrencyController{
+			minWorkerCount: cfg.MinWorkerCount,
+			maxWorkerCount: cfg.MaxWorkerCount,
+			targetLatency:  cfg.TargetFlushLatency,
+		}
+		result.setActiveWorkerCount(cfg.WorkerCount)
+	} else {
+		mysql.SetActiveWorkerCount(changefeedID, cfg.WorkerCount)
+	}
 	return result
 }
 
@@ -129,6 +153,11 @@ func (s *Sink) Run(ctx context.Context) error {
 			return s.runDMLWriter(ctx, idx)
 		})
 	}
+	if s.concurrency != nil {
+		g.Go(func() error {
+			return s.runConcurrencyController(ctx)
+		})
+	}
 	err := g.Wait()
 	s.isNormal.Store(false)
 	return err
@@ -171,6 +200,11 @@ func (s *Sink) runDMLWriter(ctx context.Context, idx int) error {
 				buffer = buffer[:0]
 				continue
 			}
+			// the writer coalesces more rows in a transaction in the catch-up mode.
+			maxTxnRows := s.maxTxnRows
+			if writer.UpdateCatchUpMode(txnEvents[0].CommitTs) {
+				maxTxnRows = s.catchUpMaxTxnRows
+			}
 			start := time.Now()
 			singleFlushStart := time.Now()
 
@@ -189,7 +223,7 @@ func (s *Sink) runDMLWriter(ctx context.Context, idx int) error {
 			workerEventRowCount.Observe(float64(rowCount))
 			for i := 1; i < len(txnEvents); i++ {
 				workerEventRowCount.Observe(float64(txnEvents[i].Len()))
-				if rowCount+txnEvents[i].Len() > int32(s.maxTxnRows) {
+				if rowCount+txnEvents[i].Len() > int32(maxTxnRows) {
 					if err := flushEvent(beginIndex, i, rowCount); err != nil {
 						return errors.Trace(err)
 					}
@@ -210,6 +244,9 @@ func (s *Sink) runDMLWriter(ctx context.Context, idx int) error {
 			workerTotalDuration.Observe(time.Since(totalStart).Seconds())
 			totalStart = time.Now()
 			buffer = buffer[:0]
+			if cap(buffer) < maxTxnRows {
+				buffer = make([]*commonEvent.DMLEvent, 0, maxTxnRows)
+			}
 		}
 	}
 }
@@ -340,5 +377,7 @@ func (s *Sink) Close(removeChangefeed bool) {
 			zap.Any("changefeed", s.changefeedID.String()),
 			zap.Error(err))
 	}
+	mysql.RemoveActiveWorkerCount(s.changefeedID)
+	mysql.RemoveDeadLetterMetrics(s.changefeedID)
 	s.statistics.Close()
 }
@@ -0,0 +1,211 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/mysql/causality"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/postgres"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultConflictDetectorSlots indicates the default slot count of conflict detector.
+const defaultConflictDetectorSlots uint64 = 16 * 1024
+
+// Sink is responsible for writing data to postgres downstream.
+// The dml events are dispatched to the writers by the conflict detector,
+// so the events which modify the same rows are written in order.
+type Sink struct {
+	changefeedID common.ChangeFeedID
+
+	dmlWriter []*postgres.Writer
+	ddlWriter *postgres.Writer
+
+	db         *sql.DB
+	statistics *metrics.Statistics
+
+	conflictDetector *causality.ConflictDetector
+
+	// isNormal indicate whether the sink is in the normal state.
+	isNormal   *atomic.Bool
+	maxTxnRows int
+}
+
+// Verify is used to verify the sink uri and config is valid
+// Currently, we verify by create a real postgres connection.
+func Verify(ctx context.Context, changefeedID common.ChangeFeedID, uri *url.URL) error {
+	_, db, err := postgres.NewPostgresConfigAndDB(ctx, changefeedID, uri)
+	if err != nil {
+		return err
+	}
+	_ = db.Close()
+	return nil
+}
+
+func New(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL) (*Sink, error) {
+	cfg, db, err := postgres.NewPostgresConfigAndDB(ctx, changefeedID, sinkURI)
+	if err != nil {
+		return nil, err
+	}
+	return NewPostgresSink(ctx, changefeedID, cfg, db), nil
+}
+
+func NewPostgresSink(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	cfg *postgres.Config,
+	db *sql.DB,
+) *Sink {
+	stat := metrics.NewStatistics(changefeedID, "PostgresSink")
+	result := &Sink{
+		changefeedID: changefeedID,
+		db:           db,
+		dmlWriter:    make([]*postgres.Writer, cfg.WorkerCount),
+		statistics:   stat,
+		conflictDetector: causality.New(defaultConflictDetectorSlots,
+			causality.TxnCacheOption{
+				Count:         cfg.WorkerCount,
+				Size:          1024,
+				BlockStrategy: causality.BlockStrategyWaitEmpty,
+			},
+			changefeedID),
+		isNormal:   atomic.NewBool(true),
+		maxTxnRows: cfg.MaxTxnRow,
+	}
+	for i := 0; i < len(result.dmlWriter); i++ {
+		result.dmlWriter[i] = postgres.NewWriter(ctx, i, db, cfg, changefeedID, stat)
+	}
+	result.ddlWriter = postgres.NewWriter(ctx, len(result.dmlWriter), db, cfg, changefeedID, stat)
+	return result
+}
+
+func (s *Sink) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.conflictDetector.Run(ctx)
+	})
+	for idx := range s.dmlWriter {
+		g.Go(func() error {
+			defer s.conflictDetector.CloseNotifiedNodes()
+			return s.runDMLWriter(ctx, idx)
+		})
+	}
+	err := g.Wait()
+	s.isNormal.Store(false)
+	return err
+}
+
+func (s *Sink) runDMLWriter(ctx context.Context, idx int) error {
+	inputCh := s.conflictDetector.GetOutChByCacheID(idx)
+	writer := s.dmlWriter[idx]
+
+	buffer := make([]*commonEvent.DMLEvent, 0, s.maxTxnRows)
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		default:
+			txnEvents, ok := inputCh.GetMultipleNoGroup(buffer)
+			if !ok {
+				return errors.Trace(ctx.Err())
+			}
+			if len(txnEvents) == 0 {
+				buffer = buffer[:0]
+				continue
+			}
+
+			// the events are flushed in batches, each batch has at most maxTxnRows rows,
+			// except the batch which has only one event.
+			beginIndex, rowCount := 0, txnEvents[0].Len()
+			for i := 1; i < len(txnEvents); i++ {
+				if rowCount+txnEvents[i].Len() > int32(s.maxTxnRows) {
+					if err := writer.Flush(txnEvents[beginIndex:i]); err != nil {
+						return errors.Trace(err)
+					}
+					beginIndex, rowCount = i, txnEvents[i].Len()
+				} else {
+					rowCount += txnEvents[i].Len()
+				}
+			}
+			if err := writer.Flush(txnEvents[beginIndex:]); err != nil {
+				return errors.Trace(err)
+			}
+			buffer = buffer[:0]
+		}
+	}
+}
+
+func (s *Sink) IsNormal() bool {
+	return s.isNormal.Load()
+}
+
+func (s *Sink) SinkType() common.SinkType {
+	return common.PostgresSinkType
+}
+
+func (s *Sink) SetTableSchemaStore(_ *commonEvent.TableSchemaStore) {}
+
+func (s *Sink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	s.conflictDetector.Add(event)
+}
+
+func (s *Sink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	var err error
+	switch event.GetType() {
+	case commonEvent.TypeDDLEvent:
+		err = s.ddlWriter.FlushDDLEvent(event.(*commonEvent.DDLEvent))
+	case commonEvent.TypeSyncPointEvent:
+		log.Warn("postgres sink does not support syncpoint, skip it",
+			zap.String("keyspace", s.changefeedID.Keyspace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Uint64("commitTs", event.GetCommitTs()))
+	default:
+		log.Panic("postgres sink meet unknown event type",
+			zap.String("keyspace", s.changefeedID.Keyspace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("event", event))
+	}
+	if err != nil {
+		s.isNormal.Store(false)
+		return errors.Trace(err)
+	}
+	event.PostFlush()
+	return nil
+}
+
+func (s *Sink) AddCheckpointTs(_ uint64) {}
+
+func (s *Sink) Close(_ bool) {
+	s.conflictDetector.CloseNotifiedNodes()
+	s.ddlWriter.Close()
+	for _, w := range s.dmlWriter {
+		w.Close()
+	}
+	if err := s.db.Close(); err != nil {
+		log.Warn("close postgres sink db meet error",
+			zap.String("changefeed", s.changefeedID.String()),
+			zap.Error(err))
+	}
+	s.statistics.Close()
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/postgres"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestPostgresSinkBasicFunctionality(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	cfg := postgres.NewConfig()
+	cfg.WorkerCount = 2
+	cfg.DMLMaxRetry = 1
+	sink := NewPostgresSink(ctx, common.NewChangefeedID4Test("test", "test"), cfg, db)
+	go func() { _ = sink.Run(ctx) }()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	ddlEvent := helper.DDL2Event("create table t (id int primary key, name varchar(32))")
+
+	var count atomic.Int64
+	ddlEvent.AddPostFlushFunc(func() { count.Inc() })
+	mock.ExpectBegin()
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS "test"."t" ("id" INTEGER NOT NULL, "name" VARCHAR(32), PRIMARY KEY ("id"))`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	require.NoError(t, sink.WriteBlockEvent(ddlEvent))
+	require.Equal(t, int64(1), count.Load())
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'a')", "insert into t values (2, 'b')")
+	dmlEvent.AddPostFlushFunc(func() { count.Inc() })
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "test"."t" ("id","name") VALUES ($1,$2),($3,$4)`).
+		WithArgs(1, "a", 2, "b").
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+	sink.AddDMLEvent(dmlEvent)
+	require.Eventually(t, func() bool {
+		return count.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.True(t, sink.IsNormal())
+	require.Equal(t, common.PostgresSinkType, sink.SinkType())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectClose()
+	sink.Close(false)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
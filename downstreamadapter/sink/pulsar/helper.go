@@ -100,7 +100,7 @@ func newPulsarSinkComponentWithFactory(ctx context.Context,
 		return pulsarComponent, protocol, errors.Trace(err)
 	}
 
-	// pulsar only support canal-json, so we don't need to check the protocol
+	// pulsar only support canal-json and maxwell, so we don't need to check the protocol
 	pulsarComponent.eventRouter, err = eventrouter.NewEventRouter(sinkConfig, topic, true, false)
 	if err != nil {
 		return pulsarComponent, protocol, errors.Trace(err)
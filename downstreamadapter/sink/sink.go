@@ -19,9 +19,12 @@ import (
 
 	"github.com/pingcap/ticdc/downstreamadapter/sink/blackhole"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/cloudstorage"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/elasticsearch"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/kafka"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/mysql"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/postgres"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/pulsar"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/webhook"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -56,6 +59,12 @@ func New(ctx context.Context, cfg *config.ChangefeedConfig, changefeedID common.
 		return pulsar.New(ctx, changefeedID, sinkURI, cfg.SinkConfig)
 	case config.S3Scheme, config.FileScheme, config.GCSScheme, config.GSScheme, config.AzblobScheme, config.AzureScheme, config.CloudStorageNoopScheme:
 		return cloudstorage.New(ctx, changefeedID, sinkURI, cfg.SinkConfig, nil)
+	case config.ElasticsearchScheme, config.ElasticsearchSSLScheme:
+		return elasticsearch.New(ctx, changefeedID, sinkURI, cfg.SinkConfig)
+	case config.HTTPScheme, config.HTTPSScheme:
+		return webhook.New(ctx, changefeedID, sinkURI, cfg.SinkConfig)
+	case config.PostgresScheme, config.PostgreSQLScheme:
+		return postgres.New(ctx, changefeedID, sinkURI)
 	case config.BlackHoleScheme:
 		return blackhole.New()
 	}
@@ -77,6 +86,12 @@ func Verify(ctx context.Context, cfg *config.ChangefeedConfig, changefeedID comm
 		return pulsar.Verify(ctx, changefeedID, sinkURI, cfg.SinkConfig)
 	case config.S3Scheme, config.FileScheme, config.GCSScheme, config.GSScheme, config.AzblobScheme, config.AzureScheme, config.CloudStorageNoopScheme:
 		return cloudstorage.Verify(ctx, changefeedID, sinkURI, cfg.SinkConfig)
+	case config.ElasticsearchScheme, config.ElasticsearchSSLScheme:
+		return elasticsearch.Verify(ctx, changefeedID, sinkURI, cfg.SinkConfig)
+	case config.HTTPScheme, config.HTTPSScheme:
+		return webhook.Verify(ctx, changefeedID, sinkURI, cfg.SinkConfig)
+	case config.PostgresScheme, config.PostgreSQLScheme:
+		return postgres.Verify(ctx, changefeedID, sinkURI)
 	case config.BlackHoleScheme:
 		return nil
 	}
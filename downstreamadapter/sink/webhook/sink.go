@@ -0,0 +1,285 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"net/url"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	backoffBaseDelay = 500 * time.Millisecond
+	backoffMaxDelay  = 30 * time.Second
+)
+
+// sink posts the encoded events to the http endpoint, which is useful for the
+// services which only need the change notifications and can't consume the MQ.
+// The events of the same table are always posted by the same worker in order,
+// and the events are flushed only after the endpoint returns a 2xx status code,
+// so the events are delivered at least once.
+type sink struct {
+	changefeedID commonType.ChangeFeedID
+	cfg          *webhook.Config
+	client       *webhook.Client
+
+	encoderConfig *common.Config
+	// ddlEncoder is only used by WriteBlockEvent.
+	ddlEncoder common.EventEncoder
+	// checkpointEncoder is only used by the checkpoint goroutine.
+	checkpointEncoder common.EventEncoder
+	checkpointChan    chan uint64
+
+	// workers post the row events concurrently, the events of the same table
+	// are always dispatched to the same worker to keep the order.
+	workers    []*worker
+	statistics *metrics.Statistics
+
+	// isNormal indicate whether the sink is in the normal state.
+	isNormal *atomic.Bool
+	ctx      context.Context
+}
+
+// Verify checks whether the webhook sink can be created by the given config.
+func Verify(ctx context.Context, changefeedID commonType.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
+	_, _, err := newSinkConfig(changefeedID, sinkURI, sinkConfig)
+	return err
+}
+
+// New creates a webhook sink.
+func New(
+	ctx context.Context, changefeedID commonType.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (*sink, error) {
+	cfg, encoderConfig, err := newSinkConfig(changefeedID, sinkURI, sinkConfig)
+	if err != nil {
+		return nil, err
+	}
+	columnSelector, err := columnselector.New(sinkConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ddlEncoder, err := codec.NewEventEncoder(ctx, encoderConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	checkpointEncoder, err := codec.NewEventEncoder(ctx, encoderConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	client := webhook.NewClient(cfg)
+	statistics := metrics.NewStatistics(changefeedID, "webhook")
+	workers := make([]*worker, cfg.WorkerCount)
+	for i := range workers {
+		// the encoder is not thread safe, each worker has its own.
+		encoder, err := codec.NewEventEncoder(ctx, encoderConfig)
+		if err != nil {
+			statistics.Close()
+			return nil, errors.Trace(err)
+		}
+		workers[i] = newWorker(i, changefeedID, cfg, client, encoder, columnSelector, statistics)
+	}
+	log.Info("webhook sink created",
+		zap.String("keyspace", changefeedID.Keyspace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.String("endpoint", cfg.Endpoint),
+		zap.String("protocol", encoderConfig.Protocol.String()),
+		zap.Bool("signed", cfg.HMACSecret != ""),
+		zap.Int("workerCount", cfg.WorkerCount))
+	return &sink{
+		changefeedID:      changefeedID,
+		cfg:               cfg,
+		client:            client,
+		encoderConfig:     encoderConfig,
+		ddlEncoder:        ddlEncoder,
+		checkpointEncoder: checkpointEncoder,
+		checkpointChan:    make(chan uint64, 16),
+		workers:           workers,
+		statistics:        statistics,
+		isNormal:          atomic.NewBool(true),
+		ctx:               ctx,
+	}, nil
+}
+
+func newSinkConfig(
+	changefeedID commonType.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (*webhook.Config, *common.Config, error) {
+	cfg := webhook.NewConfig()
+	if err := cfg.Apply(sinkURI); err != nil {
+		return nil, nil, err
+	}
+	protocol, err := helper.GetProtocol(utils.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	encoderConfig, err := helper.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, math.MaxInt)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err = webhook.ValidateProtocol(encoderConfig); err != nil {
+		return nil, nil, err
+	}
+	return cfg, encoderConfig, nil
+}
+
+func (s *sink) SinkType() commonType.SinkType {
+	return commonType.WebhookSinkType
+}
+
+func (s *sink) IsNormal() bool {
+	return s.isNormal.Load()
+}
+
+func (s *sink) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, w := range s.workers {
+		g.Go(func() error {
+			return w.run(ctx)
+		})
+	}
+	g.Go(func() error {
+		return s.sendCheckpoint(ctx)
+	})
+	err := g.Wait()
+	s.isNormal.Store(false)
+	return errors.Trace(err)
+}
+
+func (s *sink) AddDMLEvent(event *commonEvent.DMLEvent) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(event.TableInfo.GetSchemaName()))
+	_, _ = h.Write([]byte{'.'})
+	_, _ = h.Write([]byte(event.TableInfo.GetTableName()))
+	s.workers[h.Sum32()%uint32(len(s.workers))].add(event)
+}
+
+func (s *sink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	var err error
+	switch v := event.(type) {
+	case *commonEvent.DDLEvent:
+		err = s.sendDDLEvent(v)
+	default:
+		log.Error("webhook sink doesn't support this type of block event",
+			zap.String("keyspace", s.changefeedID.Keyspace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.String("eventType", commonEvent.TypeToString(event.GetType())))
+		return errors.ErrInvalidEventType.GenWithStackByArgs(commonEvent.TypeToString(event.GetType()))
+	}
+	if err != nil {
+		s.isNormal.Store(false)
+		return err
+	}
+	event.PostFlush()
+	return nil
+}
+
+func (s *sink) sendDDLEvent(event *commonEvent.DDLEvent) error {
+	values := make([][]byte, 0, len(event.GetEvents()))
+	for _, e := range event.GetEvents() {
+		message, err := s.ddlEncoder.EncodeDDLEvent(e)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if message == nil {
+			log.Info("Skip ddl event", zap.Uint64("commitTs", e.GetCommitTs()),
+				zap.String("query", e.Query),
+				zap.Stringer("changefeed", s.changefeedID))
+			continue
+		}
+		values = append(values, message.Value)
+	}
+	err := s.statistics.RecordDDLExecution(func() error {
+		return postWithRetry(s.ctx, s.cfg, func() error {
+			return s.client.Post(s.ctx, values)
+		})
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("webhook sink send DDL event",
+		zap.String("keyspace", s.changefeedID.Keyspace()), zap.String("changefeed", s.changefeedID.Name()),
+		zap.Uint64("commitTs", event.GetCommitTs()), zap.String("query", event.GetDDLQuery()))
+	return nil
+}
+
+func (s *sink) AddCheckpointTs(ts uint64) {
+	select {
+	case s.checkpointChan <- ts:
+	case <-s.ctx.Done():
+		return
+		// We can just drop the checkpoint ts if the channel is full to avoid blocking since the checkpointTs will come indefinitely
+	default:
+	}
+}
+
+// sendCheckpoint posts the checkpoint ts if the protocol supports the checkpoint event.
+// The checkpoint ts is only advanced after all the events before it are accepted by the endpoint.
+func (s *sink) sendCheckpoint(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case ts := <-s.checkpointChan:
+			message, err := s.checkpointEncoder.EncodeCheckpointEvent(ts)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if message == nil {
+				continue
+			}
+			err = postWithRetry(ctx, s.cfg, func() error {
+				return s.client.Post(ctx, [][]byte{message.Value})
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (s *sink) SetTableSchemaStore(_ *commonEvent.TableSchemaStore) {}
+
+func (s *sink) Close(_ bool) {
+	s.ddlEncoder.Clean()
+	s.checkpointEncoder.Clean()
+	s.statistics.Close()
+}
+
+// postWithRetry retries the post with backoff until it succeeds or the error is not retryable.
+func postWithRetry(ctx context.Context, cfg *webhook.Config, post func() error) error {
+	return retry.Do(ctx, post,
+		retry.WithBackoffBaseDelay(backoffBaseDelay.Milliseconds()),
+		retry.WithBackoffMaxDelay(backoffMaxDelay.Milliseconds()),
+		retry.WithMaxTries(cfg.MaxRetry),
+		retry.WithIsRetryableErr(webhook.IsRetryableError))
+}
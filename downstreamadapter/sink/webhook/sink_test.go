@@ -0,0 +1,185 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+const testSecret = "secret"
+
+// mockServer receives the webhook requests and verifies the signature.
+type mockServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// messages holds the messages of the accepted requests in order.
+	messages []map[string]interface{}
+	// failures is the number of the following requests which return the status.
+	failures     int
+	failedStatus int
+	requests     int
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	s := &mockServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, webhook.ContentType, r.Header.Get("Content-Type"))
+		require.Equal(t, "sha256="+webhook.Sign([]byte(testSecret), body), r.Header.Get(webhook.SignatureHeader))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests++
+		if s.failures > 0 {
+			s.failures--
+			w.WriteHeader(s.failedStatus)
+			return
+		}
+		for _, line := range bytes.Split(bytes.TrimSuffix(body, []byte{'\n'}), []byte{'\n'}) {
+			var message map[string]interface{}
+			require.NoError(t, json.Unmarshal(line, &message))
+			s.messages = append(s.messages, message)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *mockServer) getMessages() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]interface{}{}, s.messages...)
+}
+
+func (s *mockServer) fail(count, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures, s.failedStatus = count, status
+}
+
+func newSinkForTest(ctx context.Context, t *testing.T, server *mockServer, query string) *sink {
+	sinkURI, err := url.Parse(server.URL + "/cdc?flush-interval=10ms&max-retry=3&hmac-secret=" + testSecret + query)
+	require.NoError(t, err)
+	sinkConfig := config.GetDefaultReplicaConfig().Sink
+	sinkConfig.Protocol = util.AddressOf(config.ProtocolCanalJSON.String())
+	s, err := New(ctx, common.NewChangefeedID4Test("test", "test"), sinkURI, sinkConfig)
+	require.NoError(t, err)
+	return s
+}
+
+func TestWebhookSinkWriteEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newMockServer(t)
+	s := newSinkForTest(ctx, t, server, "&enable-tidb-extension=true")
+	go func() { _ = s.Run(ctx) }()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	ddlEvent := helper.DDL2Event("create table test.t (id int primary key, name varchar(32))")
+	require.NoError(t, s.WriteBlockEvent(ddlEvent))
+
+	var flushed atomic.Int64
+	for _, event := range []*commonEvent.DMLEvent{
+		helper.DML2Event("test", "t", "insert into test.t values (1, 'a')", "insert into test.t values (2, 'b')"),
+		helper.DML2Event("test", "t", "insert into test.t values (3, 'c')"),
+	} {
+		event.AddPostFlushFunc(func() { flushed.Inc() })
+		s.AddDMLEvent(event)
+	}
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	messages := server.getMessages()
+	require.Len(t, messages, 4)
+	require.Equal(t, true, messages[0]["isDdl"])
+	// the rows of the same table are posted in order.
+	for i, message := range messages[1:] {
+		require.Equal(t, "INSERT", message["type"])
+		require.Equal(t, "t", message["table"])
+		data := message["data"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, string(rune('1'+i)), data["id"])
+	}
+
+	// the checkpoint is posted since the tidb extension is enabled.
+	s.AddCheckpointTs(100)
+	require.Eventually(t, func() bool {
+		return len(server.getMessages()) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "TIDB_WATERMARK", server.getMessages()[4]["type"])
+	require.True(t, s.IsNormal())
+}
+
+func TestWebhookSinkRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := newMockServer(t)
+	s := newSinkForTest(ctx, t, server, "")
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Run(ctx) }()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table test.t (id int primary key)")
+
+	// the events are flushed only after the request is accepted.
+	server.fail(2, http.StatusServiceUnavailable)
+	var flushed atomic.Bool
+	event := helper.DML2Event("test", "t", "insert into test.t values (1)")
+	event.AddPostFlushFunc(func() { flushed.Store(true) })
+	s.AddDMLEvent(event)
+	require.Eventually(t, flushed.Load, 20*time.Second, 10*time.Millisecond)
+	require.Len(t, server.getMessages(), 1)
+	server.mu.Lock()
+	require.Equal(t, 3, server.requests)
+	server.mu.Unlock()
+
+	// the rejected request is not retried, and the events are not flushed.
+	server.fail(1, http.StatusBadRequest)
+	flushed.Store(false)
+	event = helper.DML2Event("test", "t", "insert into test.t values (2)")
+	event.AddPostFlushFunc(func() { flushed.Store(true) })
+	s.AddDMLEvent(event)
+	select {
+	case err := <-errCh:
+		require.True(t, cerror.ErrWebhookRequestRejected.Equal(err))
+	case <-time.After(20 * time.Second):
+		require.FailNow(t, "the sink is not failed")
+	}
+	require.False(t, flushed.Load())
+	require.False(t, s.IsNormal())
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
+	"go.uber.org/zap"
+)
+
+const (
+	// inputChanSize is the size of the input channel of each worker.
+	inputChanSize = 128
+)
+
+// worker encodes the row events of the tables dispatched to it, and posts them in batches.
+// The batch is posted once it's full or the flush interval is reached,
+// the events are flushed only after the endpoint accepts the batch.
+type worker struct {
+	id             int
+	changefeedID   commonType.ChangeFeedID
+	cfg            *webhook.Config
+	client         *webhook.Client
+	encoder        common.EventEncoder
+	columnSelector *columnselector.ColumnSelectors
+	statistics     *metrics.Statistics
+
+	inputCh chan *commonEvent.DMLEvent
+
+	events []*commonEvent.DMLEvent
+	rows   int
+}
+
+func newWorker(
+	id int,
+	changefeedID commonType.ChangeFeedID,
+	cfg *webhook.Config,
+	client *webhook.Client,
+	encoder common.EventEncoder,
+	columnSelector *columnselector.ColumnSelectors,
+	statistics *metrics.Statistics,
+) *worker {
+	return &worker{
+		id:             id,
+		changefeedID:   changefeedID,
+		cfg:            cfg,
+		client:         client,
+		encoder:        encoder,
+		columnSelector: columnSelector,
+		statistics:     statistics,
+		inputCh:        make(chan *commonEvent.DMLEvent, inputChanSize),
+	}
+}
+
+func (w *worker) add(event *commonEvent.DMLEvent) {
+	w.inputCh <- event
+}
+
+func (w *worker) run(ctx context.Context) error {
+	defer w.encoder.Clean()
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event := <-w.inputCh:
+			if err := w.appendEvent(ctx, event); err != nil {
+				return err
+			}
+			if w.rows < w.cfg.MaxBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(w.events) == 0 {
+				continue
+			}
+		}
+		if err := w.flush(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// appendEvent encodes each row of the event by the encoder.
+func (w *worker) appendEvent(ctx context.Context, event *commonEvent.DMLEvent) error {
+	selector := w.columnSelector.Get(event.TableInfo.GetSchemaName(), event.TableInfo.GetTableName())
+	defer event.Rewind()
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		err := w.encoder.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			PhysicalTableID: event.PhysicalTableID,
+			TableInfo:       event.TableInfo,
+			StartTs:         event.StartTs,
+			CommitTs:        event.CommitTs,
+			Event:           row,
+			ColumnSelector:  selector,
+			Checksum:        row.Checksum,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.rows++
+	}
+	w.events = append(w.events, event)
+	return nil
+}
+
+// flush posts all the encoded messages by one request, and then calls the callbacks of the events.
+// The request is retried until it's accepted, so the events may be delivered more than once.
+func (w *worker) flush(ctx context.Context) error {
+	start := time.Now()
+	messages := w.encoder.Build()
+	values := make([][]byte, 0, len(messages))
+	var size int64
+	for _, message := range messages {
+		values = append(values, message.Value)
+		size += int64(message.Length())
+	}
+	err := postWithRetry(ctx, w.cfg, func() error {
+		return w.statistics.RecordBatchExecution(func() (int, int64, error) {
+			if err := w.client.Post(ctx, values); err != nil {
+				log.Warn("webhook sink post events failed",
+					zap.String("keyspace", w.changefeedID.Keyspace()),
+					zap.String("changefeed", w.changefeedID.Name()),
+					zap.Int("workerID", w.id),
+					zap.Int("messages", len(values)),
+					zap.Error(err))
+				return 0, 0, err
+			}
+			return w.rows, size, nil
+		})
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Debug("webhook sink flush events",
+		zap.Int("workerID", w.id),
+		zap.Int("events", len(w.events)),
+		zap.Int("rows", w.rows),
+		zap.Duration("duration", time.Since(start)))
+
+	for _, event := range w.events {
+		event.PostFlush()
+	}
+	clear(w.events)
+	w.events = w.events[:0]
+	w.rows = 0
+	return nil
+}
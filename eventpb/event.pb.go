@@ -177,6 +177,7 @@ type InnerFilterConfig struct {
 	Rules            []string           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
 	IgnoreTxnStartTs []uint64           `protobuf:"varint,2,rep,packed,name=ignore_txn_start_ts,json=ignoreTxnStartTs,proto3" json:"ignore_txn_start_ts,omitempty"`
 	EventFilters     []*EventFilterRule `protobuf:"bytes,3,rep,name=EventFilters,proto3" json:"EventFilters,omitempty"`
+	OnlineDdl        bool               `protobuf:"varint,4,opt,name=online_ddl,json=onlineDdl,proto3" json:"online_ddl,omitempty"`
 }
 
 func (m *InnerFilterConfig) Reset()         { *m = InnerFilterConfig{} }
@@ -233,10 +234,20 @@ func (m *InnerFilterConfig) GetEventFilters() []*EventFilterRule {
 	return nil
 }
 
+func (m *InnerFilterConfig) GetOnlineDdl() bool {
+	if m != nil {
+		return m.OnlineDdl
+	}
+	return false
+}
+
 type FilterConfig struct {
 	CaseSensitive  bool               `protobuf:"varint,1,opt,name=caseSensitive,proto3" json:"caseSensitive,omitempty"`
 	ForceReplicate bool               `protobuf:"varint,2,opt,name=forceReplicate,proto3" json:"forceReplicate,omitempty"`
 	FilterConfig   *InnerFilterConfig `protobuf:"bytes,3,opt,name=filterConfig,proto3" json:"filterConfig,omitempty"`
+	// column_selectors are the column selectors of the sink, the event service
+	// doesn't send the values of the columns which are not selected.
+	ColumnSelectors []*ColumnSelector `protobuf:"bytes,4,rep,name=column_selectors,json=columnSelectors,proto3" json:"column_selectors,omitempty"`
 }
 
 func (m *FilterConfig) Reset()         { *m = FilterConfig{} }
@@ -293,6 +304,13 @@ func (m *FilterConfig) GetFilterConfig() *InnerFilterConfig {
 	return nil
 }
 
+func (m *FilterConfig) GetColumnSelectors() []*ColumnSelector {
+	if m != nil {
+		return m.ColumnSelectors
+	}
+	return nil
+}
+
 type ResolvedTs struct {
 }
 
@@ -814,6 +832,59 @@ func (m *DispatcherRequest) GetTxnAtomicity() string {
 	return ""
 }
 
+// ColumnSelector selects the columns of the matched tables.
+type ColumnSelector struct {
+	Matcher []string `protobuf:"bytes,1,rep,name=matcher,proto3" json:"matcher,omitempty"`
+	Columns []string `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (m *ColumnSelector) Reset()         { *m = ColumnSelector{} }
+func (m *ColumnSelector) String() string { return proto.CompactTextString(m) }
+func (*ColumnSelector) ProtoMessage()    {}
+func (*ColumnSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{10}
+}
+func (m *ColumnSelector) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ColumnSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ColumnSelector.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ColumnSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnSelector.Merge(m, src)
+}
+func (m *ColumnSelector) XXX_Size() int {
+	return m.Size()
+}
+func (m *ColumnSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnSelector proto.InternalMessageInfo
+
+func (m *ColumnSelector) GetMatcher() []string {
+	if m != nil {
+		return m.Matcher
+	}
+	return nil
+}
+
+func (m *ColumnSelector) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("eventpb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("eventpb.ActionType", ActionType_name, ActionType_value)
@@ -827,85 +898,89 @@ func init() {
 	proto.RegisterType((*EventFeed)(nil), "eventpb.EventFeed")
 	proto.RegisterType((*IntegrityConfig)(nil), "eventpb.IntegrityConfig")
 	proto.RegisterType((*DispatcherRequest)(nil), "eventpb.DispatcherRequest")
+	proto.RegisterType((*ColumnSelector)(nil), "eventpb.ColumnSelector")
 }
 
 func init() { proto.RegisterFile("eventpb/event.proto", fileDescriptor_d7fb2554dfcf7f7d) }
 
 var fileDescriptor_d7fb2554dfcf7f7d = []byte{
-	// 1157 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4f, 0x73, 0xdb, 0x44,
-	0x14, 0x8f, 0x9c, 0x3f, 0xb6, 0x9e, 0xed, 0xc4, 0xde, 0x34, 0xad, 0xda, 0x42, 0x08, 0x86, 0xe9,
-	0x98, 0xce, 0xe0, 0x94, 0xd0, 0xc2, 0x4c, 0x87, 0xe9, 0x4c, 0x49, 0x5c, 0xd0, 0x0c, 0x6d, 0x32,
-	0x6b, 0xb5, 0x33, 0x70, 0xd1, 0xc8, 0xd2, 0x4b, 0x22, 0x2a, 0xef, 0xaa, 0xab, 0x95, 0x13, 0xf3,
-	0x29, 0x38, 0x71, 0xe2, 0xce, 0x57, 0xe1, 0xd8, 0x23, 0x37, 0x98, 0xf6, 0xc0, 0xd7, 0x60, 0x76,
-	0x57, 0x96, 0xad, 0xb6, 0x70, 0xe1, 0xe4, 0xdd, 0xf7, 0xfb, 0xbd, 0xdd, 0xb7, 0xbf, 0xf7, 0x47,
-	0x86, 0x6d, 0x9c, 0x22, 0x93, 0xe9, 0x78, 0x5f, 0xff, 0x0e, 0x52, 0xc1, 0x25, 0x27, 0xf5, 0xc2,
-	0x78, 0xe3, 0xe6, 0x39, 0x06, 0x42, 0x8e, 0x31, 0x50, 0x8c, 0x72, 0x6d, 0x58, 0xbd, 0x3f, 0x6b,
-	0xb0, 0x35, 0x54, 0xc4, 0x47, 0x71, 0x22, 0x51, 0xd0, 0x3c, 0x41, 0xe2, 0x40, 0x7d, 0x12, 0xc8,
-	0xf0, 0x1c, 0x85, 0x63, 0xed, 0xad, 0xf6, 0x6d, 0x3a, 0xdf, 0x92, 0x0f, 0xa1, 0x15, 0x9f, 0x31,
-	0x2e, 0xd0, 0xd7, 0x87, 0x3b, 0x35, 0x0d, 0x37, 0x8d, 0x4d, 0x1f, 0x43, 0xde, 0x07, 0x28, 0x28,
-	0xd9, 0x8b, 0xc4, 0x59, 0xd5, 0x04, 0xdb, 0x58, 0x46, 0x2f, 0x12, 0xf2, 0x25, 0x38, 0x05, 0x1c,
-	0xb3, 0x0c, 0x85, 0xf4, 0xa7, 0x41, 0x92, 0xa3, 0x8f, 0x97, 0xa9, 0x70, 0xd6, 0xf6, 0xac, 0xbe,
-	0x4d, 0x77, 0x0c, 0xee, 0x6a, 0xf8, 0x99, 0x42, 0x87, 0x97, 0xa9, 0x20, 0x0f, 0xe0, 0xbd, 0xc2,
-	0x31, 0x4f, 0xa3, 0x40, 0xa2, 0xcf, 0xf0, 0x62, 0xd9, 0x79, 0x5d, 0x3b, 0x17, 0x87, 0x3f, 0xd5,
-	0x94, 0x27, 0x78, 0xf1, 0x1f, 0xfe, 0x3c, 0x89, 0x96, 0xfd, 0x37, 0xde, 0xf6, 0x3f, 0x4e, 0xa2,
-	0x85, 0xff, 0x22, 0xf0, 0x08, 0x13, 0x94, 0xb8, 0xec, 0x5b, 0x5f, 0x0e, 0xfc, 0x48, 0xc3, 0xa5,
-	0x63, 0xef, 0x17, 0x0b, 0xba, 0x2e, 0x63, 0x28, 0x8c, 0xc2, 0x87, 0x9c, 0x9d, 0xc6, 0x67, 0xe4,
-	0x0a, 0xac, 0x8b, 0x3c, 0xc1, 0xac, 0x50, 0xd8, 0x6c, 0xc8, 0xa7, 0xb0, 0x5d, 0x5c, 0x22, 0x2f,
-	0x99, 0x9f, 0xc9, 0x40, 0x48, 0x5f, 0x66, 0x5a, 0xe6, 0x35, 0xda, 0x31, 0x90, 0x77, 0xc9, 0x46,
-	0x0a, 0xf0, 0x32, 0xf2, 0x15, 0xb4, 0x96, 0x72, 0x97, 0x69, 0xb5, 0x9b, 0x07, 0xce, 0xa0, 0xc8,
-	0xfc, 0xe0, 0x8d, 0xc4, 0xd2, 0x0a, 0xbb, 0xf7, 0xab, 0x05, 0xad, 0x4a, 0x4c, 0x1f, 0x43, 0x3b,
-	0x0c, 0x32, 0x1c, 0x21, 0xcb, 0x62, 0x19, 0x4f, 0xd1, 0xb1, 0xf6, 0xac, 0x7e, 0x83, 0x56, 0x8d,
-	0xe4, 0x16, 0x6c, 0x9e, 0x72, 0x11, 0x22, 0xc5, 0x34, 0x89, 0xc3, 0x40, 0xa2, 0x53, 0xd3, 0xb4,
-	0x37, 0xac, 0xe4, 0x01, 0xb4, 0x4e, 0x97, 0x4e, 0x77, 0x56, 0xf7, 0xac, 0x7e, 0xf3, 0xe0, 0x46,
-	0x19, 0xdc, 0x5b, 0x9a, 0xd0, 0x0a, 0xbf, 0xd7, 0x02, 0xa0, 0x98, 0xf1, 0x64, 0x8a, 0x91, 0x97,
-	0xf5, 0x72, 0x58, 0x37, 0xf5, 0xd5, 0x81, 0xd5, 0xe7, 0x38, 0xd3, 0xa1, 0xb5, 0xa8, 0x5a, 0x2a,
-	0x29, 0x75, 0x2e, 0x74, 0x1c, 0x2d, 0x6a, 0x36, 0xe4, 0x06, 0x34, 0xe6, 0xf9, 0xd3, 0x57, 0xb7,
-	0x68, 0xb9, 0x27, 0x7d, 0xa8, 0xf3, 0xd4, 0x97, 0xb3, 0x14, 0x75, 0xcd, 0x6d, 0x1e, 0x6c, 0x95,
-	0x51, 0x1d, 0xa7, 0xde, 0x2c, 0x45, 0xba, 0xc1, 0xf5, 0x6f, 0xef, 0x47, 0x68, 0x78, 0x97, 0xcc,
-	0xdc, 0x7c, 0x0b, 0x36, 0x34, 0xcb, 0xe4, 0xac, 0x79, 0xb0, 0x59, 0xd5, 0x99, 0x16, 0x28, 0xb9,
-	0x09, 0x76, 0xc8, 0x27, 0x93, 0xb8, 0x48, 0x9d, 0xd5, 0x5f, 0xa3, 0x0d, 0x63, 0xf0, 0x32, 0x72,
-	0x1d, 0x1a, 0x65, 0x5a, 0x57, 0x35, 0x56, 0xcf, 0x4c, 0x36, 0x7b, 0x4d, 0xb0, 0xbd, 0x60, 0x9c,
-	0xa0, 0xcb, 0x4e, 0x79, 0xef, 0x6f, 0x0b, 0x6c, 0x93, 0x2d, 0xc4, 0x88, 0xdc, 0x01, 0x50, 0x05,
-	0x51, 0xb9, 0xbe, 0x5b, 0x5e, 0x3f, 0x8f, 0x90, 0xda, 0xb2, 0x58, 0x65, 0xe4, 0x03, 0x68, 0x8a,
-	0x42, 0xbd, 0x45, 0x18, 0x20, 0x4a, 0x41, 0xc9, 0x03, 0x68, 0x47, 0x71, 0x96, 0x9a, 0xc6, 0xf6,
-	0xe3, 0xa8, 0xc8, 0xcf, 0xf5, 0xc1, 0xd2, 0xb4, 0x18, 0x1c, 0x95, 0x0c, 0xf7, 0x88, 0xb6, 0x16,
-	0x7c, 0x37, 0xd2, 0x05, 0x1c, 0xc8, 0x98, 0x6b, 0x05, 0x6b, 0xd4, 0x6c, 0xc8, 0x67, 0x00, 0x52,
-	0xbd, 0xc1, 0x8f, 0xd9, 0x29, 0xd7, 0x3d, 0xd9, 0x3c, 0x20, 0x8b, 0x40, 0xe7, 0xcf, 0xa3, 0xb6,
-	0x2c, 0x5f, 0x3a, 0x83, 0x2d, 0x97, 0x49, 0x3c, 0x13, 0xb1, 0x9c, 0x15, 0x85, 0x78, 0x07, 0xb6,
-	0x17, 0xa6, 0x73, 0x0c, 0x9f, 0x7f, 0x87, 0x53, 0x4c, 0x74, 0xce, 0x6d, 0xfa, 0x2e, 0x88, 0xdc,
-	0x85, 0x9d, 0x43, 0x2e, 0x44, 0x9e, 0xca, 0x98, 0xb3, 0x6f, 0x03, 0x16, 0x25, 0x68, 0x7c, 0x6a,
-	0xa6, 0x35, 0xdf, 0x09, 0xf6, 0x7e, 0xdb, 0x80, 0xee, 0xe2, 0x89, 0x14, 0x5f, 0xe4, 0x98, 0xe9,
-	0x09, 0x16, 0x26, 0x79, 0x26, 0x8d, 0x2c, 0x96, 0x56, 0xce, 0x2e, 0x2c, 0x6e, 0xa4, 0x84, 0x0b,
-	0xcf, 0x03, 0x76, 0x86, 0xa7, 0x88, 0x91, 0x62, 0xd4, 0xde, 0x21, 0xdc, 0x61, 0xc9, 0x50, 0xc2,
-	0x2d, 0xf8, 0xc6, 0xff, 0x7f, 0x09, 0x7f, 0x6f, 0x2e, 0x71, 0x96, 0x06, 0x4c, 0xab, 0xdf, 0x3c,
-	0xb8, 0x5a, 0x71, 0xd6, 0x32, 0x8f, 0xd2, 0x80, 0x15, 0x32, 0xab, 0x65, 0xa5, 0xf0, 0xd6, 0x2b,
-	0x85, 0xa7, 0x0a, 0x36, 0x43, 0x31, 0x35, 0xd1, 0x98, 0x39, 0xd8, 0x30, 0x06, 0x37, 0x22, 0x77,
-	0xa1, 0x19, 0x84, 0x4a, 0x38, 0xd3, 0x2f, 0x75, 0xdd, 0x2f, 0xdb, 0x65, 0x4a, 0x1f, 0x6a, 0x4c,
-	0xf7, 0x0c, 0x04, 0xe5, 0x9a, 0xdc, 0x87, 0xb6, 0x69, 0x66, 0x3f, 0x34, 0xdd, 0xdf, 0xd0, 0x71,
-	0xee, 0x94, 0x7e, 0xff, 0xde, 0xf8, 0xe4, 0x36, 0x74, 0x91, 0x99, 0x17, 0xce, 0x58, 0xe8, 0xa7,
-	0x3c, 0x66, 0xd2, 0xb1, 0xf5, 0x8c, 0xd9, 0x32, 0xc0, 0x68, 0xc6, 0xc2, 0x13, 0x65, 0x26, 0x3d,
-	0x68, 0x2f, 0x48, 0xea, 0x69, 0xa0, 0x9f, 0xd6, 0xcc, 0xe6, 0x0c, 0x2f, 0x23, 0x03, 0xd8, 0x5e,
-	0xe2, 0xc4, 0x4c, 0xa2, 0x98, 0x06, 0x89, 0xd3, 0xd4, 0xcc, 0x6e, 0xc9, 0x74, 0x0b, 0x40, 0xe5,
-	0x9f, 0xb3, 0x64, 0xe6, 0x0b, 0xcc, 0x33, 0x74, 0x5a, 0xfa, 0x62, 0x5b, 0x59, 0xa8, 0x32, 0x28,
-	0x21, 0xc7, 0x91, 0xf0, 0x27, 0x3c, 0x42, 0xa7, 0xad, 0xc1, 0xfa, 0x38, 0x12, 0x8f, 0x79, 0x84,
-	0xe4, 0x0b, 0xb0, 0xe3, 0x79, 0x71, 0x3a, 0x9b, 0xfa, 0xc5, 0xce, 0xd2, 0xbc, 0xab, 0x14, 0x39,
-	0x5d, 0x50, 0xd5, 0xac, 0x92, 0xf1, 0x04, 0x7f, 0xe2, 0x0c, 0x9d, 0x2d, 0xa3, 0xff, 0x7c, 0xaf,
-	0xfa, 0x0c, 0x53, 0x1e, 0x9e, 0x3b, 0x1d, 0x1d, 0xaf, 0xd9, 0x90, 0x7b, 0x70, 0x8d, 0xe7, 0x32,
-	0xcd, 0xa5, 0x2f, 0x82, 0x0b, 0xdf, 0xd4, 0x57, 0xf1, 0x4d, 0xee, 0xea, 0x98, 0xae, 0x18, 0x98,
-	0x06, 0x17, 0xa6, 0x14, 0xcd, 0x08, 0x23, 0xb0, 0xa6, 0xe3, 0x26, 0x7b, 0x56, 0x7f, 0x95, 0xea,
-	0x35, 0xf9, 0x08, 0xda, 0x6a, 0xb6, 0x04, 0x92, 0x4f, 0xe2, 0x50, 0x05, 0xbe, 0xad, 0x23, 0x68,
-	0xc9, 0x4b, 0xf6, 0x70, 0x6e, 0xbb, 0xfd, 0x09, 0x6c, 0x98, 0xc9, 0x48, 0xda, 0x60, 0x9b, 0xd5,
-	0x49, 0x2e, 0x3b, 0x2b, 0xa4, 0x03, 0x2d, 0xb3, 0x35, 0x9f, 0xbd, 0x8e, 0x75, 0x5b, 0x00, 0x2c,
-	0x8a, 0x82, 0xdc, 0x84, 0x6b, 0x0f, 0x0f, 0x3d, 0xf7, 0xf8, 0x89, 0xef, 0x7d, 0x7f, 0x32, 0xf4,
-	0x9f, 0x3e, 0x19, 0x9d, 0x0c, 0x0f, 0xdd, 0x47, 0xee, 0xf0, 0xa8, 0xb3, 0x42, 0x1c, 0xb8, 0xb2,
-	0x0c, 0xd2, 0xe1, 0x37, 0xee, 0xc8, 0x1b, 0xd2, 0x8e, 0x45, 0xae, 0x02, 0xa9, 0x22, 0x8f, 0x8f,
-	0x9f, 0x0d, 0x3b, 0x35, 0xb2, 0x03, 0xdd, 0xaa, 0x7d, 0x34, 0xf4, 0x3a, 0xeb, 0x5f, 0xdf, 0xff,
-	0xfd, 0xd5, 0xae, 0xf5, 0xf2, 0xd5, 0xae, 0xf5, 0xd7, 0xab, 0x5d, 0xeb, 0xe7, 0xd7, 0xbb, 0x2b,
-	0x2f, 0x5f, 0xef, 0xae, 0xfc, 0xf1, 0x7a, 0x77, 0xe5, 0x87, 0xbd, 0xb3, 0x58, 0x9e, 0xe7, 0xe3,
-	0x41, 0xc8, 0x27, 0xfb, 0x69, 0xcc, 0xce, 0xc2, 0x20, 0xdd, 0x97, 0x71, 0x18, 0x85, 0xfb, 0x45,
-	0x5e, 0xc6, 0x1b, 0xfa, 0x8f, 0xd0, 0xe7, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0xce, 0xc1, 0x69,
-	0x79, 0x45, 0x09, 0x00, 0x00,
+	// 1216 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcf, 0x6e, 0xdb, 0xc6,
+	0x13, 0x36, 0xe5, 0x3f, 0x12, 0x47, 0x92, 0x2d, 0xad, 0xe3, 0x84, 0x49, 0x7e, 0x3f, 0xd7, 0x55,
+	0x8b, 0xc0, 0x0d, 0x50, 0x39, 0x75, 0x93, 0x16, 0x08, 0x8a, 0x00, 0x89, 0xad, 0xb4, 0x04, 0x9a,
+	0xd8, 0x58, 0x31, 0x01, 0xda, 0x0b, 0x41, 0x91, 0x63, 0x9b, 0x0d, 0xb5, 0xcb, 0xec, 0x2e, 0x65,
+	0xa9, 0x4f, 0xd1, 0xb7, 0xe8, 0x13, 0xf4, 0x1d, 0x7a, 0xcc, 0xb1, 0xb7, 0x06, 0xc9, 0xa1, 0xaf,
+	0x51, 0x70, 0x97, 0xa2, 0xc4, 0x24, 0xc8, 0xa5, 0x27, 0xed, 0xcc, 0xf7, 0x0d, 0x39, 0xf3, 0xcd,
+	0xec, 0x88, 0xb0, 0x8d, 0x13, 0x64, 0x2a, 0x1d, 0x1d, 0xe8, 0xdf, 0x7e, 0x2a, 0xb8, 0xe2, 0xa4,
+	0x5e, 0x38, 0x6f, 0xdc, 0xbc, 0xc0, 0x40, 0xa8, 0x11, 0x06, 0x39, 0xa3, 0x3c, 0x1b, 0x56, 0xef,
+	0xef, 0x1a, 0x6c, 0x0d, 0x72, 0xe2, 0xe3, 0x38, 0x51, 0x28, 0x68, 0x96, 0x20, 0x71, 0xa0, 0x3e,
+	0x0e, 0x54, 0x78, 0x81, 0xc2, 0xb1, 0xf6, 0x56, 0xf7, 0x6d, 0x3a, 0x37, 0xc9, 0xa7, 0xd0, 0x8a,
+	0xcf, 0x19, 0x17, 0xe8, 0xeb, 0x87, 0x3b, 0x35, 0x0d, 0x37, 0x8d, 0x4f, 0x3f, 0x86, 0xfc, 0x1f,
+	0xa0, 0xa0, 0xc8, 0x97, 0x89, 0xb3, 0xaa, 0x09, 0xb6, 0xf1, 0x0c, 0x5f, 0x26, 0xe4, 0x5b, 0x70,
+	0x0a, 0x38, 0x66, 0x12, 0x85, 0xf2, 0x27, 0x41, 0x92, 0xa1, 0x8f, 0xd3, 0x54, 0x38, 0x6b, 0x7b,
+	0xd6, 0xbe, 0x4d, 0x77, 0x0c, 0xee, 0x6a, 0xf8, 0x79, 0x8e, 0x0e, 0xa6, 0xa9, 0x20, 0x0f, 0xe0,
+	0x7f, 0x45, 0x60, 0x96, 0x46, 0x81, 0x42, 0x9f, 0xe1, 0xe5, 0x72, 0xf0, 0xba, 0x0e, 0x2e, 0x1e,
+	0xfe, 0x4c, 0x53, 0x9e, 0xe2, 0xe5, 0x47, 0xe2, 0x79, 0x12, 0x2d, 0xc7, 0x6f, 0xbc, 0x1f, 0x7f,
+	0x92, 0x44, 0x8b, 0xf8, 0x45, 0xe2, 0x11, 0x26, 0xa8, 0x70, 0x39, 0xb6, 0xbe, 0x9c, 0xf8, 0xb1,
+	0x86, 0xcb, 0xc0, 0xde, 0x1f, 0x16, 0x74, 0x5d, 0xc6, 0x50, 0x18, 0x85, 0x8f, 0x38, 0x3b, 0x8b,
+	0xcf, 0xc9, 0x15, 0x58, 0x17, 0x59, 0x82, 0xb2, 0x50, 0xd8, 0x18, 0xe4, 0x4b, 0xd8, 0x2e, 0x5e,
+	0xa2, 0xa6, 0xcc, 0x97, 0x2a, 0x10, 0xca, 0x57, 0x52, 0xcb, 0xbc, 0x46, 0x3b, 0x06, 0xf2, 0xa6,
+	0x6c, 0x98, 0x03, 0x9e, 0x24, 0xdf, 0x41, 0x6b, 0xa9, 0x77, 0x52, 0xab, 0xdd, 0x3c, 0x74, 0xfa,
+	0x45, 0xe7, 0xfb, 0xef, 0x34, 0x96, 0x56, 0xd8, 0x79, 0xa7, 0x38, 0x4b, 0x62, 0x86, 0x7e, 0x14,
+	0x25, 0x5a, 0xfc, 0x06, 0xb5, 0x8d, 0xe7, 0x38, 0x4a, 0x7a, 0xaf, 0x2d, 0x68, 0x55, 0x52, 0xfe,
+	0x1c, 0xda, 0x61, 0x20, 0x71, 0x88, 0x4c, 0xc6, 0x2a, 0x9e, 0xa0, 0x63, 0xe9, 0x90, 0xaa, 0x93,
+	0xdc, 0x82, 0xcd, 0x33, 0x2e, 0x42, 0xa4, 0x98, 0x26, 0x71, 0x18, 0x28, 0x74, 0x6a, 0x9a, 0xf6,
+	0x8e, 0x97, 0x3c, 0x80, 0xd6, 0xd9, 0xd2, 0xd3, 0x9d, 0xd5, 0x3d, 0x6b, 0xbf, 0x79, 0x78, 0xa3,
+	0xcc, 0xfd, 0x3d, 0xc9, 0x68, 0x85, 0x4f, 0x1e, 0x41, 0x27, 0xe4, 0x49, 0x36, 0x66, 0xbe, 0xc4,
+	0x04, 0x43, 0xc5, 0x85, 0x74, 0xd6, 0x74, 0xfd, 0xd7, 0xca, 0x67, 0x1c, 0x69, 0xc2, 0xb0, 0xc0,
+	0xe9, 0x56, 0x58, 0xb1, 0x65, 0xaf, 0x05, 0x40, 0x51, 0xf2, 0x64, 0x82, 0x91, 0x27, 0x7b, 0x19,
+	0xac, 0x9b, 0x11, 0xee, 0xc0, 0xea, 0x0b, 0x9c, 0xe9, 0xf2, 0x5a, 0x34, 0x3f, 0xe6, 0xdd, 0xd2,
+	0xed, 0xd6, 0xb5, 0xb4, 0xa8, 0x31, 0xc8, 0x0d, 0x68, 0xcc, 0x47, 0x44, 0xa7, 0xdf, 0xa2, 0xa5,
+	0x4d, 0xf6, 0xa1, 0xce, 0x53, 0x5f, 0xcd, 0x52, 0xd4, 0xca, 0x6e, 0x1e, 0x6e, 0x95, 0x59, 0x9d,
+	0xa4, 0xde, 0x2c, 0x45, 0xba, 0xc1, 0xf5, 0x6f, 0xef, 0x17, 0x68, 0x78, 0x53, 0x66, 0xde, 0x7c,
+	0x0b, 0x36, 0x34, 0xcb, 0x8c, 0x45, 0xf3, 0x70, 0xb3, 0xda, 0x4a, 0x5a, 0xa0, 0xe4, 0x26, 0xd8,
+	0x21, 0x1f, 0x8f, 0xe3, 0x62, 0x3a, 0xac, 0xfd, 0x35, 0xda, 0x30, 0x0e, 0x4f, 0x92, 0xeb, 0xd0,
+	0x28, 0x27, 0x67, 0x55, 0x63, 0x75, 0x69, 0x06, 0xa6, 0xd7, 0x04, 0xdb, 0x0b, 0x46, 0x09, 0xba,
+	0xec, 0x8c, 0xf7, 0xfe, 0xb1, 0xc0, 0x36, 0x03, 0x81, 0x18, 0x91, 0x3b, 0x00, 0xf9, 0xcc, 0x55,
+	0x5e, 0xdf, 0x2d, 0x5f, 0x3f, 0xcf, 0x90, 0xda, 0xaa, 0x38, 0x49, 0xf2, 0x09, 0x34, 0x45, 0xa1,
+	0xde, 0x22, 0x0d, 0x10, 0xa5, 0xa0, 0xe4, 0x01, 0xb4, 0xa3, 0x58, 0xa6, 0x66, 0x77, 0xf8, 0x71,
+	0x54, 0xf4, 0xf8, 0x7a, 0x7f, 0x69, 0x21, 0xf5, 0x8f, 0x4b, 0x86, 0x7b, 0x4c, 0x5b, 0x0b, 0xbe,
+	0x1b, 0xe9, 0x3b, 0x12, 0xa8, 0x98, 0x6b, 0x05, 0x6b, 0xd4, 0x18, 0xe4, 0x2b, 0x00, 0x95, 0xd7,
+	0xe0, 0xc7, 0xec, 0x8c, 0xeb, 0x6b, 0xdf, 0x3c, 0x24, 0x8b, 0x44, 0xe7, 0xe5, 0x51, 0x5b, 0x95,
+	0x95, 0xce, 0x60, 0xcb, 0x65, 0x0a, 0xcf, 0x45, 0xac, 0x66, 0xc5, 0xf8, 0xdc, 0x81, 0xed, 0x85,
+	0xeb, 0x02, 0xc3, 0x17, 0x3f, 0xe2, 0x04, 0x13, 0xdd, 0x73, 0x9b, 0x7e, 0x08, 0x22, 0x77, 0x61,
+	0xe7, 0x88, 0x0b, 0x91, 0xa5, 0x2a, 0xe6, 0xec, 0x87, 0x80, 0x45, 0x09, 0x9a, 0x98, 0x9a, 0xb9,
+	0xfd, 0x1f, 0x04, 0x7b, 0xbf, 0x6f, 0x40, 0x77, 0x51, 0x22, 0xc5, 0x97, 0x19, 0x4a, 0xbd, 0x24,
+	0xc3, 0x24, 0x93, 0xca, 0xc8, 0x62, 0x69, 0xe5, 0xec, 0xc2, 0xe3, 0x46, 0xb9, 0x70, 0xe1, 0x45,
+	0xc0, 0xce, 0xf1, 0x0c, 0x31, 0xca, 0x19, 0xb5, 0x0f, 0x08, 0x77, 0x54, 0x32, 0x72, 0xe1, 0x16,
+	0x7c, 0x13, 0xff, 0x9f, 0x84, 0xbf, 0x37, 0x97, 0x58, 0xa6, 0x01, 0xd3, 0xea, 0x37, 0x0f, 0xaf,
+	0x56, 0x82, 0xb5, 0xcc, 0xc3, 0x34, 0x60, 0x85, 0xcc, 0xf9, 0xb1, 0x32, 0x78, 0xeb, 0x95, 0xc1,
+	0xcb, 0x07, 0x56, 0xa2, 0x98, 0x98, 0x6c, 0xcc, 0xaa, 0x6d, 0x18, 0x87, 0x1b, 0x91, 0xbb, 0xd0,
+	0x0c, 0xc2, 0x5c, 0x38, 0x73, 0x5f, 0xea, 0xfa, 0xbe, 0x6c, 0x97, 0x2d, 0x7d, 0xa8, 0x31, 0x7d,
+	0x67, 0x20, 0x28, 0xcf, 0xe4, 0x3e, 0xb4, 0xcd, 0x42, 0xf0, 0x43, 0xb3, 0x41, 0x1a, 0x3a, 0xcf,
+	0x9d, 0x32, 0xee, 0x23, 0xcb, 0xe3, 0x36, 0x74, 0x91, 0x99, 0x0a, 0x67, 0x2c, 0xf4, 0x53, 0x1e,
+	0x33, 0xe5, 0xd8, 0x7a, 0x4f, 0x6d, 0x19, 0x60, 0x38, 0x63, 0xe1, 0x69, 0xee, 0x26, 0x3d, 0x68,
+	0x2f, 0x48, 0x79, 0x69, 0xa0, 0x4b, 0x6b, 0xca, 0x39, 0xc3, 0x93, 0xa4, 0x0f, 0xdb, 0x4b, 0x9c,
+	0x98, 0x29, 0x14, 0x93, 0x20, 0x71, 0x9a, 0x9a, 0xd9, 0x2d, 0x99, 0x6e, 0x01, 0x14, 0xab, 0x77,
+	0xe6, 0x0b, 0xcc, 0x24, 0x3a, 0xad, 0x72, 0xf5, 0xce, 0x68, 0xee, 0xc8, 0x85, 0x1c, 0x45, 0xc2,
+	0x1f, 0xf3, 0x08, 0x9d, 0xb6, 0x06, 0xeb, 0xa3, 0x48, 0x3c, 0xe1, 0x11, 0x92, 0x6f, 0xc0, 0x8e,
+	0xe7, 0xc3, 0xe9, 0x6c, 0xee, 0x59, 0x95, 0x7d, 0xff, 0xce, 0x90, 0xd3, 0x05, 0x35, 0xdf, 0x55,
+	0x2a, 0x1e, 0xe3, 0xaf, 0x9c, 0xa1, 0xb3, 0x65, 0xf4, 0x9f, 0xdb, 0xf9, 0x3d, 0xc3, 0x94, 0x87,
+	0x17, 0x4e, 0x47, 0xe7, 0x6b, 0x0c, 0x72, 0x0f, 0xae, 0xf1, 0x4c, 0xa5, 0x99, 0xf2, 0x45, 0x70,
+	0xe9, 0x9b, 0xf9, 0x2a, 0xfe, 0xf6, 0xbb, 0x3a, 0xa7, 0x2b, 0x06, 0xa6, 0xc1, 0xa5, 0x19, 0x45,
+	0xb3, 0xc2, 0x08, 0xac, 0xe9, 0xbc, 0xc9, 0x9e, 0xb5, 0xbf, 0x4a, 0xf5, 0x99, 0x7c, 0x06, 0xed,
+	0x7c, 0xb7, 0x04, 0x8a, 0x8f, 0xe3, 0x30, 0x4f, 0x7c, 0x5b, 0x67, 0xd0, 0x52, 0x53, 0xf6, 0x70,
+	0xee, 0xeb, 0x1d, 0xc3, 0x66, 0x75, 0x5f, 0x7f, 0xe4, 0x3b, 0xc4, 0x81, 0xba, 0xd9, 0xe5, 0xb2,
+	0xf8, 0x04, 0x99, 0x9b, 0xb7, 0xbf, 0x80, 0x0d, 0xb3, 0x5f, 0x49, 0x1b, 0x6c, 0x73, 0x3a, 0xcd,
+	0x54, 0x67, 0x85, 0x74, 0xa0, 0x65, 0x4c, 0xf3, 0xff, 0xdc, 0xb1, 0x6e, 0x0b, 0x80, 0xc5, 0x68,
+	0x91, 0x9b, 0x70, 0xed, 0xe1, 0x91, 0xe7, 0x9e, 0x3c, 0xf5, 0xbd, 0x9f, 0x4e, 0x07, 0xfe, 0xb3,
+	0xa7, 0xc3, 0xd3, 0xc1, 0x91, 0xfb, 0xd8, 0x1d, 0x1c, 0x77, 0x56, 0x88, 0x03, 0x57, 0x96, 0x41,
+	0x3a, 0xf8, 0xde, 0x1d, 0x7a, 0x03, 0xda, 0xb1, 0xc8, 0x55, 0x20, 0x55, 0xe4, 0xc9, 0xc9, 0xf3,
+	0x41, 0xa7, 0x46, 0x76, 0xa0, 0x5b, 0xf5, 0x0f, 0x07, 0x5e, 0x67, 0xfd, 0xd1, 0xfd, 0x3f, 0xdf,
+	0xec, 0x5a, 0xaf, 0xde, 0xec, 0x5a, 0xaf, 0xdf, 0xec, 0x5a, 0xbf, 0xbd, 0xdd, 0x5d, 0x79, 0xf5,
+	0x76, 0x77, 0xe5, 0xaf, 0xb7, 0xbb, 0x2b, 0x3f, 0xef, 0x9d, 0xc7, 0xea, 0x22, 0x1b, 0xf5, 0x43,
+	0x3e, 0x3e, 0x48, 0x63, 0x76, 0x1e, 0x06, 0xe9, 0x81, 0x8a, 0xc3, 0x28, 0x3c, 0x28, 0xba, 0x3b,
+	0xda, 0xd0, 0x5f, 0x6c, 0x5f, 0xff, 0x3b, 0x00, 0x47, 0xf9, 0x9d, 0x6f, 0xee, 0x09, 0x00, 0x00,
 }
 
 func (m *EventFilterRule) Marshal() (dAtA []byte, err error) {
@@ -1006,6 +1081,16 @@ func (m *InnerFilterConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.OnlineDdl {
+		i--
+		if m.OnlineDdl {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.EventFilters) > 0 {
 		for iNdEx := len(m.EventFilters) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1070,6 +1155,20 @@ func (m *FilterConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ColumnSelectors) > 0 {
+		for iNdEx := len(m.ColumnSelectors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ColumnSelectors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEvent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if m.FilterConfig != nil {
 		{
 			size, err := m.FilterConfig.MarshalToSizedBuffer(dAtA[:i])
@@ -1543,6 +1642,47 @@ func (m *DispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ColumnSelector) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ColumnSelector) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ColumnSelector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Columns) > 0 {
+		for iNdEx := len(m.Columns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Columns[iNdEx])
+			copy(dAtA[i:], m.Columns[iNdEx])
+			i = encodeVarintEvent(dAtA, i, uint64(len(m.Columns[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Matcher) > 0 {
+		for iNdEx := len(m.Matcher) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Matcher[iNdEx])
+			copy(dAtA[i:], m.Matcher[iNdEx])
+			i = encodeVarintEvent(dAtA, i, uint64(len(m.Matcher[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintEvent(dAtA []byte, offset int, v uint64) int {
 	offset -= sovEvent(v)
 	base := offset
@@ -1622,6 +1762,9 @@ func (m *InnerFilterConfig) Size() (n int) {
 			n += 1 + l + sovEvent(uint64(l))
 		}
 	}
+	if m.OnlineDdl {
+		n += 2
+	}
 	return n
 }
 
@@ -1641,6 +1784,12 @@ func (m *FilterConfig) Size() (n int) {
 		l = m.FilterConfig.Size()
 		n += 1 + l + sovEvent(uint64(l))
 	}
+	if len(m.ColumnSelectors) > 0 {
+		for _, e := range m.ColumnSelectors {
+			l = e.Size()
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1827,6 +1976,27 @@ func (m *DispatcherRequest) Size() (n int) {
 	return n
 }
 
+func (m *ColumnSelector) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Matcher) > 0 {
+		for _, s := range m.Matcher {
+			l = len(s)
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
+	if len(m.Columns) > 0 {
+		for _, s := range m.Columns {
+			l = len(s)
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovEvent(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2278,6 +2448,26 @@ func (m *InnerFilterConfig) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OnlineDdl", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OnlineDdl = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -2404,6 +2594,40 @@ func (m *FilterConfig) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnSelectors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnSelectors = append(m.ColumnSelectors, &ColumnSelector{})
+			if err := m.ColumnSelectors[len(m.ColumnSelectors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -3657,6 +3881,120 @@ func (m *DispatcherRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ColumnSelector) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ColumnSelector: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ColumnSelector: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matcher", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matcher = append(m.Matcher, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Columns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Columns = append(m.Columns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipEvent(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
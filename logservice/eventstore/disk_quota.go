@@ -0,0 +1,386 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// The disk usage of the event store is controlled as follows:
+//  1. above the soft watermark, the deleted data is compacted to release the disk space,
+//     and the changefeeds of the subscriptions which retain the most data are warned.
+//  2. above the hard watermark, the subscriptions which retain the most data are throttled: their events
+//     are held without calling the callbacks, so their paths in the dynamic stream of the puller are
+//     blocked and the ingestion is paused by the memory control, the other subscriptions are not affected.
+//     Besides, the largest subscription is evicted: its dispatchers are marked as evicted to be removed
+//     by the event service, which reports an error to the changefeed. The evicted dispatchers can't be
+//     registered again until the data of the subscription is deleted after all dispatchers are removed.
+//     At most one subscription is evicted in a relief window: no more subscription is evicted until
+//     the data of the evicted one is deleted and the disk usage is checked again.
+
+type diskPressure int32
+
+const (
+	diskPressureNone diskPressure = iota
+	diskPressureSoft
+	diskPressureHard
+)
+
+func (p diskPressure) String() string {
+	switch p {
+	case diskPressureSoft:
+		return "soft"
+	case diskPressureHard:
+		return "hard"
+	default:
+		return "none"
+	}
+}
+
+const (
+	diskQuotaCheckInterval = 5 * time.Second
+	// the number of the largest subscriptions whose changefeeds are warned under pressure.
+	largeSubscriptionWarnCount = 3
+)
+
+type diskQuotaController struct {
+	quota         uint64
+	softWatermark float64
+	hardWatermark float64
+
+	pressure atomic.Int32
+}
+
+func newDiskQuotaController(cfg *config.EventStoreConfig) *diskQuotaController {
+	if cfg == nil {
+		return &diskQuotaController{}
+	}
+	return &diskQuotaController{
+		quota:         cfg.DiskQuota,
+		softWatermark: cfg.DiskQuotaSoftWatermark,
+		hardWatermark: cfg.DiskQuotaHardWatermark,
+	}
+}
+
+func (c *diskQuotaController) enabled() bool {
+	return c.quota > 0
+}
+
+// update sets the pressure according to the disk usage and returns it.
+func (c *diskQuotaController) update(usage uint64) diskPressure {
+	pressure := diskPressureNone
+	ratio := float64(usage) / float64(c.quota)
+	if ratio >= c.hardWatermark {
+		pressure = diskPressureHard
+	} else if ratio >= c.softWatermark {
+		pressure = diskPressureSoft
+	}
+	old := diskPressure(c.pressure.Swap(int32(pressure)))
+	if old != pressure {
+		log.Info("event store disk pressure changed",
+			zap.Stringer("from", old), zap.Stringer("to", pressure),
+			zap.Uint64("usage", usage), zap.Uint64("quota", c.quota))
+	}
+	metrics.EventStoreDiskQuotaUsageRatioGauge.Set(ratio)
+	return pressure
+}
+
+// holdIfThrottled holds the event if the subscription is throttled and returns true,
+// the callback of the event is called after it's written when the subscription is not throttled.
+func (s *subscriptionStat) holdIfThrottled(event eventWithCallback) bool {
+	if !s.throttled.Load() {
+		return false
+	}
+	s.throttledMu.Lock()
+	defer s.throttledMu.Unlock()
+	if !s.throttled.Load() {
+		return false
+	}
+	s.throttledEvents = append(s.throttledEvents, event)
+	return true
+}
+
+// setThrottled sets whether the subscription is throttled and returns true if it's changed,
+// the held events are pushed to be written when the subscription is not throttled.
+func (s *subscriptionStat) setThrottled(throttled bool) bool {
+	s.throttledMu.Lock()
+	defer s.throttledMu.Unlock()
+	if s.throttled.Swap(throttled) == throttled {
+		return false
+	}
+	if throttled {
+		metrics.EventStoreDiskQuotaThrottledSubscriptionGauge.Inc()
+		return true
+	}
+	metrics.EventStoreDiskQuotaThrottledSubscriptionGauge.Dec()
+	for _, event := range s.throttledEvents {
+		s.eventCh.Push(event)
+	}
+	s.throttledEvents = nil
+	return true
+}
+
+// dropThrottledEvents drops the held events of the removed subscription,
+// the callbacks are called to release the path in the dynamic stream.
+func (s *subscriptionStat) dropThrottledEvents() {
+	s.throttledMu.Lock()
+	events := s.throttledEvents
+	s.throttledEvents = nil
+	if s.throttled.Swap(false) {
+		metrics.EventStoreDiskQuotaThrottledSubscriptionGauge.Dec()
+	}
+	s.throttledMu.Unlock()
+	for _, event := range events {
+		event.callback()
+	}
+}
+
+func (e *eventStore) diskUsage() uint64 {
+	var usage uint64
+	for _, db := range e.dbs {
+		usage += diskSpaceUsage(db.Metrics())
+	}
+	return usage
+}
+
+func (e *eventStore) runDiskQuotaController(ctx context.Context) error {
+	ticker := time.NewTicker(diskQuotaCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			e.checkDiskQuota(e.diskUsage())
+		}
+	}
+}
+
+func (e *eventStore) checkDiskQuota(usage uint64) {
+	pressure := e.diskQuota.update(usage)
+	// the data of the evicted subscriptions is deleted once all dispatchers are removed.
+	evicting := e.cleanEvictedSubscriptions()
+	if pressure != diskPressureHard {
+		e.unthrottleSubscriptions()
+	}
+	if pressure == diskPressureNone {
+		return
+	}
+	e.gcManager.doCompaction()
+
+	largest := e.largestSubscriptions(largeSubscriptionWarnCount)
+	for _, sub := range largest {
+		log.Warn("event store is under disk pressure, the changefeed retains too much data",
+			zap.Stringer("pressure", pressure),
+			zap.Uint64("usage", usage),
+			zap.Uint64("quota", e.diskQuota.quota),
+			zap.Strings("changefeeds", e.getChangefeedsOfSubStat(sub.subStat)),
+			zap.Uint64("subscriptionID", uint64(sub.subStat.subID)),
+			zap.String("subSpan", common.FormatTableSpan(sub.subStat.tableSpan)),
+			zap.Uint64("retainedBytes", sub.size),
+			zap.Uint64("checkpointTs", sub.subStat.checkpointTs.Load()),
+			zap.Uint64("resolvedTs", sub.subStat.resolvedTs.Load()))
+	}
+	if pressure == diskPressureHard && len(largest) > 0 {
+		for _, sub := range largest {
+			e.throttleSubscription(sub.subStat)
+		}
+		// the usage is not relieved by the eviction until the data of the evicted subscription
+		// is deleted, evicting more subscriptions before that only fails more changefeeds.
+		if evicting {
+			log.Info("event store is under hard disk pressure, wait for the evicted subscription to be cleaned",
+				zap.Uint64("usage", usage), zap.Uint64("quota", e.diskQuota.quota))
+			return
+		}
+		e.evictSubscription(largest[0].subStat)
+	}
+}
+
+// throttleSubscription pauses the ingestion of the subscription until the disk pressure is relieved.
+func (e *eventStore) throttleSubscription(subStat *subscriptionStat) {
+	if !subStat.setThrottled(true) {
+		return
+	}
+	log.Warn("throttle the subscription because of the disk quota",
+		zap.Uint64("subscriptionID", uint64(subStat.subID)),
+		zap.String("subSpan", common.FormatTableSpan(subStat.tableSpan)),
+		zap.Uint64("checkpointTs", subStat.checkpointTs.Load()),
+		zap.Uint64("resolvedTs", subStat.resolvedTs.Load()))
+}
+
+// unthrottleSubscriptions resumes the ingestion of the throttled subscriptions,
+// the evicted subscriptions are kept throttled until they are removed.
+func (e *eventStore) unthrottleSubscriptions() {
+	e.dispatcherMeta.RLock()
+	defer e.dispatcherMeta.RUnlock()
+	for _, subStats := range e.dispatcherMeta.tableStats {
+		for _, subStat := range subStats {
+			if !subStat.evicted.Load() && subStat.setThrottled(false) {
+				log.Info("unthrottle the subscription because the disk pressure is relieved",
+					zap.Uint64("subscriptionID", uint64(subStat.subID)),
+					zap.String("subSpan", common.FormatTableSpan(subStat.tableSpan)))
+			}
+		}
+	}
+}
+
+type subscriptionSize struct {
+	subStat *subscriptionStat
+	size    uint64
+}
+
+// largestSubscriptions returns at most n subscriptions which have dispatchers and retain the most
+// data on disk. The data is measured instead of the checkpoint ts, so a new subscription with an
+// old start ts is not penalized before it retains much data.
+func (e *eventStore) largestSubscriptions(n int) []subscriptionSize {
+	var candidates []*subscriptionStat
+	e.dispatcherMeta.RLock()
+	for _, subStats := range e.dispatcherMeta.tableStats {
+		for _, subStat := range subStats {
+			subscribers := subStat.subscribers.Load()
+			if subStat.evicted.Load() || subStat.lastReceiveDMLTime.Load() == 0 ||
+				subscribers == nil || len(subscribers.subscribers) == 0 {
+				continue
+			}
+			candidates = append(candidates, subStat)
+		}
+	}
+	e.dispatcherMeta.RUnlock()
+
+	sizes := make([]subscriptionSize, 0, len(candidates))
+	for _, subStat := range candidates {
+		size, err := e.dbs[subStat.dbIndex].EstimateDiskUsage(
+			EncodeKeyPrefix(uint64(subStat.subID), subStat.tableSpan.TableID, 0),
+			EncodeKeyPrefix(uint64(subStat.subID), subStat.tableSpan.TableID, math.MaxUint64))
+		if err != nil {
+			log.Warn("fail to estimate the disk usage of the subscription",
+				zap.Uint64("subscriptionID", uint64(subStat.subID)), zap.Error(err))
+			continue
+		}
+		sizes = append(sizes, subscriptionSize{subStat: subStat, size: size})
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		return sizes[i].size > sizes[j].size
+	})
+	if len(sizes) > n {
+		sizes = sizes[:n]
+	}
+	return sizes
+}
+
+func (e *eventStore) getChangefeedsOfSubStat(subStat *subscriptionStat) []string {
+	e.dispatcherMeta.RLock()
+	defer e.dispatcherMeta.RUnlock()
+	changefeeds := make(map[string]struct{})
+	if subscribers := subStat.subscribers.Load(); subscribers != nil {
+		for dispatcherID := range subscribers.subscribers {
+			if stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]; ok {
+				changefeeds[stat.changefeedID.String()] = struct{}{}
+			}
+		}
+	}
+	result := make([]string, 0, len(changefeeds))
+	for changefeed := range changefeeds {
+		result = append(result, changefeed)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// evictSubscription marks the dispatchers of the subscription as evicted, they stop receiving
+// the resolved ts from the subscription and will be removed by the event service.
+func (e *eventStore) evictSubscription(subStat *subscriptionStat) {
+	e.dispatcherMeta.Lock()
+	defer e.dispatcherMeta.Unlock()
+	subStat.evicted.Store(true)
+	subscribers := subStat.subscribers.Load()
+	if subscribers == nil {
+		return
+	}
+	for dispatcherID := range subscribers.subscribers {
+		stat, ok := e.dispatcherMeta.dispatcherStats[dispatcherID]
+		if !ok {
+			continue
+		}
+		e.dispatcherMeta.evictedDispatchers[dispatcherID] = subStat
+		e.stopReceiveEventFromSubStat(dispatcherID, stat.subStat)
+		e.stopReceiveEventFromSubStat(dispatcherID, stat.pendingSubStat)
+		e.stopReceiveEventFromSubStat(dispatcherID, stat.removingSubStat)
+		metrics.EventStoreDiskQuotaEvictedCount.WithLabelValues(
+			stat.changefeedID.Keyspace(), stat.changefeedID.Name()).Inc()
+		log.Warn("evict the dispatcher from event store because of the disk quota",
+			zap.Stringer("changefeedID", stat.changefeedID),
+			zap.Stringer("dispatcherID", dispatcherID),
+			zap.Uint64("subscriptionID", uint64(subStat.subID)),
+			zap.String("subSpan", common.FormatTableSpan(subStat.tableSpan)),
+			zap.Uint64("checkpointTs", subStat.checkpointTs.Load()),
+			zap.Uint64("resolvedTs", subStat.resolvedTs.Load()))
+	}
+}
+
+// cleanEvictedSubscriptions removes the evicted subscriptions which have no dispatchers.
+// It returns true if the disk usage is not relieved by the eviction yet, that is, some evicted
+// subscriptions still have dispatchers, or their data is just deleted in this round.
+func (e *eventStore) cleanEvictedSubscriptions() bool {
+	e.dispatcherMeta.Lock()
+	var (
+		removed []*subscriptionStat
+		pending bool
+	)
+	for tableID, subStats := range e.dispatcherMeta.tableStats {
+		for _, subStat := range subStats {
+			if !subStat.evicted.Load() {
+				continue
+			}
+			if subscribers := subStat.subscribers.Load(); subscribers != nil && len(subscribers.subscribers) > 0 {
+				pending = true
+				continue
+			}
+			log.Info("clean evicted subscription",
+				zap.Uint64("subscriptionID", uint64(subStat.subID)),
+				zap.Int("dbIndex", subStat.dbIndex),
+				zap.Int64("tableID", subStat.tableSpan.TableID))
+			e.removeSubscription(tableID, subStats, subStat)
+			removed = append(removed, subStat)
+		}
+	}
+	// the evicted dispatchers can be registered again after the data is dropped.
+	for dispatcherID, subStat := range e.dispatcherMeta.evictedDispatchers {
+		if slices.Contains(removed, subStat) {
+			delete(e.dispatcherMeta.evictedDispatchers, dispatcherID)
+		}
+	}
+	e.dispatcherMeta.Unlock()
+
+	// compact the deleted data to release the disk space as soon as possible.
+	for _, subStat := range removed {
+		db := e.dbs[subStat.dbIndex]
+		if err := compactDataRange(db, uint64(subStat.subID), subStat.tableSpan.TableID, 0, math.MaxUint64); err != nil {
+			log.Warn("fail to compact the data of the evicted subscription",
+				zap.Uint64("subscriptionID", uint64(subStat.subID)), zap.Error(err))
+		}
+	}
+	return pending || len(removed) > 0
+}
@@ -87,6 +87,10 @@ type EventStore interface {
 	// GetIterator return an iterator which scan the data in ts range (dataRange.CommitTsStart, dataRange.CommitTsEnd]
 	GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) EventIterator
 
+	// IsDispatcherEvicted returns true if the data of the dispatcher is evicted because of the disk quota
+	// and not dropped yet, the dispatcher should be unregistered and kept out until then.
+	IsDispatcherEvicted(dispatcherID common.DispatcherID) bool
+
 	GetLogCoordinatorNodeID() node.ID
 }
 
@@ -107,6 +111,7 @@ type EventIterator interface {
 
 type dispatcherStat struct {
 	dispatcherID common.DispatcherID
+	changefeedID common.ChangeFeedID
 	// data span of this dispatcher
 	tableSpan *heartbeatpb.TableSpan
 
@@ -173,6 +178,17 @@ type subscriptionStat struct {
 	resolvedTs atomic.Uint64
 	// the max commit ts of dml event in the store
 	maxEventCommitTs atomic.Uint64
+	// whether the subscription is recovered from the data of the previous server
+	// and has not subscribed the upstream yet
+	recovered atomic.Bool
+	// whether the subscription is evicted because of the disk quota, it can't be reused
+	evicted atomic.Bool
+	// whether the ingestion of the subscription is paused because of the disk quota
+	throttled atomic.Bool
+	// the events received when the subscription is throttled, their callbacks are not called
+	// until they are written, so the dynamic stream path of the subscription is blocked.
+	throttledMu     sync.Mutex
+	throttledEvents []eventWithCallback
 }
 
 type subscriptionStats map[logpuller.SubscriptionID]*subscriptionStat
@@ -195,6 +211,7 @@ func eventWithCallbackSizer(e eventWithCallback) int {
 }
 
 type eventStore struct {
+	dbPath    string
 	pdClock   pdutil.Clock
 	subClient logpuller.SubscriptionClient
 
@@ -204,6 +221,8 @@ type eventStore struct {
 
 	gcManager *gcManager
 
+	diskQuota *diskQuotaController
+
 	messageCenter messaging.MessageCenter
 
 	coordinatorInfo struct {
@@ -222,6 +241,8 @@ type eventStore struct {
 		dispatcherStats map[common.DispatcherID]*dispatcherStat
 		// table id -> subscription stats
 		tableStats map[int64]subscriptionStats
+		// dispatcher id -> the evicted subscription whose data is not dropped yet
+		evictedDispatchers map[common.DispatcherID]*subscriptionStat
 	}
 
 	decoderPool *sync.Pool
@@ -245,17 +266,32 @@ func New(
 ) EventStore {
 	dbPath := fmt.Sprintf("%s/%s", root, dataDir)
 
-	// FIXME: avoid remove
-	err := os.RemoveAll(dbPath)
+	// the data is reused only if the previous server closed the event store gracefully.
+	subscriptions := loadStoreMeta(dbPath)
+	if len(subscriptions) == 0 {
+		if err := os.RemoveAll(dbPath); err != nil {
+			log.Panic("fail to remove path", zap.String("path", dbPath), zap.Error(err))
+		}
+	}
+	dbs, err := createPebbleDBs(dbPath, dbCount)
+	if err != nil && len(subscriptions) > 0 {
+		log.Warn("fail to open the data of the previous server, discard it", zap.String("path", dbPath), zap.Error(err))
+		subscriptions = nil
+		if err = os.RemoveAll(dbPath); err != nil {
+			log.Panic("fail to remove path", zap.String("path", dbPath), zap.Error(err))
+		}
+		dbs, err = createPebbleDBs(dbPath, dbCount)
+	}
 	if err != nil {
-		log.Panic("fail to remove path", zap.String("path", dbPath), zap.Error(err))
+		log.Panic("fail to open pebble dbs", zap.String("path", dbPath), zap.Error(err))
 	}
 
 	store := &eventStore{
+		dbPath:    dbPath,
 		pdClock:   appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock),
 		subClient: subClient,
 
-		dbs:            createPebbleDBs(dbPath, dbCount),
+		dbs:            dbs,
 		chs:            make([]*chann.UnlimitedChannel[eventWithCallback, uint64], 0, dbCount),
 		writeTaskPools: make([]*writeTaskPool, 0, dbCount),
 
@@ -272,6 +308,7 @@ func New(
 			},
 		},
 		compressionThreshold: config.GetGlobalServerConfig().Debug.EventStore.CompressionThreshold,
+		diskQuota:            newDiskQuotaController(config.GetGlobalServerConfig().Debug.EventStore),
 	}
 	store.gcManager = newGCManager(store.dbs, deleteDataRange, compactDataRange)
 
@@ -282,6 +319,8 @@ func New(
 	}
 	store.dispatcherMeta.dispatcherStats = make(map[common.DispatcherID]*dispatcherStat)
 	store.dispatcherMeta.tableStats = make(map[int64]subscriptionStats)
+	store.dispatcherMeta.evictedDispatchers = make(map[common.DispatcherID]*subscriptionStat)
+	store.recoverSubscriptions(subscriptions)
 
 	store.messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
 	return store
@@ -394,6 +433,12 @@ func (e *eventStore) Run(ctx context.Context) error {
 		return e.uploadStatePeriodically(ctx)
 	})
 
+	if e.diskQuota.enabled() {
+		eg.Go(func() error {
+			return e.runDiskQuotaController(ctx)
+		})
+	}
+
 	return eg.Wait()
 }
 
@@ -401,14 +446,38 @@ func (e *eventStore) Close(_ context.Context) error {
 	log.Info("event store start to close")
 	defer log.Info("event store closed")
 
-	e.closed.Store(true)
+	if !e.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	// the resolved ts doesn't advance after the event store is closed,
+	// so the data of the subscriptions is complete up to the collected resolved ts.
+	subscriptions := e.collectSubscriptionMetas()
 
+	// wait the write workers to finish the pending events before closing the dbs.
+	for _, ch := range e.chs {
+		ch.Close()
+	}
+	e.wg.Wait()
+
+	// the WAL is disabled, so the data must be flushed to be reused after restart.
+	persisted := true
 	for _, db := range e.dbs {
+		if err := db.Flush(); err != nil {
+			log.Warn("failed to flush pebble db", zap.Error(err))
+			persisted = false
+		}
 		if err := db.Close(); err != nil {
 			log.Error("failed to close pebble db", zap.Error(err))
+			persisted = false
+		}
+	}
+	if persisted {
+		if err := writeStoreMeta(e.dbPath, subscriptions); err != nil {
+			log.Warn("failed to write the event store meta file", zap.Error(err))
+		} else {
+			log.Info("event store data persisted", zap.Int("subscriptionCount", len(subscriptions)))
 		}
 	}
-
 	return nil
 }
 
@@ -461,6 +530,7 @@ func (e *eventStore) RegisterDispatcher(
 
 	stat := &dispatcherStat{
 		dispatcherID: dispatcherID,
+		changefeedID: changefeedID,
 		tableSpan:    dispatcherSpan,
 		checkpointTs: startTs,
 	}
@@ -484,6 +554,11 @@ func (e *eventStore) RegisterDispatcher(
 					continue
 				}
 
+				// The evicted subStat will be removed soon
+				if subStat.evicted.Load() {
+					continue
+				}
+
 				// Check whether the subStat ts range contains startTs
 				if subStat.checkpointTs.Load() > startTs || startTs > subStat.resolvedTs.Load() {
 					continue
@@ -494,7 +569,11 @@ func (e *eventStore) RegisterDispatcher(
 					stat.subStat = subStat
 					e.dispatcherMeta.dispatcherStats[dispatcherID] = stat
 					e.addSubscriberToSubStat(subStat, dispatcherID, &Subscriber{notifyFunc: wrappedNotifier})
+					needSubscribe := subStat.recovered.CompareAndSwap(true, false)
 					e.dispatcherMeta.Unlock()
+					if needSubscribe {
+						e.subscribeRecovered(subStat, bdrMode)
+					}
 					log.Info("reuse existing subscription with exact span match",
 						zap.Stringer("dispatcherID", dispatcherID),
 						zap.String("dispatcherSpan", common.FormatTableSpan(dispatcherSpan)),
@@ -521,7 +600,14 @@ func (e *eventStore) RegisterDispatcher(
 		stat.subStat = bestMatch
 		e.dispatcherMeta.dispatcherStats[dispatcherID] = stat
 		e.addSubscriberToSubStat(bestMatch, dispatcherID, &Subscriber{notifyFunc: wrappedNotifier})
+		// a recovered subscription is only resubscribed when it keeps serving the dispatcher,
+		// otherwise the dispatcher just reads the recovered data until the new subscription
+		// with the exact span is ready, and the recovered one is cleaned after its ttl.
+		needSubscribe := onlyReuse && bestMatch.recovered.CompareAndSwap(true, false)
 		e.dispatcherMeta.Unlock()
+		if needSubscribe {
+			e.subscribeRecovered(bestMatch, bdrMode)
+		}
 		log.Info("reuse existing subscription with smallest containing span",
 			zap.Stringer("dispatcherID", dispatcherID),
 			zap.String("dispatcherSpan", common.FormatTableSpan(dispatcherSpan)),
@@ -573,7 +659,32 @@ func (e *eventStore) RegisterDispatcher(
 	e.dispatcherMeta.tableStats[dispatcherSpan.TableID][subStat.subID] = subStat
 	e.dispatcherMeta.Unlock()
 
+	e.subscribe(subStat, startTs, bdrMode)
+	log.Info("new subscription created",
+		zap.Stringer("dispatcherID", dispatcherID),
+		zap.Uint64("startTs", startTs),
+		zap.Uint64("subscriptionID", uint64(subStat.subID)),
+		zap.String("subSpan", common.FormatTableSpan(subStat.tableSpan)))
+	e.subscriptionChangeCh.In() <- SubscriptionChange{
+		ChangeType:   SubscriptionChangeTypeAdd,
+		SubID:        uint64(subStat.subID),
+		Span:         dispatcherSpan,
+		CheckpointTs: startTs,
+		ResolvedTs:   startTs,
+	}
+	metrics.EventStoreSubscriptionGauge.Inc()
+	return true
+}
+
+// subscribe subscribes the upstream for the subscription from startTs.
+// Note: don't hold any lock when call subscribe
+func (e *eventStore) subscribe(subStat *subscriptionStat, startTs uint64, bdrMode bool) {
+	start := time.Now()
 	consumeKVEvents := func(kvs []common.RawKVEntry, finishCallback func()) bool {
+		// the events are dropped after the event store is closed, and the resolved ts doesn't advance.
+		if e.closed.Load() {
+			return false
+		}
 		maxCommitTs := uint64(0)
 		// Must find the max commit ts in the kvs, since the kvs is not sorted yet.
 		for _, kv := range kvs {
@@ -583,16 +694,22 @@ func (e *eventStore) RegisterDispatcher(
 		}
 		subStat.lastReceiveDMLTime.Store(time.Now().UnixMilli())
 		util.CompareAndMonotonicIncrease(&subStat.maxEventCommitTs, maxCommitTs)
-		subStat.eventCh.Push(eventWithCallback{
+		event := eventWithCallback{
 			subID:             subStat.subID,
 			tableID:           subStat.tableSpan.TableID,
 			kvs:               kvs,
 			currentResolvedTs: subStat.resolvedTs.Load(),
 			callback:          finishCallback,
-		})
+		}
+		if !subStat.holdIfThrottled(event) {
+			subStat.eventCh.Push(event)
+		}
 		return true
 	}
 	advanceResolvedTs := func(ts uint64) {
+		if e.closed.Load() {
+			return
+		}
 		// filter out identical resolved ts
 		currentResolvedTs := subStat.resolvedTs.Load()
 		if ts <= currentResolvedTs {
@@ -619,22 +736,19 @@ func (e *eventStore) RegisterDispatcher(
 
 	serverConfig := config.GetGlobalServerConfig()
 	resolvedTsAdvanceInterval := int64(serverConfig.KVClient.AdvanceIntervalInMs)
-	// Note: don't hold any lock when call Subscribe
-	e.subClient.Subscribe(subStat.subID, *dispatcherSpan, startTs, consumeKVEvents, advanceResolvedTs, resolvedTsAdvanceInterval, bdrMode)
-	log.Info("new subscription created",
-		zap.Stringer("dispatcherID", dispatcherID),
-		zap.Uint64("startTs", startTs),
+	e.subClient.Subscribe(subStat.subID, *subStat.tableSpan, startTs, consumeKVEvents, advanceResolvedTs, resolvedTsAdvanceInterval, bdrMode)
+}
+
+// subscribeRecovered subscribes the upstream for the recovered subscription from its resolved ts,
+// the data before the resolved ts is reused.
+func (e *eventStore) subscribeRecovered(subStat *subscriptionStat, bdrMode bool) {
+	resolvedTs := subStat.resolvedTs.Load()
+	e.subscribe(subStat, resolvedTs, bdrMode)
+	log.Info("reuse the data of the previous server",
 		zap.Uint64("subscriptionID", uint64(subStat.subID)),
-		zap.String("subSpan", common.FormatTableSpan(subStat.tableSpan)))
-	e.subscriptionChangeCh.In() <- SubscriptionChange{
-		ChangeType:   SubscriptionChangeTypeAdd,
-		SubID:        uint64(subStat.subID),
-		Span:         dispatcherSpan,
-		CheckpointTs: startTs,
-		ResolvedTs:   startTs,
-	}
-	metrics.EventStoreSubscriptionGauge.Inc()
-	return true
+		zap.String("subSpan", common.FormatTableSpan(subStat.tableSpan)),
+		zap.Uint64("checkpointTs", subStat.checkpointTs.Load()),
+		zap.Uint64("resolvedTs", resolvedTs))
 }
 
 func (e *eventStore) UnregisterDispatcher(changefeedID common.ChangeFeedID, dispatcherID common.DispatcherID) {
@@ -887,6 +1001,13 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 	}
 }
 
+func (e *eventStore) IsDispatcherEvicted(dispatcherID common.DispatcherID) bool {
+	e.dispatcherMeta.RLock()
+	defer e.dispatcherMeta.RUnlock()
+	_, ok := e.dispatcherMeta.evictedDispatchers[dispatcherID]
+	return ok
+}
+
 func (e *eventStore) GetLogCoordinatorNodeID() node.ID {
 	return e.getCoordinatorInfo()
 }
@@ -985,27 +1106,18 @@ func (e *eventStore) cleanObsoleteSubscriptions(ctx context.Context) error {
 			e.dispatcherMeta.Lock()
 			for tableID, subStats := range e.dispatcherMeta.tableStats {
 				for subID, subStat := range subStats {
+					ttl := ttlInMsForMarkDeletion
+					if subStat.recovered.Load() {
+						ttl = recoveredSubscriptionTTL.Milliseconds()
+					}
 					subData := subStat.subscribers.Load()
-					if subData != nil && len(subData.subscribers) == 0 && subData.idleTime > 0 && now-subData.idleTime > ttlInMsForMarkDeletion {
+					if subData != nil && len(subData.subscribers) == 0 && subData.idleTime > 0 && now-subData.idleTime > ttl {
 						log.Info("clean obsolete subscription",
 							zap.Uint64("subscriptionID", uint64(subID)),
 							zap.Int("dbIndex", subStat.dbIndex),
-							zap.Int64("tableID", subStat.tableSpan.TableID))
-						e.subClient.Unsubscribe(subID)
-						db := e.dbs[subStat.dbIndex]
-						if err := deleteDataRange(db, uint64(subID), subStat.tableSpan.TableID, 0, math.MaxUint64); err != nil {
-							log.Warn("fail to delete events", zap.Error(err))
-						}
-						delete(subStats, subID)
-						e.subscriptionChangeCh.In() <- SubscriptionChange{
-							ChangeType: SubscriptionChangeTypeRemove,
-							SubID:      uint64(subStat.subID),
-							Span:       subStat.tableSpan,
-						}
-						metrics.EventStoreSubscriptionGauge.Dec()
-						if len(subStats) == 0 {
-							delete(e.dispatcherMeta.tableStats, tableID)
-						}
+							zap.Int64("tableID", subStat.tableSpan.TableID),
+							zap.Bool("recovered", subStat.recovered.Load()))
+						e.removeSubscription(tableID, subStats, subStat)
 					}
 				}
 			}
@@ -1014,6 +1126,29 @@ func (e *eventStore) cleanObsoleteSubscriptions(ctx context.Context) error {
 	}
 }
 
+// removeSubscription unsubscribes the upstream and deletes the data of the subscription,
+// it must be called with the dispatcherMeta lock held.
+func (e *eventStore) removeSubscription(tableID int64, subStats subscriptionStats, subStat *subscriptionStat) {
+	if !subStat.recovered.Load() {
+		e.subClient.Unsubscribe(subStat.subID)
+	}
+	subStat.dropThrottledEvents()
+	db := e.dbs[subStat.dbIndex]
+	if err := deleteDataRange(db, uint64(subStat.subID), subStat.tableSpan.TableID, 0, math.MaxUint64); err != nil {
+		log.Warn("fail to delete events", zap.Error(err))
+	}
+	delete(subStats, subStat.subID)
+	e.subscriptionChangeCh.In() <- SubscriptionChange{
+		ChangeType: SubscriptionChangeTypeRemove,
+		SubID:      uint64(subStat.subID),
+		Span:       subStat.tableSpan,
+	}
+	metrics.EventStoreSubscriptionGauge.Dec()
+	if len(subStats) == 0 {
+		delete(e.dispatcherMeta.tableStats, tableID)
+	}
+}
+
 func (e *eventStore) runMetricsCollector(ctx context.Context) error {
 	storeMetricsTicker := time.NewTicker(10 * time.Second)
 	for {
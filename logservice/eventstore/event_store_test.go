@@ -16,6 +16,7 @@ package eventstore
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"fmt"
 	"os"
 	"sync"
@@ -28,14 +29,16 @@ import (
 	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/stretchr/testify/require"
 )
 
 type mockSubscriptionStat struct {
-	span    heartbeatpb.TableSpan
-	startTs uint64
+	span            heartbeatpb.TableSpan
+	startTs         uint64
+	consumeKVEvents func(raw []common.RawKVEntry, wakeCallback func()) bool
 }
 
 type mockSubscriptionClient struct {
@@ -67,6 +70,15 @@ func (s *mockSubscriptionClient) AllocSubscriptionID() logpuller.SubscriptionID
 	return logpuller.SubscriptionID(nextID)
 }
 
+func (s *mockSubscriptionClient) ReserveSubscriptionID(subID logpuller.SubscriptionID) {
+	for {
+		current := s.nextID.Load()
+		if current >= uint64(subID) || s.nextID.CompareAndSwap(current, uint64(subID)) {
+			return
+		}
+	}
+}
+
 func (s *mockSubscriptionClient) Subscribe(
 	subID logpuller.SubscriptionID,
 	span heartbeatpb.TableSpan,
@@ -79,8 +91,9 @@ func (s *mockSubscriptionClient) Subscribe(
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.subscriptions[subID] = &mockSubscriptionStat{
-		span:    span,
-		startTs: startTs,
+		span:            span,
+		startTs:         startTs,
+		consumeKVEvents: consumeKVEvents,
 	}
 }
 
@@ -922,3 +935,253 @@ func TestEventStoreIter_NextWithFiltering(t *testing.T) {
 		require.NoError(t, os.RemoveAll(dir))
 	}
 }
+
+func countKeysForTest(t *testing.T, store *eventStore) int {
+	count := 0
+	for _, db := range store.dbs {
+		iter, err := db.NewIter(&pebble.IterOptions{})
+		require.NoError(t, err)
+		for iter.First(); iter.Valid(); iter.Next() {
+			count++
+		}
+		require.NoError(t, iter.Close())
+	}
+	return count
+}
+
+func TestEventStoreReuseDataAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	_, store := newEventStoreForTest(dir)
+	es := store.(*eventStore)
+	cfID := common.NewChangefeedID4Test("default", "test-cf")
+	notifier := func(watermark, latestCommitTs uint64) {}
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer encoder.Close()
+
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("a"), EndKey: []byte("z")}
+	require.True(t, store.RegisterDispatcher(cfID, common.NewDispatcherID(), span, 100, notifier, false, false))
+	// an uninitialized subscription, its data is discarded after restart.
+	span2 := &heartbeatpb.TableSpan{TableID: 2, StartKey: []byte("a"), EndKey: []byte("z")}
+	require.True(t, store.RegisterDispatcher(cfID, common.NewDispatcherID(), span2, 100, notifier, false, false))
+
+	var subStat *subscriptionStat
+	for _, stat := range es.dispatcherMeta.tableStats[1] {
+		subStat = stat
+	}
+	var events []eventWithCallback
+	for i := 0; i < 10; i++ {
+		events = append(events, eventWithCallback{
+			subID:   subStat.subID,
+			tableID: 1,
+			kvs: []common.RawKVEntry{{
+				OpType:  common.OpTypePut,
+				StartTs: 100 + uint64(i*10),
+				CRTs:    105 + uint64(i*10),
+				Key:     []byte(fmt.Sprintf("key-%d", i)),
+				Value:   []byte("value"),
+			}},
+			callback: func() {},
+		})
+	}
+	require.NoError(t, es.writeEvents(es.dbs[subStat.dbIndex], events, encoder))
+	subStat.resolvedTs.Store(200)
+	subStat.initialized.Store(true)
+	for _, stat := range es.dispatcherMeta.tableStats[2] {
+		events[0].subID = stat.subID
+		events[0].tableID = 2
+		require.NoError(t, es.writeEvents(es.dbs[stat.dbIndex], events[:1], encoder))
+	}
+	require.Equal(t, 11, countKeysForTest(t, es))
+	require.NoError(t, store.Close(ctx))
+
+	// restart the event store, the subscription of table 1 is recovered.
+	subClient, store := newEventStoreForTest(dir)
+	es = store.(*eventStore)
+	require.Equal(t, 10, countKeysForTest(t, es))
+	require.Empty(t, es.dispatcherMeta.tableStats[2])
+	require.Len(t, es.dispatcherMeta.tableStats[1], 1)
+	recovered := es.dispatcherMeta.tableStats[1][subStat.subID]
+	require.NotNil(t, recovered)
+	require.True(t, recovered.recovered.Load())
+	require.Equal(t, uint64(100), recovered.checkpointTs.Load())
+	require.Equal(t, uint64(200), recovered.resolvedTs.Load())
+	require.Greater(t, subClient.AllocSubscriptionID(), subStat.subID)
+
+	// the start ts is not covered by the recovered data.
+	require.False(t, store.RegisterDispatcher(cfID, common.NewDispatcherID(), span, 300, notifier, true, false))
+	// the recovered subscription which only contains the span is not resubscribed,
+	// a new subscription with the exact span is created for the dispatcher.
+	subSpan := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("b"), EndKey: []byte("c")}
+	require.True(t, store.RegisterDispatcher(cfID, common.NewDispatcherID(), subSpan, 150, notifier, false, false))
+	require.True(t, recovered.recovered.Load())
+	mockSubClient := subClient.(*mockSubscriptionClient)
+	mockSubClient.mu.Lock()
+	require.Len(t, mockSubClient.subscriptions, 1)
+	require.NotContains(t, mockSubClient.subscriptions, subStat.subID)
+	mockSubClient.mu.Unlock()
+	// attach to the recovered subscription and subscribe from the stored resolved ts.
+	dispatcherID := common.NewDispatcherID()
+	require.True(t, store.RegisterDispatcher(cfID, dispatcherID, span, 150, notifier, false, false))
+	require.False(t, recovered.recovered.Load())
+	mockSubClient.mu.Lock()
+	require.Len(t, mockSubClient.subscriptions, 2)
+	require.Equal(t, uint64(200), mockSubClient.subscriptions[subStat.subID].startTs)
+	mockSubClient.mu.Unlock()
+
+	iter := store.GetIterator(dispatcherID, common.DataRange{Span: span, CommitTsStart: 150, CommitTsEnd: 200})
+	require.NotNil(t, iter)
+	rowCount := 0
+	for {
+		if _, ok := iter.Next(); !ok {
+			break
+		}
+		rowCount++
+	}
+	_, err = iter.Close()
+	require.NoError(t, err)
+	require.Equal(t, 5, rowCount)
+	require.NoError(t, store.Close(ctx))
+
+	// the data is discarded if the meta file is corrupted.
+	metaPath := fmt.Sprintf("%s/%s/%s", dir, dataDir, metaFileName)
+	data, err := os.ReadFile(metaPath)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(metaPath, data, 0o600))
+	_, store = newEventStoreForTest(dir)
+	defer store.Close(ctx)
+	es = store.(*eventStore)
+	require.Empty(t, es.dispatcherMeta.tableStats)
+	require.Equal(t, 0, countKeysForTest(t, es))
+}
+
+func TestEventStoreDiskQuota(t *testing.T) {
+	ctx := context.Background()
+	subClient, store := newEventStoreForTest(t.TempDir())
+	defer store.Close(ctx)
+	es := store.(*eventStore)
+	es.diskQuota = newDiskQuotaController(&config.EventStoreConfig{
+		DiskQuota:              100,
+		DiskQuotaSoftWatermark: 0.5,
+		DiskQuotaHardWatermark: 0.9,
+	})
+	cfID := common.NewChangefeedID4Test("default", "test-cf")
+	notifier := func(watermark, latestCommitTs uint64) {}
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer encoder.Close()
+
+	// the dispatcher of table i has checkpoint ts i*100, but table i retains i*100 rows,
+	// so table 4 retains the most data though table 1 is the slowest.
+	dispatcherIDs := make(map[int64]common.DispatcherID)
+	spans := make(map[int64]*heartbeatpb.TableSpan)
+	for tableID := int64(1); tableID <= 4; tableID++ {
+		dispatcherIDs[tableID] = common.NewDispatcherID()
+		spans[tableID] = &heartbeatpb.TableSpan{TableID: tableID, StartKey: []byte("a"), EndKey: []byte("z")}
+		require.True(t, store.RegisterDispatcher(cfID, dispatcherIDs[tableID], spans[tableID],
+			uint64(tableID*100), notifier, false, false))
+	}
+	getSubStat := func(tableID int64) *subscriptionStat {
+		for _, subStat := range es.dispatcherMeta.tableStats[tableID] {
+			return subStat
+		}
+		return nil
+	}
+	for tableID := int64(1); tableID <= 4; tableID++ {
+		subStat := getSubStat(tableID)
+		subStat.lastReceiveDMLTime.Store(1)
+		var events []eventWithCallback
+		for i := 0; i < int(tableID)*100; i++ {
+			value := make([]byte, 1024)
+			_, _ = rand.Read(value)
+			events = append(events, eventWithCallback{
+				subID:   subStat.subID,
+				tableID: tableID,
+				kvs: []common.RawKVEntry{{
+					OpType:  common.OpTypePut,
+					StartTs: 500,
+					CRTs:    505,
+					Key:     []byte(fmt.Sprintf("key-%d", i)),
+					Value:   value,
+				}},
+				callback: func() {},
+			})
+		}
+		require.NoError(t, es.writeEvents(es.dbs[subStat.dbIndex], events, encoder))
+	}
+	for _, db := range es.dbs {
+		require.NoError(t, db.Flush())
+	}
+	consume := func(tableID int64) *atomic.Bool {
+		subStat := getSubStat(tableID)
+		called := &atomic.Bool{}
+		subClient.(*mockSubscriptionClient).subscriptions[subStat.subID].consumeKVEvents(
+			[]common.RawKVEntry{{OpType: common.OpTypePut, CRTs: 1000}}, func() { called.Store(true) })
+		return called
+	}
+
+	// nothing is throttled or evicted under the soft watermark.
+	es.checkDiskQuota(60)
+	for tableID := int64(1); tableID <= 4; tableID++ {
+		require.False(t, getSubStat(tableID).throttled.Load())
+		require.False(t, store.IsDispatcherEvicted(dispatcherIDs[tableID]))
+	}
+
+	// the largest subscriptions are throttled and the largest one is evicted under the hard watermark.
+	es.checkDiskQuota(95)
+	for tableID := int64(2); tableID <= 4; tableID++ {
+		require.True(t, getSubStat(tableID).throttled.Load())
+	}
+	require.False(t, getSubStat(1).throttled.Load())
+	require.True(t, store.IsDispatcherEvicted(dispatcherIDs[4]))
+	for tableID := int64(1); tableID <= 3; tableID++ {
+		require.False(t, store.IsDispatcherEvicted(dispatcherIDs[tableID]))
+	}
+	// the evicted subscription can't be reused.
+	require.False(t, store.RegisterDispatcher(cfID, common.NewDispatcherID(), spans[4], 400, notifier, true, false))
+
+	// the events of the throttled subscription are held, the others are not affected.
+	require.Len(t, getSubStat(2).throttledEvents, 0)
+	require.False(t, consume(2).Load())
+	require.Len(t, getSubStat(2).throttledEvents, 1)
+	consume(1)
+	require.Len(t, getSubStat(1).throttledEvents, 0)
+
+	// no more subscription is evicted until the data of the evicted one is deleted.
+	es.checkDiskQuota(95)
+	require.False(t, store.IsDispatcherEvicted(dispatcherIDs[3]))
+
+	// the evicted dispatcher is kept out until the data of the subscription is dropped.
+	evictedSubStat := getSubStat(4)
+	evictedCallbackCalled := consume(4)
+	store.UnregisterDispatcher(cfID, dispatcherIDs[4])
+	require.True(t, store.IsDispatcherEvicted(dispatcherIDs[4]))
+
+	// the evicted subscription is removed after its dispatchers are removed, and the next
+	// subscription is not evicted before the disk usage is checked again.
+	es.checkDiskQuota(95)
+	require.False(t, store.IsDispatcherEvicted(dispatcherIDs[4]))
+	require.Empty(t, es.dispatcherMeta.tableStats[4])
+	require.False(t, store.IsDispatcherEvicted(dispatcherIDs[3]))
+	// the held events of the evicted subscription are dropped.
+	require.False(t, evictedSubStat.throttled.Load())
+	require.Len(t, evictedSubStat.throttledEvents, 0)
+	require.True(t, evictedCallbackCalled.Load())
+
+	// the next largest subscription is evicted if the disk pressure is not relieved.
+	es.checkDiskQuota(95)
+	require.True(t, store.IsDispatcherEvicted(dispatcherIDs[3]))
+	require.False(t, store.IsDispatcherEvicted(dispatcherIDs[2]))
+	store.UnregisterDispatcher(cfID, dispatcherIDs[3])
+
+	// the throttled subscriptions are resumed after the pressure is relieved.
+	es.checkDiskQuota(10)
+	for tableID := int64(1); tableID <= 2; tableID++ {
+		require.False(t, getSubStat(tableID).throttled.Load())
+		require.Len(t, es.dispatcherMeta.tableStats[tableID], 1)
+	}
+	require.Len(t, getSubStat(2).throttledEvents, 0)
+	require.Empty(t, es.dispatcherMeta.tableStats[3])
+}
@@ -31,6 +31,19 @@ const (
 	DMLOrderInsert
 )
 
+func (o DMLOrder) String() string {
+	switch o {
+	case DMLOrderDelete:
+		return "delete"
+	case DMLOrderUpdate:
+		return "update"
+	case DMLOrderInsert:
+		return "insert"
+	default:
+		return "unknown"
+	}
+}
+
 type CompressionType uint16
 
 const (
@@ -38,6 +51,17 @@ const (
 	CompressionZSTD
 )
 
+func (t CompressionType) String() string {
+	switch t {
+	case CompressionNone:
+		return "none"
+	case CompressionZSTD:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
 const (
 	// Bitmask for DML order and compression type.
 	dmlOrderMask    = 0xFF00 // DML order is stored in the high 8 bits for sorting.
@@ -113,6 +137,12 @@ func DecodeKeyMetas(key []byte) (DMLOrder, CompressionType) {
 	return DMLOrder((combinedOrder & dmlOrderMask) >> dmlOrderShift), CompressionType(combinedOrder & compressionMask)
 }
 
+// decodeKey decodes uniqueID, tableID, CRTs and startTs from the key.
+func decodeKey(key []byte) (uint64, int64, uint64, uint64) {
+	return binary.BigEndian.Uint64(key[0:8]), int64(binary.BigEndian.Uint64(key[8:16])),
+		binary.BigEndian.Uint64(key[16:24]), binary.BigEndian.Uint64(key[24:32])
+}
+
 // getDMLOrder returns the order of the dml types: delete<update<insert
 func getDMLOrder(rowKV *common.RawKVEntry) DMLOrder {
 	if rowKV.OpType == common.OpTypeDelete {
@@ -0,0 +1,231 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+)
+
+// the length of uniqueID, tableID, CRTs, startTs and the combined order in the key.
+const keyMetaLength = 8 + 8 + 8 + 8 + 2
+
+// Inspector reads the event store data on disk offline, it's used to debug the data loss issues.
+// The data must not be opened by a running server at the same time.
+type Inspector struct {
+	dbs     []*pebble.DB
+	meta    *storeMeta
+	decoder *zstd.Decoder
+}
+
+// SubscriptionInfo describes the data of a subscription on disk.
+type SubscriptionInfo struct {
+	DBIndex        int
+	SubscriptionID uint64
+	TableID        int64
+	// the range of the commit ts of the events on disk, they are zero if there is no event.
+	MinCommitTs uint64
+	MaxCommitTs uint64
+	// the following fields are only set if the subscription is recorded in the meta file,
+	// which is written when the event store is closed gracefully.
+	InMeta       bool
+	StartKey     []byte
+	EndKey       []byte
+	CheckpointTs uint64
+	ResolvedTs   uint64
+}
+
+// Event is an event stored in the event store.
+type Event struct {
+	SubscriptionID uint64
+	TableID        int64
+	DMLOrder       DMLOrder
+	Compression    CompressionType
+	RawKV          *common.RawKVEntry
+}
+
+// NewInspector opens the event store data under the data dir of the server in read-only mode.
+func NewInspector(root string) (*Inspector, error) {
+	dbPath := fmt.Sprintf("%s/%s", root, dataDir)
+	inspector := &Inspector{}
+	for i := 0; i < dbCount; i++ {
+		db, err := pebble.Open(fmt.Sprintf("%s/%04d", dbPath, i), &pebble.Options{
+			ReadOnly:         true,
+			ErrorIfNotExists: true,
+		})
+		if err != nil {
+			_ = inspector.Close()
+			return nil, errors.Trace(err)
+		}
+		inspector.dbs = append(inspector.dbs, db)
+	}
+
+	// the meta file is not removed, so the server can still reuse the data.
+	data, err := os.ReadFile(filepath.Join(dbPath, metaFileName))
+	if err == nil {
+		inspector.meta, err = decodeStoreMeta(data)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		_ = inspector.Close()
+		return nil, errors.Trace(err)
+	}
+
+	inspector.decoder, err = zstd.NewReader(nil)
+	if err != nil {
+		_ = inspector.Close()
+		return nil, errors.Trace(err)
+	}
+	return inspector, nil
+}
+
+// Close closes the underlying dbs.
+func (i *Inspector) Close() error {
+	if i.decoder != nil {
+		i.decoder.Close()
+	}
+	var err error
+	for _, db := range i.dbs {
+		if closeErr := db.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return errors.Trace(err)
+}
+
+// ListSubscriptions returns the subscriptions which have data on disk or are recorded in the meta file,
+// sorted by the table id and the subscription id.
+func (i *Inspector) ListSubscriptions() ([]SubscriptionInfo, error) {
+	type subKey struct {
+		dbIndex int
+		subID   uint64
+		tableID int64
+	}
+	subscriptions := make(map[subKey]*SubscriptionInfo)
+	for dbIndex, db := range i.dbs {
+		iter, err := db.NewIter(nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// skip to the next subscription after reading the first and the last key of a subscription.
+		for valid := iter.First(); valid; {
+			if len(iter.Key()) < keyMetaLength {
+				_ = iter.Close()
+				return nil, errors.Errorf("invalid key %x in db %d", iter.Key(), dbIndex)
+			}
+			subID, tableID, minCommitTs, _ := decodeKey(iter.Key())
+			next := EncodeKeyPrefix(subID, tableID+1, 0)
+			if uint64(tableID) == math.MaxUint64 {
+				next = EncodeKeyPrefix(subID+1, 0, 0)
+			}
+			iter.SeekLT(next)
+			_, _, maxCommitTs, _ := decodeKey(iter.Key())
+			subscriptions[subKey{dbIndex, subID, tableID}] = &SubscriptionInfo{
+				DBIndex:        dbIndex,
+				SubscriptionID: subID,
+				TableID:        tableID,
+				MinCommitTs:    minCommitTs,
+				MaxCommitTs:    maxCommitTs,
+			}
+			if subID == math.MaxUint64 && uint64(tableID) == math.MaxUint64 {
+				break
+			}
+			valid = iter.SeekGE(next)
+		}
+		if err = iter.Close(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if i.meta != nil {
+		for _, sub := range i.meta.Subscriptions {
+			key := subKey{sub.DBIndex, sub.UniqueID, sub.TableID}
+			info, ok := subscriptions[key]
+			if !ok {
+				info = &SubscriptionInfo{
+					DBIndex:        sub.DBIndex,
+					SubscriptionID: sub.UniqueID,
+					TableID:        sub.TableID,
+				}
+				subscriptions[key] = info
+			}
+			info.InMeta = true
+			info.StartKey = sub.StartKey
+			info.EndKey = sub.EndKey
+			info.CheckpointTs = sub.CheckpointTs
+			info.ResolvedTs = sub.ResolvedTs
+		}
+	}
+
+	result := make([]SubscriptionInfo, 0, len(subscriptions))
+	for _, info := range subscriptions {
+		result = append(result, *info)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].TableID != result[b].TableID {
+			return result[a].TableID < result[b].TableID
+		}
+		return result[a].SubscriptionID < result[b].SubscriptionID
+	})
+	return result, nil
+}
+
+// IterateEvents calls fn for each event of the subscription whose commit ts is in range [startTs, endTs).
+func (i *Inspector) IterateEvents(sub SubscriptionInfo, startTs, endTs uint64, fn func(event *Event) error) error {
+	if sub.DBIndex < 0 || sub.DBIndex >= len(i.dbs) {
+		return errors.Errorf("invalid db index %d", sub.DBIndex)
+	}
+	iter, err := i.dbs[sub.DBIndex].NewIter(&pebble.IterOptions{
+		LowerBound: EncodeKeyPrefix(sub.SubscriptionID, sub.TableID, startTs),
+		UpperBound: EncodeKeyPrefix(sub.SubscriptionID, sub.TableID, endTs),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer iter.Close()
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) < keyMetaLength {
+			return errors.Errorf("invalid key %x in db %d", key, sub.DBIndex)
+		}
+		dmlOrder, compressionType := DecodeKeyMetas(key)
+		value := iter.Value()
+		if compressionType == CompressionZSTD {
+			if value, err = i.decoder.DecodeAll(value, nil); err != nil {
+				return errors.Annotatef(err, "fail to decompress the value of key %x", key)
+			}
+		}
+		rawKV := &common.RawKVEntry{}
+		if err = rawKV.Decode(value); err != nil {
+			return errors.Annotatef(err, "fail to decode the value of key %x", key)
+		}
+		err = fn(&Event{
+			SubscriptionID: sub.SubscriptionID,
+			TableID:        sub.TableID,
+			DMLOrder:       dmlOrder,
+			Compression:    compressionType,
+			RawKV:          rawKV,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return errors.Trace(iter.Error())
+}
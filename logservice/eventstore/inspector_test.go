@@ -0,0 +1,111 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := fmt.Sprintf("%s/%s", dir, dataDir)
+	dbs, err := createPebbleDBs(dbPath, dbCount)
+	require.NoError(t, err)
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer encoder.Close()
+
+	write := func(db *pebble.DB, subID uint64, tableID int64, kv *common.RawKVEntry, compressionType CompressionType) {
+		value := kv.Encode()
+		if compressionType == CompressionZSTD {
+			value = encoder.EncodeAll(value, nil)
+		}
+		require.NoError(t, db.Set(EncodeKey(subID, tableID, kv, compressionType), value, pebble.NoSync))
+	}
+	for i := 0; i < 5; i++ {
+		write(dbs[0], 1, 100, &common.RawKVEntry{
+			OpType:  common.OpTypePut,
+			StartTs: 100 + uint64(i*10),
+			CRTs:    105 + uint64(i*10),
+			Key:     []byte(fmt.Sprintf("key-%d", i)),
+			Value:   []byte("value"),
+		}, CompressionType(i%2))
+	}
+	write(dbs[0], 1, 101, &common.RawKVEntry{
+		OpType: common.OpTypeDelete, StartTs: 200, CRTs: 205, Key: []byte("key"), OldValue: []byte("value"),
+	}, CompressionNone)
+	write(dbs[1], 2, 100, &common.RawKVEntry{
+		OpType: common.OpTypePut, StartTs: 300, CRTs: 305, Key: []byte("key"), Value: []byte("value"), OldValue: []byte("old"),
+	}, CompressionNone)
+	for _, db := range dbs {
+		require.NoError(t, db.Flush())
+		require.NoError(t, db.Close())
+	}
+	require.NoError(t, writeStoreMeta(dbPath, []subscriptionMeta{
+		{UniqueID: 2, DBIndex: 1, TableID: 100, StartKey: []byte("a"), EndKey: []byte("z"), CheckpointTs: 300, ResolvedTs: 400},
+		{UniqueID: 3, DBIndex: 2, TableID: 102, StartKey: []byte("a"), EndKey: []byte("z"), CheckpointTs: 500, ResolvedTs: 600},
+	}))
+
+	inspector, err := NewInspector(dir)
+	require.NoError(t, err)
+	defer inspector.Close()
+	// the meta file is kept for the server.
+	_, err = os.Stat(fmt.Sprintf("%s/%s", dbPath, metaFileName))
+	require.NoError(t, err)
+
+	subscriptions, err := inspector.ListSubscriptions()
+	require.NoError(t, err)
+	require.Equal(t, []SubscriptionInfo{
+		{DBIndex: 0, SubscriptionID: 1, TableID: 100, MinCommitTs: 105, MaxCommitTs: 145},
+		{
+			DBIndex: 1, SubscriptionID: 2, TableID: 100, MinCommitTs: 305, MaxCommitTs: 305,
+			InMeta: true, StartKey: []byte("a"), EndKey: []byte("z"), CheckpointTs: 300, ResolvedTs: 400,
+		},
+		{DBIndex: 0, SubscriptionID: 1, TableID: 101, MinCommitTs: 205, MaxCommitTs: 205},
+		{
+			DBIndex: 2, SubscriptionID: 3, TableID: 102,
+			InMeta: true, StartKey: []byte("a"), EndKey: []byte("z"), CheckpointTs: 500, ResolvedTs: 600,
+		},
+	}, subscriptions)
+
+	var events []*Event
+	collect := func(e *Event) error {
+		events = append(events, e)
+		return nil
+	}
+	require.NoError(t, inspector.IterateEvents(subscriptions[0], 115, 135, collect))
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(115), events[0].RawKV.CRTs)
+	require.Equal(t, CompressionZSTD, events[0].Compression)
+	require.Equal(t, []byte("key-1"), events[0].RawKV.Key)
+	require.Equal(t, []byte("value"), events[0].RawKV.Value)
+	require.Equal(t, CompressionNone, events[1].Compression)
+
+	events = nil
+	require.NoError(t, inspector.IterateEvents(subscriptions[1], 0, math.MaxUint64, collect))
+	require.Len(t, events, 1)
+	require.Equal(t, DMLOrderUpdate, events[0].DMLOrder)
+	events = nil
+	require.NoError(t, inspector.IterateEvents(subscriptions[2], 0, math.MaxUint64, collect))
+	require.Len(t, events, 1)
+	require.Equal(t, DMLOrderDelete, events[0].DMLOrder)
+}
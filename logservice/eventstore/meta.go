@@ -0,0 +1,261 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// The data of the event store is reused across server restarts as follows:
+//  1. when the event store is closed gracefully, all the pebble dbs are flushed, and the metadata of
+//     the subscriptions is written to the meta file, the data of a subscription in range
+//     (CheckpointTs, ResolvedTs] is complete because the resolved ts is advanced after the data is written.
+//  2. when the event store is created, the meta file is loaded and removed immediately, so the data is
+//     discarded if the server exits unexpectedly later. The data which is not recorded in the meta file
+//     is deleted, and the subscriptions in the meta file are recovered without subscribing the upstream.
+//  3. a dispatcher whose start ts is in (CheckpointTs, ResolvedTs] of a recovered subscription attaches
+//     to it, and the subscription resubscribes the upstream from the stored resolved ts if it serves the
+//     dispatcher, that is its span equals the dispatcher span or the dispatcher only reuses subscriptions.
+//     Otherwise the dispatcher reads the recovered data until its new subscription is ready.
+//  4. the recovered subscriptions which are not used by any dispatcher are cleaned after a while.
+
+const (
+	metaFileName = "meta"
+	// metaVersion must be increased when the format of the data in the event store is changed,
+	// so that the data written by an incompatible version is discarded.
+	metaVersion = 1
+	// recoveredSubscriptionTTL is the time to keep the recovered subscriptions which are not used,
+	// it's longer than the normal ttl because the dispatchers are scheduled slowly after restart.
+	recoveredSubscriptionTTL = 10 * time.Minute
+)
+
+// subscriptionMeta is the metadata of a subscription persisted in the meta file.
+type subscriptionMeta struct {
+	UniqueID         uint64 `json:"unique-id"`
+	DBIndex          int    `json:"db-index"`
+	TableID          int64  `json:"table-id"`
+	StartKey         []byte `json:"start-key"`
+	EndKey           []byte `json:"end-key"`
+	CheckpointTs     uint64 `json:"checkpoint-ts"`
+	ResolvedTs       uint64 `json:"resolved-ts"`
+	MaxEventCommitTs uint64 `json:"max-event-commit-ts"`
+}
+
+type storeMeta struct {
+	Version       int                `json:"version"`
+	DBCount       int                `json:"db-count"`
+	Subscriptions []subscriptionMeta `json:"subscriptions"`
+}
+
+// writeStoreMeta writes the meta file atomically, the file is the crc32 checksum
+// of the content followed by the json encoded content.
+func writeStoreMeta(dbPath string, subscriptions []subscriptionMeta) error {
+	content, err := json.Marshal(&storeMeta{
+		Version:       metaVersion,
+		DBCount:       dbCount,
+		Subscriptions: subscriptions,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data := binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(content))
+	data = append(data, content...)
+
+	path := filepath.Join(dbPath, metaFileName)
+	tmpPath := path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(tmpPath, path))
+}
+
+// loadStoreMeta loads and removes the meta file, it returns nil if the meta file
+// doesn't exist or is invalid, which means all the data should be discarded.
+func loadStoreMeta(dbPath string) []subscriptionMeta {
+	path := filepath.Join(dbPath, metaFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("fail to read the event store meta file", zap.String("path", path), zap.Error(err))
+		}
+		return nil
+	}
+	// the data is only valid until the event store is closed gracefully again.
+	if err = os.Remove(path); err != nil {
+		log.Warn("fail to remove the event store meta file", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+	meta, err := decodeStoreMeta(data)
+	if err != nil {
+		log.Warn("fail to decode the event store meta file", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+	if meta.Version != metaVersion || meta.DBCount != dbCount {
+		log.Info("the event store data is incompatible, discard it",
+			zap.Int("version", meta.Version), zap.Int("dbCount", meta.DBCount))
+		return nil
+	}
+
+	subscriptions := make([]subscriptionMeta, 0, len(meta.Subscriptions))
+	uniqueIDs := make(map[uint64]struct{}, len(meta.Subscriptions))
+	for _, sub := range meta.Subscriptions {
+		_, duplicated := uniqueIDs[sub.UniqueID]
+		if duplicated || sub.UniqueID == 0 || sub.UniqueID == math.MaxUint64 ||
+			sub.DBIndex < 0 || sub.DBIndex >= dbCount ||
+			bytes.Compare(sub.StartKey, sub.EndKey) >= 0 ||
+			sub.ResolvedTs == 0 || sub.CheckpointTs > sub.ResolvedTs {
+			log.Warn("invalid subscription in the event store meta file, discard it", zap.Any("subscription", sub))
+			continue
+		}
+		uniqueIDs[sub.UniqueID] = struct{}{}
+		subscriptions = append(subscriptions, sub)
+	}
+	return subscriptions
+}
+
+func decodeStoreMeta(data []byte) (*storeMeta, error) {
+	if len(data) < 4 || binary.BigEndian.Uint32(data) != crc32.ChecksumIEEE(data[4:]) {
+		return nil, errors.New("the event store meta file is corrupted")
+	}
+	meta := &storeMeta{}
+	if err := json.Unmarshal(data[4:], meta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// deleteUnrecoveredData deletes all the data in the db except the subscriptions to be recovered.
+func deleteUnrecoveredData(db *pebble.DB, subscriptions []subscriptionMeta) error {
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if subscriptions[i].UniqueID != subscriptions[j].UniqueID {
+			return subscriptions[i].UniqueID < subscriptions[j].UniqueID
+		}
+		return subscriptions[i].TableID < subscriptions[j].TableID
+	})
+	start := EncodeKeyPrefix(0, 0, 0)
+	for _, sub := range subscriptions {
+		// the data which is not needed by the subscription is also deleted.
+		end := EncodeKeyPrefix(sub.UniqueID, sub.TableID, sub.CheckpointTs+1)
+		if err := db.DeleteRange(start, end, pebble.NoSync); err != nil {
+			return errors.Trace(err)
+		}
+		start = EncodeKeyPrefix(sub.UniqueID, sub.TableID, sub.ResolvedTs+1)
+	}
+	return errors.Trace(db.DeleteRange(start, EncodeKeyPrefix(math.MaxUint64, 0, 0), pebble.NoSync))
+}
+
+// recoverSubscriptions recovers the subscriptions of the previous server,
+// they will subscribe the upstream when a dispatcher attaches to them.
+func (e *eventStore) recoverSubscriptions(subscriptions []subscriptionMeta) {
+	subscriptionsByDB := make([][]subscriptionMeta, dbCount)
+	for _, sub := range subscriptions {
+		subscriptionsByDB[sub.DBIndex] = append(subscriptionsByDB[sub.DBIndex], sub)
+	}
+	now := time.Now().UnixMilli()
+	for i, db := range e.dbs {
+		if err := deleteUnrecoveredData(db, subscriptionsByDB[i]); err != nil {
+			log.Warn("fail to delete the data which is not recovered, discard the db",
+				zap.Int("dbIndex", i), zap.Error(err))
+			subscriptionsByDB[i] = nil
+			if err = db.DeleteRange(EncodeKeyPrefix(0, 0, 0), EncodeKeyPrefix(math.MaxUint64, 0, 0), pebble.NoSync); err != nil {
+				log.Panic("fail to delete the data of the event store", zap.Int("dbIndex", i), zap.Error(err))
+			}
+		}
+		for _, sub := range subscriptionsByDB[i] {
+			subStat := &subscriptionStat{
+				subID: logpuller.SubscriptionID(sub.UniqueID),
+				tableSpan: &heartbeatpb.TableSpan{
+					TableID:  sub.TableID,
+					StartKey: sub.StartKey,
+					EndKey:   sub.EndKey,
+				},
+				dbIndex: i,
+				eventCh: e.chs[i],
+			}
+			subStat.subscribers.Store(&subscribersWithIdleTime{
+				subscribers: make(map[common.DispatcherID]*Subscriber),
+				idleTime:    now,
+			})
+			subStat.checkpointTs.Store(sub.CheckpointTs)
+			subStat.resolvedTs.Store(sub.ResolvedTs)
+			subStat.maxEventCommitTs.Store(sub.MaxEventCommitTs)
+			// the recovered data may be deleted when the checkpoint ts advances.
+			subStat.lastReceiveDMLTime.Store(now)
+			subStat.initialized.Store(true)
+			subStat.recovered.Store(true)
+
+			if len(e.dispatcherMeta.tableStats[sub.TableID]) == 0 {
+				e.dispatcherMeta.tableStats[sub.TableID] = make(subscriptionStats)
+			}
+			e.dispatcherMeta.tableStats[sub.TableID][subStat.subID] = subStat
+			e.subClient.ReserveSubscriptionID(subStat.subID)
+			e.subscriptionChangeCh.In() <- SubscriptionChange{
+				ChangeType:   SubscriptionChangeTypeAdd,
+				SubID:        sub.UniqueID,
+				Span:         subStat.tableSpan,
+				CheckpointTs: sub.CheckpointTs,
+				ResolvedTs:   sub.ResolvedTs,
+			}
+			metrics.EventStoreSubscriptionGauge.Inc()
+			log.Info("recover subscription from the previous server",
+				zap.Uint64("subscriptionID", sub.UniqueID),
+				zap.Int("dbIndex", i),
+				zap.String("subSpan", common.FormatTableSpan(subStat.tableSpan)),
+				zap.Uint64("checkpointTs", sub.CheckpointTs),
+				zap.Uint64("resolvedTs", sub.ResolvedTs))
+		}
+	}
+}
+
+// collectSubscriptionMetas returns the metadata of the subscriptions which have received resolved ts.
+func (e *eventStore) collectSubscriptionMetas() []subscriptionMeta {
+	e.dispatcherMeta.RLock()
+	defer e.dispatcherMeta.RUnlock()
+	var subscriptions []subscriptionMeta
+	for _, subStats := range e.dispatcherMeta.tableStats {
+		for _, subStat := range subStats {
+			if !subStat.initialized.Load() {
+				continue
+			}
+			subscriptions = append(subscriptions, subscriptionMeta{
+				UniqueID:         uint64(subStat.subID),
+				DBIndex:          subStat.dbIndex,
+				TableID:          subStat.tableSpan.TableID,
+				StartKey:         subStat.tableSpan.StartKey,
+				EndKey:           subStat.tableSpan.EndKey,
+				CheckpointTs:     subStat.checkpointTs.Load(),
+				ResolvedTs:       subStat.resolvedTs.Load(),
+				MaxEventCommitTs: subStat.maxEventCommitTs.Load(),
+			})
+		}
+	}
+	return subscriptions
+}
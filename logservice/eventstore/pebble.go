@@ -19,8 +19,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/bloom"
-	"github.com/pingcap/log"
-	"go.uber.org/zap"
+	"github.com/pingcap/errors"
 )
 
 // TODO: add config for pebble options
@@ -70,19 +69,22 @@ func newPebbleOptions(dbNum int) *pebble.Options {
 	return opts
 }
 
-func createPebbleDBs(rootDir string, dbNum int) []*pebble.DB {
+func createPebbleDBs(rootDir string, dbNum int) ([]*pebble.DB, error) {
 	cache := pebble.NewCache(cacheSize)
 	tableCache := pebble.NewTableCache(cache, dbNum, int(cache.MaxSize()))
-	dbs := make([]*pebble.DB, dbNum)
+	dbs := make([]*pebble.DB, 0, dbNum)
 	for i := 0; i < dbNum; i++ {
 		opts := newPebbleOptions(dbNum)
 		opts.Cache = cache
 		opts.TableCache = tableCache
 		db, err := pebble.Open(fmt.Sprintf("%s/%04d", rootDir, i), opts)
 		if err != nil {
-			log.Fatal("open db failed", zap.Error(err))
+			for _, opened := range dbs {
+				_ = opened.Close()
+			}
+			return nil, errors.Trace(err)
 		}
-		dbs[i] = db
+		dbs = append(dbs, db)
 	}
-	return dbs
+	return dbs, nil
 }
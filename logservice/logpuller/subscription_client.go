@@ -162,6 +162,9 @@ type SubscriptionClient interface {
 	common.SubModule
 	// allocate a unique id for the subscription
 	AllocSubscriptionID() SubscriptionID
+	// make sure the ids allocated later are larger than subID,
+	// it's used when the subscriptions of the previous server are reused.
+	ReserveSubscriptionID(subID SubscriptionID)
 	// subscribe a table span
 	Subscribe(
 		subID SubscriptionID,
@@ -272,6 +275,15 @@ func (s *subscriptionClient) AllocSubscriptionID() SubscriptionID {
 	return SubscriptionID(subscriptionIDGen.Add(1))
 }
 
+func (s *subscriptionClient) ReserveSubscriptionID(subID SubscriptionID) {
+	for {
+		current := subscriptionIDGen.Load()
+		if current >= uint64(subID) || subscriptionIDGen.CompareAndSwap(current, uint64(subID)) {
+			return
+		}
+	}
+}
+
 func (s *subscriptionClient) initMetrics() {
 	// TODO: fix metrics
 	s.metrics.batchResolvedSize = metrics.BatchResolvedEventSize.WithLabelValues("event-store")
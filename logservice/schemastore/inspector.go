@@ -0,0 +1,197 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemastore
+
+import (
+	"os"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"go.uber.org/zap"
+)
+
+// Inspector reads the schema store data on disk offline, it's used to debug the schema errors.
+// The data must not be opened by a running server at the same time.
+type Inspector struct {
+	lock    *pebble.Lock
+	storage *persistentStorage
+}
+
+// lockDB acquires the lock of the schema store data at dbPath,
+// it fails if the data is opened by a running server.
+func lockDB(dbPath string) (*pebble.Lock, error) {
+	if !exists(dbPath) {
+		return nil, errors.Errorf("schema store data %s does not exist", dbPath)
+	}
+	lock, err := pebble.LockDirectory(dbPath, vfs.Default)
+	if err != nil {
+		return nil, errors.Annotatef(err,
+			"schema store data %s is being used by a running server, stop the server first", dbPath)
+	}
+	return lock, nil
+}
+
+// NewInspector opens the schema store data at dbPath in read-only mode.
+func NewInspector(dbPath string) (*Inspector, error) {
+	lock, err := lockDB(dbPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	db, err := pebble.Open(dbPath, &pebble.Options{
+		ReadOnly:         true,
+		ErrorIfNotExists: true,
+		Lock:             lock,
+	})
+	if err != nil {
+		_ = lock.Close()
+		return nil, errors.Trace(err)
+	}
+	gcTs, err := readGcTs(db)
+	if err != nil {
+		_ = db.Close()
+		_ = lock.Close()
+		return nil, errors.Annotate(err, "read gc ts failed")
+	}
+	upperBound, err := readUpperBoundMeta(db)
+	if err != nil {
+		_ = db.Close()
+		_ = lock.Close()
+		return nil, errors.Annotate(err, "read upper bound failed")
+	}
+
+	storage := &persistentStorage{
+		db:                     db,
+		gcTs:                   gcTs,
+		upperBound:             upperBound,
+		tableMap:               make(map[int64]*BasicTableInfo),
+		partitionMap:           make(map[int64]BasicPartitionInfo),
+		databaseMap:            make(map[int64]*BasicDatabaseInfo),
+		tablesDDLHistory:       make(map[int64][]uint64),
+		tableTriggerDDLHistory: make([]uint64, 0),
+		tableInfoStoreMap:      make(map[int64]*versionedTableInfoStore),
+		tableRegisteredCount:   make(map[int64]int),
+	}
+	storage.loadFromDisk(upperBound.FinishedDDLTs)
+	return &Inspector{lock: lock, storage: storage}, nil
+}
+
+// Close closes the underlying db and releases the lock.
+func (i *Inspector) Close() error {
+	err := i.storage.db.Close()
+	if lockErr := i.lock.Close(); err == nil {
+		err = lockErr
+	}
+	return errors.Trace(err)
+}
+
+// GcTs returns the ts of the schema snapshot on disk.
+func (i *Inspector) GcTs() uint64 {
+	return i.storage.gcTs
+}
+
+// UpperBound returns the upper bound of the valid data on disk.
+func (i *Inspector) UpperBound() UpperBoundMeta {
+	return i.storage.upperBound
+}
+
+// ListDDLJobs returns the persisted ddl jobs whose finished ts is in range [startTs, endTs).
+func (i *Inspector) ListDDLJobs(startTs, endTs uint64) ([]PersistedDDLEvent, error) {
+	startKey, err := ddlJobKey(startTs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endKey, err := ddlJobKey(endTs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snap := i.storage.db.NewSnapshot()
+	defer snap.Close()
+	iter, err := snap.NewIter(&pebble.IterOptions{
+		LowerBound: startKey,
+		UpperBound: endKey,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer iter.Close()
+
+	var events []PersistedDDLEvent
+	for iter.First(); iter.Valid(); iter.Next() {
+		events = append(events, unmarshalPersistedDDLEvent(iter.Value()))
+	}
+	return events, nil
+}
+
+// GetTableInfo returns the table info of the table at ts, which is built from the snapshot
+// and the ddl jobs on disk in the same way as the server.
+func (i *Inspector) GetTableInfo(tableID int64, ts uint64) (*common.TableInfo, error) {
+	if ts < i.storage.gcTs {
+		return nil, errors.Errorf("ts %d is smaller than gcTs %d", ts, i.storage.gcTs)
+	}
+	// the versioned table info is kept after registered, so it's not rebuilt for each ts.
+	if err := i.storage.registerTable(tableID, i.storage.gcTs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return i.storage.getTableInfo(tableID, ts)
+}
+
+// RebuildFromSnapshot replaces the schema store data at dbPath with the upstream schema snapshot at snapTs.
+// The ddl jobs after snapTs are pulled from the upstream again when the data is used by the server.
+// The old data is kept if the snapshot can't be read, for example, snapTs is already garbage collected.
+func RebuildFromSnapshot(dbPath string, kvStorage kv.Storage, snapTs uint64) error {
+	// hold the lock until the data is replaced, so the server can't open the data during the rebuild.
+	if exists(dbPath) {
+		lock, err := lockDB(dbPath)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer lock.Close()
+	}
+	tmpPath := dbPath + ".rebuild"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return errors.Trace(err)
+	}
+	db := openDB(tmpPath)
+	if _, _, _, err := persistSchemaSnapshot(db, kvStorage, snapTs, false); err != nil {
+		_ = db.Close()
+		_ = os.RemoveAll(tmpPath)
+		return errors.Trace(err)
+	}
+	writeUpperBoundMeta(db, UpperBoundMeta{
+		FinishedDDLTs: 0,
+		ResolvedTs:    snapTs,
+	})
+	// the wal is disabled, so the data must be flushed before the db is closed.
+	if err := db.Flush(); err != nil {
+		_ = db.Close()
+		return errors.Trace(err)
+	}
+	if err := db.Close(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := os.RemoveAll(dbPath); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("schema store rebuilt from the upstream snapshot",
+		zap.String("dbPath", dbPath), zap.Uint64("snapTs", snapTs))
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemastore
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	schemaID, tableID := int64(50), int64(100)
+	pStorage := newPersistentStorageForTest(dbPath, []mockDBInfo{
+		{
+			dbInfo: &model.DBInfo{ID: schemaID, Name: ast.NewCIStr("test")},
+			tables: []*model.TableInfo{newEligibleTableInfoForTest(tableID, "t")},
+		},
+	})
+	jobs := []*model.Job{
+		buildRenameTableJobForTest(schemaID, tableID, "t1", 200, &model.InvolvingSchemaInfo{
+			Database: "test",
+			Table:    "t",
+		}),
+		buildRenameTableJobForTest(schemaID, tableID, "t2", 300, &model.InvolvingSchemaInfo{
+			Database: "test",
+			Table:    "t1",
+		}),
+	}
+	for _, job := range jobs {
+		require.NoError(t, pStorage.handleDDLJob(job))
+	}
+	writeUpperBoundMeta(pStorage.db, UpperBoundMeta{FinishedDDLTs: 300, ResolvedTs: 300})
+	require.NoError(t, pStorage.close())
+
+	inspector, err := NewInspector(dbPath)
+	require.NoError(t, err)
+	defer inspector.Close()
+
+	require.Equal(t, uint64(0), inspector.GcTs())
+	require.Equal(t, uint64(300), inspector.UpperBound().FinishedDDLTs)
+
+	ddlJobs, err := inspector.ListDDLJobs(0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, ddlJobs, 2)
+	require.Equal(t, uint64(200), ddlJobs[0].FinishedTs)
+	require.Equal(t, uint64(300), ddlJobs[1].FinishedTs)
+	ddlJobs, err = inspector.ListDDLJobs(250, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, ddlJobs, 1)
+
+	for ts, name := range map[uint64]string{100: "t", 250: "t1", 300: "t2"} {
+		tableInfo, err := inspector.GetTableInfo(tableID, ts)
+		require.NoError(t, err)
+		require.Equal(t, name, tableInfo.GetTableName())
+	}
+	_, err = inspector.GetTableInfo(tableID+1, 300)
+	require.Error(t, err)
+
+	_, err = NewInspector(dbPath + "-not-exist")
+	require.Error(t, err)
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemastore
+
+import (
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"go.uber.org/zap"
+)
+
+// getCutOverTables returns the indexes of the ghost table and the original table if the
+// RenameTables is the cut-over of an online schema change, such as gh-ost's
+// `RENAME TABLE t TO _t_del, _t_gho TO t` and pt-osc's `RENAME TABLE t TO _t_old, _t_new TO t`.
+func getCutOverTables(event *PersistedDDLEvent) (int, int, bool) {
+	if len(event.MultipleTableInfos) != 2 {
+		return 0, 0, false
+	}
+	for ghost := 0; ghost < 2; ghost++ {
+		origin := 1 - ghost
+		ghostInfo, originInfo := event.MultipleTableInfos[ghost], event.MultipleTableInfos[origin]
+		if ghostInfo.ID == InvalidTableID || originInfo.ID == InvalidTableID ||
+			isPartitionTable(ghostInfo) || isPartitionTable(originInfo) {
+			continue
+		}
+		schemaID := event.SchemaIDs[ghost]
+		if event.ExtraSchemaIDs[ghost] != schemaID ||
+			event.SchemaIDs[origin] != schemaID || event.ExtraSchemaIDs[origin] != schemaID {
+			continue
+		}
+		tableName := event.ExtraTableNames[origin]
+		tp, name := filter.GetOnlineDDLTableType(event.ExtraTableNames[ghost])
+		if tp != filter.OnlineDDLGhostTable || name != tableName || ghostInfo.Name.O != tableName {
+			continue
+		}
+		tp, name = filter.GetOnlineDDLTableType(originInfo.Name.O)
+		if tp != filter.OnlineDDLTrashTable || name != tableName {
+			continue
+		}
+		return ghost, origin, true
+	}
+	return 0, 0, false
+}
+
+// buildCutOverQuery merges the ALTER TABLEs on the ghost table into one ALTER TABLE on the original table.
+// It returns an empty query if the DDLs on the ghost table are incomplete, for example, the creation of
+// the ghost table has been garbage collected.
+func (p *persistentStorage) buildCutOverQuery(event *PersistedDDLEvent) (string, byte) {
+	ghost, _, ok := getCutOverTables(event)
+	if !ok {
+		return "", 0
+	}
+	ghostID := event.MultipleTableInfos[ghost].ID
+
+	// get the storage snapshot before the history, so that all the history can be read from the snapshot.
+	storageSnap := p.db.NewSnapshot()
+	defer storageSnap.Close()
+	p.mu.RLock()
+	history := append([]uint64(nil), p.tablesDDLHistory[ghostID]...)
+	p.mu.RUnlock()
+
+	var (
+		created    bool
+		specs      []*ast.AlterTableSpec
+		actionType model.ActionType
+	)
+	for _, ts := range history {
+		if ts >= event.FinishedTs {
+			break
+		}
+		rawEvent := readPersistedDDLEvent(storageSnap, ts)
+		if rawEvent.TableID != ghostID {
+			continue
+		}
+		if model.ActionType(rawEvent.Type) == model.ActionCreateTable {
+			created = true
+			continue
+		}
+		stmt, err := parser.New().ParseOneStmt(rawEvent.Query, "", "")
+		if err != nil {
+			log.Warn("parse the ddl on the ghost table failed",
+				zap.String("query", rawEvent.Query), zap.Error(err))
+			return "", 0
+		}
+		alterStmt, ok := stmt.(*ast.AlterTableStmt)
+		if !ok {
+			log.Warn("unsupported ddl on the ghost table", zap.String("query", rawEvent.Query))
+			return "", 0
+		}
+		specs = append(specs, alterStmt.Specs...)
+		if actionType == model.ActionNone {
+			actionType = model.ActionType(rawEvent.Type)
+		} else {
+			actionType = model.ActionMultiSchemaChange
+		}
+	}
+	if !created || len(specs) == 0 {
+		log.Warn("the ddls on the ghost table are incomplete, can not build the cut-over query",
+			zap.Int64("tableID", ghostID), zap.String("query", event.Query), zap.Bool("created", created))
+		return "", 0
+	}
+
+	alterStmt := &ast.AlterTableStmt{
+		Table: &ast.TableName{
+			Schema: ast.NewCIStr(event.SchemaNames[ghost]),
+			Name:   event.MultipleTableInfos[ghost].Name,
+		},
+		Specs: specs,
+	}
+	var sb strings.Builder
+	restoreFlags := format.RestoreTiDBSpecialComment | format.RestoreNameBackQuotes |
+		format.RestoreKeyWordUppercase | format.RestoreStringSingleQuotes
+	if err := alterStmt.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		log.Warn("restore the cut-over query failed", zap.String("query", event.Query), zap.Error(err))
+		return "", 0
+	}
+	log.Info("build the cut-over query of the online schema change",
+		zap.String("query", event.Query), zap.String("cutOverQuery", sb.String()))
+	return sb.String(), byte(actionType)
+}
+
+// buildDDLEventForCutOver builds the ddl event for the cut-over of an online schema change,
+// the original table is replaced by the ghost table, and the ALTER TABLE is sent to downstream.
+func buildDDLEventForCutOver(
+	rawEvent *PersistedDDLEvent, tableFilter filter.Filter, ghost, origin int,
+) (commonEvent.DDLEvent, bool, error) {
+	ghostInfo, originInfo := rawEvent.MultipleTableInfos[ghost], rawEvent.MultipleTableInfos[origin]
+	schemaName := rawEvent.SchemaNames[ghost]
+	_, notSync, err := filterDDL(tableFilter, schemaName, ghostInfo.Name.O, rawEvent.CutOverQuery,
+		model.ActionType(rawEvent.CutOverType), ghostInfo, rawEvent.StartTs)
+	if err != nil {
+		return commonEvent.DDLEvent{}, false, err
+	}
+	return commonEvent.DDLEvent{
+		Version:    commonEvent.DDLEventVersion1,
+		Type:       rawEvent.CutOverType,
+		SchemaID:   rawEvent.SchemaIDs[ghost],
+		SchemaName: schemaName,
+		TableName:  ghostInfo.Name.O,
+		Query:      rawEvent.CutOverQuery,
+		TableInfo:  common.WrapTableInfo(schemaName, ghostInfo),
+		FinishedTs: rawEvent.FinishedTs,
+		BDRMode:    rawEvent.BDRRole,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{originInfo.ID, common.DDLSpanTableID},
+		},
+		BlockedTableNames: []commonEvent.SchemaTableName{{SchemaName: schemaName, TableName: ghostInfo.Name.O}},
+		// like truncate table, the original table is replaced by the ghost table with the same name.
+		NeedDroppedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{originInfo.ID},
+		},
+		NeedAddedTables: []commonEvent.Table{
+			{
+				SchemaID:  rawEvent.SchemaIDs[ghost],
+				TableID:   ghostInfo.ID,
+				Splitable: isSplitable(ghostInfo),
+			},
+		},
+		NotSync: notSync,
+	}, true, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemastore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func buildAlterGhostTableJobForTest(
+	jobType model.ActionType, schemaID, tableID int64, query string, finishedTs uint64,
+) *model.Job {
+	return &model.Job{
+		Type:     jobType,
+		SchemaID: schemaID,
+		TableID:  tableID,
+		Query:    query,
+		BinlogInfo: &model.HistoryInfo{
+			FinishedTS: finishedTs,
+			TableInfo:  newEligibleTableInfoForTest(tableID, "_t_gho"),
+		},
+	}
+}
+
+func TestOnlineDDLCutOver(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	schemaID := int64(50)
+	originID, ghostID := int64(100), int64(101)
+	pStorage := newPersistentStorageForTest(dbPath, []mockDBInfo{
+		{
+			dbInfo: &model.DBInfo{ID: schemaID, Name: ast.NewCIStr("test")},
+			tables: []*model.TableInfo{newEligibleTableInfoForTest(originID, "t")},
+		},
+	})
+	defer pStorage.close()
+
+	jobs := []*model.Job{
+		buildCreateTableJobForTest(schemaID, ghostID, "_t_gho", 200),
+		buildAlterGhostTableJobForTest(model.ActionAddColumn, schemaID, ghostID,
+			"ALTER TABLE `test`.`_t_gho` ADD COLUMN `c` INT", 210),
+		buildAlterGhostTableJobForTest(model.ActionAddIndex, schemaID, ghostID,
+			"ALTER TABLE `test`.`_t_gho` ADD INDEX `idx`(`c`)", 220),
+		buildRenameTablesJobForTest(
+			[]int64{schemaID, schemaID}, []int64{schemaID, schemaID}, []int64{originID, ghostID},
+			[]string{"test", "test"}, []string{"t", "_t_gho"}, []string{"_t_del", "t"}, 230),
+	}
+	for _, job := range jobs {
+		require.NoError(t, pStorage.handleDDLJob(job))
+	}
+
+	onlineDDLFilter, err := filter.NewFilter(&config.FilterConfig{Rules: []string{"test.*"}, OnlineDDL: true}, "", false, false)
+	require.NoError(t, err)
+	require.True(t, onlineDDLFilter.ShouldIgnoreTable("test", "_t_gho"))
+	require.False(t, onlineDDLFilter.ShouldIgnoreTable("test", "t"))
+
+	expectedQuery := "ALTER TABLE `test`.`t` ADD COLUMN `c` INT, ADD INDEX `idx`(`c`)"
+	{
+		ddlEvents, err := pStorage.fetchTableTriggerDDLEvents(onlineDDLFilter, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, ddlEvents, 1)
+		ddlEvent := ddlEvents[0]
+		require.Equal(t, byte(model.ActionMultiSchemaChange), ddlEvent.Type)
+		require.Equal(t, expectedQuery, ddlEvent.Query)
+		require.Equal(t, "t", ddlEvent.TableName)
+		require.Equal(t, []int64{originID, common.DDLSpanTableID}, ddlEvent.BlockedTables.TableIDs)
+		require.Equal(t, []int64{originID}, ddlEvent.NeedDroppedTables.TableIDs)
+		require.Equal(t, []commonEvent.Table{{SchemaID: schemaID, TableID: ghostID, Splitable: true}}, ddlEvent.NeedAddedTables)
+	}
+	{
+		ddlEvents, err := pStorage.fetchTableDDLEvents(common.NewDispatcherID(), originID, onlineDDLFilter, 0, 300)
+		require.NoError(t, err)
+		require.Len(t, ddlEvents, 1)
+		require.Equal(t, expectedQuery, ddlEvents[0].Query)
+	}
+
+	// without online ddl, the cut-over is replicated as the rename tables.
+	{
+		tableFilter, err := filter.NewFilter(&config.FilterConfig{Rules: []string{"test.*"}}, "", false, false)
+		require.NoError(t, err)
+		ddlEvents, err := pStorage.fetchTableTriggerDDLEvents(tableFilter, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, ddlEvents, 2)
+		require.Equal(t, byte(model.ActionCreateTable), ddlEvents[0].Type)
+		require.Equal(t, byte(model.ActionRenameTables), ddlEvents[1].Type)
+	}
+}
+
+func TestGetCutOverTables(t *testing.T) {
+	build := func(names ...string) *PersistedDDLEvent {
+		event := &PersistedDDLEvent{}
+		for i := 0; i < len(names); i += 2 {
+			event.SchemaIDs = append(event.SchemaIDs, 50)
+			event.ExtraSchemaIDs = append(event.ExtraSchemaIDs, 50)
+			event.ExtraTableNames = append(event.ExtraTableNames, names[i])
+			event.MultipleTableInfos = append(event.MultipleTableInfos,
+				newEligibleTableInfoForTest(int64(100+i), names[i+1]))
+		}
+		return event
+	}
+
+	ghost, origin, ok := getCutOverTables(build("t", "_t_old", "_t_new", "t"))
+	require.True(t, ok)
+	require.Equal(t, 1, ghost)
+	require.Equal(t, 0, origin)
+
+	_, _, ok = getCutOverTables(build("t", "t1"))
+	require.False(t, ok)
+	_, _, ok = getCutOverTables(build("t", "_t_del", "_t1_gho", "t1"))
+	require.False(t, ok)
+	_, _, ok = getCutOverTables(build("t", "t2", "_t_gho", "t"))
+	require.False(t, ok)
+
+	event := build("t", "_t_del", "_t_gho", "t")
+	event.SchemaIDs[1] = 51
+	_, _, ok = getCutOverTables(event)
+	require.False(t, ok)
+}
@@ -16,6 +16,7 @@ package schemastore
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"sort"
 	"strings"
@@ -101,6 +102,11 @@ type persistentStorage struct {
 	tableRegisteredCount map[int64]int
 }
 
+// GetDBPath returns the path of the schema store data of the keyspace under the data dir.
+func GetDBPath(rootDir string, keyspaceID uint32) string {
+	return fmt.Sprintf("%s/%s/%d", rootDir, dataDir, keyspaceID)
+}
+
 func exists(path string) bool {
 	_, err := os.Stat(path)
 	if err == nil {
@@ -200,12 +206,7 @@ func (p *persistentStorage) initialize(ctx context.Context) error {
 
 	defer gc.UndoEnsureChangefeedStartTsSafety(ctx, p.pdCli, p.keyspaceID, defaultSchemaStoreGcServiceID, fakeChangefeedID)
 
-	dbPath := fmt.Sprintf("%s/%s/%d", p.rootDir, dataDir, p.keyspaceID)
-
-	// FIXME: currently we don't try to reuse data at restart, when we need, just remove the following line
-	if err := os.RemoveAll(dbPath); err != nil {
-		log.Panic("fail to remove path", zap.String("dbPath", dbPath), zap.Error(err))
-	}
+	dbPath := GetDBPath(p.rootDir, p.keyspaceID)
 
 	isDataReusable := false
 	if exists(dbPath) {
@@ -217,7 +218,12 @@ func (p *persistentStorage) initialize(ctx context.Context) error {
 			isDataReusable = false
 		}
 		if gcSafePoint < gcTs {
-			return errors.New(fmt.Sprintf("gc safe point %d is smaller than gcTs %d on disk", gcSafePoint, gcTs))
+			// the data may be rebuilt offline from a snapshot newer than the gc safe point,
+			// the schema before gcTs is not available, but the data is still valid after gcTs.
+			log.Warn("gc safe point is smaller than gcTs on disk",
+				zap.String("dbPath", dbPath),
+				zap.Uint64("gcSafePoint", gcSafePoint),
+				zap.Uint64("gcTs", gcTs))
 		}
 		upperBound, err := readUpperBoundMeta(db)
 		if err != nil {
@@ -273,7 +279,19 @@ func (p *persistentStorage) initializeFromKVStorage(dbPath string, gcTs uint64)
 
 func (p *persistentStorage) initializeFromDisk() {
 	cleanObsoleteData(p.db, 0, p.gcTs)
+	p.loadFromDisk(p.upperBound.FinishedDDLTs)
+	log.Info("schema store initialize from disk done",
+		zap.Uint64("gcTs", p.gcTs),
+		zap.Uint64("finishedDDLTs", p.upperBound.FinishedDDLTs),
+		zap.Uint64("resolvedTs", p.upperBound.ResolvedTs))
+}
 
+// loadFromDisk loads the snapshot at gcTs and the ddl jobs in range (gcTs, maxFinishedDDLTs].
+func (p *persistentStorage) loadFromDisk(maxFinishedDDLTs uint64) {
+	// the upper bound of loadAndApplyDDLHistory is exclusive.
+	if maxFinishedDDLTs < math.MaxUint64 {
+		maxFinishedDDLTs++
+	}
 	storageSnap := p.db.NewSnapshot()
 	defer storageSnap.Close()
 
@@ -289,7 +307,7 @@ func (p *persistentStorage) initializeFromDisk() {
 	if p.tablesDDLHistory, p.tableTriggerDDLHistory, err = loadAndApplyDDLHistory(
 		storageSnap,
 		p.gcTs,
-		p.upperBound.FinishedDDLTs,
+		maxFinishedDDLTs,
 		p.databaseMap,
 		p.tableMap,
 		p.partitionMap); err != nil {
@@ -307,6 +325,17 @@ func (p *persistentStorage) run() error {
 func (p *persistentStorage) close() error {
 	p.cancel()
 	p.wg.Wait()
+	// the wal is disabled, persist the latest upper bound and flush the data to reuse it at restart.
+	p.mu.Lock()
+	upperBound, upperBoundChanged := p.upperBound, p.upperBoundChanged
+	p.upperBoundChanged = false
+	p.mu.Unlock()
+	if upperBoundChanged {
+		writeUpperBoundMeta(p.db, upperBound)
+	}
+	if err := p.db.Flush(); err != nil {
+		log.Warn("flush schema store data failed", zap.Error(err))
+	}
 	return p.db.Close()
 }
 
@@ -758,6 +787,9 @@ func (p *persistentStorage) handleDDLJob(job *model.Job) error {
 		// ExtraTableInfo is the normal table info before exchange
 		ddlEvent.ExtraTableInfo, _ = p.forceGetTableInfo(ddlEvent.TableID, ddlEvent.FinishedTs)
 	}
+	if ddlEvent.Type == byte(model.ActionRenameTables) {
+		ddlEvent.CutOverQuery, ddlEvent.CutOverType = p.buildCutOverQuery(&ddlEvent)
+	}
 
 	// Note: need write ddl event to disk before update ddl history,
 	// because other goroutines may read ddl events from disk according to ddl history
@@ -2395,6 +2395,24 @@ func buildDDLEventForRenameTables(rawEvent *PersistedDDLEvent, tableFilter filte
 	if len(querys) != len(rawEvent.MultipleTableInfos) {
 		log.Panic("rename tables length is not equal table infos", zap.Any("querys", querys), zap.Any("tableInfos", rawEvent.MultipleTableInfos))
 	}
+	if rawEvent.CutOverQuery != "" {
+		// the ghost table is renamed into the filter and the original table is renamed out of the filter,
+		// it's the cut-over of an online schema change when the ghost tables are filtered out.
+		ghost, origin, _ := getCutOverTables(rawEvent)
+		ignoreGhost, _, err := filterDDL(tableFilter, rawEvent.ExtraSchemaNames[ghost], rawEvent.ExtraTableNames[ghost],
+			rawEvent.Query, model.ActionType(rawEvent.Type), rawEvent.MultipleTableInfos[ghost], rawEvent.StartTs)
+		if err != nil {
+			return commonEvent.DDLEvent{}, false, err
+		}
+		ignoreOrigin, _, err := filterDDL(tableFilter, rawEvent.SchemaNames[origin], rawEvent.ExtraTableNames[origin],
+			rawEvent.Query, model.ActionType(rawEvent.Type), rawEvent.MultipleTableInfos[origin], rawEvent.StartTs)
+		if err != nil {
+			return commonEvent.DDLEvent{}, false, err
+		}
+		if ignoreGhost && !ignoreOrigin {
+			return buildDDLEventForCutOver(rawEvent, tableFilter, ghost, origin)
+		}
+	}
 	for i, tableInfo := range rawEvent.MultipleTableInfos {
 		ignorePrevTable, ignoreCurrentTable := false, false
 		notSyncPrevTable := false
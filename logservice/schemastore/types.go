@@ -85,6 +85,12 @@ type PersistedDDLEvent struct {
 	// TODO: do we need the following two fields?
 	BDRRole        string `msg:"bdr_role"`
 	CDCWriteSource uint64 `msg:"cdc_write_source"`
+
+	// the following fields are only set when the RenameTables is the cut-over of an online schema change,
+	// CutOverQuery is the ALTER TABLE on the original table which is equivalent to the online schema change,
+	// and CutOverType is its action type.
+	CutOverQuery string `msg:"cut_over_query"`
+	CutOverType  byte   `msg:"cut_over_type"`
 }
 
 // TODO: use msgp.Raw to do version management
@@ -265,6 +265,18 @@ func (z *PersistedDDLEvent) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "CDCWriteSource")
 				return
 			}
+		case "cut_over_query":
+			z.CutOverQuery, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "CutOverQuery")
+				return
+			}
+		case "cut_over_type":
+			z.CutOverType, err = dc.ReadByte()
+			if err != nil {
+				err = msgp.WrapError(err, "CutOverType")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -278,9 +290,9 @@ func (z *PersistedDDLEvent) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 25
+	// map header, size 27
 	// write "id"
-	err = en.Append(0xde, 0x0, 0x19, 0xa2, 0x69, 0x64)
+	err = en.Append(0xde, 0x0, 0x1b, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
@@ -578,15 +590,35 @@ func (z *PersistedDDLEvent) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "CDCWriteSource")
 		return
 	}
+	// write "cut_over_query"
+	err = en.Append(0xae, 0x63, 0x75, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.CutOverQuery)
+	if err != nil {
+		err = msgp.WrapError(err, "CutOverQuery")
+		return
+	}
+	// write "cut_over_type"
+	err = en.Append(0xad, 0x63, 0x75, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteByte(z.CutOverType)
+	if err != nil {
+		err = msgp.WrapError(err, "CutOverType")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *PersistedDDLEvent) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 25
+	// map header, size 27
 	// string "id"
-	o = append(o, 0xde, 0x0, 0x19, 0xa2, 0x69, 0x64)
+	o = append(o, 0xde, 0x0, 0x1b, 0xa2, 0x69, 0x64)
 	o = msgp.AppendInt64(o, z.ID)
 	// string "type"
 	o = append(o, 0xa4, 0x74, 0x79, 0x70, 0x65)
@@ -681,6 +713,12 @@ func (z *PersistedDDLEvent) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "cdc_write_source"
 	o = append(o, 0xb0, 0x63, 0x64, 0x63, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65)
 	o = msgp.AppendUint64(o, z.CDCWriteSource)
+	// string "cut_over_query"
+	o = append(o, 0xae, 0x63, 0x75, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79)
+	o = msgp.AppendString(o, z.CutOverQuery)
+	// string "cut_over_type"
+	o = append(o, 0xad, 0x63, 0x75, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65)
+	o = msgp.AppendByte(o, z.CutOverType)
 	return
 }
 
@@ -943,6 +981,18 @@ func (z *PersistedDDLEvent) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "CDCWriteSource")
 				return
 			}
+		case "cut_over_query":
+			z.CutOverQuery, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CutOverQuery")
+				return
+			}
+		case "cut_over_type":
+			z.CutOverType, bts, err = msgp.ReadByteBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CutOverType")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -973,7 +1023,7 @@ func (z *PersistedDDLEvent) Msgsize() (s int) {
 	for za0007 := range z.MultipleTableInfosValue {
 		s += msgp.BytesPrefixSize + len(z.MultipleTableInfosValue[za0007])
 	}
-	s += 9 + msgp.StringPrefixSize + len(z.BDRRole) + 17 + msgp.Uint64Size
+	s += 9 + msgp.StringPrefixSize + len(z.BDRRole) + 17 + msgp.Uint64Size + 15 + msgp.StringPrefixSize + len(z.CutOverQuery) + 14 + msgp.ByteSize
 	return
 }
 
@@ -169,6 +169,12 @@ type DSState byte
 const (
 	DSStateNormal DSState = iota
 	DSStateRemoved
+	// DSStateEvicted means the dispatcher is removed because its data is evicted
+	// by the disk quota of the event store, it can't be registered again for a while.
+	// The event collectors of the older versions only handle DSStateRemoved and ignore
+	// the other states, so they keep the dispatcher until the evicted data is dropped,
+	// and then they are replied DSStateRemoved to register the dispatcher again.
+	DSStateEvicted
 )
 
 // It is a part of DispatcherHeartbeatResponse, so it has no version field.
@@ -166,6 +166,17 @@ func TestDispatcherState(t *testing.T) {
 
 	require.Equal(t, ds.State, unmarshaledState.State)
 	require.Equal(t, ds.DispatcherID, unmarshaledState.DispatcherID)
+
+	// the evicted state is encoded in the same v1 format, the older versions decode it
+	// as an unknown state and ignore it.
+	evicted := NewDispatcherState(dispatcherID, DSStateEvicted)
+	data, err = evicted.Marshal()
+	require.NoError(t, err)
+	require.Len(t, data, ds.GetSize())
+	require.Equal(t, byte(2), data[len(data)-1])
+	err = unmarshaledState.Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, DSStateEvicted, unmarshaledState.State)
 }
 
 func TestDispatcherHeartbeatResponse(t *testing.T) {
@@ -180,6 +180,32 @@ func (ti *TableInfo) ShadowCopyColumnSchema() *columnSchema {
 	return ti.columnSchema.Clone()
 }
 
+// CloneWithTableName returns a copy of the table info with the given schema and table name,
+// the copy shares the column schema with the original one, and its pre sqls use the new name.
+func (ti *TableInfo) CloneWithTableName(schema string, table string) *TableInfo {
+	res := &TableInfo{
+		TableName: TableName{
+			Schema:      schema,
+			Table:       table,
+			TableID:     ti.TableName.TableID,
+			IsPartition: ti.TableName.IsPartition,
+		},
+		Charset:          ti.Charset,
+		Collate:          ti.Collate,
+		Comment:          ti.Comment,
+		columnSchema:     ti.ShadowCopyColumnSchema(),
+		HasPKOrNotNullUK: ti.HasPKOrNotNullUK,
+		View:             ti.View,
+		Sequence:         ti.Sequence,
+		UpdateTS:         ti.UpdateTS,
+	}
+	runtime.SetFinalizer(res, func(ti *TableInfo) {
+		GetSharedColumnSchemaStorage().tryReleaseColumnSchema(ti.columnSchema)
+	})
+	res.InitPrivateFields()
+	return res
+}
+
 func (ti *TableInfo) GetColumns() []*model.ColumnInfo {
 	return ti.columnSchema.Columns
 }
@@ -300,6 +300,9 @@ const (
 	CloudStorageSinkType
 	BlackHoleSinkType
 	RedoSinkType
+	ElasticsearchSinkType
+	WebhookSinkType
+	PostgresSinkType
 )
 
 type RowType byte
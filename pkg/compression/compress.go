@@ -15,9 +15,12 @@ package compression
 
 import (
 	"bytes"
+	"compress/gzip"
+	"io"
 	"sync"
 
 	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v4"
 	"github.com/pingcap/ticdc/pkg/errors"
 )
@@ -31,6 +34,28 @@ const (
 
 	// LZ4 compression
 	LZ4 string = "lz4"
+
+	// Zstd compression
+	Zstd string = "zstd"
+
+	// Gzip compression
+	Gzip string = "gzip"
+)
+
+const (
+	// DefaultLevel means the default compression level of the codec is used.
+	DefaultLevel = 0
+
+	// MinZstdLevel and MaxZstdLevel are the bounds of the zstd compression level.
+	MinZstdLevel = 1
+	MaxZstdLevel = 22
+)
+
+var (
+	lz4MagicNumber  = []byte{0x04, 0x22, 0x4D, 0x18}
+	zstdMagicNumber = []byte{0x28, 0xB5, 0x2F, 0xFD}
+	// gzipMagicNumber includes the deflate method byte to reduce false positives.
+	gzipMagicNumber = []byte{0x1F, 0x8B, 0x08}
 )
 
 var (
@@ -45,19 +70,165 @@ var (
 			return new(bytes.Buffer)
 		},
 	}
+
+	// zstdEncoders caches the zstd encoder of each level,
+	// the encoder is safe for concurrent use by EncodeAll.
+	zstdEncoders sync.Map
+	// zstdDecoder is safe for concurrent use by DecodeAll.
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
 )
 
 // Supported return true if the given compression is supported.
 func Supported(cc string) bool {
 	switch cc {
-	case None, Snappy, LZ4:
+	case None, Snappy, LZ4, Zstd, Gzip:
 		return true
 	}
 	return false
 }
 
+// ValidateLevel checks whether the level is valid for the given compression codec.
+// The DefaultLevel is always valid, and only zstd and gzip accept other levels.
+func ValidateLevel(cc string, level int) error {
+	if level == DefaultLevel {
+		return nil
+	}
+	switch cc {
+	case Zstd:
+		if level >= MinZstdLevel && level <= MaxZstdLevel {
+			return nil
+		}
+		return errors.ErrCompressionFailed.GenWithStack(
+			"zstd compression level %d is out of range [%d, %d]", level, MinZstdLevel, MaxZstdLevel)
+	case Gzip:
+		if level >= gzip.BestSpeed && level <= gzip.BestCompression {
+			return nil
+		}
+		return errors.ErrCompressionFailed.GenWithStack(
+			"gzip compression level %d is out of range [%d, %d]", level, gzip.BestSpeed, gzip.BestCompression)
+	default:
+	}
+	return errors.ErrCompressionFailed.GenWithStack("compression %s does not support level %d", cc, level)
+}
+
+// FileExtension returns the file extension of the data compressed by the given codec,
+// an empty string is returned if the data is not compressed.
+func FileExtension(cc string) string {
+	switch cc {
+	case Snappy:
+		return ".snappy"
+	case LZ4:
+		return ".lz4"
+	case Zstd:
+		return ".zst"
+	case Gzip:
+		return ".gz"
+	default:
+	}
+	return ""
+}
+
+// Detect returns the compression codec of the data by its magic number,
+// None is returned if the data is not compressed in a frame format.
+// Snappy block format has no magic number, so it cannot be detected.
+func Detect(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, lz4MagicNumber):
+		return LZ4
+	case bytes.HasPrefix(data, zstdMagicNumber):
+		return Zstd
+	case bytes.HasPrefix(data, gzipMagicNumber):
+		return Gzip
+	default:
+	}
+	return None
+}
+
+// NewWriter returns a writer which compresses the data written to w by the given codec.
+// Close must be called to flush the buffered data, it does not close w.
+func NewWriter(cc string, level int, w io.Writer) (io.WriteCloser, error) {
+	if err := ValidateLevel(cc, level); err != nil {
+		return nil, err
+	}
+	switch cc {
+	case LZ4:
+		return lz4.NewWriter(w), nil
+	case Zstd:
+		writer, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstdLevel(level)))
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		return writer, nil
+	case Gzip:
+		writer, err := gzip.NewWriterLevel(w, gzipLevel(level))
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		return writer, nil
+	default:
+	}
+	return nil, errors.ErrCompressionFailed.GenWithStack("Unsupported stream compression %s", cc)
+}
+
+// NewReader returns a reader which decompresses the data read from r by the given codec.
+func NewReader(cc string, r io.Reader) (io.ReadCloser, error) {
+	switch cc {
+	case LZ4:
+		return io.NopCloser(lz4.NewReader(r)), nil
+	case Zstd:
+		reader, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		return reader.IOReadCloser(), nil
+	case Gzip:
+		reader, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		return reader, nil
+	default:
+	}
+	return nil, errors.ErrCompressionFailed.GenWithStack("Unsupported stream compression %s", cc)
+}
+
+func zstdLevel(level int) zstd.EncoderLevel {
+	if level == DefaultLevel {
+		return zstd.SpeedDefault
+	}
+	return zstd.EncoderLevelFromZstd(level)
+}
+
+func gzipLevel(level int) int {
+	if level == DefaultLevel {
+		return gzip.DefaultCompression
+	}
+	return level
+}
+
+func getZstdEncoder(level int) (*zstd.Encoder, error) {
+	l := zstdLevel(level)
+	if encoder, ok := zstdEncoders.Load(l); ok {
+		return encoder.(*zstd.Encoder), nil
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(l))
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+	}
+	actual, _ := zstdEncoders.LoadOrStore(l, encoder)
+	return actual.(*zstd.Encoder), nil
+}
+
 // Encode the given data by the given compression codec.
 func Encode(cc string, data []byte) ([]byte, error) {
+	return EncodeWithLevel(cc, DefaultLevel, data)
+}
+
+// EncodeWithLevel encodes the given data by the given compression codec and level.
+func EncodeWithLevel(cc string, level int, data []byte) ([]byte, error) {
+	if err := ValidateLevel(cc, level); err != nil {
+		return nil, err
+	}
 	switch cc {
 	case None:
 		return data, nil
@@ -73,6 +244,25 @@ func Encode(cc string, data []byte) ([]byte, error) {
 			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
 		}
 		return buf.Bytes(), nil
+	case Zstd:
+		encoder, err := getZstdEncoder(level)
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeAll(data, nil), nil
+	case Gzip:
+		var buf bytes.Buffer
+		writer, err := NewWriter(cc, level, &buf)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = writer.Write(data); err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		if err = writer.Close(); err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		return buf.Bytes(), nil
 	default:
 	}
 
@@ -104,6 +294,22 @@ func Decode(cc string, data []byte) ([]byte, error) {
 		bufferPool.Put(buffer)
 
 		return res, err
+	case Zstd:
+		res, err := zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		return res, nil
+	case Gzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		res, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrCompressionFailed, err)
+		}
+		return res, nil
 	default:
 	}
 
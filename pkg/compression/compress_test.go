@@ -0,0 +1,94 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeAndDecode(t *testing.T) {
+	data := bytes.Repeat([]byte("ticdc compression test data "), 128)
+	for _, cc := range []string{None, Snappy, LZ4, Zstd, Gzip} {
+		require.True(t, Supported(cc))
+		encoded, err := Encode(cc, data)
+		require.NoError(t, err)
+		decoded, err := Decode(cc, encoded)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+	require.False(t, Supported("brotli"))
+	_, err := Encode("brotli", data)
+	require.Error(t, err)
+}
+
+func TestEncodeWithLevel(t *testing.T) {
+	data := bytes.Repeat([]byte("ticdc compression level test data "), 128)
+	for _, level := range []int{DefaultLevel, MinZstdLevel, 3, 11, MaxZstdLevel} {
+		encoded, err := EncodeWithLevel(Zstd, level, data)
+		require.NoError(t, err)
+		require.Equal(t, Zstd, Detect(encoded))
+		decoded, err := Decode(Zstd, encoded)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+	for _, level := range []int{DefaultLevel, 1, 9} {
+		encoded, err := EncodeWithLevel(Gzip, level, data)
+		require.NoError(t, err)
+		require.Equal(t, Gzip, Detect(encoded))
+		decoded, err := Decode(Gzip, encoded)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+
+	_, err := EncodeWithLevel(Zstd, MaxZstdLevel+1, data)
+	require.Error(t, err)
+	_, err = EncodeWithLevel(Gzip, 10, data)
+	require.Error(t, err)
+	_, err = EncodeWithLevel(LZ4, 1, data)
+	require.Error(t, err)
+}
+
+func TestStream(t *testing.T) {
+	data := bytes.Repeat([]byte("ticdc stream compression test data "), 128)
+	for _, cc := range []string{LZ4, Zstd, Gzip} {
+		var buf bytes.Buffer
+		writer, err := NewWriter(cc, DefaultLevel, &buf)
+		require.NoError(t, err)
+		_, err = writer.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		require.Equal(t, cc, Detect(buf.Bytes()))
+
+		reader, err := NewReader(cc, &buf)
+		require.NoError(t, err)
+		decoded, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		require.Equal(t, data, decoded)
+	}
+	_, err := NewWriter(Snappy, DefaultLevel, io.Discard)
+	require.Error(t, err)
+	require.Equal(t, None, Detect(data))
+}
+
+func TestFileExtension(t *testing.T) {
+	require.Equal(t, "", FileExtension(None))
+	require.Equal(t, ".lz4", FileExtension(LZ4))
+	require.Equal(t, ".zst", FileExtension(Zstd))
+	require.Equal(t, ".gz", FileExtension(Gzip))
+}
@@ -458,11 +458,12 @@ func (info *ChangeFeedInfo) RmUnusedFields() {
 		return
 	}
 	if !IsMQScheme(uri.Scheme) {
-		info.rmMQOnlyFields()
+		info.rmMQOnlyFields(uri.Scheme)
 	} else {
 		// remove schema registry for MQ downstream with
-		// protocol other than avro
-		if util.GetOrZero(info.Config.Sink.Protocol) != ProtocolAvro.String() {
+		// protocol other than avro and protobuf
+		protocol := util.GetOrZero(info.Config.Sink.Protocol)
+		if protocol != ProtocolAvro.String() && protocol != ProtocolProtobuf.String() {
 			info.Config.Sink.SchemaRegistry = nil
 		}
 	}
@@ -478,18 +479,29 @@ func (info *ChangeFeedInfo) RmUnusedFields() {
 		info.Config.Sink.Protocol = nil
 		info.Config.Sink.Terminator = nil
 	}
+	// the postgres sink writes the events by sql as the mysql sink does.
+	if IsPostgresScheme(uri.Scheme) {
+		info.Config.Sink.Protocol = nil
+		info.Config.Sink.Terminator = nil
+	}
 }
 
-func (info *ChangeFeedInfo) rmMQOnlyFields() {
+func (info *ChangeFeedInfo) rmMQOnlyFields(scheme string) {
 	log.Info("since the downstream is not a MQ, remove MQ only fields",
 		zap.String("keyspace", info.ChangefeedID.Keyspace()),
 		zap.String("changefeed", info.ChangefeedID.Name()))
-	info.Config.Sink.DispatchRules = nil
+	// the elasticsearch index is routed by the dispatch rules.
+	if !IsElasticsearchScheme(scheme) {
+		info.Config.Sink.DispatchRules = nil
+	}
 	info.Config.Sink.SchemaRegistry = nil
 	info.Config.Sink.EncoderConcurrency = nil
-	info.Config.Sink.OnlyOutputUpdatedColumns = nil
-	info.Config.Sink.DeleteOnlyOutputHandleKeyColumns = nil
-	info.Config.Sink.ContentCompatible = nil
+	// the webhook encodes the events by the row encoders as the MQ does.
+	if !IsWebhookScheme(scheme) {
+		info.Config.Sink.OnlyOutputUpdatedColumns = nil
+		info.Config.Sink.DeleteOnlyOutputHandleKeyColumns = nil
+		info.Config.Sink.ContentCompatible = nil
+	}
 	info.Config.Sink.KafkaConfig = nil
 }
 
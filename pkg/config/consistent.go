@@ -54,8 +54,11 @@ type ConsistentConfig struct {
 	UseFileBackend bool `toml:"use-file-backend" json:"use-file-backend"`
 	// Compression is the compression algorithm used for redo log.
 	// Default is "", it means no compression, equals to `none`.
-	// Supported compression algorithms are `none` and `lz4`.
+	// Supported compression algorithms are `none`, `lz4`, `zstd` and `gzip`.
 	Compression string `toml:"compression" json:"compression"`
+	// CompressionLevel is the compression level, only `zstd` and `gzip` support it.
+	// Default is 0, it means the default level of the compression algorithm.
+	CompressionLevel int `toml:"compression-level" json:"compression-level,omitempty"`
 	// FlushConcurrency is the concurrency of flushing a single log file.
 	// Default is 1. It means a single log file will be flushed by only one worker.
 	// The singe file concurrent flushing feature supports only `s3` storage.
@@ -98,10 +101,17 @@ func (c *ConsistentConfig) ValidateAndAdjust() error {
 			fmt.Sprintf("The consistent.meta-flush-interval:%d must be equal or greater than %d",
 				c.MetaFlushIntervalInMs, redo.MinFlushIntervalInMs))
 	}
-	if len(c.Compression) > 0 &&
-		c.Compression != compression.None && c.Compression != compression.LZ4 {
+	switch c.Compression {
+	case "", compression.None, compression.LZ4, compression.Zstd, compression.Gzip:
+	default:
 		return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
-			fmt.Sprintf("The consistent.compression:%s must be 'none' or 'lz4'", c.Compression))
+			fmt.Sprintf("The consistent.compression:%s must be 'none', 'lz4', 'zstd' or 'gzip'", c.Compression))
+	}
+	if c.CompressionLevel != compression.DefaultLevel {
+		if err := compression.ValidateLevel(c.Compression, c.CompressionLevel); err != nil {
+			return cerror.ErrInvalidReplicaConfig.FastGenByArgs(
+				fmt.Sprintf("The consistent.compression-level:%d is invalid, %s", c.CompressionLevel, err.Error()))
+		}
 	}
 
 	if c.EncodingWorkerNum == 0 {
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
 )
 
 // DebugConfig represents config for ticdc unexposed feature configurations
@@ -52,6 +53,9 @@ func (c *DebugConfig) ValidateAndAdjust() error {
 	if c.EventStore == nil {
 		c.EventStore = NewDefaultEventStoreConfig()
 	}
+	if err := c.EventStore.ValidateAndAdjust(); err != nil {
+		return errors.Trace(err)
+	}
 
 	return nil
 }
@@ -84,13 +88,40 @@ func NewDefaultPullerConfig() *PullerConfig {
 
 type EventStoreConfig struct {
 	CompressionThreshold int `toml:"compression-threshold" json:"compression_threshold"`
+
+	// DiskQuota is the upper bound of the disk usage of the event store in bytes, 0 means no limit.
+	DiskQuota uint64 `toml:"disk-quota" json:"disk_quota"`
+	// DiskQuotaSoftWatermark is the ratio of the disk quota above which the event store compacts
+	// the deleted data and warns the changefeeds which retain the most data.
+	DiskQuotaSoftWatermark float64 `toml:"disk-quota-soft-watermark" json:"disk_quota_soft_watermark"`
+	// DiskQuotaHardWatermark is the ratio of the disk quota above which the event store throttles
+	// the ingestion of the subscriptions which retain the most data and evicts the data of the largest
+	// one, the changefeed of the evicted data is reported with an error. No more data is evicted until
+	// the evicted data is deleted.
+	DiskQuotaHardWatermark float64 `toml:"disk-quota-hard-watermark" json:"disk_quota_hard_watermark"`
 }
 
 // NewDefaultEventStoreConfig returns the default event store configuration.
 func NewDefaultEventStoreConfig() *EventStoreConfig {
 	return &EventStoreConfig{
-		CompressionThreshold: 4096, // 4KB
+		CompressionThreshold:   4096, // 4KB
+		DiskQuota:              0,
+		DiskQuotaSoftWatermark: 0.8,
+		DiskQuotaHardWatermark: 0.95,
+	}
+}
+
+// ValidateAndAdjust validates the event store configuration.
+func (c *EventStoreConfig) ValidateAndAdjust() error {
+	if c.DiskQuota == 0 {
+		return nil
 	}
+	if c.DiskQuotaSoftWatermark <= 0 || c.DiskQuotaSoftWatermark >= c.DiskQuotaHardWatermark ||
+		c.DiskQuotaHardWatermark > 1 {
+		return cerror.ErrInvalidServerOption.GenWithStackByArgs(
+			"event-store disk-quota-soft-watermark and disk-quota-hard-watermark must satisfy 0 < soft < hard <= 1")
+	}
+	return nil
 }
 
 // SchemaStoreConfig represents config for schema store
@@ -0,0 +1,37 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStoreConfigValidateAndAdjust(t *testing.T) {
+	cfg := NewDefaultEventStoreConfig()
+	require.NoError(t, cfg.ValidateAndAdjust())
+
+	// the watermarks are not checked if the disk quota is disabled.
+	cfg.DiskQuotaSoftWatermark = 2
+	require.NoError(t, cfg.ValidateAndAdjust())
+
+	cfg.DiskQuota = 1 << 30
+	for _, watermarks := range [][2]float64{{0, 0.9}, {0.9, 0.8}, {0.8, 0.8}, {0.8, 1.1}} {
+		cfg.DiskQuotaSoftWatermark, cfg.DiskQuotaHardWatermark = watermarks[0], watermarks[1]
+		require.Error(t, cfg.ValidateAndAdjust())
+	}
+	cfg.DiskQuotaSoftWatermark, cfg.DiskQuotaHardWatermark = 0.5, 1
+	require.NoError(t, cfg.ValidateAndAdjust())
+}
@@ -22,6 +22,10 @@ type FilterConfig struct {
 	Rules            []string           `toml:"rules" json:"rules"`
 	IgnoreTxnStartTs []uint64           `toml:"ignore-txn-start-ts" json:"ignore-txn-start-ts"`
 	EventFilters     []*EventFilterRule `toml:"event-filters" json:"event-filters"`
+	// OnlineDDL recognizes the ghost tables of the online schema change tools such as
+	// gh-ost and pt-osc, their DMLs are skipped and the cut-over is replicated as the
+	// equivalent ALTER TABLE on the original table.
+	OnlineDDL bool `toml:"online-ddl" json:"online-ddl"`
 }
 
 func NewDefaultFilterConfig() *FilterConfig {
@@ -316,6 +316,11 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 				"integrity check enabled and column selector set, not allowed")
 
 		}
+		if c.Integrity.Enabled() && len(c.Sink.ColumnTransformers) != 0 {
+			log.Error("it's not allowed to enable the integrity check and column transformer at the same time")
+			return cerror.ErrInvalidReplicaConfig.GenWithStack(
+				"integrity check enabled and column transformer set, not allowed")
+		}
 	}
 
 	if c.ChangefeedErrorStuckDuration != nil &&
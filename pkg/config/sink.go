@@ -142,7 +142,12 @@ type SinkConfig struct {
 	DispatchRules []*DispatchRule `toml:"dispatchers" json:"dispatchers,omitempty"`
 
 	ColumnSelectors []*ColumnSelector `toml:"column-selectors" json:"column-selectors,omitempty"`
-	// SchemaRegistry is only available when the downstream is MQ using avro protocol.
+	// ColumnTransformers is available for all kinds of downstream, the matched column values
+	// are transformed before they are written to the downstream.
+	ColumnTransformers []*ColumnTransformer `toml:"column-transformers" json:"column-transformers,omitempty"`
+	// MetadataColumns is only available when the downstream is MySQL compatible or cloud storage.
+	MetadataColumns *MetadataColumnsConfig `toml:"metadata-columns" json:"metadata-columns,omitempty"`
+	// SchemaRegistry is only available when the downstream is MQ using avro or protobuf protocol.
 	SchemaRegistry *string `toml:"schema-registry" json:"schema-registry,omitempty"`
 	// EncoderConcurrency is only available when the downstream is MQ.
 	EncoderConcurrency *int `toml:"encoder-concurrency" json:"encoder-concurrency,omitempty"`
@@ -408,6 +413,56 @@ type ColumnSelector struct {
 	Columns []string `toml:"columns" json:"columns"`
 }
 
+// ColumnTransformer represents a transformation rule for the columns of the matched tables.
+type ColumnTransformer struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	Columns []string `toml:"columns" json:"columns"`
+	// Action is one of `hash`, `redact`, `truncate`, `constant` and `null`.
+	Action string `toml:"action" json:"action"`
+	// Salt is prepended to the value before it is hashed by SHA-256, only used by `hash`.
+	Salt string `toml:"salt" json:"salt,omitempty"`
+	// Length is the number of characters kept by `truncate`.
+	Length int `toml:"length" json:"length,omitempty"`
+	// Value is the value set by `constant`.
+	Value string `toml:"value" json:"value,omitempty"`
+}
+
+// MetadataColumnsConfig represents the metadata columns appended to the rows by the sink,
+// the column is not appended if its name is empty.
+type MetadataColumnsConfig struct {
+	// CommitTs is the name of the column which stores the commit ts of the row change.
+	CommitTs *string `toml:"commit-ts" json:"commit-ts,omitempty"`
+	// Op is the name of the column which stores the type of the row change,
+	// which is `insert`, `update` or `delete`.
+	Op *string `toml:"op" json:"op,omitempty"`
+	// SourceChangefeed is the name of the column which stores the changefeed ID.
+	SourceChangefeed *string `toml:"source-changefeed" json:"source-changefeed,omitempty"`
+	// AppendOnly turns the updates and deletes into inserts, which is used to write history tables.
+	// The update is written as its post-image and the delete is written as its pre-image.
+	AppendOnly *bool `toml:"append-only" json:"append-only,omitempty"`
+}
+
+// Validate checks the metadata columns config.
+func (c *MetadataColumnsConfig) Validate() error {
+	names := make(map[string]struct{})
+	for _, name := range []*string{c.CommitTs, c.Op, c.SourceChangefeed} {
+		if util.GetOrZero(name) == "" {
+			continue
+		}
+		lower := strings.ToLower(*name)
+		if _, ok := names[lower]; ok {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"duplicated metadata column name %s", *name)
+		}
+		names[lower] = struct{}{}
+	}
+	if util.GetOrZero(c.AppendOnly) && util.GetOrZero(c.Op) == "" {
+		return cerror.ErrSinkInvalidConfig.GenWithStack(
+			"the op metadata column is required by the append-only mode")
+	}
+	return nil
+}
+
 // CodecConfig represents a MQ codec configuration
 type CodecConfig struct {
 	EnableTiDBExtension            *bool   `toml:"enable-tidb-extension" json:"enable-tidb-extension,omitempty"`
@@ -686,6 +741,88 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `toml:"enable-batch-dml" json:"enable-batch-dml,omitempty"`
 	EnableMultiStatement         *bool   `toml:"enable-multi-statement" json:"enable-multi-statement,omitempty"`
 	EnableCachePreparedStatement *bool   `toml:"enable-cache-prepared-statement" json:"enable-cache-prepared-statement,omitempty"`
+
+	// RoutingRules routes the upstream tables to the downstream tables with different names,
+	// the first matched rule is used, and the tables matched by no rule keep their names.
+	RoutingRules []*RoutingRule `toml:"routing-rules" json:"routing-rules,omitempty"`
+	// SharedTargetDDLPolicy decides how to handle the ddl which drops or truncates a downstream table
+	// shared by several upstream tables, it can be `skip`, `execute` or `fail`, the default value is `skip`.
+	SharedTargetDDLPolicy *string `toml:"shared-target-ddl-policy" json:"shared-target-ddl-policy,omitempty"`
+
+	// ErrorPolicy decides how to handle the rows which can not be written to the downstream
+	// after retrying, it can be `fail` or `dead-letter`, the default value is `fail`.
+	ErrorPolicy *string `toml:"error-policy" json:"error-policy,omitempty"`
+	// DeadLetter is the destination of the rows skipped by the `dead-letter` error policy.
+	DeadLetter *DeadLetterConfig `toml:"dead-letter" json:"dead-letter,omitempty"`
+
+	// ConflictResolution resolves the conflicts of the concurrent writes to the same row
+	// on both clusters, it's only available in BDR mode.
+	ConflictResolution *ConflictResolutionConfig `toml:"conflict-resolution" json:"conflict-resolution,omitempty"`
+
+	// CatchUp enables the catch-up mode, in which the row changes are coalesced and written
+	// in large batches when the changefeed falls far behind.
+	CatchUp *CatchUpConfig `toml:"catch-up" json:"catch-up,omitempty"`
+
+	// AdaptiveConcurrency adjusts the active worker count at runtime by the feedback of the downstream.
+	AdaptiveConcurrency *AdaptiveConcurrencyConfig `toml:"adaptive-concurrency" json:"adaptive-concurrency,omitempty"`
+}
+
+// AdaptiveConcurrencyConfig represents the adaptive worker concurrency of the mysql sink. The worker
+// count starts from WorkerCount, and is decreased when the downstream is slow or returns errors,
+// increased when the transactions are backlogged, within the bounds of the min and max worker count.
+// The connections to the downstream are limited to the active worker count as well.
+type AdaptiveConcurrencyConfig struct {
+	Enable         *bool `toml:"enable" json:"enable,omitempty"`
+	MinWorkerCount *int  `toml:"min-worker-count" json:"min-worker-count,omitempty"`
+	MaxWorkerCount *int  `toml:"max-worker-count" json:"max-worker-count,omitempty"`
+	// TargetLatency is the flush latency above which the worker count is decreased, e.g. `1s`.
+	TargetLatency *string `toml:"target-latency" json:"target-latency,omitempty"`
+}
+
+// CatchUpConfig represents the catch-up mode of the mysql sink. In the catch-up mode, only the
+// final image of every row is written by the multi-row REPLACE and DELETE statements, so the
+// upstream transactions are not atomic in the downstream until the sink leaves the mode.
+// LOAD DATA LOCAL INFILE is not supported in the catch-up mode.
+type CatchUpConfig struct {
+	// LagThreshold is the lag above which the sink enters the catch-up mode, e.g. `10m`.
+	// The sink leaves the mode once the lag drops below half of it.
+	LagThreshold *string `toml:"lag-threshold" json:"lag-threshold,omitempty"`
+	// MaxTxnRow is the max number of rows written in a transaction in the catch-up mode.
+	MaxTxnRow *int `toml:"max-txn-row" json:"max-txn-row,omitempty"`
+}
+
+// ConflictResolutionConfig represents the policy to resolve the conflicts in BDR mode. It can not
+// be used with the dead-letter error policy or the catch-up mode. The rows replayed after a restart
+// are resolved by the policy, but they are not recorded in the conflict log.
+type ConflictResolutionConfig struct {
+	// Policy can be `last-writer-wins`, `upstream-wins` or `log-and-skip`.
+	Policy *string `toml:"policy" json:"policy,omitempty"`
+	// CompareBy decides what is compared by the `last-writer-wins` policy, it can be
+	// `commit-ts` or `timestamp-column`, the default value is `commit-ts`. The commit-ts
+	// is kept in the commit-ts metadata column, which must be set in the metadata columns.
+	// The rows written by the applications on the downstream do not update the metadata
+	// column, and the commit-ts of the two clusters come from different PDs, so use
+	// `timestamp-column` if the applications write both clusters.
+	CompareBy *string `toml:"compare-by" json:"compare-by,omitempty"`
+	// TimestampColumn is the column compared by the `last-writer-wins` policy if CompareBy
+	// is `timestamp-column`, it's never written by the sink.
+	TimestampColumn *string `toml:"timestamp-column" json:"timestamp-column,omitempty"`
+}
+
+// DeadLetterConfig represents the destination of the dead-letter rows, the rows are written to
+// the downstream table by default, or to the files in the storage if StorageURI is set.
+type DeadLetterConfig struct {
+	Table      *string `toml:"table" json:"table,omitempty"`
+	StorageURI *string `toml:"storage-uri" json:"storage-uri,omitempty"`
+}
+
+// RoutingRule routes the tables matched by the matcher to the target schema and table.
+// The target can contain the placeholders `{schema}` and `{table}`, which are replaced
+// by the upstream schema and table name, an empty target keeps the upstream name.
+type RoutingRule struct {
+	Matcher      []string `toml:"matcher" json:"matcher"`
+	TargetSchema string   `toml:"target-schema" json:"target-schema"`
+	TargetTable  string   `toml:"target-table" json:"target-table"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -701,6 +838,12 @@ type CloudStorageConfig struct {
 
 	// OutputRawChangeEvent controls whether to split the update pk/uk events.
 	OutputRawChangeEvent *bool `toml:"output-raw-change-event" json:"output-raw-change-event,omitempty"`
+
+	// Compression is the compression algorithm of the data files, it can be `none`, `lz4`, `zstd` or `gzip`.
+	// The extension of the compression is appended to the data file name.
+	Compression *string `toml:"compression" json:"compression,omitempty"`
+	// CompressionLevel is the compression level, only `zstd` and `gzip` support it.
+	CompressionLevel *int `toml:"compression-level" json:"compression-level,omitempty"`
 }
 
 // GetOutputRawChangeEvent returns the value of OutputRawChangeEvent
@@ -716,6 +859,16 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 		return err
 	}
 
+	if s.MetadataColumns != nil {
+		if !IsMySQLCompatibleScheme(sinkURI.Scheme) && !IsStorageScheme(sinkURI.Scheme) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"metadata-columns is only available when the downstream is MySQL compatible or cloud storage")
+		}
+		if err := s.MetadataColumns.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if IsMySQLCompatibleScheme(sinkURI.Scheme) {
 		return nil
 	}
@@ -861,12 +1014,12 @@ func (s *SinkConfig) validateAndAdjustSinkURI(sinkURI *url.URL) error {
 		zap.String("txnAtomicity", string(util.GetOrZero(s.TxnAtomicity))))
 
 	// Check that protocol config is compatible with the scheme.
-	if IsMySQLCompatibleScheme(sinkURI.Scheme) && s.Protocol != nil {
+	if (IsMySQLCompatibleScheme(sinkURI.Scheme) || IsPostgresScheme(sinkURI.Scheme)) && s.Protocol != nil {
 		return cerror.ErrSinkURIInvalid.GenWithStackByArgs(fmt.Sprintf("protocol %s "+
 			"is incompatible with %s scheme", util.GetOrZero(s.Protocol), sinkURI.Scheme))
 	}
 	// For testing purposes, any protocol should be legal for blackhole.
-	if IsMQScheme(sinkURI.Scheme) || IsStorageScheme(sinkURI.Scheme) {
+	if IsMQScheme(sinkURI.Scheme) || IsStorageScheme(sinkURI.Scheme) || IsWebhookScheme(sinkURI.Scheme) {
 		return s.ValidateProtocol(sinkURI.Scheme)
 	}
 	return nil
@@ -892,7 +1045,7 @@ func (s *SinkConfig) ValidateProtocol(scheme string) error {
 		if s.CSVConfig != nil {
 			outputOldValue = s.CSVConfig.OutputOldValue
 		}
-	case ProtocolAvro:
+	case ProtocolAvro, ProtocolProtobuf:
 		outputOldValue = false
 	default:
 		return nil
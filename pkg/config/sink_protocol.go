@@ -41,6 +41,8 @@ const (
 	ProtocolCsv
 	ProtocolDebezium
 	ProtocolSimple
+	ProtocolParquet
+	ProtocolProtobuf
 )
 
 // IsBatchEncode returns whether the protocol is a batch encoder.
@@ -48,6 +50,12 @@ func (p Protocol) IsBatchEncode() bool {
 	return p == ProtocolOpen || p == ProtocolCanal || p == ProtocolMaxwell || p == ProtocolCraft
 }
 
+// IsSchemaRegistryBased returns whether the protocol registers the schema of each topic
+// to the schema registry, such protocols require the topic to be dispatched by table.
+func (p Protocol) IsSchemaRegistryBased() bool {
+	return p == ProtocolAvro || p == ProtocolProtobuf
+}
+
 // ParseSinkProtocolFromString converts the protocol from string to Protocol enum type.
 func ParseSinkProtocolFromString(protocol string) (Protocol, error) {
 	switch strings.ToLower(protocol) {
@@ -73,6 +81,10 @@ func ParseSinkProtocolFromString(protocol string) (Protocol, error) {
 		return ProtocolDebezium, nil
 	case "simple":
 		return ProtocolSimple, nil
+	case "parquet":
+		return ProtocolParquet, nil
+	case "protobuf":
+		return ProtocolProtobuf, nil
 	default:
 		return ProtocolUnknown, errors.ErrSinkUnknownProtocol.GenWithStackByArgs(protocol)
 	}
@@ -101,6 +113,10 @@ func (p Protocol) String() string {
 		return "debezium"
 	case ProtocolSimple:
 		return "simple"
+	case ProtocolParquet:
+		return "parquet"
+	case ProtocolProtobuf:
+		return "protobuf"
 	default:
 		panic("unreachable")
 	}
@@ -143,6 +159,18 @@ const (
 	PulsarHTTPScheme = "pulsar+http"
 	// PulsarHTTPSScheme indicates the schema is pulsar with https protocol
 	PulsarHTTPSScheme = "pulsar+https"
+	// ElasticsearchScheme indicates the scheme is elasticsearch, OpenSearch is also supported.
+	ElasticsearchScheme = "elasticsearch"
+	// ElasticsearchSSLScheme indicates the scheme is elasticsearch with https protocol
+	ElasticsearchSSLScheme = "elasticsearch+ssl"
+	// HTTPScheme indicates the scheme is http, the events are posted to the webhook.
+	HTTPScheme = "http"
+	// HTTPSScheme indicates the scheme is https, the events are posted to the webhook.
+	HTTPSScheme = "https"
+	// PostgresScheme indicates the scheme is PostgreSQL.
+	PostgresScheme = "postgres"
+	// PostgreSQLScheme is an alias for "postgres".
+	PostgreSQLScheme = "postgresql"
 )
 
 // IsMQScheme returns true if the scheme belong to mq scheme.
@@ -168,6 +196,21 @@ func IsPulsarScheme(scheme string) bool {
 	return scheme == PulsarScheme || scheme == PulsarSSLScheme || scheme == PulsarHTTPScheme || scheme == PulsarHTTPSScheme
 }
 
+// IsElasticsearchScheme returns true if the scheme belong to elasticsearch scheme.
+func IsElasticsearchScheme(scheme string) bool {
+	return scheme == ElasticsearchScheme || scheme == ElasticsearchSSLScheme
+}
+
+// IsWebhookScheme returns true if the scheme belong to webhook scheme.
+func IsWebhookScheme(scheme string) bool {
+	return scheme == HTTPScheme || scheme == HTTPSScheme
+}
+
+// IsPostgresScheme returns true if the scheme belong to postgres scheme.
+func IsPostgresScheme(scheme string) bool {
+	return scheme == PostgresScheme || scheme == PostgreSQLScheme
+}
+
 // IsBlackHoleScheme returns true if the scheme belong to blackhole scheme.
 func IsBlackHoleScheme(scheme string) bool {
 	return scheme == BlackHoleScheme
@@ -180,5 +223,5 @@ func GetScheme(url *url.URL) string {
 
 // IsPulsarSupportedProtocols returns whether the protocol is supported by pulsar.
 func IsPulsarSupportedProtocols(p Protocol) bool {
-	return p == ProtocolCanalJSON
+	return p == ProtocolCanalJSON || p == ProtocolMaxwell
 }
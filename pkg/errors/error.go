@@ -89,11 +89,20 @@ var (
 		"dispatcher failed",
 		errors.RFCCodeText("CDC:ErrDispatcherFailed"),
 	)
+	ErrEventStoreDiskQuotaExceeded = errors.Normalize(
+		"the data of dispatcher %s is evicted by the disk quota of the event store on %s, "+
+			"the changefeed is too slow to consume the data",
+		errors.RFCCodeText("CDC:ErrEventStoreDiskQuotaExceeded"),
+	)
 
 	ErrColumnSelectorFailed = errors.Normalize(
 		"column selector failed",
 		errors.RFCCodeText("CDC:ErrColumnSelectorFailed"),
 	)
+	ErrColumnTransformerFailed = errors.Normalize(
+		"column transformer failed",
+		errors.RFCCodeText("CDC:ErrColumnTransformerFailed"),
+	)
 
 	// Errors caused by unexpected behavior from external systems
 	ErrTiDBUnexpectedJobMeta = errors.Normalize(
@@ -249,6 +258,22 @@ var (
 		"canal encode failed",
 		errors.RFCCodeText("CDC:ErrCanalEncodeFailed"),
 	)
+	ErrMaxwellEncodeFailed = errors.Normalize(
+		"maxwell encode failed",
+		errors.RFCCodeText("CDC:ErrMaxwellEncodeFailed"),
+	)
+	ErrCraftCodecInvalidData = errors.Normalize(
+		"craft codec invalid data",
+		errors.RFCCodeText("CDC:ErrCraftCodecInvalidData"),
+	)
+	ErrProtobufEncodeFailed = errors.Normalize(
+		"protobuf encode failed",
+		errors.RFCCodeText("CDC:ErrProtobufEncodeFailed"),
+	)
+	ErrProtobufInvalidMessage = errors.Normalize(
+		"protobuf invalid message format, %s",
+		errors.RFCCodeText("CDC:ErrProtobufInvalidMessage"),
+	)
 	ErrSinkInvalidConfig = errors.Normalize(
 		"sink config invalid",
 		errors.RFCCodeText("CDC:ErrSinkInvalidConfig"),
@@ -269,6 +294,10 @@ var (
 		"csv decode failed",
 		errors.RFCCodeText("CDC:ErrCSVDecodeFailed"),
 	)
+	ErrParquetEncodeFailed = errors.Normalize(
+		"parquet encode failed",
+		errors.RFCCodeText("CDC:ErrParquetEncodeFailed"),
+	)
 	ErrDebeziumEncodeFailed = errors.Normalize(
 		"debezium encode failed",
 		errors.RFCCodeText("CDC:ErrDebeziumEncodeFailed"),
@@ -289,6 +318,50 @@ var (
 		"filename in storage sink is invalid",
 		errors.RFCCodeText("CDC:ErrStorageSinkInvalidFileName"),
 	)
+	ErrElasticsearchInvalidConfig = errors.Normalize(
+		"elasticsearch sink config invalid",
+		errors.RFCCodeText("CDC:ErrElasticsearchInvalidConfig"),
+	)
+	ErrElasticsearchRequestFailed = errors.Normalize(
+		"elasticsearch request failed",
+		errors.RFCCodeText("CDC:ErrElasticsearchRequestFailed"),
+	)
+	ErrElasticsearchRequestRejected = errors.Normalize(
+		"elasticsearch request is rejected: %s",
+		errors.RFCCodeText("CDC:ErrElasticsearchRequestRejected"),
+	)
+	ErrElasticsearchBulkFailed = errors.Normalize(
+		"elasticsearch bulk request has failed items: %s",
+		errors.RFCCodeText("CDC:ErrElasticsearchBulkFailed"),
+	)
+	ErrElasticsearchNoHandleKey = errors.Normalize(
+		"table %s has no primary key or not null unique key, it can not be written to elasticsearch",
+		errors.RFCCodeText("CDC:ErrElasticsearchNoHandleKey"),
+	)
+	ErrWebhookInvalidConfig = errors.Normalize(
+		"webhook sink config invalid",
+		errors.RFCCodeText("CDC:ErrWebhookInvalidConfig"),
+	)
+	ErrWebhookRequestFailed = errors.Normalize(
+		"webhook request failed",
+		errors.RFCCodeText("CDC:ErrWebhookRequestFailed"),
+	)
+	ErrWebhookRequestRejected = errors.Normalize(
+		"webhook request is rejected: %s",
+		errors.RFCCodeText("CDC:ErrWebhookRequestRejected"),
+	)
+	ErrPostgresInvalidConfig = errors.Normalize(
+		"postgres sink config invalid",
+		errors.RFCCodeText("CDC:ErrPostgresInvalidConfig"),
+	)
+	ErrPostgresTxnError = errors.Normalize(
+		"postgres txn error",
+		errors.RFCCodeText("CDC:ErrPostgresTxnError"),
+	)
+	ErrPostgresUnsupportedDDL = errors.Normalize(
+		"ddl %s is not supported by postgres sink",
+		errors.RFCCodeText("CDC:ErrPostgresUnsupportedDDL"),
+	)
 
 	// utilities related errors
 	ErrToTLSConfigFailed = errors.Normalize(
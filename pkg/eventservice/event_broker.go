@@ -829,6 +829,7 @@ func (c *eventBroker) reportDispatcherStatToStore(ctx context.Context, tickInter
 			return context.Cause(ctx)
 		case <-ticker.C:
 			inActiveDispatchers := make([]*dispatcherStat, 0)
+			evictedDispatchers := make([]*dispatcherStat, 0)
 			c.dispatchers.Range(func(key, value interface{}) bool {
 				dispatcher := value.(*atomic.Pointer[dispatcherStat]).Load()
 				checkpointTs := dispatcher.checkpointTs.Load()
@@ -837,6 +838,8 @@ func (c *eventBroker) reportDispatcherStatToStore(ctx context.Context, tickInter
 				}
 				if isInactiveDispatcher(dispatcher) {
 					inActiveDispatchers = append(inActiveDispatchers, dispatcher)
+				} else if c.eventStore.IsDispatcherEvicted(dispatcher.id) {
+					evictedDispatchers = append(evictedDispatchers, dispatcher)
 				}
 				return true
 			})
@@ -855,6 +858,16 @@ func (c *eventBroker) reportDispatcherStatToStore(ctx context.Context, tickInter
 					zap.Stringer("dispatcherID", d.id), zap.Time("lastReceivedHeartbeatTime", time.Unix(d.lastReceivedHeartbeatTime.Load(), 0)))
 				c.removeDispatcher(d.info)
 			}
+
+			// the event collector is notified by the heartbeat response to report the error to the changefeed,
+			// the dispatcher can't be registered again until its data is dropped from the event store.
+			for _, d := range evictedDispatchers {
+				log.Warn("remove dispatcher evicted by the disk quota of event store, "+
+					"the changefeed may be too slow or stuck",
+					zap.Stringer("changefeedID", d.changefeedStat.changefeedID),
+					zap.Stringer("dispatcherID", d.id), zap.Uint64("checkpointTs", d.checkpointTs.Load()))
+				c.removeDispatcher(d.info)
+			}
 		}
 	}
 }
@@ -910,12 +923,23 @@ func (c *eventBroker) getDispatcher(id common.DispatcherID) *atomic.Pointer[disp
 }
 
 func (c *eventBroker) addDispatcher(info DispatcherInfo) error {
-	defer c.metricsCollector.metricDispatcherCount.Inc()
-
 	id := info.GetID()
 	span := info.GetTableSpan()
 	changefeedID := info.GetChangefeedID()
 
+	// The evicted dispatcher is kept out until its data is dropped from the event store,
+	// otherwise it pulls the same data from the upstream again and fills the disk soon.
+	// The event collector is notified by the heartbeat response.
+	if c.eventStore.IsDispatcherEvicted(id) {
+		log.Warn("ignore the register request of the dispatcher evicted by the disk quota of event store",
+			zap.Stringer("changefeedID", changefeedID),
+			zap.Stringer("dispatcherID", id),
+			zap.String("span", common.FormatTableSpan(span)),
+			zap.Uint64("startTs", info.GetStartTs()))
+		return nil
+	}
+	defer c.metricsCollector.metricDispatcherCount.Inc()
+
 	status := c.getOrSetChangefeedStatus(changefeedID)
 	dispatcher := newDispatcherStat(info, uint64(len(c.taskChan)), uint64(len(c.messageCh)), nil, status)
 	dispatcherPtr := &atomic.Pointer[dispatcherStat]{}
@@ -1149,7 +1173,14 @@ func (c *eventBroker) handleDispatcherHeartbeat(heartbeat *DispatcherHeartBeatWi
 				response = event.NewDispatcherHeartbeatResponse()
 				responseMap[heartbeat.serverID] = response
 			}
-			response.Append(event.NewDispatcherState(dp.DispatcherID, event.DSStateRemoved))
+			// The evicted dispatcher is replied DSStateEvicted instead of DSStateRemoved until
+			// its data is dropped, so the event collector does not register it again in vain,
+			// the event collectors of the older versions ignore the state.
+			state := event.DSStateRemoved
+			if c.eventStore.IsDispatcherEvicted(dp.DispatcherID) {
+				state = event.DSStateEvicted
+			}
+			response.Append(event.NewDispatcherState(dp.DispatcherID, state))
 			continue
 		}
 		dispatcher := dispatcherPtr.Load()
@@ -418,6 +418,67 @@ func TestHandleDispatcherHeartbeat_InactiveDispatcherCleanup(t *testing.T) {
 	}
 }
 
+func TestHandleDispatcherHeartbeat_EvictedDispatcher(t *testing.T) {
+	broker, es, _, outputCh := newEventBrokerForTest()
+	defer broker.close()
+
+	dispInfo := newMockDispatcherInfoForTest(t)
+	require.NoError(t, broker.addDispatcher(dispInfo))
+	dispatcher := broker.getDispatcher(dispInfo.GetID()).Load()
+	dispatcher.setHandshaked()
+	dispatcher.lastReceivedHeartbeatTime.Store(time.Now().Unix())
+
+	// the dispatcher evicted by the event store is removed.
+	es.evictedDispatchers.Store(dispInfo.GetID(), struct{}{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go broker.reportDispatcherStatToStore(ctx, time.Millisecond)
+	require.Eventually(t, func() bool {
+		return broker.getDispatcher(dispInfo.GetID()) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the event collector is notified that the dispatcher is evicted.
+	heartbeat := &DispatcherHeartBeatWithServerID{
+		serverID: "test-server-1",
+		heartbeat: &event.DispatcherHeartbeat{
+			Version:         event.DispatcherHeartbeatVersion1,
+			DispatcherCount: 1,
+			DispatcherProgresses: []event.DispatcherProgress{
+				{DispatcherID: dispInfo.GetID(), CheckpointTs: 200},
+			},
+		},
+	}
+	requireHeartbeatResponse := func(state event.DSState) {
+		broker.handleDispatcherHeartbeat(heartbeat)
+		select {
+		case msg := <-outputCh:
+			require.Equal(t, messaging.TypeDispatcherHeartbeatResponse, msg.Type)
+			states := msg.Message[0].(*event.DispatcherHeartbeatResponse).DispatcherStates
+			require.Len(t, states, 1)
+			require.Equal(t, dispInfo.GetID(), states[0].DispatcherID)
+			require.Equal(t, state, states[0].State)
+		case <-ctx.Done():
+			require.Fail(t, "Expected to receive a dispatcher heartbeat response")
+		}
+	}
+	requireHeartbeatResponse(event.DSStateEvicted)
+
+	// the evicted dispatcher is kept out until its data is dropped from the event store,
+	// and it's still replied as evicted, so the event collector does not register it again in vain.
+	require.NoError(t, broker.addDispatcher(dispInfo))
+	require.Nil(t, broker.getDispatcher(dispInfo.GetID()))
+	_, ok := broker.changefeedMap.Load(dispInfo.GetChangefeedID())
+	require.False(t, ok)
+	requireHeartbeatResponse(event.DSStateEvicted)
+
+	// the dispatcher is replied as removed after its data is dropped, the event collectors
+	// of the older versions which ignore the evicted state register it again successfully.
+	es.evictedDispatchers.Delete(dispInfo.GetID())
+	requireHeartbeatResponse(event.DSStateRemoved)
+	require.NoError(t, broker.addDispatcher(dispInfo))
+	require.NotNil(t, broker.getDispatcher(dispInfo.GetID()))
+}
+
 // TestSendHandshakeIfNeedConcurrency tests the concurrent safety of sendHandshakeIfNeed method
 func TestSendHandshakeIfNeedConcurrency(t *testing.T) {
 	broker, _, _, outputCh := newEventBrokerForTest()
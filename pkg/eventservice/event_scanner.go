@@ -189,6 +189,8 @@ func (s *eventScanner) scanAndMergeEvents(
 	tableID := session.dataRange.Span.TableID
 	dispatcher := session.dispatcherStat
 	processor := newDMLProcessor(s.mounter, s.schemaGetter, dispatcher.filter, dispatcher.info.IsOutputRawChangeEvent())
+	// the redo log must record all the columns.
+	processor.projectColumns = common.IsDefaultMode(dispatcher.info.GetMode())
 
 	for {
 		shouldStop, err := s.checkScanConditions(session)
@@ -673,6 +675,52 @@ type dmlProcessor struct {
 
 	batchDML             *event.BatchDMLEvent
 	outputRawChangeEvent bool
+
+	// projectColumns indicates whether the columns which are not selected by the filter are
+	// set to NULL, so that their values are not sent to the dispatcher.
+	projectColumns bool
+	projection     *columnProjection
+}
+
+// columnProjection is the projection of the columns of a table.
+type columnProjection struct {
+	tableInfo *common.TableInfo
+	// selected is whether each column is selected, nil means all the columns are selected.
+	selected []bool
+	buf      *chunk.Chunk
+}
+
+// project sets the values of the columns which are not selected to NULL
+// for the rows appended to the DML event after the given offset.
+func (c *columnProjection) project(dml *event.DMLEvent, offset int) {
+	rows := dml.Rows
+	if c.selected == nil || rows.NumRows() <= offset {
+		return
+	}
+	fieldTypes := c.tableInfo.GetFieldSlice()
+	if c.buf == nil {
+		c.buf = chunk.NewChunkWithCapacity(fieldTypes, 2)
+	}
+	c.buf.Reset()
+	var projectedSize int64
+	for i := offset; i < rows.NumRows(); i++ {
+		row := rows.GetRow(i)
+		for j, ft := range fieldTypes {
+			if c.selected[j] {
+				d := row.GetDatum(j, ft)
+				c.buf.AppendDatum(j, &d)
+				continue
+			}
+			if !row.IsNull(j) {
+				projectedSize += int64(len(row.GetRaw(j)))
+			}
+			c.buf.AppendNull(j)
+		}
+	}
+	rows.TruncateTo(offset)
+	rows.Append(c.buf, 0, c.buf.NumRows())
+	// the size is estimated by the raw kv entry, so it's adjusted approximately.
+	dml.ApproximateSize = max(dml.ApproximateSize-projectedSize, 0)
 }
 
 // newDMLProcessor creates a new DML processor
@@ -690,6 +738,27 @@ func newDMLProcessor(
 	}
 }
 
+// appendToTxn appends the row to the current transaction, the columns which are
+// not selected are projected after the row is checked by the filter.
+func (p *dmlProcessor) appendToTxn(rawEvent *common.RawKVEntry) error {
+	if !p.projectColumns || p.filter == nil {
+		return p.currentTxn.AppendRow(rawEvent, p.mounter.DecodeToChunk, p.filter)
+	}
+	tableInfo := p.currentTxn.CurrentDMLEvent.TableInfo
+	if p.projection == nil || p.projection.tableInfo != tableInfo {
+		p.projection = &columnProjection{
+			tableInfo: tableInfo,
+			selected:  p.filter.SelectColumns(tableInfo),
+		}
+	}
+	offset := p.batchDML.Rows.NumRows()
+	if err := p.currentTxn.AppendRow(rawEvent, p.mounter.DecodeToChunk, p.filter); err != nil {
+		return err
+	}
+	p.projection.project(p.currentTxn.CurrentDMLEvent, offset)
+	return nil
+}
+
 // startTxn should be called after flush the current transaction
 func (p *dmlProcessor) startTxn(
 	dispatcherID common.DispatcherID,
@@ -710,7 +779,7 @@ func (p *dmlProcessor) startTxn(
 func (p *dmlProcessor) commitTxn() error {
 	if p.currentTxn != nil && len(p.insertRowCache) > 0 {
 		for _, insertRow := range p.insertRowCache {
-			if err := p.currentTxn.AppendRow(insertRow, p.mounter.DecodeToChunk, p.filter); err != nil {
+			if err := p.appendToTxn(insertRow); err != nil {
 				return err
 			}
 		}
@@ -753,7 +822,7 @@ func (p *dmlProcessor) appendRow(rawEvent *common.RawKVEntry) error {
 	rawEvent.Key = event.RemoveKeyspacePrefix(rawEvent.Key)
 
 	if !rawEvent.IsUpdate() {
-		return p.currentTxn.AppendRow(rawEvent, p.mounter.DecodeToChunk, p.filter)
+		return p.appendToTxn(rawEvent)
 	}
 
 	var (
@@ -768,7 +837,7 @@ func (p *dmlProcessor) appendRow(rawEvent *common.RawKVEntry) error {
 	}
 
 	if !shouldSplit {
-		return p.currentTxn.AppendRow(rawEvent, p.mounter.DecodeToChunk, p.filter)
+		return p.appendToTxn(rawEvent)
 	}
 
 	log.Debug("split update event", zap.Uint64("startTs", rawEvent.StartTs),
@@ -779,7 +848,7 @@ func (p *dmlProcessor) appendRow(rawEvent *common.RawKVEntry) error {
 		return err
 	}
 	p.insertRowCache = append(p.insertRowCache, insertRow)
-	return p.currentTxn.AppendRow(deleteRow, p.mounter.DecodeToChunk, p.filter)
+	return p.appendToTxn(deleteRow)
 }
 
 // getCurrentBatchDML returns the current batch DML event
@@ -20,10 +20,12 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/integrity"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/stretchr/testify/require"
@@ -1792,3 +1794,66 @@ func TestTxnEventSplit(t *testing.T) {
 		}
 	})
 }
+
+func TestDMLProcessorProjectColumns(t *testing.T) {
+	helper := event.NewEventTestHelper(t)
+	defer helper.Close()
+
+	ddlEvent, kvEvents := genEvents(helper, `create table test.t(id int primary key, a char(50), b char(50), c char(50), index idx_c(c))`, []string{
+		`insert into test.t(id,a,b,c) values (0, "a0", "b0", "c0")`,
+		`insert into test.t(id,a,b,c) values (1, "a1", "b1", "c1")`,
+	}...)
+	tableInfo := ddlEvent.TableInfo
+	tableID := ddlEvent.GetTableID()
+	insertSQL, updateSQL := "insert into test.t(id,a,b,c) values (2, 'a2', 'b2', 'c2')", "update test.t set a = 'a2_new', b = 'b2_new' where id = 2"
+	_, updateEvent := helper.DML2UpdateEvent("test", "t", insertSQL, updateSQL)
+
+	// the row predicate is evaluated before the columns are projected.
+	tableFilter, err := filter.GetSharedFilterStorage().GetOrSetFilter(common.NewChangefeedID4Test("default", t.Name()), &eventpb.FilterConfig{
+		FilterConfig: &eventpb.InnerFilterConfig{
+			Rules: []string{"*.*"},
+			EventFilters: []*eventpb.EventFilterRule{
+				{Matcher: []string{"test.t"}, IgnoreInsertValueExpr: "b = 'b1'"},
+			},
+		},
+		ColumnSelectors: []*eventpb.ColumnSelector{{Matcher: []string{"test.t"}, Columns: []string{"id", "a"}}},
+	}, "UTC")
+	require.NoError(t, err)
+
+	mounter := event.NewMounter(time.UTC, &integrity.Config{})
+	processor := newDMLProcessor(mounter, NewMockSchemaStore(), tableFilter, false)
+	processor.projectColumns = true
+	require.NoError(t, processor.startTxn(common.NewDispatcherID(), tableID, tableInfo, updateEvent.StartTs, updateEvent.CRTs, false))
+	for _, rawEvent := range append(kvEvents, updateEvent) {
+		require.NoError(t, processor.appendRow(rawEvent))
+	}
+	require.NoError(t, processor.commitTxn())
+
+	dml := processor.getCurrentBatchDML().DMLEvents[0]
+	require.Equal(t, int32(2), dml.Len())
+	row, ok := dml.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, common.RowTypeInsert, row.RowType)
+	require.Equal(t, int64(0), row.Row.GetInt64(0))
+	require.Equal(t, "a0", row.Row.GetString(1))
+	// the column b is not selected, but the index column c is always selected.
+	require.True(t, row.Row.IsNull(2))
+	require.Equal(t, "c0", row.Row.GetString(3))
+
+	row, ok = dml.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, common.RowTypeUpdate, row.RowType)
+	require.Equal(t, "a2", row.PreRow.GetString(1))
+	require.True(t, row.PreRow.IsNull(2))
+	require.Equal(t, "a2_new", row.Row.GetString(1))
+	require.True(t, row.Row.IsNull(2))
+	require.Equal(t, "c2", row.Row.GetString(3))
+
+	// the columns are not projected if the projection is disabled, such as for the redo log.
+	processor = newDMLProcessor(mounter, NewMockSchemaStore(), tableFilter, false)
+	require.NoError(t, processor.startTxn(common.NewDispatcherID(), tableID, tableInfo, updateEvent.StartTs, updateEvent.CRTs, false))
+	require.NoError(t, processor.appendRow(kvEvents[0]))
+	row, ok = processor.getCurrentBatchDML().DMLEvents[0].GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, "b0", row.Row.GetString(2))
+}
@@ -163,6 +163,7 @@ type mockEventStore struct {
 	resolvedTsUpdateInterval time.Duration
 	dispatcherMap            sync.Map // key is common.DispatcherID, value is span
 	spansMap                 sync.Map // key is *heartbeatpb.TableSpan
+	evictedDispatchers       sync.Map // key is common.DispatcherID
 }
 
 func newMockEventStore(resolvedTsUpdateInterval int) *mockEventStore {
@@ -276,6 +277,11 @@ func (m *mockEventStore) GetIterator(dispatcherID common.DispatcherID, dataRange
 	return iter
 }
 
+func (m *mockEventStore) IsDispatcherEvicted(dispatcherID common.DispatcherID) bool {
+	_, ok := m.evictedDispatchers.Load(dispatcherID)
+	return ok
+}
+
 func (m *mockEventStore) GetLogCoordinatorNodeID() node.ID {
 	return ""
 }
@@ -0,0 +1,99 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"github.com/pingcap/ticdc/eventpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+// columnSelector selects the columns of the matched tables.
+type columnSelector struct {
+	tableF  tfilter.Filter
+	columnM tfilter.ColumnFilter
+}
+
+// columnSelectors is the column projection pushed down to the event service,
+// the first selector which matches the table is used, the same as the sink.
+type columnSelectors struct {
+	selectors []*columnSelector
+}
+
+func newColumnSelectors(rules []*eventpb.ColumnSelector, caseSensitive bool) (*columnSelectors, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	selectors := make([]*columnSelector, 0, len(rules))
+	for _, rule := range rules {
+		tableF, err := tfilter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rule.Matcher)
+		}
+		if !caseSensitive {
+			tableF = tfilter.CaseInsensitive(tableF)
+		}
+		columnM, err := tfilter.ParseColumnFilter(rule.Columns)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rule.Columns)
+		}
+		selectors = append(selectors, &columnSelector{
+			tableF:  tableF,
+			columnM: columnM,
+		})
+	}
+	return &columnSelectors{selectors: selectors}, nil
+}
+
+// selectColumns returns whether each column of the table is selected, it returns nil if all the columns
+// are selected. The index columns are always selected since they may be used to identify or dispatch the rows.
+func (s *columnSelectors) selectColumns(tableInfo *common.TableInfo) []bool {
+	if s == nil {
+		return nil
+	}
+	for _, selector := range s.selectors {
+		if !selector.tableF.MatchTable(tableInfo.GetSchemaName(), tableInfo.GetTableName()) {
+			continue
+		}
+		indexColumns := make(map[string]struct{})
+		for _, name := range tableInfo.GetPrimaryKeyColumnNames() {
+			indexColumns[name] = struct{}{}
+		}
+		for _, index := range tableInfo.GetIndices() {
+			for _, col := range index.Columns {
+				indexColumns[col.Name.O] = struct{}{}
+			}
+		}
+
+		var selected []bool
+		for i, col := range tableInfo.GetColumns() {
+			if col == nil || selector.columnM.MatchColumn(col.Name.O) {
+				continue
+			}
+			if _, ok := indexColumns[col.Name.O]; ok {
+				continue
+			}
+			if selected == nil {
+				selected = make([]bool, len(tableInfo.GetColumns()))
+				for j := range selected {
+					selected[j] = true
+				}
+			}
+			selected[i] = false
+		}
+		return selected
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/eventpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectColumns(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("default", t.Name())
+	f, err := GetSharedFilterStorage().GetOrSetFilter(changefeedID, &eventpb.FilterConfig{
+		FilterConfig: &eventpb.InnerFilterConfig{Rules: []string{"*.*"}},
+		ColumnSelectors: []*eventpb.ColumnSelector{
+			{Matcher: []string{"test.t1"}, Columns: []string{"a", "!b"}},
+			{Matcher: []string{"test.*"}, Columns: []string{"*"}},
+		},
+	}, "UTC")
+	require.NoError(t, err)
+
+	columns := []*model.ColumnInfo{
+		newColumnInfo(1, "id", mysql.TypeLong, mysql.PriKeyFlag),
+		newColumnInfo(2, "a", mysql.TypeVarchar, 0),
+		newColumnInfo(3, "b", mysql.TypeVarchar, 0),
+		newColumnInfo(4, "c", mysql.TypeVarchar, 0),
+		newColumnInfo(5, "d", mysql.TypeVarchar, 0),
+	}
+	indices := []*model.IndexInfo{
+		newIndexInfo("idx_d", []*model.IndexColumn{{Name: ast.NewCIStr("d"), Offset: 4}}, false, false),
+	}
+	// the primary key and index columns are always selected.
+	t1 := mustNewCommonTableInfo("test", "t1", columns, indices)
+	require.Equal(t, []bool{true, true, false, false, true}, f.SelectColumns(t1))
+	// all the columns are selected by the second selector.
+	t2 := mustNewCommonTableInfo("test", "t2", columns, indices)
+	require.Nil(t, f.SelectColumns(t2))
+	// no selector matches the table.
+	t3 := mustNewCommonTableInfo("other", "t1", columns, indices)
+	require.Nil(t, f.SelectColumns(t3))
+
+	// the column selectors are not pushed down.
+	f, err = NewFilter(config.NewDefaultFilterConfig(), "", false, false)
+	require.NoError(t, err)
+	require.Nil(t, f.SelectColumns(t1))
+
+	_, err = GetSharedFilterStorage().GetOrSetFilter(changefeedID, &eventpb.FilterConfig{
+		FilterConfig:    &eventpb.InnerFilterConfig{Rules: []string{"*.*"}},
+		ColumnSelectors: []*eventpb.ColumnSelector{{Matcher: []string{"test.t1"}, Columns: []string{"["}}},
+	}, "UTC")
+	require.Error(t, err)
+}
@@ -70,6 +70,9 @@ type Filter interface {
 	// Verify should only be called by create changefeed OpenAPI.
 	// Its purpose is to verify the expression filter config.
 	Verify(tableInfos []*common.TableInfo) error
+	// SelectColumns returns whether each column of the table should be sent to the dispatcher,
+	// it returns nil if all the columns should be sent.
+	SelectColumns(tableInfo *common.TableInfo) []bool
 }
 
 // filter implements Filter.
@@ -83,6 +86,10 @@ type filter struct {
 	// ignoreTxnStartTs is used to filter out dml/ddl event by its starsTs.
 	ignoreTxnStartTs []uint64
 	forceReplicate   bool
+	// onlineDDL is used to filter out the ghost tables of the online schema change tools.
+	onlineDDL bool
+	// columnSelectors is used to select the columns sent to the dispatcher by the event service.
+	columnSelectors *columnSelectors
 }
 
 // NewFilter creates a filter.
@@ -110,6 +117,7 @@ func NewFilter(cfg *config.FilterConfig, tz string, caseSensitive bool, forceRep
 		sqlEventFilter:   sqlEventFilter,
 		ignoreTxnStartTs: cfg.IgnoreTxnStartTs,
 		forceReplicate:   forceReplicate,
+		onlineDDL:        cfg.OnlineDDL,
 	}, nil
 }
 
@@ -180,6 +188,9 @@ func (f *filter) ShouldIgnoreTable(db, tbl string) bool {
 	if IsSysSchema(db) {
 		return true
 	}
+	if f.onlineDDL && IsOnlineDDLTable(tbl) {
+		return true
+	}
 
 	return !f.tableFilter.MatchTable(db, tbl)
 }
@@ -203,6 +214,11 @@ func (f *filter) IsEligibleTable(tableInfo *common.TableInfo) bool {
 	return tableInfo.IsEligible(f.forceReplicate)
 }
 
+// SelectColumns implements Filter interface.
+func (f *filter) SelectColumns(tableInfo *common.TableInfo) []bool {
+	return f.columnSelectors.selectColumns(tableInfo)
+}
+
 func (f *filter) shouldIgnoreStartTs(ts uint64) bool {
 	for _, ignoreTs := range f.ignoreTxnStartTs {
 		if ignoreTs == ts {
@@ -272,6 +288,7 @@ func (s *SharedFilterStorage) GetOrSetFilter(
 	filterCfg := &config.FilterConfig{
 		Rules:            cfg.FilterConfig.Rules,
 		IgnoreTxnStartTs: cfg.FilterConfig.IgnoreTxnStartTs,
+		OnlineDDL:        cfg.FilterConfig.OnlineDdl,
 	}
 	for _, rule := range cfg.FilterConfig.EventFilters {
 		f := &config.EventFilterRule{
@@ -293,6 +310,11 @@ func (s *SharedFilterStorage) GetOrSetFilter(
 	if err != nil {
 		return nil, err
 	}
+	// the column selectors of the sink are only applied by the event service.
+	f.(*filter).columnSelectors, err = newColumnSelectors(cfg.ColumnSelectors, cfg.CaseSensitive)
+	if err != nil {
+		return nil, err
+	}
 	s.m[changeFeedID] = FilterWithConfig{
 		Filter:   f,
 		config:   cfg,
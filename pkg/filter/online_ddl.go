@@ -0,0 +1,52 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import "regexp"
+
+// OnlineDDLTableType is the type of the tables created by the online schema change tools.
+type OnlineDDLTableType int
+
+const (
+	// OnlineDDLNone means the table is not created by the online schema change tools.
+	OnlineDDLNone OnlineDDLTableType = iota
+	// OnlineDDLGhostTable is the table with the new schema which replaces the original
+	// table at cut-over, such as `_t_gho` of gh-ost and `_t_new` of pt-osc.
+	OnlineDDLGhostTable
+	// OnlineDDLTrashTable is the changelog table of gh-ost `_t_ghc`, or the original table
+	// renamed at cut-over, such as `_t_del` of gh-ost and `_t_old` of pt-osc.
+	OnlineDDLTrashTable
+)
+
+var (
+	onlineDDLGhostTableRegex = regexp.MustCompile(`^_(.+)_(gho|new)$`)
+	onlineDDLTrashTableRegex = regexp.MustCompile(`^_(.+)_(ghc|del|old)$`)
+)
+
+// GetOnlineDDLTableType returns the type of the table and the name of the original table.
+func GetOnlineDDLTableType(table string) (OnlineDDLTableType, string) {
+	if matches := onlineDDLGhostTableRegex.FindStringSubmatch(table); matches != nil {
+		return OnlineDDLGhostTable, matches[1]
+	}
+	if matches := onlineDDLTrashTableRegex.FindStringSubmatch(table); matches != nil {
+		return OnlineDDLTrashTable, matches[1]
+	}
+	return OnlineDDLNone, table
+}
+
+// IsOnlineDDLTable returns true if the table is created by the online schema change tools.
+func IsOnlineDDLTable(table string) bool {
+	tp, _ := GetOnlineDDLTableType(table)
+	return tp != OnlineDDLNone
+}
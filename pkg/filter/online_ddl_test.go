@@ -0,0 +1,58 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOnlineDDLTableType(t *testing.T) {
+	for _, tc := range []struct {
+		table  string
+		tp     OnlineDDLTableType
+		origin string
+	}{
+		{"t", OnlineDDLNone, "t"},
+		{"_t", OnlineDDLNone, "_t"},
+		{"t_gho", OnlineDDLNone, "t_gho"},
+		{"_t_gho", OnlineDDLGhostTable, "t"},
+		{"_my_table_new", OnlineDDLGhostTable, "my_table"},
+		{"_t_ghc", OnlineDDLTrashTable, "t"},
+		{"_t_del", OnlineDDLTrashTable, "t"},
+		{"_t_old", OnlineDDLTrashTable, "t"},
+	} {
+		tp, origin := GetOnlineDDLTableType(tc.table)
+		require.Equal(t, tc.tp, tp, tc.table)
+		require.Equal(t, tc.origin, origin, tc.table)
+	}
+}
+
+func TestOnlineDDLFilter(t *testing.T) {
+	cfg := &config.FilterConfig{Rules: []string{"test.*"}}
+	f, err := NewFilter(cfg, "", false, false)
+	require.NoError(t, err)
+	require.False(t, f.ShouldIgnoreTable("test", "_t_gho"))
+
+	cfg.OnlineDDL = true
+	f, err = NewFilter(cfg, "", false, false)
+	require.NoError(t, err)
+	require.False(t, f.ShouldIgnoreTable("test", "t"))
+	require.True(t, f.ShouldIgnoreTable("test", "_t_gho"))
+	require.True(t, f.ShouldIgnoreTable("test", "_t_ghc"))
+	require.True(t, f.ShouldIgnoreTable("test", "_t_del"))
+	require.True(t, f.ShouldIgnoreTable("other", "t"))
+}
@@ -113,6 +113,30 @@ var (
 		Help:      "The amount of pending data stored in-memory for event store",
 	}, []string{"id"})
 
+	EventStoreDiskQuotaUsageRatioGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "disk_quota_usage_ratio",
+			Help:      "The ratio of the disk usage to the disk quota of event store.",
+		})
+
+	EventStoreDiskQuotaThrottledSubscriptionGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "disk_quota_throttled_subscription_count",
+			Help:      "The number of subscriptions whose ingestion is throttled by the disk quota of event store.",
+		})
+
+	EventStoreDiskQuotaEvictedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_store",
+			Name:      "disk_quota_evicted_dispatcher_count",
+			Help:      "The number of dispatchers whose data is evicted by the disk quota of event store.",
+		}, []string{getKeyspaceLabel(), "changefeed"})
+
 	EventStoreResolvedTsLagGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "ticdc",
@@ -214,6 +238,9 @@ func initEventStoreMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventStoreOnDiskDataSizeGauge)
 	registry.MustRegister(EventStoreInMemoryDataSizeGauge)
 	registry.MustRegister(EventStoreResolvedTsLagGauge)
+	registry.MustRegister(EventStoreDiskQuotaUsageRatioGauge)
+	registry.MustRegister(EventStoreDiskQuotaThrottledSubscriptionGauge)
+	registry.MustRegister(EventStoreDiskQuotaEvictedCount)
 	registry.MustRegister(EventStoreWriteBytes)
 	registry.MustRegister(EventStoreSubscriptionDataGCLagHist)
 	registry.MustRegister(EventStoreWriteBatchEventsCountHist)
@@ -176,6 +176,25 @@ var (
 			Name:      "txn_prepare_statement_errors",
 			Help:      "Prepare statement errors",
 		}, []string{getKeyspaceLabel(), "changefeed"})
+
+	// DeadLetterRowCounter records the count of rows written to the dead-letter destination.
+	DeadLetterRowCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_dead_letter_rows",
+			Help:      "Total count of rows written to the dead-letter destination.",
+		}, []string{getKeyspaceLabel(), "changefeed"})
+
+	// ActiveWorkerCountGauge records the count of the active txn workers, it's
+	// adjusted at runtime if the adaptive concurrency is enabled.
+	ActiveWorkerCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_active_worker_count",
+			Help:      "The count of the active txn workers.",
+		}, []string{getKeyspaceLabel(), "changefeed"})
 )
 
 // ---------- Metrics for kafka sink and backends. ---------- //
@@ -247,6 +266,8 @@ func initSinkMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(WorkerEventRowCount)
 	registry.MustRegister(SinkDMLBatchCommit)
 	registry.MustRegister(SinkDMLBatchCallback)
+	registry.MustRegister(DeadLetterRowCounter)
+	registry.MustRegister(ActiveWorkerCountGauge)
 	registry.MustRegister(PrepareStatementErrors)
 
 	// kafka sink metrics
@@ -53,9 +53,6 @@ const (
 	defaultWorkerNum = 16
 )
 
-// lz4MagicNumber is the magic number of lz4 compressed data
-var lz4MagicNumber = []byte{0x04, 0x22, 0x4D, 0x18}
-
 type fileReader interface {
 	io.Closer
 	// Read return the log from log file
@@ -207,13 +204,6 @@ func selectDownLoadFile(
 	return files, nil
 }
 
-func isLZ4Compressed(data []byte) bool {
-	if len(data) < 4 {
-		return false
-	}
-	return bytes.Equal(data[:4], lz4MagicNumber)
-}
-
 func readAllFromBuffer(buf []byte) (logHeap, error) {
 	r := &reader{
 		br: bytes.NewReader(buf),
@@ -262,9 +252,9 @@ func sortAndWriteFile(
 		log.Warn("download file is empty", zap.String("file", fileName))
 		return nil
 	}
-	// it's lz4 compressed, decompress it
-	if isLZ4Compressed(fileContent) {
-		if fileContent, err = compression.Decode(compression.LZ4, fileContent); err != nil {
+	// the compression is detected by the magic number, decompress it if necessary
+	if cc := compression.Detect(fileContent); cc != compression.None {
+		if fileContent, err = compression.Decode(cc, fileContent); err != nil {
 			return err
 		}
 	}
@@ -16,6 +16,7 @@ package file
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -26,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/redo"
 	"github.com/pingcap/ticdc/pkg/redo/writer"
+	"github.com/pingcap/ticdc/pkg/util/s3mock"
 	"github.com/pingcap/ticdc/pkg/uuid"
 	mockstorage "github.com/pingcap/tidb/br/pkg/mock/storage"
 	"github.com/pingcap/tidb/br/pkg/storage"
@@ -401,3 +403,63 @@ func TestRotateFileWithoutFileAllocator(t *testing.T) {
 
 	w.Close()
 }
+
+func TestWriterWithS3Storage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s3Server := s3mock.NewServer(t, "bucket")
+	uri, err := url.Parse(s3Server.URI("bucket", "redo"))
+	require.NoError(t, err)
+
+	uuidGen := uuid.NewMock()
+	uuidGen.Push("uuid-1")
+	uuidGen.Push("uuid-2")
+	uuidGen.Push("uuid-3")
+	changefeed := common.NewChangeFeedIDWithDisplayName(common.ChangeFeedDisplayName{
+		Keyspace: "abcd",
+		Name:     "test",
+	})
+	// the faults are recovered by the retryer of the external storage.
+	s3Server.InjectThrottling(s3mock.OpHeadObject, 1)
+	w, err := NewFileWriter(ctx, &writer.LogWriterConfig{
+		Dir:                t.TempDir(),
+		CaptureID:          "cp",
+		ChangeFeedID:       changefeed,
+		URI:                uri,
+		UseExternalStorage: true,
+		MaxLogSizeInBytes:  redo.DefaultMaxLogSize * redo.Megabyte,
+	},
+		redo.RedoRowLogFileType,
+		writer.WithUUIDGenerator(func() uuid.Generator { return uuidGen }),
+	)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("test1"))
+	require.NoError(t, err)
+	s3Server.InjectInternalError(s3mock.OpPutObject, 1)
+	require.NoError(t, w.rotate())
+
+	w.AdvanceTs(100)
+	_, err = w.Write([]byte("test2"))
+	require.NoError(t, err)
+	s3Server.InjectThrottling(s3mock.OpPutObject, 1)
+	require.NoError(t, w.Close())
+	require.False(t, w.IsRunning())
+
+	require.Equal(t, []string{
+		"redo/cp_abcd_test_row_0_uuid-1.log",
+		"redo/cp_abcd_test_row_100_uuid-2.log",
+	}, s3Server.Keys("bucket"))
+	for key, expected := range map[string]string{
+		"redo/cp_abcd_test_row_0_uuid-1.log":   "test1",
+		"redo/cp_abcd_test_row_100_uuid-2.log": "test2",
+	} {
+		data, ok := s3Server.Object("bucket", key)
+		require.True(t, ok)
+		require.Contains(t, string(data), expected)
+	}
+	require.Equal(t, 4, s3Server.RequestCount(s3mock.OpPutObject))
+}
@@ -22,7 +22,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/pierrec/lz4/v4"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -314,9 +313,14 @@ func (f *fileWorkerGroup) newFileCache(data []byte, commitTs common.Ts) *fileCac
 	)
 	bufferWriter := bytes.NewBuffer(buf)
 	wr = bufferWriter
-	if f.cfg.Compression == compression.LZ4 {
-		wr = lz4.NewWriter(bufferWriter)
-		closer = wr.(io.Closer)
+	if f.cfg.Compression != "" && f.cfg.Compression != compression.None {
+		cw, err := compression.NewWriter(f.cfg.Compression, f.cfg.CompressionLevel, bufferWriter)
+		if err != nil {
+			log.Error("create compression writer failed", zap.Error(err))
+			return nil
+		}
+		wr = cw
+		closer = cw
 	}
 	_, err := wr.Write(data)
 	if err != nil {
@@ -19,6 +19,8 @@ import (
 
 	"github.com/pingcap/ticdc/pkg/common"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/compression"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/redo"
 	"github.com/pingcap/ticdc/pkg/redo/writer"
 	"github.com/pingcap/ticdc/pkg/util"
@@ -60,6 +62,40 @@ func TestWriteDML(t *testing.T) {
 	testWriteEvents(t, ddls)
 }
 
+func TestWriteCompressed(t *testing.T) {
+	t.Parallel()
+
+	for _, cc := range []string{compression.LZ4, compression.Zstd, compression.Gzip} {
+		ctx, cancel := context.WithCancel(context.Background())
+		extStorage, uri, err := util.GetTestExtStorage(ctx, t.TempDir())
+		require.NoError(t, err)
+		lwcfg := &writer.LogWriterConfig{
+			ConsistentConfig: config.ConsistentConfig{
+				Compression: cc,
+			},
+			CaptureID:          "test-capture",
+			ChangeFeedID:       common.NewChangeFeedIDWithName("test-changefeed", common.DefaultKeyspaceNamme),
+			URI:                uri,
+			UseExternalStorage: true,
+			MaxLogSizeInBytes:  10 * redo.Megabyte,
+		}
+		filename := t.Name()
+		lw, err := NewLogWriter(ctx, lwcfg, redo.RedoDDLLogFileType, writer.WithLogFileName(func() string {
+			return filename
+		}))
+		require.NoError(t, err)
+		require.NoError(t, lw.WriteEvents(ctx, &pevent.DDLEvent{FinishedTs: 1}, &pevent.DDLEvent{FinishedTs: 2}))
+		require.NoError(t, lw.Close())
+
+		data, err := extStorage.ReadFile(ctx, filename)
+		require.NoError(t, err)
+		require.Equal(t, cc, compression.Detect(data))
+		_, err = compression.Decode(cc, data)
+		require.NoError(t, err)
+		cancel()
+	}
+}
+
 func testWriteEvents(t *testing.T, events []writer.RedoEvent) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
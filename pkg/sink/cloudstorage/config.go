@@ -24,6 +24,7 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/imdario/mergo"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/util"
@@ -79,6 +80,8 @@ type Config struct {
 	EnablePartitionSeparator bool
 	OutputColumnID           bool
 	FlushConcurrency         int
+	Compression              string
+	CompressionLevel         int
 }
 
 // NewConfig returns the default cloud storage sink config.
@@ -89,6 +92,7 @@ func NewConfig() *Config {
 		FileSize:            defaultFileSize,
 		FileExpirationDays:  defaultFileExpirationDays,
 		FileCleanupCronSpec: defaultFileCleanupCronSpec,
+		Compression:         compression.None,
 	}
 }
 
@@ -140,6 +144,13 @@ func (c *Config) Apply(
 			c.FileCleanupCronSpec = *sinkConfig.CloudStorageConfig.FileCleanupCronSpec
 		}
 		c.FlushConcurrency = util.GetOrZero(sinkConfig.CloudStorageConfig.FlushConcurrency)
+		if sinkConfig.CloudStorageConfig.Compression != nil {
+			c.Compression = *sinkConfig.CloudStorageConfig.Compression
+		}
+		c.CompressionLevel = util.GetOrZero(sinkConfig.CloudStorageConfig.CompressionLevel)
+	}
+	if err = c.validateCompression(sinkConfig); err != nil {
+		return err
 	}
 
 	if c.FileIndexWidth < config.MinFileIndexWidth || c.FileIndexWidth > config.MaxFileIndexWidth {
@@ -152,6 +163,28 @@ func (c *Config) Apply(
 	return nil
 }
 
+func (c *Config) validateCompression(sinkConfig *config.SinkConfig) error {
+	if c.Compression == "" {
+		c.Compression = compression.None
+	}
+	switch c.Compression {
+	case compression.None, compression.LZ4, compression.Zstd, compression.Gzip:
+	default:
+		return cerror.ErrStorageSinkInvalidConfig.GenWithStack(
+			"unsupported compression %s, it must be 'none', 'lz4', 'zstd' or 'gzip'", c.Compression)
+	}
+	if err := compression.ValidateLevel(c.Compression, c.CompressionLevel); err != nil {
+		return cerror.WrapError(cerror.ErrStorageSinkInvalidConfig, err)
+	}
+	// the parquet file is compressed by its own codec.
+	if c.Compression != compression.None &&
+		util.GetOrZero(sinkConfig.Protocol) == config.ProtocolParquet.String() {
+		return cerror.ErrStorageSinkInvalidConfig.GenWithStack(
+			"compression %s is not supported for the parquet protocol", c.Compression)
+	}
+	return nil
+}
+
 func mergeConfig(
 	sinkConfig *config.SinkConfig,
 	urlParameters *urlConfig,
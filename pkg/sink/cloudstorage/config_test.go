@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -165,3 +166,37 @@ func TestMergeConfig(t *testing.T) {
 	require.Equal(t, 33554432, c.FileSize)
 	require.Equal(t, "2m2s", c.FlushInterval.String())
 }
+
+func TestCompressionConfig(t *testing.T) {
+	sinkURI, err := url.Parse("s3://bucket/prefix")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+
+	c := NewConfig()
+	require.NoError(t, c.Apply(context.TODO(), sinkURI, replicaConfig.Sink))
+	require.Equal(t, compression.None, c.Compression)
+
+	replicaConfig.Sink.CloudStorageConfig = &config.CloudStorageConfig{
+		Compression:      aws.String(compression.Zstd),
+		CompressionLevel: aws.Int(9),
+	}
+	c = NewConfig()
+	require.NoError(t, c.Apply(context.TODO(), sinkURI, replicaConfig.Sink))
+	require.Equal(t, compression.Zstd, c.Compression)
+	require.Equal(t, 9, c.CompressionLevel)
+
+	// the level is out of range.
+	replicaConfig.Sink.CloudStorageConfig.Compression = aws.String(compression.Gzip)
+	replicaConfig.Sink.CloudStorageConfig.CompressionLevel = aws.Int(10)
+	require.Error(t, NewConfig().Apply(context.TODO(), sinkURI, replicaConfig.Sink))
+
+	// snappy has no frame format, it's not supported for files.
+	replicaConfig.Sink.CloudStorageConfig.Compression = aws.String(compression.Snappy)
+	replicaConfig.Sink.CloudStorageConfig.CompressionLevel = nil
+	require.Error(t, NewConfig().Apply(context.TODO(), sinkURI, replicaConfig.Sink))
+
+	// the parquet file is compressed by itself.
+	replicaConfig.Sink.CloudStorageConfig.Compression = aws.String(compression.Gzip)
+	replicaConfig.Sink.Protocol = aws.String(config.ProtocolParquet.String())
+	require.Error(t, NewConfig().Apply(context.TODO(), sinkURI, replicaConfig.Sink))
+}
@@ -44,19 +44,25 @@ const magicByte = uint8(0)
 // look up local cache according to the table's name, and fetch from the Registry
 // in cache the local cache entry is missing.
 type confluentSchemaManager struct {
-	registryURL string
-
-	credential *security.Credential // placeholder, currently always nil
+	client *ConfluentRegistryClient
 
 	cacheRWLock  sync.RWMutex
 	cache        map[string]*schemaCacheEntry
 	registryType string
 }
 
+// ConfluentRegistryClient talks to the confluent Registry server by the REST API,
+// it's shared by all protocols which use the confluent schema registry.
+type ConfluentRegistryClient struct {
+	registryURL string
+
+	credential *security.Credential // placeholder, currently always nil
+}
+
 type registerRequest struct {
 	Schema string `json:"schema"`
-	// Commented out for compatibility with Confluent 5.4.x
-	// SchemaType string `json:"schemaType"`
+	// SchemaType is omitted for the Avro schema, for compatibility with Confluent 5.4.x
+	SchemaType string `json:"schemaType,omitempty"`
 }
 
 type registerResponse struct {
@@ -64,9 +70,10 @@ type registerResponse struct {
 }
 
 type lookupResponse struct {
-	Name     string `json:"name"`
-	SchemaID int    `json:"id"`
-	Schema   string `json:"schema"`
+	Name       string `json:"name"`
+	SchemaID   int    `json:"id"`
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
 }
 
 // NewConfluentSchemaManager create schema managers,
@@ -76,6 +83,23 @@ func NewConfluentSchemaManager(
 	registryURL string,
 	credential *security.Credential,
 ) (SchemaManager, error) {
+	client, err := NewConfluentRegistryClient(ctx, registryURL, credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &confluentSchemaManager{
+		client:       client,
+		cache:        make(map[string]*schemaCacheEntry, 1),
+		registryType: common.SchemaRegistryTypeConfluent,
+	}, nil
+}
+
+// NewConfluentRegistryClient creates a client, and test connectivity to the schema registry
+func NewConfluentRegistryClient(
+	ctx context.Context,
+	registryURL string,
+	credential *security.Credential,
+) (*ConfluentRegistryClient, error) {
 	registryURL = strings.TrimRight(registryURL, "/")
 	httpCli, err := httputil.NewClient(credential)
 	if err != nil {
@@ -107,10 +131,9 @@ func NewConfluentSchemaManager(
 		zap.String("registryURL", registryURL),
 	)
 
-	return &confluentSchemaManager{
-		registryURL:  registryURL,
-		cache:        make(map[string]*schemaCacheEntry, 1),
-		registryType: common.SchemaRegistryTypeConfluent,
+	return &ConfluentRegistryClient{
+		registryURL: registryURL,
+		credential:  credential,
 	}, nil
 }
 
@@ -130,21 +153,37 @@ func (m *confluentSchemaManager) Register(
 		log.Error("Could not compact schema", zap.Error(err))
 		return id, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
+	id.confluentSchemaID, err = m.client.RegisterSchema(ctx, schemaName, "", buffer.String())
+	if err != nil {
+		return id, errors.Trace(err)
+	}
+	return id, nil
+}
+
+// RegisterSchema registers the schema under the subject, and returns the schema ID.
+// The schemaType should be empty for the Avro schema.
+func (c *ConfluentRegistryClient) RegisterSchema(
+	ctx context.Context,
+	subject string,
+	schemaType string,
+	schema string,
+) (int, error) {
 	reqBody := registerRequest{
-		Schema: buffer.String(),
+		Schema:     schema,
+		SchemaType: schemaType,
 	}
 	payload, err := json.Marshal(&reqBody)
 	if err != nil {
 		log.Error("Could not marshal request to the Registry", zap.Error(err))
-		return id, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+		return 0, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
-	uri := m.registryURL + "/subjects/" + url.QueryEscape(schemaName) + "/versions"
+	uri := c.registryURL + "/subjects/" + url.QueryEscape(subject) + "/versions"
 	log.Info("Registering schema", zap.String("uri", uri), zap.ByteString("payload", payload))
 
 	req, err := http.NewRequestWithContext(ctx, "POST", uri, bytes.NewReader(payload))
 	if err != nil {
 		log.Error("Failed to NewRequestWithContext", zap.Error(err))
-		return id, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+		return 0, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
 	req.Header.Add(
 		"Accept",
@@ -152,16 +191,16 @@ func (m *confluentSchemaManager) Register(
 			"application/json",
 	)
 	req.Header.Add("Content-Type", "application/vnd.schemaregistry.v1+json")
-	resp, err := httpRetry(ctx, m.credential, req)
+	resp, err := httpRetry(ctx, c.credential, req)
 	if err != nil {
-		return id, err
+		return 0, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Error("Failed to read response from Registry", zap.Error(err))
-		return id, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+		return 0, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
 
 	if resp.StatusCode != 200 {
@@ -175,18 +214,18 @@ func (m *confluentSchemaManager) Register(
 			zap.ByteString("requestBody", payload),
 			zap.ByteString("responseBody", body),
 		)
-		return id, errors.ErrAvroSchemaAPIError.GenWithStackByArgs()
+		return 0, errors.ErrAvroSchemaAPIError.GenWithStackByArgs()
 	}
 
 	var jsonResp registerResponse
 	err = json.Unmarshal(body, &jsonResp)
 	if err != nil {
 		log.Error("Failed to parse result from Registry", zap.Error(err))
-		return id, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+		return 0, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
 
 	if jsonResp.SchemaID == 0 {
-		return id, errors.ErrAvroSchemaAPIError.GenWithStack(
+		return 0, errors.ErrAvroSchemaAPIError.GenWithStack(
 			"Illegal schema ID returned from Registry %d",
 			jsonResp.SchemaID,
 		)
@@ -196,9 +235,7 @@ func (m *confluentSchemaManager) Register(
 		zap.Int("schemaID", jsonResp.SchemaID),
 		zap.String("uri", uri),
 		zap.ByteString("body", body))
-
-	id.confluentSchemaID = jsonResp.SchemaID
-	return id, nil
+	return jsonResp.SchemaID, nil
 }
 
 // Lookup the cached schema entry first, if not found, fetch from the Registry server.
@@ -215,12 +252,37 @@ func (m *confluentSchemaManager) Lookup(
 	}
 	m.cacheRWLock.RUnlock()
 
-	uri := m.registryURL + "/schemas/ids/" + strconv.Itoa(schemaID.confluentSchemaID)
+	schema, err := m.client.LookupSchema(ctx, schemaID.confluentSchemaID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cacheEntry := new(schemaCacheEntry)
+	cacheEntry.codec, err = GenCodec(schema)
+	if err != nil {
+		log.Error("Creating Avro codec failed", zap.Error(err))
+		return nil, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+	}
+	cacheEntry.schemaID.confluentSchemaID = schemaID.confluentSchemaID
+	cacheEntry.header, err = m.getMsgHeader(schemaID.confluentSchemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.cacheRWLock.Lock()
+	m.cache[schemaName] = cacheEntry
+	m.cacheRWLock.Unlock()
+	return cacheEntry.codec, nil
+}
+
+// LookupSchema fetches the schema by the schema ID from the Registry.
+func (c *ConfluentRegistryClient) LookupSchema(ctx context.Context, id int) (string, error) {
+	uri := c.registryURL + "/schemas/ids/" + strconv.Itoa(id)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	if err != nil {
 		log.Error("Error constructing request for Registry lookup", zap.Error(err))
-		return nil, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+		return "", errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
 	req.Header.Add(
 		"Accept",
@@ -228,16 +290,16 @@ func (m *confluentSchemaManager) Lookup(
 			"application/json",
 	)
 
-	resp, err := httpRetry(ctx, m.credential, req)
+	resp, err := httpRetry(ctx, c.credential, req)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Error("Failed to parse result from Registry", zap.Error(err))
-		return nil, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+		return "", errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
 
 	if resp.StatusCode != 200 && resp.StatusCode != 404 {
@@ -245,16 +307,15 @@ func (m *confluentSchemaManager) Lookup(
 			zap.Int("status", resp.StatusCode),
 			zap.String("uri", uri),
 			zap.ByteString("responseBody", body))
-		return nil, errors.ErrAvroSchemaAPIError.GenWithStack(
+		return "", errors.ErrAvroSchemaAPIError.GenWithStack(
 			"Failed to query schema from the Registry, HTTP error",
 		)
 	}
 
 	if resp.StatusCode == 404 {
 		log.Warn("Specified schema not found in Registry",
-			zap.String("key", schemaName),
-			zap.Int("schemaID", schemaID.confluentSchemaID))
-		return nil, errors.ErrAvroSchemaAPIError.GenWithStackByArgs(
+			zap.Int("schemaID", id))
+		return "", errors.ErrAvroSchemaAPIError.GenWithStackByArgs(
 			"Schema not found in Registry",
 		)
 	}
@@ -263,25 +324,10 @@ func (m *confluentSchemaManager) Lookup(
 	err = json.Unmarshal(body, &jsonResp)
 	if err != nil {
 		log.Error("Failed to parse result from Registry", zap.Error(err))
-		return nil, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
+		return "", errors.WrapError(errors.ErrAvroSchemaAPIError, err)
 	}
 
-	cacheEntry := new(schemaCacheEntry)
-	cacheEntry.codec, err = GenCodec(jsonResp.Schema)
-	if err != nil {
-		log.Error("Creating Avro codec failed", zap.Error(err))
-		return nil, errors.WrapError(errors.ErrAvroSchemaAPIError, err)
-	}
-	cacheEntry.schemaID.confluentSchemaID = schemaID.confluentSchemaID
-	cacheEntry.header, err = m.getMsgHeader(schemaID.confluentSchemaID)
-	if err != nil {
-		return nil, err
-	}
-
-	m.cacheRWLock.Lock()
-	m.cache[schemaName] = cacheEntry
-	m.cacheRWLock.Unlock()
-	return cacheEntry.codec, nil
+	return jsonResp.Schema, nil
 }
 
 // GetCachedOrRegister checks if the suitable Avro schema has been cached.
@@ -352,7 +398,12 @@ func (m *confluentSchemaManager) GetCachedOrRegister(
 // Exported for testing.
 // NOT USED for now, reserved for future use.
 func (m *confluentSchemaManager) ClearRegistry(ctx context.Context, schemaSubject string) error {
-	uri := m.registryURL + "/subjects/" + url.QueryEscape(schemaSubject)
+	return m.client.ClearRegistry(ctx, schemaSubject)
+}
+
+// ClearRegistry deletes the subject from the Registry. Should be idempotent.
+func (c *ConfluentRegistryClient) ClearRegistry(ctx context.Context, schemaSubject string) error {
+	uri := c.registryURL + "/subjects/" + url.QueryEscape(schemaSubject)
 	req, err := http.NewRequestWithContext(ctx, "DELETE", uri, nil)
 	if err != nil {
 		log.Error("Could not construct request for clearRegistry", zap.Error(err))
@@ -363,7 +414,7 @@ func (m *confluentSchemaManager) ClearRegistry(ctx context.Context, schemaSubjec
 		"application/vnd.schemaregistry.v1+json, application/vnd.schemaregistry+json, "+
 			"application/json",
 	)
-	resp, err := httpRetry(ctx, m.credential, req)
+	resp, err := httpRetry(ctx, c.credential, req)
 	if err != nil {
 		return err
 	}
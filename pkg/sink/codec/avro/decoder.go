@@ -209,21 +209,7 @@ func assembleEvent(
 			break
 		}
 		// query the field to get `tidbType`, and get the mysql type from it.
-		var holder map[string]interface{}
-		switch ty := field["type"].(type) {
-		case []interface{}:
-			if m, ok := ty[0].(map[string]interface{}); ok {
-				holder = m["connect.parameters"].(map[string]interface{})
-			} else if m, ok := ty[1].(map[string]interface{}); ok {
-				holder = m["connect.parameters"].(map[string]interface{})
-			} else {
-				log.Panic("type info is anything else", zap.Any("typeInfo", field["type"]))
-			}
-		case map[string]interface{}:
-			holder = ty["connect.parameters"].(map[string]interface{})
-		default:
-			log.Panic("type info is anything else", zap.Any("typeInfo", field["type"]))
-		}
+		holder := getFieldParameters(field)
 		tidbType := holder["tidb_type"].(string)
 		mysqlType := mysqlTypeFromTiDBType(tidbType)
 		flag := flagFromTiDBType(tidbType)
@@ -281,6 +267,27 @@ func assembleEvent(
 	return event, nil
 }
 
+// getFieldParameters returns the `connect.parameters` of the field in the schema,
+// it holds the column type information.
+func getFieldParameters(field map[string]interface{}) map[string]interface{} {
+	var holder map[string]interface{}
+	switch ty := field["type"].(type) {
+	case []interface{}:
+		if m, ok := ty[0].(map[string]interface{}); ok {
+			holder = m["connect.parameters"].(map[string]interface{})
+		} else if m, ok := ty[1].(map[string]interface{}); ok {
+			holder = m["connect.parameters"].(map[string]interface{})
+		} else {
+			log.Panic("type info is anything else", zap.Any("typeInfo", field["type"]))
+		}
+	case map[string]interface{}:
+		holder = ty["connect.parameters"].(map[string]interface{})
+	default:
+		log.Panic("type info is anything else", zap.Any("typeInfo", field["type"]))
+	}
+	return holder
+}
+
 func queryTableInfo(schemaName, tableName string, columns []*timodel.ColumnInfo, keyMap map[string]interface{}) *commonType.TableInfo {
 	tableInfo := newTableInfo(schemaName, tableName, columns, keyMap)
 	return tableInfo
@@ -43,6 +43,9 @@ const (
 const (
 	insertOperation = "c"
 	updateOperation = "u"
+	// deleteOperation is only used by the object container file,
+	// the delete event is sent as a tombstone message to the kafka.
+	deleteOperation = "d"
 )
 
 const (
@@ -23,9 +23,10 @@ import (
 )
 
 type mockConfluentRegistrySchema struct {
-	content string
-	version int
-	ID      int
+	content    string
+	schemaType string
+	version    int
+	ID         int
 }
 
 type mockRegistry struct {
@@ -69,17 +70,19 @@ func startHTTPInterceptForTestingRegistry() {
 			item, exists := registry.subjects[subject]
 			if !exists {
 				item = &mockConfluentRegistrySchema{
-					content: reqData.Schema,
-					version: 1,
-					ID:      registry.newID,
+					content:    reqData.Schema,
+					schemaType: reqData.SchemaType,
+					version:    1,
+					ID:         registry.newID,
 				}
 				registry.subjects[subject] = item
 				respData.SchemaID = registry.newID
 			} else {
-				if item.content == reqData.Schema {
+				if item.content == reqData.Schema && item.schemaType == reqData.SchemaType {
 					respData.SchemaID = item.ID
 				} else {
 					item.content = reqData.Schema
+					item.schemaType = reqData.SchemaType
 					item.version++
 					item.ID = registry.newID
 					respData.SchemaID = registry.newID
@@ -103,6 +106,7 @@ func startHTTPInterceptForTestingRegistry() {
 					respData.Schema = item.content
 					respData.Name = key
 					respData.SchemaID = item.ID
+					respData.SchemaType = item.schemaType
 					return httpmock.NewJsonResponse(200, &respData)
 				}
 			}
@@ -144,3 +148,14 @@ func startHTTPInterceptForTestingRegistry() {
 func stopHTTPInterceptForTestingRegistry() {
 	httpmock.DeactivateAndReset()
 }
+
+// StartConfluentRegistry4Testing intercepts the requests to the confluent schema registry
+// at "http://127.0.0.1:8081", it's used by other protocols which rely on the confluent schema registry.
+func StartConfluentRegistry4Testing() {
+	startHTTPInterceptForTestingRegistry()
+}
+
+// StopConfluentRegistry4Testing stops intercepting the requests to the confluent schema registry.
+func StopConfluentRegistry4Testing() {
+	stopHTTPInterceptForTestingRegistry()
+}
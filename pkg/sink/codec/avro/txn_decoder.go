@@ -0,0 +1,191 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+type txnDecoder struct {
+	config *common.Config
+	// tableInfo is the table schema of the file, it's read from the schema file.
+	tableInfo *commonType.TableInfo
+
+	reader *goavro.OCFReader
+	// parameters holds the `connect.parameters` of each column in the embedded schema.
+	parameters map[string]map[string]interface{}
+}
+
+// NewTxnDecoder return a new decoder for the object container files written by the avro txn encoder.
+func NewTxnDecoder(codecConfig *common.Config, tableInfo *commonType.TableInfo) common.Decoder {
+	return &txnDecoder{
+		config:    codecConfig,
+		tableInfo: tableInfo,
+	}
+}
+
+// AddKeyValue set the content of the object container file to the decoder
+func (d *txnDecoder) AddKeyValue(_, value []byte) {
+	reader, err := goavro.NewOCFReader(bytes.NewReader(value))
+	if err != nil {
+		log.Panic("create the avro object container file reader failed", zap.Error(err))
+	}
+	parameters, err := extractParameters(reader.MetaData()[ocfSchemaKey])
+	if err != nil {
+		log.Panic("extract the column parameters from the avro schema failed", zap.Error(err))
+	}
+	metadata := reader.MetaData()
+	log.Debug("avro object container file header decoded",
+		zap.ByteString("schema", metadata[ocfTiDBSchemaKey]),
+		zap.ByteString("table", metadata[ocfTiDBTableKey]),
+		zap.ByteString("tableVersion", metadata[ocfTiDBTableVersionKey]))
+	d.reader = reader
+	d.parameters = parameters
+}
+
+// HasNext return true if there is any event can be returned.
+func (d *txnDecoder) HasNext() (common.MessageType, bool) {
+	if d.reader == nil {
+		return common.MessageTypeUnknown, false
+	}
+	if !d.reader.Scan() {
+		if err := d.reader.Err(); err != nil {
+			log.Panic("scan the avro object container file failed", zap.Error(err))
+		}
+		d.reader = nil
+		return common.MessageTypeUnknown, false
+	}
+	return common.MessageTypeRow, true
+}
+
+// NextDMLEvent implements the Decoder interface
+func (d *txnDecoder) NextDMLEvent() *commonEvent.DMLEvent {
+	if d.reader == nil {
+		log.Panic("no avro object container file found for the DML event")
+	}
+	native, err := d.reader.Read()
+	if err != nil {
+		log.Panic("read the record from the avro object container file failed", zap.Error(err))
+	}
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		log.Panic("the record is not a map", zap.Any("record", native))
+	}
+	result, err := d.assembleEvent(record)
+	if err != nil {
+		log.Panic("assemble event failed", zap.Error(err))
+	}
+	return result
+}
+
+func (d *txnDecoder) assembleEvent(record map[string]interface{}) (*commonEvent.DMLEvent, error) {
+	columns := d.tableInfo.GetColumns()
+	data := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		name := common.SanitizeName(col.Name.O)
+		value, ok := record[name]
+		if !ok {
+			continue
+		}
+		holder, ok := d.parameters[name]
+		if !ok {
+			return nil, errors.ErrAvroInvalidMessage.GenWithStackByArgs("column " + name + " not found in the schema")
+		}
+		value, err := getColumnValue(value, holder, col.GetType(), col.GetFlag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// the fsp is not carried by the schema, take it from the table info.
+		switch v := value.(type) {
+		case types.Time:
+			v.SetFsp(col.GetDecimal())
+			value = v
+		case types.Duration:
+			v.Fsp = col.GetDecimal()
+			value = v
+		}
+		data[col.Name.O] = value
+	}
+
+	commitTs, ok := record[tidbCommitTs].(int64)
+	if !ok {
+		return nil, errors.ErrAvroInvalidMessage.GenWithStackByArgs("commit ts not found")
+	}
+
+	event := new(commonEvent.DMLEvent)
+	event.TableInfo = d.tableInfo
+	event.StartTs = uint64(commitTs)
+	event.CommitTs = uint64(commitTs)
+	event.Rows = chunk.NewChunkFromPoolWithCapacity(d.tableInfo.GetFieldSlice(), chunk.InitialCapacity)
+	event.AddPostFlushFunc(func() {
+		event.Rows.Destroy(chunk.InitialCapacity, d.tableInfo.GetFieldSlice())
+	})
+	event.Length++
+	common.AppendRow2Chunk(data, columns, event.Rows)
+
+	// the update event only carries the new value, treat it as the insert event like the csv protocol.
+	rowType := commonType.RowTypeInsert
+	if record[tidbOp] == deleteOperation {
+		rowType = commonType.RowTypeDelete
+	}
+	event.RowTypes = append(event.RowTypes, rowType)
+	return event, nil
+}
+
+// NextResolvedEvent implements the Decoder interface
+func (d *txnDecoder) NextResolvedEvent() uint64 {
+	return 0
+}
+
+// NextDDLEvent implements the Decoder interface
+func (d *txnDecoder) NextDDLEvent() *commonEvent.DDLEvent {
+	return nil
+}
+
+// extractParameters returns the `connect.parameters` of each column field in the schema.
+func extractParameters(schema []byte) (map[string]map[string]interface{}, error) {
+	var top map[string]interface{}
+	if err := json.Unmarshal(schema, &top); err != nil {
+		return nil, errors.ErrAvroInvalidMessage.GenWithStackByArgs(err.Error())
+	}
+	fields, ok := top["fields"].([]interface{})
+	if !ok {
+		return nil, errors.ErrAvroInvalidMessage.GenWithStackByArgs("schema fields should be a list")
+	}
+	result := make(map[string]map[string]interface{}, len(fields))
+	for _, item := range fields {
+		field, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.ErrAvroInvalidMessage.GenWithStackByArgs("schema field should be a map")
+		}
+		name := field["name"].(string)
+		// `tidbOp` is the first extension field in the schema, the following fields are not columns.
+		if name == tidbOp {
+			break
+		}
+		result[name] = getFieldParameters(field)
+	}
+	return result, nil
+}
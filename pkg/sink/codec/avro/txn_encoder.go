@@ -0,0 +1,266 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"go.uber.org/zap"
+)
+
+const (
+	ocfMagic = "Obj\x01"
+
+	// metadata keys of the object container file header
+	ocfSchemaKey           = "avro.schema"
+	ocfCodecKey            = "avro.codec"
+	ocfTiDBSchemaKey       = "tidb.schema"
+	ocfTiDBTableKey        = "tidb.table"
+	ocfTiDBTableVersionKey = "tidb.table.version"
+)
+
+// ocfSchema is the avro schema of one table in one table version.
+type ocfSchema struct {
+	updateTS         uint64
+	tableInfoVersion uint64
+
+	codec  *goavro.Codec
+	header []byte
+	sync   []byte
+}
+
+// TxnEventEncoder encodes the txn event into the avro object container file format.
+// The file header, which embeds the schema, is set as the key of the message,
+// and all rows of the txn event are encoded into one data block, which is set as the value.
+// The sync marker is derived from the schema, so the blocks built by different encoders
+// for the same table version can be appended to one file after one header.
+type TxnEventEncoder struct {
+	inner          *BatchEncoder
+	columnSelector commonEvent.Selector
+
+	// schemas caches the schema of each table, keyed by the physical table id.
+	schemas map[int64]*ocfSchema
+
+	schema    *ocfSchema
+	valueBuf  *bytes.Buffer
+	batchSize int
+	callback  func()
+}
+
+// NewTxnEventEncoder creates a new TxnEventEncoder,
+// the schema registry is not required, since the schema is embedded in the file.
+func NewTxnEventEncoder(config *common.Config) common.TxnEventEncoder {
+	return &TxnEventEncoder{
+		inner: &BatchEncoder{
+			keyspace: config.ChangefeedID.Keyspace(),
+			config:   config,
+		},
+		columnSelector: columnselector.NewDefaultColumnSelector(),
+		schemas:        make(map[int64]*ocfSchema),
+		valueBuf:       &bytes.Buffer{},
+	}
+}
+
+// AppendTxnEvent appends a txn event to the encoder.
+func (e *TxnEventEncoder) AppendTxnEvent(event *commonEvent.DMLEvent) error {
+	schema, err := e.getSchema(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		data  []byte
+		count int
+		input = e.newEncodeInput(event)
+	)
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			event.Rewind()
+			break
+		}
+		rowEvent := &commonEvent.RowEvent{
+			PhysicalTableID: event.PhysicalTableID,
+			TableInfo:       event.TableInfo,
+			CommitTs:        event.CommitTs,
+			Event:           row,
+			ColumnSelector:  e.columnSelector,
+			Checksum:        row.Checksum,
+		}
+		if rowEvent.IsDelete() {
+			input.row = rowEvent.GetPreRows()
+		} else {
+			input.row = rowEvent.GetRows()
+		}
+		native, err := e.inner.columns2AvroData(input)
+		if err != nil {
+			log.Error("avro: converting input to native failed", zap.Error(err))
+			return errors.Trace(err)
+		}
+		native = e.inner.nativeValueWithExtension(native, rowEvent)
+		if rowEvent.IsDelete() {
+			native[tidbOp] = deleteOperation
+		}
+		data, err = schema.codec.BinaryFromNative(data, native)
+		if err != nil {
+			log.Error("avro: converting native to Avro binary failed", zap.Error(err))
+			return errors.WrapError(errors.ErrAvroEncodeToBinary, err)
+		}
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+
+	length := len(schema.header) + len(data) + common.MaxRecordOverhead
+	// For single block that is longer than max-message-bytes, do not send it.
+	if length > e.inner.config.MaxMessageBytes {
+		log.Warn("Single message is too large for avro",
+			zap.Int("maxMessageBytes", e.inner.config.MaxMessageBytes),
+			zap.Int("length", length),
+			zap.Any("table", event.TableInfo.TableName))
+		return errors.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+
+	// data block: the count of rows, the size of the serialized rows, the rows and the sync marker.
+	e.valueBuf.Write(binary.AppendVarint(nil, int64(count)))
+	e.valueBuf.Write(binary.AppendVarint(nil, int64(len(data))))
+	e.valueBuf.Write(data)
+	e.valueBuf.Write(schema.sync)
+	e.batchSize += count
+	e.schema = schema
+	e.callback = event.PostFlush
+	return nil
+}
+
+// Build builds a message from the encoder and resets the encoder.
+func (e *TxnEventEncoder) Build() []*common.Message {
+	if e.batchSize == 0 {
+		return nil
+	}
+
+	value := make([]byte, e.valueBuf.Len())
+	copy(value, e.valueBuf.Bytes())
+	ret := common.NewMsg(e.schema.header, value)
+	ret.SetRowsCount(e.batchSize)
+	ret.Callback = e.callback
+	if e.valueBuf.Cap() > common.MemBufShrinkThreshold {
+		e.valueBuf = &bytes.Buffer{}
+	} else {
+		e.valueBuf.Reset()
+	}
+	e.schema = nil
+	e.callback = nil
+	e.batchSize = 0
+	return []*common.Message{ret}
+}
+
+func (e *TxnEventEncoder) newEncodeInput(event *commonEvent.DMLEvent) *avroEncodeInput {
+	columns := event.TableInfo.GetColumns()
+	input := &avroEncodeInput{
+		colInfos:       make([]*timodel.ColumnInfo, len(columns)),
+		index:          make([]int, len(columns)),
+		columnselector: e.columnSelector,
+	}
+	copy(input.colInfos, columns)
+	for i := range input.index {
+		input.index[i] = i
+	}
+	// keep the same order as the checksum calculation.
+	if e.inner.config.EnableRowChecksum {
+		sort.Sort(input)
+	}
+	return input
+}
+
+// getSchema returns the schema of the table in the table version of the event.
+func (e *TxnEventEncoder) getSchema(event *commonEvent.DMLEvent) (*ocfSchema, error) {
+	tableInfo := event.TableInfo
+	schema, ok := e.schemas[event.PhysicalTableID]
+	if ok && schema.updateTS == tableInfo.GetUpdateTS() && schema.tableInfoVersion == event.TableInfoVersion {
+		return schema, nil
+	}
+
+	input := e.newEncodeInput(event)
+	top, err := e.inner.columns2AvroSchema(&tableInfo.TableName, input)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// the operation and the commit ts are always required to replay the file.
+	top = e.inner.schemaWithExtension(top)
+	str, err := json.Marshal(top)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrAvroMarshalFailed, err)
+	}
+	codec, err := GenCodec(string(str))
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrAvroEncodeFailed, err)
+	}
+
+	sync := md5.Sum(str)
+	schema = &ocfSchema{
+		updateTS:         tableInfo.GetUpdateTS(),
+		tableInfoVersion: event.TableInfoVersion,
+		codec:            codec,
+		sync:             sync[:],
+	}
+	schema.header = encodeOCFHeader(map[string][]byte{
+		ocfSchemaKey:           str,
+		ocfCodecKey:            []byte(goavro.CompressionNullLabel),
+		ocfTiDBSchemaKey:       []byte(tableInfo.GetSchemaName()),
+		ocfTiDBTableKey:        []byte(tableInfo.GetTableName()),
+		ocfTiDBTableVersionKey: []byte(strconv.FormatUint(event.TableInfoVersion, 10)),
+	}, schema.sync)
+	e.schemas[event.PhysicalTableID] = schema
+	log.Info("avro: table schema for the object container file",
+		zap.String("schema", tableInfo.GetSchemaName()),
+		zap.String("table", tableInfo.GetTableName()),
+		zap.Uint64("tableInfoVersion", event.TableInfoVersion),
+		zap.ByteString("avroSchema", str))
+	return schema, nil
+}
+
+// encodeOCFHeader encodes the header of the object container file,
+// the metadata is written in the order of the keys, to make the header deterministic.
+func encodeOCFHeader(metadata map[string][]byte, sync []byte) []byte {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := []byte(ocfMagic)
+	buf = binary.AppendVarint(buf, int64(len(keys)))
+	for _, k := range keys {
+		buf = binary.AppendVarint(buf, int64(len(k)))
+		buf = append(buf, k...)
+		buf = binary.AppendVarint(buf, int64(len(metadata[k])))
+		buf = append(buf, metadata[k]...)
+	}
+	// the end of the metadata map
+	buf = binary.AppendVarint(buf, 0)
+	return append(buf, sync...)
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxnEventEncoderOCF(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c double, d datetime(3), e blob, f bit(10),
+		g json, h enum('a','b'), i set('a','b'), j bigint unsigned, k time, l year, m date)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	event := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'hello', 3.14, '2025-01-02 03:04:05.678', x'0102ff', b'1010101',
+		'{"k": [1, "v"]}', 'b', 'a,b', 18446744073709551615, '-12:34:56', 2025, '2025-01-02')`,
+		`insert into test.t values (2, null, null, null, null, null, null, null, null, null, null, null, null)`,
+		`insert into test.t values (3, 'null', 0, '2025-01-02 03:04:05', x'', b'0', '[]', 'a', '', 0,
+		'00:00:00', 1970, '1970-01-01')`)
+	// the second and the third rows make up an update event.
+	event.RowTypes[1] = commonType.RowTypeUpdate
+	event.RowTypes[2] = commonType.RowTypeUpdate
+	event.TableInfoVersion = 100
+
+	deleteEvent := helper.DML2Event("test", "t", `insert into test.t values
+		(4, 'world', 1.5, null, null, null, null, null, null, null, null, null, null)`)
+	deleteEvent.RowTypes[0] = commonType.RowTypeDelete
+	deleteEvent.TableInfoVersion = 100
+
+	codecConfig := common.NewConfig(config.ProtocolAvro).
+		WithChangefeedID(commonType.NewChangeFeedIDWithName("test", commonType.DefaultKeyspaceNamme))
+	var called int
+	event.AddPostFlushFunc(func() { called++ })
+
+	// blocks encoded by different encoders can be appended to the same file.
+	var (
+		header []byte
+		file   bytes.Buffer
+	)
+	for _, e := range []*commonEvent.DMLEvent{event, deleteEvent} {
+		encoder := NewTxnEventEncoder(codecConfig)
+		require.NoError(t, encoder.AppendTxnEvent(e))
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+		require.Equal(t, len(e.RowTypes)-countUpdates(e), messages[0].GetRowsCount())
+		if header == nil {
+			header = messages[0].Key
+			file.Write(header)
+		}
+		require.Equal(t, header, messages[0].Key)
+		file.Write(messages[0].Value)
+		messages[0].Callback()
+	}
+	require.Equal(t, 1, called)
+
+	reader, err := goavro.NewOCFReader(bytes.NewReader(file.Bytes()))
+	require.NoError(t, err)
+	metadata := reader.MetaData()
+	require.Equal(t, "test", string(metadata[ocfTiDBSchemaKey]))
+	require.Equal(t, "t", string(metadata[ocfTiDBTableKey]))
+	require.Equal(t, "100", string(metadata[ocfTiDBTableVersionKey]))
+
+	decoder := NewTxnDecoder(codecConfig, tableInfo)
+	decoder.AddKeyValue(nil, file.Bytes())
+
+	var (
+		expected  []commonEvent.RowChange
+		commitTss []uint64
+	)
+	for _, e := range []*commonEvent.DMLEvent{event, deleteEvent} {
+		for {
+			row, ok := e.GetNextRow()
+			if !ok {
+				e.Rewind()
+				break
+			}
+			expected = append(expected, row)
+			commitTss = append(commitTss, e.CommitTs)
+		}
+	}
+	for i, row := range expected {
+		messageType, hasNext := decoder.HasNext()
+		require.True(t, hasNext)
+		require.Equal(t, common.MessageTypeRow, messageType)
+
+		decoded := decoder.NextDMLEvent()
+		require.Equal(t, commitTss[i], decoded.GetCommitTs())
+		change, ok := decoded.GetNextRow()
+		require.True(t, ok)
+		switch row.RowType {
+		case commonType.RowTypeDelete:
+			require.Equal(t, commonType.RowTypeDelete, change.RowType)
+		default:
+			// the update event only carries the new value.
+			require.Equal(t, commonType.RowTypeInsert, change.RowType)
+			row.PreRow, change.PreRow = row.Row, change.Row
+		}
+		common.CompareRow(t, row, tableInfo, change, decoded.TableInfo)
+	}
+	_, hasNext := decoder.HasNext()
+	require.False(t, hasNext)
+}
+
+func countUpdates(event *commonEvent.DMLEvent) int {
+	var count int
+	for _, rowType := range event.RowTypes {
+		if rowType == commonType.RowTypeUpdate {
+			count++
+		}
+	}
+	return count / 2
+}
@@ -24,9 +24,13 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/codec/avro"
 	"github.com/pingcap/ticdc/pkg/sink/codec/canal"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/craft"
 	"github.com/pingcap/ticdc/pkg/sink/codec/csv"
 	"github.com/pingcap/ticdc/pkg/sink/codec/debezium"
+	"github.com/pingcap/ticdc/pkg/sink/codec/maxwell"
 	"github.com/pingcap/ticdc/pkg/sink/codec/open"
+	"github.com/pingcap/ticdc/pkg/sink/codec/parquet"
+	"github.com/pingcap/ticdc/pkg/sink/codec/protobuf"
 	"github.com/pingcap/ticdc/pkg/sink/codec/simple"
 	"go.uber.org/zap"
 )
@@ -37,8 +41,16 @@ func NewEventEncoder(ctx context.Context, cfg *common.Config) (common.EventEncod
 		return open.NewBatchEncoder(ctx, cfg)
 	case config.ProtocolAvro:
 		return avro.NewAvroEncoder(ctx, cfg)
+	case config.ProtocolProtobuf:
+		return protobuf.NewBatchEncoder(ctx, cfg)
+	case config.ProtocolCanal:
+		return canal.NewBatchEncoder(cfg), nil
 	case config.ProtocolCanalJSON:
 		return canal.NewJSONRowEventEncoder(ctx, cfg)
+	case config.ProtocolMaxwell:
+		return maxwell.NewBatchEncoder(cfg), nil
+	case config.ProtocolCraft:
+		return craft.NewBatchEncoder(cfg), nil
 	case config.ProtocolDebezium:
 		return debezium.NewBatchEncoder(cfg, config.GetGlobalServerConfig().ClusterID), nil
 	case config.ProtocolSimple:
@@ -55,14 +67,22 @@ func NewEventDecoder(
 	switch codecConfig.Protocol {
 	case config.ProtocolOpen, config.ProtocolDefault:
 		return open.NewDecoder(ctx, idx, codecConfig, upstreamTiDB)
+	case config.ProtocolCanal:
+		return canal.NewBatchDecoder(codecConfig), nil
 	case config.ProtocolCanalJSON:
 		return canal.NewDecoder(ctx, codecConfig, upstreamTiDB)
+	case config.ProtocolMaxwell:
+		return maxwell.NewDecoder(codecConfig), nil
+	case config.ProtocolCraft:
+		return craft.NewBatchDecoder(codecConfig), nil
 	case config.ProtocolAvro:
 		schemaM, err := avro.NewConfluentSchemaManager(ctx, codecConfig.AvroConfluentSchemaRegistry, nil)
 		if err != nil {
 			return nil, cerror.Trace(err)
 		}
 		return avro.NewDecoder(codecConfig, idx, schemaM, topic, upstreamTiDB), nil
+	case config.ProtocolProtobuf:
+		return protobuf.NewDecoder(ctx, codecConfig, idx)
 	case config.ProtocolSimple:
 		return simple.NewDecoder(ctx, codecConfig, upstreamTiDB)
 	case config.ProtocolDebezium:
@@ -82,6 +102,12 @@ func NewTxnEventEncoder(
 		return csv.NewTxnEventEncoder(c), nil
 	case config.ProtocolCanalJSON:
 		return canal.NewJSONTxnEventEncoder(c), nil
+	case config.ProtocolAvro:
+		return avro.NewTxnEventEncoder(c), nil
+	case config.ProtocolSimple:
+		return simple.NewTxnEventEncoder(c)
+	case config.ProtocolParquet:
+		return parquet.NewTxnEventEncoder(c), nil
 	default:
 		return nil, errors.ErrSinkUnknownProtocol.GenWithStackByArgs(c.Protocol)
 	}
@@ -0,0 +1,270 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canal
+
+import (
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	canal "github.com/pingcap/tiflow/proto/canal"
+	"go.uber.org/zap"
+)
+
+// batchDecoder decodes the canal protobuf packet into the original events.
+type batchDecoder struct {
+	entries []*canal.Entry
+
+	entry     *canal.Entry
+	rowChange *canal.RowChange
+
+	config         *common.Config
+	tableInfoCache map[tableKey]*commonType.TableInfo
+}
+
+// NewBatchDecoder return a decoder for the canal protobuf protocol
+func NewBatchDecoder(codecConfig *common.Config) common.Decoder {
+	tableIDAllocator.Clean()
+	return &batchDecoder{
+		config:         codecConfig,
+		tableInfoCache: make(map[tableKey]*commonType.TableInfo),
+	}
+}
+
+// AddKeyValue implements the Decoder interface
+func (d *batchDecoder) AddKeyValue(_, value []byte) {
+	packet := new(canal.Packet)
+	if err := proto.Unmarshal(value, packet); err != nil {
+		log.Panic("unmarshal canal packet failed", zap.Int("length", len(value)), zap.Error(err))
+	}
+	if packet.GetType() != canal.PacketType_MESSAGES {
+		log.Panic("unexpected canal packet type", zap.Stringer("type", packet.GetType()))
+	}
+	messages := new(canal.Messages)
+	if err := proto.Unmarshal(packet.GetBody(), messages); err != nil {
+		log.Panic("unmarshal canal messages failed", zap.Int("length", len(packet.GetBody())), zap.Error(err))
+	}
+	for _, data := range messages.GetMessages() {
+		entry := new(canal.Entry)
+		if err := proto.Unmarshal(data, entry); err != nil {
+			log.Panic("unmarshal canal entry failed", zap.Int("length", len(data)), zap.Error(err))
+		}
+		d.entries = append(d.entries, entry)
+	}
+}
+
+// HasNext implements the Decoder interface
+func (d *batchDecoder) HasNext() (common.MessageType, bool) {
+	for len(d.entries) != 0 {
+		entry := d.entries[0]
+		d.entries = d.entries[1:]
+
+		switch entry.GetEntryType() {
+		case canal.EntryType_ENTRYHEARTBEAT:
+			if _, ok := getHeaderProp(entry.GetHeader(), watermarkTsKey); !ok {
+				continue
+			}
+			d.entry = entry
+			d.rowChange = nil
+			return common.MessageTypeResolved, true
+		case canal.EntryType_ROWDATA:
+			rowChange := new(canal.RowChange)
+			if err := proto.Unmarshal(entry.GetStoreValue(), rowChange); err != nil {
+				log.Panic("unmarshal canal row change failed", zap.Error(err))
+			}
+			d.entry = entry
+			d.rowChange = rowChange
+			if rowChange.GetIsDdl() {
+				return common.MessageTypeDDL, true
+			}
+			return common.MessageTypeRow, true
+		default:
+			// transaction begin / end entries carry no data for TiCDC, skip them.
+		}
+	}
+	return common.MessageTypeUnknown, false
+}
+
+// NextResolvedEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *batchDecoder) NextResolvedEvent() uint64 {
+	if d.entry == nil || d.entry.GetEntryType() != canal.EntryType_ENTRYHEARTBEAT {
+		log.Panic("message type is not watermark", zap.Any("entry", d.entry))
+	}
+	value, _ := getHeaderProp(d.entry.GetHeader(), watermarkTsKey)
+	ts, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		log.Panic("invalid watermark ts", zap.String("value", value), zap.Error(err))
+	}
+	d.entry = nil
+	return ts
+}
+
+// NextDDLEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *batchDecoder) NextDDLEvent() *commonEvent.DDLEvent {
+	if d.rowChange == nil || !d.rowChange.GetIsDdl() {
+		log.Panic("message type is not DDL Event", zap.Any("entry", d.entry))
+	}
+	header := d.entry.GetHeader()
+
+	result := new(commonEvent.DDLEvent)
+	result.FinishedTs = d.getCommitTs(header)
+	result.SchemaName = header.GetSchemaName()
+	result.TableName = header.GetTableName()
+	result.Query = d.rowChange.GetSql()
+	actionType := common.GetDDLActionType(result.Query)
+	result.Type = byte(actionType)
+	tableIDAllocator.AddBlockTableID(result.SchemaName, result.TableName,
+		tableIDAllocator.Allocate(result.SchemaName, result.TableName))
+	result.BlockedTables = common.GetBlockedTables(tableIDAllocator, result)
+
+	// if receive a table level DDL, just remove the table info to trigger create a new one.
+	delete(d.tableInfoCache, tableKey{schema: result.SchemaName, table: result.TableName})
+	d.entry = nil
+	d.rowChange = nil
+	return result
+}
+
+// NextDMLEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *batchDecoder) NextDMLEvent() *commonEvent.DMLEvent {
+	if d.rowChange == nil || d.rowChange.GetIsDdl() {
+		log.Panic("message type is not row changed", zap.Any("entry", d.entry))
+	}
+	var (
+		header    = d.entry.GetHeader()
+		eventType = d.rowChange.GetEventType()
+	)
+	d.entry = nil
+	rowChange := d.rowChange
+	d.rowChange = nil
+	if len(rowChange.GetRowDatas()) != 1 {
+		log.Panic("canal row change should contain exactly one row",
+			zap.Int("count", len(rowChange.GetRowDatas())))
+	}
+	rowData := rowChange.GetRowDatas()[0]
+
+	schemaColumns := rowData.GetAfterColumns()
+	if eventType == canal.EventType_DELETE {
+		schemaColumns = rowData.GetBeforeColumns()
+	}
+	tableInfo := d.queryTableInfo(header.GetSchemaName(), header.GetTableName(), schemaColumns)
+
+	commitTs := d.getCommitTs(header)
+	result := new(commonEvent.DMLEvent)
+	result.TableInfo = tableInfo
+	result.StartTs = commitTs
+	result.CommitTs = commitTs
+	result.PhysicalTableID = tableInfo.TableName.TableID
+	result.Rows = chunk.NewChunkFromPoolWithCapacity(tableInfo.GetFieldSlice(), chunk.InitialCapacity)
+	result.AddPostFlushFunc(func() {
+		result.Rows.Destroy(chunk.InitialCapacity, tableInfo.GetFieldSlice())
+	})
+	result.Length++
+
+	columns := tableInfo.GetColumns()
+	switch eventType {
+	case canal.EventType_DELETE:
+		data := formatAllColumnsValue(columnsValue(rowData.GetBeforeColumns()), columns)
+		common.AppendRow2Chunk(data, columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeDelete)
+	case canal.EventType_INSERT:
+		data := formatAllColumnsValue(columnsValue(rowData.GetAfterColumns()), columns)
+		common.AppendRow2Chunk(data, columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeInsert)
+	case canal.EventType_UPDATE:
+		previous := formatAllColumnsValue(columnsValue(rowData.GetBeforeColumns()), columns)
+		data := formatAllColumnsValue(columnsValue(rowData.GetAfterColumns()), columns)
+		common.AppendRow2Chunk(previous, columns, result.Rows)
+		common.AppendRow2Chunk(data, columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeUpdate)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeUpdate)
+	default:
+		log.Panic("unknown event type for the DML event", zap.Stringer("eventType", eventType))
+	}
+	return result
+}
+
+// getCommitTs returns the commitTs carried by the TiDB extension,
+// fallback to the physical time of the execute time if not found, which is not accurate.
+func (d *batchDecoder) getCommitTs(header *canal.Header) uint64 {
+	if value, ok := getHeaderProp(header, commitTsKey); ok {
+		commitTs, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			log.Panic("invalid commit ts", zap.String("value", value), zap.Error(err))
+		}
+		return commitTs
+	}
+	return uint64(header.GetExecuteTime()) << 18
+}
+
+func (d *batchDecoder) queryTableInfo(schemaName, tableName string, columns []*canal.Column) *commonType.TableInfo {
+	cacheKey := tableKey{
+		schema: schemaName,
+		table:  tableName,
+	}
+	tableInfo, ok := d.tableInfoCache[cacheKey]
+	if ok {
+		return tableInfo
+	}
+
+	tidbTableInfo := new(timodel.TableInfo)
+	tidbTableInfo.ID = tableIDAllocator.Allocate(schemaName, tableName)
+	tableIDAllocator.AddBlockTableID(schemaName, tableName, tidbTableInfo.ID)
+	tidbTableInfo.Name = ast.NewCIStr(tableName)
+
+	keys := make(map[string]struct{})
+	tiColumns := make([]*timodel.ColumnInfo, 0, len(columns))
+	for idx, column := range columns {
+		if column.GetIsKey() {
+			keys[column.GetName()] = struct{}{}
+		}
+		tiColumns = append(tiColumns,
+			newTiColumn(int64(idx), column.GetName(), column.GetMysqlType(), column.GetIsKey()))
+	}
+	tidbTableInfo.Columns = tiColumns
+	tidbTableInfo.Indices = newTiIndices(tiColumns, keys)
+	tidbTableInfo.PKIsHandle = len(tidbTableInfo.Indices) != 0
+	tableInfo = commonType.NewTableInfo4Decoder(schemaName, tidbTableInfo)
+	d.tableInfoCache[cacheKey] = tableInfo
+	return tableInfo
+}
+
+func columnsValue(columns []*canal.Column) map[string]any {
+	result := make(map[string]any, len(columns))
+	for _, column := range columns {
+		if column.GetIsNull() {
+			result[column.GetName()] = nil
+			continue
+		}
+		result[column.GetName()] = column.GetValue()
+	}
+	return result
+}
+
+func getHeaderProp(header *canal.Header, key string) (string, bool) {
+	for _, prop := range header.GetProps() {
+		if prop.GetKey() == key {
+			return prop.GetValue(), true
+		}
+	}
+	return "", false
+}
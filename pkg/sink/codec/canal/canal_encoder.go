@@ -0,0 +1,189 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canal
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	canal "github.com/pingcap/tiflow/proto/canal"
+	"go.uber.org/zap"
+)
+
+// packetOverhead is the estimated bytes used by the packet and messages envelope.
+const packetOverhead = 16
+
+// BatchEncoder encodes the row events into canal protobuf packets,
+// each packet contains at most MaxBatchSize entries and does not exceed MaxMessageBytes.
+type BatchEncoder struct {
+	messages []*common.Message
+
+	// entries and callbacks buffered for the packet which is not finalized yet.
+	entries     [][]byte
+	entriesSize int
+	callbackBuf []func()
+
+	entryBuilder *entryBuilder
+	config       *common.Config
+}
+
+// NewBatchEncoder creates a new canal protobuf BatchEncoder.
+func NewBatchEncoder(config *common.Config) common.EventEncoder {
+	return &BatchEncoder{
+		entryBuilder: newEntryBuilder(config),
+		config:       config,
+	}
+}
+
+// EncodeCheckpointEvent implements the EventEncoder interface
+func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	// For canal, there is no such a corresponding type to the checkpoint event,
+	// only send it as a heartbeat entry if the TiDB extension is enabled.
+	if !d.config.EnableTiDBExtension {
+		return nil, nil
+	}
+	entry := d.entryBuilder.fromCheckpoint(ts)
+	b, err := proto.Marshal(entry)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCanalEncodeFailed, err)
+	}
+	value, err := newPacket([][]byte{b})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMsg(nil, value), nil
+}
+
+// AppendRowChangedEvent implements the EventEncoder interface
+func (d *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *commonEvent.RowEvent,
+) error {
+	entry, err := d.entryBuilder.fromRowEvent(e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	b, err := proto.Marshal(entry)
+	if err != nil {
+		return errors.WrapError(errors.ErrCanalEncodeFailed, err)
+	}
+
+	length := len(b) + packetOverhead + common.MaxRecordOverhead
+	if length > d.config.MaxMessageBytes {
+		log.Warn("Single message is too large for canal",
+			zap.Int("maxMessageBytes", d.config.MaxMessageBytes),
+			zap.Int("length", length),
+			zap.Any("table", e.TableInfo.TableName))
+		return errors.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+
+	if len(d.entries) != 0 &&
+		(d.entriesSize+length > d.config.MaxMessageBytes || len(d.entries) >= d.config.MaxBatchSize) {
+		if err = d.flushPacket(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	d.entries = append(d.entries, b)
+	d.entriesSize += len(b) + packetOverhead
+	if e.Callback != nil {
+		d.callbackBuf = append(d.callbackBuf, e.Callback)
+	}
+	return nil
+}
+
+// EncodeDDLEvent implements the EventEncoder interface
+func (d *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*common.Message, error) {
+	entry, err := d.entryBuilder.fromDDLEvent(e)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b, err := proto.Marshal(entry)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCanalEncodeFailed, err)
+	}
+	value, err := newPacket([][]byte{b})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMsg(nil, value), nil
+}
+
+// Build implements the EventEncoder interface
+func (d *BatchEncoder) Build() []*common.Message {
+	if err := d.flushPacket(); err != nil {
+		log.Panic("Error when generating Canal packet", zap.Error(err))
+	}
+	if len(d.messages) == 0 {
+		return nil
+	}
+	result := d.messages
+	d.messages = nil
+	return result
+}
+
+// Clean implements the EventEncoder interface
+func (d *BatchEncoder) Clean() {}
+
+// flushPacket wraps all buffered entries into one packet message.
+func (d *BatchEncoder) flushPacket() error {
+	if len(d.entries) == 0 {
+		return nil
+	}
+	value, err := newPacket(d.entries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	message := common.NewMsg(nil, value)
+	message.SetRowsCount(len(d.entries))
+	if len(d.callbackBuf) != 0 {
+		callbacks := d.callbackBuf
+		message.Callback = func() {
+			for _, cb := range callbacks {
+				cb()
+			}
+		}
+	}
+	d.messages = append(d.messages, message)
+
+	d.entries = nil
+	d.entriesSize = 0
+	d.callbackBuf = nil
+	return nil
+}
+
+// newPacket marshals the entries as the canal messages packet.
+func newPacket(entries [][]byte) ([]byte, error) {
+	messages := &canal.Messages{Messages: entries}
+	body, err := proto.Marshal(messages)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCanalEncodeFailed, err)
+	}
+	packet := &canal.Packet{
+		VersionPresent: &canal.Packet_Version{
+			Version: CanalPacketVersion,
+		},
+		Type: canal.PacketType_MESSAGES,
+		Body: body,
+	}
+	value, err := proto.Marshal(packet)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCanalEncodeFailed, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canal
+
+import (
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	canal "github.com/pingcap/tiflow/proto/canal"
+)
+
+// compatible with canal-1.1.4
+// https://github.com/alibaba/canal/tree/canal-1.1.4
+const (
+	CanalPacketVersion   int32  = 1
+	CanalProtocolVersion int32  = 1
+	CanalServerEncode    string = "UTF-8"
+)
+
+// The keys of the header props used by the TiDB extension,
+// official canal clients ignore unknown props.
+const (
+	rowsCountKey   = "rowsCount"
+	commitTsKey    = "commitTs"
+	watermarkTsKey = "watermarkTs"
+)
+
+type entryBuilder struct {
+	config *common.Config
+}
+
+func newEntryBuilder(config *common.Config) *entryBuilder {
+	return &entryBuilder{
+		config: config,
+	}
+}
+
+// build the header of a canal entry
+func (b *entryBuilder) buildHeader(
+	commitTs uint64, schema string, table string, eventType canal.EventType, rowCount int,
+) *canal.Header {
+	h := &canal.Header{
+		VersionPresent:    &canal.Header_Version{Version: CanalProtocolVersion},
+		ServerenCode:      CanalServerEncode,
+		ExecuteTime:       convertToCanalTs(commitTs),
+		SourceTypePresent: &canal.Header_SourceType{SourceType: canal.Type_MYSQL},
+		SchemaName:        schema,
+		TableName:         table,
+		EventTypePresent:  &canal.Header_EventType{EventType: eventType},
+	}
+	if rowCount > 0 {
+		h.Props = append(h.Props, &canal.Pair{
+			Key:   rowsCountKey,
+			Value: strconv.Itoa(rowCount),
+		})
+	}
+	if b.config.EnableTiDBExtension {
+		h.Props = append(h.Props, &canal.Pair{
+			Key:   commitTsKey,
+			Value: strconv.FormatUint(commitTs, 10),
+		})
+	}
+	return h
+}
+
+// build the Column in the canal RowData
+// see https://github.com/alibaba/canal/blob/b54bea5e3337c9597c427a53071d214ff04628d1/parse/src/main/java/com/alibaba/otter/canal/parse/inbound/mysql/dbsync/LogEventConvert.java#L756-L872
+func (b *entryBuilder) buildColumns(
+	row *chunk.Row,
+	tableInfo *commonType.TableInfo,
+	columnSelector commonEvent.Selector,
+	onlyHandleKeyColumns bool,
+	updated bool,
+) []*canal.Column {
+	columns := make([]*canal.Column, 0, len(tableInfo.GetColumns()))
+	for idx, col := range tableInfo.GetColumns() {
+		if col == nil || col.IsVirtualGenerated() || !columnSelector.Select(col) {
+			continue
+		}
+		if onlyHandleKeyColumns && !tableInfo.IsHandleKey(col.ID) {
+			continue
+		}
+		value, javaType := formatColumnValue(row, idx, col)
+		isNull := row.IsNull(idx)
+		if isNull {
+			value = ""
+		}
+		columns = append(columns, &canal.Column{
+			Index:         int32(idx),
+			SqlType:       int32(javaType),
+			Name:          col.Name.O,
+			IsKey:         mysql.HasPriKeyFlag(col.GetFlag()),
+			Updated:       updated,
+			IsNullPresent: &canal.Column_IsNull{IsNull: isNull},
+			Value:         value,
+			MysqlType:     common.GetMySQLType(col, b.config.ContentCompatible),
+		})
+	}
+	return columns
+}
+
+// build the RowData of a canal entry
+func (b *entryBuilder) buildRowData(e *commonEvent.RowEvent) *canal.RowData {
+	rowData := &canal.RowData{}
+	switch {
+	case e.IsInsert():
+		rowData.AfterColumns = b.buildColumns(e.GetRows(), e.TableInfo, e.ColumnSelector, false, true)
+	case e.IsDelete():
+		rowData.BeforeColumns = b.buildColumns(e.GetPreRows(), e.TableInfo, e.ColumnSelector,
+			b.config.DeleteOnlyHandleKeyColumns, false)
+	default:
+		rowData.BeforeColumns = b.buildColumns(e.GetPreRows(), e.TableInfo, e.ColumnSelector, false, true)
+		rowData.AfterColumns = b.buildColumns(e.GetRows(), e.TableInfo, e.ColumnSelector, false, true)
+	}
+	return rowData
+}
+
+// fromRowEvent builds canal entry from the RowEvent
+func (b *entryBuilder) fromRowEvent(e *commonEvent.RowEvent) (*canal.Entry, error) {
+	eventType := convertRowEventType(e)
+	header := b.buildHeader(e.CommitTs, e.TableInfo.GetSchemaName(), e.TableInfo.GetTableName(), eventType, 1)
+	rc := &canal.RowChange{
+		EventTypePresent: &canal.RowChange_EventType{EventType: eventType},
+		IsDdlPresent:     &canal.RowChange_IsDdl{IsDdl: false},
+		RowDatas:         []*canal.RowData{b.buildRowData(e)},
+	}
+	rcBytes, err := proto.Marshal(rc)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCanalEncodeFailed, err)
+	}
+
+	return &canal.Entry{
+		Header:           header,
+		EntryTypePresent: &canal.Entry_EntryType{EntryType: canal.EntryType_ROWDATA},
+		StoreValue:       rcBytes,
+	}, nil
+}
+
+// fromDDLEvent builds canal entry from the DDLEvent
+func (b *entryBuilder) fromDDLEvent(e *commonEvent.DDLEvent) (*canal.Entry, error) {
+	eventType := convertDdlEventType(e.Type)
+	header := b.buildHeader(e.GetCommitTs(), e.GetSchemaName(), e.GetTableName(), eventType, -1)
+	rc := &canal.RowChange{
+		EventTypePresent: &canal.RowChange_EventType{EventType: eventType},
+		IsDdlPresent:     &canal.RowChange_IsDdl{IsDdl: isCanalDDL(eventType)},
+		Sql:              e.Query,
+		DdlSchemaName:    e.GetSchemaName(),
+	}
+	rcBytes, err := proto.Marshal(rc)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrCanalEncodeFailed, err)
+	}
+
+	return &canal.Entry{
+		Header:           header,
+		EntryTypePresent: &canal.Entry_EntryType{EntryType: canal.EntryType_ROWDATA},
+		StoreValue:       rcBytes,
+	}, nil
+}
+
+// fromCheckpoint builds a heartbeat entry which carries the watermark,
+// it's only sent if the TiDB extension is enabled.
+func (b *entryBuilder) fromCheckpoint(ts uint64) *canal.Entry {
+	header := &canal.Header{
+		VersionPresent:    &canal.Header_Version{Version: CanalProtocolVersion},
+		ServerenCode:      CanalServerEncode,
+		ExecuteTime:       convertToCanalTs(ts),
+		SourceTypePresent: &canal.Header_SourceType{SourceType: canal.Type_MYSQL},
+		Props: []*canal.Pair{{
+			Key:   watermarkTsKey,
+			Value: strconv.FormatUint(ts, 10),
+		}},
+	}
+	return &canal.Entry{
+		Header:           header,
+		EntryTypePresent: &canal.Entry_EntryType{EntryType: canal.EntryType_ENTRYHEARTBEAT},
+	}
+}
+
+// get the canal EventType according to the RowEvent
+func convertRowEventType(e *commonEvent.RowEvent) canal.EventType {
+	if e.IsDelete() {
+		return canal.EventType_DELETE
+	}
+	if e.IsInsert() {
+		return canal.EventType_INSERT
+	}
+	return canal.EventType_UPDATE
+}
+
+func isCanalDDL(t canal.EventType) bool {
+	// see https://github.com/alibaba/canal/blob/b54bea5e3337c9597c427a53071d214ff04628d1/parse/src/main/java/com/alibaba/otter/canal/parse/inbound/mysql/dbsync/LogEventConvert.java#L297
+	switch t {
+	case canal.EventType_CREATE,
+		canal.EventType_RENAME,
+		canal.EventType_CINDEX,
+		canal.EventType_DINDEX,
+		canal.EventType_ALTER,
+		canal.EventType_ERASE,
+		canal.EventType_TRUNCATE,
+		canal.EventType_QUERY:
+		return true
+	}
+	return false
+}
@@ -558,54 +558,55 @@ func newTiColumns(msg canalJSONMessageInterface) []*timodel.ColumnInfo {
 		return strings.Compare(a.name, b.name)
 	})
 
-	var nextColumnID int64
 	result := make([]*timodel.ColumnInfo, 0, len(msg.getMySQLType()))
-	for _, rawColumn := range rawColumnList {
-		mysqlType := rawColumn.mysqlType
-		name := rawColumn.name
-		col := new(timodel.ColumnInfo)
-		col.ID = nextColumnID
-		col.Name = ast.NewCIStr(name)
-		basicType := common.ExtractBasicMySQLType(mysqlType)
-		col.FieldType = *types.NewFieldType(basicType)
-		if common.IsBinaryMySQLType(mysqlType) {
-			col.AddFlag(mysql.BinaryFlag)
-			col.SetCharset("binary")
-			col.SetCollate("binary")
-		}
-		if strings.HasPrefix(mysqlType, "char") ||
-			strings.HasPrefix(mysqlType, "varchar") ||
-			strings.Contains(mysqlType, "text") ||
-			strings.Contains(mysqlType, "enum") ||
-			strings.Contains(mysqlType, "set") {
-			col.SetCharset("utf8mb4")
-			col.SetCollate("utf8mb4_bin")
-		}
+	for idx, rawColumn := range rawColumnList {
+		_, isPrimaryKey := msg.pkNameSet()[rawColumn.name]
+		result = append(result, newTiColumn(int64(idx), rawColumn.name, rawColumn.mysqlType, isPrimaryKey))
+	}
+	return result
+}
 
-		if _, ok := msg.pkNameSet()[name]; ok {
-			col.AddFlag(mysql.PriKeyFlag)
-			col.AddFlag(mysql.UniqueKeyFlag)
-			col.AddFlag(mysql.NotNullFlag)
-		}
-		if common.IsUnsignedMySQLType(mysqlType) {
-			col.AddFlag(mysql.UnsignedFlag)
-		}
-		flen, decimal := common.ExtractFlenDecimal(mysqlType, col.GetType())
-		col.FieldType.SetFlen(flen)
+func newTiColumn(id int64, name string, mysqlType string, isPrimaryKey bool) *timodel.ColumnInfo {
+	col := new(timodel.ColumnInfo)
+	col.ID = id
+	col.Name = ast.NewCIStr(name)
+	basicType := common.ExtractBasicMySQLType(mysqlType)
+	col.FieldType = *types.NewFieldType(basicType)
+	if common.IsBinaryMySQLType(mysqlType) {
+		col.AddFlag(mysql.BinaryFlag)
+		col.SetCharset("binary")
+		col.SetCollate("binary")
+	}
+	if strings.HasPrefix(mysqlType, "char") ||
+		strings.HasPrefix(mysqlType, "varchar") ||
+		strings.Contains(mysqlType, "text") ||
+		strings.Contains(mysqlType, "enum") ||
+		strings.Contains(mysqlType, "set") {
+		col.SetCharset("utf8mb4")
+		col.SetCollate("utf8mb4_bin")
+	}
+
+	if isPrimaryKey {
+		col.AddFlag(mysql.PriKeyFlag)
+		col.AddFlag(mysql.UniqueKeyFlag)
+		col.AddFlag(mysql.NotNullFlag)
+	}
+	if common.IsUnsignedMySQLType(mysqlType) {
+		col.AddFlag(mysql.UnsignedFlag)
+	}
+	flen, decimal := common.ExtractFlenDecimal(mysqlType, col.GetType())
+	col.FieldType.SetFlen(flen)
+	col.FieldType.SetDecimal(decimal)
+	switch basicType {
+	case mysql.TypeEnum, mysql.TypeSet:
+		elements := common.ExtractElements(mysqlType)
+		col.SetElems(elements)
+	case mysql.TypeDuration:
+		decimal = common.ExtractDecimal(mysqlType)
 		col.FieldType.SetDecimal(decimal)
-		switch basicType {
-		case mysql.TypeEnum, mysql.TypeSet:
-			elements := common.ExtractElements(mysqlType)
-			col.SetElems(elements)
-		case mysql.TypeDuration:
-			decimal = common.ExtractDecimal(mysqlType)
-			col.FieldType.SetDecimal(decimal)
-		default:
-		}
-		result = append(result, col)
-		nextColumnID++
+	default:
 	}
-	return result
+	return col
 }
 
 func newTiIndices(columns []*timodel.ColumnInfo, keys map[string]struct{}) []*timodel.IndexInfo {
@@ -0,0 +1,221 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	canal "github.com/pingcap/tiflow/proto/canal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanalBatchEncoderRowEvents(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c decimal(10, 2), d datetime,
+		e blob, f bit(10), g json, h enum('a','b'), i set('a','b'), j bigint unsigned)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	insert := helper.DML2Event("test", "t", `insert into test.t values
+		(1, 'hello', 12.34, '2025-01-02 03:04:05', x'0102ff', b'1010101', '{"k": "v"}', 'b', 'a,b', 18446744073709551615)`)
+	insertRow, ok := insert.GetNextRow()
+	require.True(t, ok)
+
+	columnSelector := columnselector.NewDefaultColumnSelector()
+	insertEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       insert.GetCommitTs(),
+		Event:          insertRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	update := helper.DML2Event("test", "t", `update test.t set b = null, c = 56.78 where a = 1`)
+	updateRow, ok := update.GetNextRow()
+	require.True(t, ok)
+	updateRow.PreRow = insertRow.Row
+	updateEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       update.GetCommitTs(),
+		Event:          updateRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	deleteRow := updateRow
+	deleteRow.PreRow = updateRow.Row
+	deleteRow.Row = chunk.Row{}
+	deleteEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       update.GetCommitTs() + 1,
+		Event:          deleteRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	codecConfig := common.NewConfig(config.ProtocolCanal)
+	codecConfig.EnableTiDBExtension = true
+
+	ctx := context.Background()
+	encoder := NewBatchEncoder(codecConfig)
+	events := []*commonEvent.RowEvent{insertEvent, updateEvent, deleteEvent}
+	for _, event := range events {
+		err := encoder.AppendRowChangedEvent(ctx, "", event)
+		require.NoError(t, err)
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, len(events), messages[0].GetRowsCount())
+
+	decoder := NewBatchDecoder(codecConfig)
+	decoder.AddKeyValue(messages[0].Key, messages[0].Value)
+	for _, event := range events {
+		messageType, hasNext := decoder.HasNext()
+		require.True(t, hasNext)
+		require.Equal(t, common.MessageTypeRow, messageType)
+
+		decoded := decoder.NextDMLEvent()
+		require.Equal(t, event.CommitTs, decoded.GetCommitTs())
+		change, ok := decoded.GetNextRow()
+		require.True(t, ok)
+		common.CompareRow(t, event.Event, event.TableInfo, change, decoded.TableInfo)
+	}
+	_, hasNext := decoder.HasNext()
+	require.False(t, hasNext)
+}
+
+func TestCanalBatchEncoderMaxBatchSize(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a int primary key, b int)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	dml := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 1)`,
+		`insert into test.t values (2, 2)`,
+		`insert into test.t values (3, 3)`,
+		`insert into test.t values (4, 4)`,
+		`insert into test.t values (5, 5)`)
+
+	codecConfig := common.NewConfig(config.ProtocolCanal)
+	codecConfig.MaxBatchSize = 2
+
+	var called int
+	ctx := context.Background()
+	encoder := NewBatchEncoder(codecConfig)
+	for {
+		row, ok := dml.GetNextRow()
+		if !ok {
+			break
+		}
+		err := encoder.AppendRowChangedEvent(ctx, "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       dml.GetCommitTs(),
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+			Callback:       func() { called++ },
+		})
+		require.NoError(t, err)
+	}
+
+	messages := encoder.Build()
+	require.Len(t, messages, 3)
+	for idx, expected := range []int{2, 2, 1} {
+		require.Equal(t, expected, messages[idx].GetRowsCount())
+		messages[idx].Callback()
+	}
+	require.Equal(t, 5, called)
+	require.Nil(t, encoder.Build())
+
+	decoder := NewBatchDecoder(codecConfig)
+	var count int
+	for _, message := range messages {
+		decoder.AddKeyValue(message.Key, message.Value)
+		for {
+			messageType, hasNext := decoder.HasNext()
+			if !hasNext {
+				break
+			}
+			require.Equal(t, common.MessageTypeRow, messageType)
+			decoded := decoder.NextDMLEvent()
+			require.Equal(t, dml.GetCommitTs()>>18<<18, decoded.GetCommitTs())
+			count++
+		}
+	}
+	require.Equal(t, 5, count)
+}
+
+func TestCanalDDLAndCheckpoint(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	codecConfig := common.NewConfig(config.ProtocolCanal)
+	encoder := NewBatchEncoder(codecConfig)
+
+	// checkpoint is not sent without the TiDB extension.
+	message, err := encoder.EncodeCheckpointEvent(1024)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	codecConfig.EnableTiDBExtension = true
+	encoder = NewBatchEncoder(codecConfig)
+	decoder := NewBatchDecoder(codecConfig)
+
+	message, err = encoder.EncodeCheckpointEvent(1024)
+	require.NoError(t, err)
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext := decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeResolved, messageType)
+	require.Equal(t, uint64(1024), decoder.NextResolvedEvent())
+
+	createTable := helper.DDL2Event(`create table test.t(a int primary key, b int)`)
+	message, err = encoder.EncodeDDLEvent(createTable)
+	require.NoError(t, err)
+
+	packet := new(canal.Packet)
+	require.NoError(t, proto.Unmarshal(message.Value, packet))
+	messages := new(canal.Messages)
+	require.NoError(t, proto.Unmarshal(packet.GetBody(), messages))
+	require.Len(t, messages.GetMessages(), 1)
+	entry := new(canal.Entry)
+	require.NoError(t, proto.Unmarshal(messages.GetMessages()[0], entry))
+	require.Equal(t, canal.EventType_CREATE, entry.GetHeader().GetEventType())
+	require.Equal(t, convertToCanalTs(createTable.GetCommitTs()), entry.GetHeader().GetExecuteTime())
+
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext = decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeDDL, messageType)
+
+	decoded := decoder.NextDDLEvent()
+	require.Equal(t, createTable.Query, decoded.Query)
+	require.Equal(t, createTable.GetCommitTs(), decoded.GetCommitTs())
+	require.Equal(t, "test", decoded.GetSchemaName())
+	require.Equal(t, "t", decoded.GetTableName())
+	require.Equal(t, byte(timodel.ActionCreateTable), decoded.Type)
+}
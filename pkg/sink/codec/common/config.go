@@ -65,6 +65,9 @@ type Config struct {
 	// and would cause error, so this is only used for ticdc internal testing purpose, should not be
 	// exposed to the outside users.
 	AvroEnableWatermark bool
+	// AvroSchemaEmbedded is true if the schema is embedded in the avro object container file,
+	// it's set for the cloud storage sink, no schema registry is required.
+	AvroSchemaEmbedded bool
 
 	// canal-json only
 	ContentCompatible bool
@@ -225,10 +228,13 @@ func (c *Config) Apply(sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
 		c.AvroBigintUnsignedHandlingMode = *urlParameter.AvroBigintUnsignedHandlingMode
 	}
 	if urlParameter.AvroEnableWatermark != nil {
-		if c.EnableTiDBExtension && c.Protocol == config.ProtocolAvro {
+		if c.EnableTiDBExtension && c.Protocol.IsSchemaRegistryBased() {
 			c.AvroEnableWatermark = *urlParameter.AvroEnableWatermark
 		}
 	}
+	if c.Protocol == config.ProtocolAvro && config.IsStorageScheme(sinkURI.Scheme) {
+		c.AvroSchemaEmbedded = true
+	}
 	if urlParameter.AvroSchemaRegistry != "" {
 		c.AvroConfluentSchemaRegistry = urlParameter.AvroSchemaRegistry
 	}
@@ -236,9 +242,9 @@ func (c *Config) Apply(sinkURI *url.URL, sinkConfig *config.SinkConfig) error {
 		sinkConfig.KafkaConfig.GlueSchemaRegistryConfig != nil {
 		c.AvroGlueSchemaRegistry = sinkConfig.KafkaConfig.GlueSchemaRegistryConfig
 	}
-	if c.Protocol == config.ProtocolAvro && sinkConfig.ForceReplicate {
+	if c.Protocol.IsSchemaRegistryBased() && sinkConfig.ForceReplicate {
 		return errors.ErrCodecInvalidConfig.GenWithStack(
-			`force-replicate must be disabled, when using avro protocol`)
+			`force-replicate must be disabled, when using %s protocol`, c.Protocol)
 	}
 
 	if sinkConfig != nil {
@@ -353,9 +359,11 @@ func (c *Config) WithChangefeedID(id common.ChangeFeedID) *Config {
 // Validate the Config
 func (c *Config) Validate() error {
 	if c.EnableTiDBExtension &&
-		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolAvro || c.Protocol == config.ProtocolDebezium) {
+		!(c.Protocol == config.ProtocolCanalJSON || c.Protocol == config.ProtocolCanal ||
+			c.Protocol == config.ProtocolMaxwell || c.Protocol == config.ProtocolAvro ||
+			c.Protocol == config.ProtocolDebezium || c.Protocol == config.ProtocolProtobuf) {
 		log.Warn("ignore invalid config, enable-tidb-extension"+
-			"only supports canal-json/avro/debezium protocol",
+			"only supports canal/canal-json/maxwell/avro/debezium/protobuf protocol",
 			zap.Bool("enableTidbExtension", c.EnableTiDBExtension),
 			zap.String("protocol", c.Protocol.String()))
 	}
@@ -369,7 +377,7 @@ func (c *Config) Validate() error {
 			)
 		}
 
-		if c.AvroConfluentSchemaRegistry == "" && c.AvroGlueSchemaRegistry == nil {
+		if !c.AvroSchemaEmbedded && c.AvroConfluentSchemaRegistry == "" && c.AvroGlueSchemaRegistry == nil {
 			return errors.ErrCodecInvalidConfig.GenWithStack(
 				`Avro protocol requires parameter "%s" or "%s" to specify the schema registry`,
 				codecOPTAvroSchemaRegistry,
@@ -410,6 +418,18 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Protocol == config.ProtocolProtobuf {
+		if c.AvroGlueSchemaRegistry != nil {
+			return errors.ErrCodecInvalidConfig.GenWithStack(
+				`Protobuf protocol does not support the "%s"`, coderOPTAvroGlueSchemaRegistry)
+		}
+		if c.AvroConfluentSchemaRegistry == "" {
+			return errors.ErrCodecInvalidConfig.GenWithStack(
+				`Protobuf protocol requires parameter "%s" to specify the schema registry`,
+				codecOPTAvroSchemaRegistry)
+		}
+	}
+
 	if c.MaxMessageBytes <= 0 {
 		return errors.ErrCodecInvalidConfig.Wrap(
 			errors.Errorf("invalid max-message-bytes %d", c.MaxMessageBytes),
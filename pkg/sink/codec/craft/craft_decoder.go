@@ -0,0 +1,261 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+var tableIDAllocator = common.NewTableIDAllocator()
+
+type decoder struct {
+	headers *Headers
+	decoder *MessageDecoder
+	index   int
+
+	allocator *SliceAllocator
+	config    *common.Config
+}
+
+// NewBatchDecoder creates a new decoder for the craft protocol.
+func NewBatchDecoder(codecConfig *common.Config) common.Decoder {
+	tableIDAllocator.Clean()
+	return &decoder{
+		allocator: NewSliceAllocator(defaultAllocatorBatchSize),
+		config:    codecConfig,
+	}
+}
+
+// AddKeyValue implements the Decoder interface
+func (d *decoder) AddKeyValue(_, value []byte) {
+	if d.decoder != nil && d.index < d.headers.Count() {
+		log.Panic("add key value to the decoder which has undecoded events",
+			zap.Int("index", d.index), zap.Int("count", d.headers.Count()))
+	}
+	messageDecoder, err := NewMessageDecoder(value, d.allocator)
+	if err != nil {
+		log.Panic("create the craft message decoder failed", zap.Error(err))
+	}
+	d.decoder = messageDecoder
+	d.headers = messageDecoder.Headers()
+	d.index = 0
+}
+
+// HasNext implements the Decoder interface
+func (d *decoder) HasNext() (common.MessageType, bool) {
+	if d.decoder == nil || d.index >= d.headers.Count() {
+		return common.MessageTypeUnknown, false
+	}
+	return d.headers.GetType(d.index), true
+}
+
+// NextResolvedEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *decoder) NextResolvedEvent() uint64 {
+	if d.headers.GetType(d.index) != common.MessageTypeResolved {
+		log.Panic("message type is not watermark", zap.Any("messageType", d.headers.GetType(d.index)))
+	}
+	ts := d.headers.GetTs(d.index)
+	d.index++
+	return ts
+}
+
+// NextDDLEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *decoder) NextDDLEvent() *commonEvent.DDLEvent {
+	if d.headers.GetType(d.index) != common.MessageTypeDDL {
+		log.Panic("message type is not DDL", zap.Any("messageType", d.headers.GetType(d.index)))
+	}
+	ty, query, err := d.decoder.DDLEvent(d.index)
+	if err != nil {
+		log.Panic("decode the DDL event failed", zap.Int("index", d.index), zap.Error(err))
+	}
+	result := new(commonEvent.DDLEvent)
+	result.FinishedTs = d.headers.GetTs(d.index)
+	result.SchemaName = d.headers.GetSchema(d.index)
+	result.TableName = d.headers.GetTable(d.index)
+	result.Query = query
+	result.Type = ty
+	tableIDAllocator.AddBlockTableID(result.SchemaName, result.TableName,
+		tableIDAllocator.Allocate(result.SchemaName, result.TableName))
+	result.BlockedTables = common.GetBlockedTables(tableIDAllocator, result)
+	d.index++
+	return result
+}
+
+// NextDMLEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *decoder) NextDMLEvent() *commonEvent.DMLEvent {
+	if d.headers.GetType(d.index) != common.MessageTypeRow {
+		log.Panic("message type is not row changed", zap.Any("messageType", d.headers.GetType(d.index)))
+	}
+	groups, err := d.decoder.RowChangedEvent(d.index)
+	if err != nil {
+		log.Panic("decode the row changed event failed", zap.Int("index", d.index), zap.Error(err))
+	}
+	var newGroup, oldGroup *columnGroup
+	for _, group := range groups {
+		switch group.ty {
+		case columnGroupTypeNew:
+			newGroup = group
+		case columnGroupTypeOld:
+			oldGroup = group
+		default:
+			log.Panic("unknown column group type", zap.Uint8("type", group.ty))
+		}
+	}
+	if newGroup == nil && oldGroup == nil {
+		log.Panic("the row changed event has no column group", zap.Int("index", d.index))
+	}
+
+	schema := d.headers.GetSchema(d.index)
+	table := d.headers.GetTable(d.index)
+	commitTs := d.headers.GetTs(d.index)
+	d.index++
+
+	group := newGroup
+	if group == nil {
+		group = oldGroup
+	}
+	tableInfo := newTableInfo(schema, table, group)
+	result := new(commonEvent.DMLEvent)
+	result.TableInfo = tableInfo
+	result.PhysicalTableID = tableInfo.TableName.TableID
+	result.StartTs = commitTs
+	result.CommitTs = commitTs
+	result.Rows = chunk.NewChunkFromPoolWithCapacity(tableInfo.GetFieldSlice(), chunk.InitialCapacity)
+	result.AddPostFlushFunc(func() {
+		result.Rows.Destroy(chunk.InitialCapacity, tableInfo.GetFieldSlice())
+	})
+	result.Length++
+
+	columns := tableInfo.GetColumns()
+	switch {
+	case oldGroup == nil:
+		common.AppendRow2Chunk(decodeColumnGroupValues(newGroup), columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeInsert)
+	case newGroup == nil:
+		common.AppendRow2Chunk(decodeColumnGroupValues(oldGroup), columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeDelete)
+	default:
+		common.AppendRow2Chunk(decodeColumnGroupValues(oldGroup), columns, result.Rows)
+		common.AppendRow2Chunk(decodeColumnGroupValues(newGroup), columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeUpdate)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeUpdate)
+	}
+	return result
+}
+
+func decodeColumnGroupValues(group *columnGroup) map[string]any {
+	result := make(map[string]any, len(group.names))
+	for idx, name := range group.names {
+		value, err := decodeColumnValue(group.values[idx], byte(group.types[idx]), group.flags[idx])
+		if err != nil {
+			log.Panic("decode the column value failed",
+				zap.String("column", name), zap.Uint64("type", group.types[idx]), zap.Error(err))
+		}
+		result[name] = value
+	}
+	return result
+}
+
+func newTableInfo(schema, table string, group *columnGroup) *commonType.TableInfo {
+	tidbTableInfo := new(timodel.TableInfo)
+	tidbTableInfo.ID = tableIDAllocator.Allocate(schema, table)
+	tableIDAllocator.AddBlockTableID(schema, table, tidbTableInfo.ID)
+	tidbTableInfo.Name = ast.NewCIStr(table)
+
+	columns := make([]*timodel.ColumnInfo, 0, len(group.names))
+	for idx, name := range group.names {
+		col := newTiColumn(int64(idx), name, byte(group.types[idx]), group.flags[idx])
+		switch col.GetType() {
+		case mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration:
+			// the fsp is not carried by the message, take it from the value.
+			col.SetDecimal(fractionDigits(string(group.values[idx])))
+		default:
+		}
+		columns = append(columns, col)
+	}
+	tidbTableInfo.Columns = columns
+	tidbTableInfo.Indices = newTiIndices(columns)
+	tidbTableInfo.PKIsHandle = len(tidbTableInfo.Indices) != 0
+	return commonType.NewTableInfo4Decoder(schema, tidbTableInfo)
+}
+
+func newTiColumn(id int64, name string, ty byte, flags uint64) *timodel.ColumnInfo {
+	col := new(timodel.ColumnInfo)
+	col.ID = id
+	col.Name = ast.NewCIStr(name)
+	col.FieldType = *types.NewFieldType(ty)
+	if flags&binaryFlag != 0 {
+		col.AddFlag(mysql.BinaryFlag)
+		col.SetCharset("binary")
+		col.SetCollate("binary")
+	} else if types.IsString(ty) {
+		col.SetCharset("utf8mb4")
+		col.SetCollate("utf8mb4_bin")
+	}
+	if flags&(primaryKeyFlag|handleKeyFlag) != 0 {
+		col.AddFlag(mysql.PriKeyFlag)
+	}
+	if flags&uniqueKeyFlag != 0 {
+		col.AddFlag(mysql.UniqueKeyFlag)
+	}
+	if flags&multipleKeyFlag != 0 {
+		col.AddFlag(mysql.MultipleKeyFlag)
+	}
+	if flags&nullableFlag == 0 {
+		col.AddFlag(mysql.NotNullFlag)
+	}
+	if flags&unsignedFlag != 0 {
+		col.AddFlag(mysql.UnsignedFlag)
+	}
+	if flags&generatedColumnFlag != 0 {
+		col.AddFlag(mysql.GeneratedColumnFlag)
+		col.GeneratedExprString = "holder" // just to make it not empty
+		col.GeneratedStored = true
+	}
+	return col
+}
+
+func newTiIndices(columns []*timodel.ColumnInfo) []*timodel.IndexInfo {
+	indexColumns := make([]*timodel.IndexColumn, 0)
+	for idx, col := range columns {
+		if mysql.HasPriKeyFlag(col.GetFlag()) {
+			indexColumns = append(indexColumns, &timodel.IndexColumn{
+				Name:   col.Name,
+				Offset: idx,
+			})
+		}
+	}
+	if len(indexColumns) == 0 {
+		return nil
+	}
+	return []*timodel.IndexInfo{{
+		ID:      1,
+		Name:    ast.NewCIStr("primary"),
+		Columns: indexColumns,
+		Primary: true,
+		Unique:  true,
+	}}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	"context"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+)
+
+// defaultAllocatorBatchSize is the batch size of the slice allocator used by the encoder.
+const defaultAllocatorBatchSize = 64
+
+// BatchEncoder encodes the events into the craft binary format.
+// Row changed events are batched into one message in columnar layout,
+// one message can contain at most MaxBatchSize events, and the estimated size cannot exceed MaxMessageBytes.
+type BatchEncoder struct {
+	rowChangedBuffer *RowChangedEventBuffer
+	messages         []*common.Message
+	// buff the callback of the events in the row changed buffer
+	callbackBuff []func()
+
+	allocator *SliceAllocator
+	config    *common.Config
+}
+
+// NewBatchEncoder creates a new BatchEncoder.
+func NewBatchEncoder(config *common.Config) common.EventEncoder {
+	allocator := NewSliceAllocator(defaultAllocatorBatchSize)
+	return &BatchEncoder{
+		rowChangedBuffer: NewRowChangedEventBuffer(allocator),
+		allocator:        allocator,
+		config:           config,
+	}
+}
+
+// EncodeCheckpointEvent implements the EventEncoder interface
+func (e *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	return common.NewMsg(nil, NewResolvedEventEncoder(e.allocator, ts).Encode()), nil
+}
+
+// AppendRowChangedEvent implements the EventEncoder interface
+func (e *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	ev *commonEvent.RowEvent,
+) error {
+	size, event := newRowChangedMessage(e.allocator, ev)
+	// flush the buffered events first if the message would be too large after appending this event.
+	if e.rowChangedBuffer.RowsCount() > 0 && e.rowChangedBuffer.Size()+size > e.config.MaxMessageBytes {
+		e.flush()
+	}
+	rows, estimatedSize := e.rowChangedBuffer.appendRowChangedEvent(ev, size, event)
+	if ev.Callback != nil {
+		e.callbackBuff = append(e.callbackBuff, ev.Callback)
+	}
+	if estimatedSize >= e.config.MaxMessageBytes || rows >= e.config.MaxBatchSize {
+		e.flush()
+	}
+	return nil
+}
+
+// EncodeDDLEvent implements the EventEncoder interface
+func (e *BatchEncoder) EncodeDDLEvent(ev *commonEvent.DDLEvent) (*common.Message, error) {
+	return common.NewMsg(nil, NewDDLEventEncoder(e.allocator, ev).Encode()), nil
+}
+
+// Build implements the EventEncoder interface
+func (e *BatchEncoder) Build() []*common.Message {
+	if e.rowChangedBuffer.RowsCount() > 0 {
+		e.flush()
+	}
+	if len(e.messages) == 0 {
+		return nil
+	}
+	result := e.messages
+	e.messages = nil
+	return result
+}
+
+// Clean implements the EventEncoder interface
+func (e *BatchEncoder) Clean() {}
+
+func (e *BatchEncoder) flush() {
+	rows := e.rowChangedBuffer.RowsCount()
+	message := common.NewMsg(nil, e.rowChangedBuffer.Encode())
+	message.SetRowsCount(rows)
+	callbacks := e.callbackBuff
+	message.Callback = func() {
+		for _, cb := range callbacks {
+			cb()
+		}
+	}
+	e.callbackBuff = make([]func(), 0)
+	e.messages = append(e.messages, message)
+}
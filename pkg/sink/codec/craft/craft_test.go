@@ -0,0 +1,264 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/open"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func newRowEvents(t testing.TB, helper *commonEvent.EventTestHelper) []*commonEvent.RowEvent {
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c decimal(10, 2), d datetime(3), e blob, f bit(10),
+		g json, h enum('a','b'), i set('a','b'), j bigint unsigned, k double, l time, m float, n year,
+		o varchar(10) not null, p date, q timestamp)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	insert := helper.DML2Event("test", "t", `insert into test.t values
+		(1, 'hello', 12.34, '2025-01-02 03:04:05.678', x'0102ff', b'1010101', '{"k": [1, "v"]}', 'b', 'a,b',
+		18446744073709551615, 3.1415, '-12:34:56', 1.5, 2025, '', '2025-01-02', '2025-01-02 03:04:05')`)
+	insertRow, ok := insert.GetNextRow()
+	require.True(t, ok)
+
+	columnSelector := columnselector.NewDefaultColumnSelector()
+	insertEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       insert.GetCommitTs(),
+		Event:          insertRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	update := helper.DML2Event("test", "t", `update test.t set b = null, c = 56.78, i = '' where a = 1`)
+	updateRow, ok := update.GetNextRow()
+	require.True(t, ok)
+	updateRow.PreRow = insertRow.Row
+	updateEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       update.GetCommitTs(),
+		Event:          updateRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	deleteRow := updateRow
+	deleteRow.PreRow = updateRow.Row
+	deleteRow.Row = chunk.Row{}
+	deleteEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       update.GetCommitTs() + 1,
+		Event:          deleteRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+	return []*commonEvent.RowEvent{insertEvent, updateEvent, deleteEvent}
+}
+
+func TestCraftRowEvents(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	events := newRowEvents(t, helper)
+
+	ctx := context.Background()
+	codecConfig := common.NewConfig(config.ProtocolCraft)
+	encoder := NewBatchEncoder(codecConfig)
+	var called int
+	for _, event := range events {
+		event.Callback = func() { called++ }
+		err := encoder.AppendRowChangedEvent(ctx, "", event)
+		require.NoError(t, err)
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, len(events), messages[0].GetRowsCount())
+	messages[0].Callback()
+	require.Equal(t, len(events), called)
+
+	decoder := NewBatchDecoder(codecConfig)
+	decoder.AddKeyValue(messages[0].Key, messages[0].Value)
+	for _, event := range events {
+		messageType, hasNext := decoder.HasNext()
+		require.True(t, hasNext)
+		require.Equal(t, common.MessageTypeRow, messageType)
+
+		decoded := decoder.NextDMLEvent()
+		require.Equal(t, event.CommitTs, decoded.GetCommitTs())
+		require.Equal(t, "test", decoded.TableInfo.GetSchemaName())
+		require.Equal(t, "t", decoded.TableInfo.GetTableName())
+		require.Equal(t, []string{"a"}, decoded.TableInfo.GetPrimaryKeyColumnNames())
+		change, ok := decoded.GetNextRow()
+		require.True(t, ok)
+		common.CompareRow(t, event.Event, event.TableInfo, change, decoded.TableInfo)
+	}
+	_, hasNext := decoder.HasNext()
+	require.False(t, hasNext)
+}
+
+func TestCraftMaxBatchSize(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	events := newRowEvents(t, helper)
+
+	ctx := context.Background()
+	codecConfig := common.NewConfig(config.ProtocolCraft)
+	codecConfig.MaxBatchSize = 2
+	encoder := NewBatchEncoder(codecConfig)
+	for i := 0; i < 5; i++ {
+		for _, event := range events {
+			err := encoder.AppendRowChangedEvent(ctx, "", event)
+			require.NoError(t, err)
+		}
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, 8)
+
+	decoder := NewBatchDecoder(codecConfig)
+	total := 0
+	for _, message := range messages {
+		require.LessOrEqual(t, message.GetRowsCount(), codecConfig.MaxBatchSize)
+		decoder.AddKeyValue(message.Key, message.Value)
+		count := 0
+		for {
+			messageType, hasNext := decoder.HasNext()
+			if !hasNext {
+				break
+			}
+			require.Equal(t, common.MessageTypeRow, messageType)
+			decoded := decoder.NextDMLEvent()
+			change, ok := decoded.GetNextRow()
+			require.True(t, ok)
+			event := events[total%len(events)]
+			common.CompareRow(t, event.Event, event.TableInfo, change, decoded.TableInfo)
+			count++
+			total++
+		}
+		require.Equal(t, message.GetRowsCount(), count)
+	}
+	require.Equal(t, 5*len(events), total)
+
+	// the message is split by the max message bytes.
+	codecConfig.MaxBatchSize = 4096
+	codecConfig.MaxMessageBytes = 512
+	encoder = NewBatchEncoder(codecConfig)
+	for i := 0; i < 5; i++ {
+		for _, event := range events {
+			err := encoder.AppendRowChangedEvent(ctx, "", event)
+			require.NoError(t, err)
+		}
+	}
+	messages = encoder.Build()
+	require.Greater(t, len(messages), 1)
+	total = 0
+	for _, message := range messages {
+		require.LessOrEqual(t, len(message.Value), codecConfig.MaxMessageBytes)
+		total += message.GetRowsCount()
+	}
+	require.Equal(t, 5*len(events), total)
+}
+
+func TestCraftDDLAndCheckpoint(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	codecConfig := common.NewConfig(config.ProtocolCraft)
+	encoder := NewBatchEncoder(codecConfig)
+	decoder := NewBatchDecoder(codecConfig)
+
+	message, err := encoder.EncodeCheckpointEvent(1024)
+	require.NoError(t, err)
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext := decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeResolved, messageType)
+	require.Equal(t, uint64(1024), decoder.NextResolvedEvent())
+	_, hasNext = decoder.HasNext()
+	require.False(t, hasNext)
+
+	createTable := helper.DDL2Event(`create table test.t(a int unsigned primary key, b enum('x','y'))`)
+	message, err = encoder.EncodeDDLEvent(createTable)
+	require.NoError(t, err)
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext = decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeDDL, messageType)
+
+	decoded := decoder.NextDDLEvent()
+	require.Equal(t, createTable.Query, decoded.Query)
+	require.Equal(t, createTable.GetCommitTs(), decoded.GetCommitTs())
+	require.Equal(t, "test", decoded.GetSchemaName())
+	require.Equal(t, "t", decoded.GetTableName())
+	require.Equal(t, byte(timodel.ActionCreateTable), decoded.Type)
+	_, hasNext = decoder.HasNext()
+	require.False(t, hasNext)
+}
+
+func TestCraftInvalidData(t *testing.T) {
+	allocator := NewSliceAllocator(defaultAllocatorBatchSize)
+	bits := NewResolvedEventEncoder(allocator, 1024).Encode()
+	_, err := NewMessageDecoder(bits, allocator)
+	require.NoError(t, err)
+
+	for i := 0; i < len(bits); i++ {
+		_, err = NewMessageDecoder(bits[:i], allocator)
+		require.Error(t, err)
+	}
+}
+
+func benchmarkEncoder(b *testing.B, encoder common.EventEncoder, events []*commonEvent.RowEvent) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, event := range events {
+			err := encoder.AppendRowChangedEvent(ctx, "", event)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		for _, message := range encoder.Build() {
+			b.SetBytes(int64(message.Length()))
+		}
+	}
+}
+
+func BenchmarkCraftEncoder(b *testing.B) {
+	helper := commonEvent.NewEventTestHelper(b)
+	defer helper.Close()
+
+	events := newRowEvents(b, helper)
+	encoder := NewBatchEncoder(common.NewConfig(config.ProtocolCraft))
+	benchmarkEncoder(b, encoder, events)
+}
+
+func BenchmarkOpenEncoder(b *testing.B) {
+	helper := commonEvent.NewEventTestHelper(b)
+	defer helper.Close()
+
+	events := newRowEvents(b, helper)
+	encoder, err := open.NewBatchEncoder(context.Background(), common.NewConfig(config.ProtocolOpen))
+	require.NoError(b, err)
+	benchmarkEncoder(b, encoder, events)
+}
@@ -0,0 +1,448 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// Primitive type decoders
+func decodeUint8(bits []byte) ([]byte, byte, error) {
+	if len(bits) < 1 {
+		return bits, 0, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+	return bits[1:], bits[0], nil
+}
+
+func decodeVarint(bits []byte) ([]byte, int64, error) {
+	x, rd := binary.Varint(bits)
+	if rd < 0 {
+		return bits, 0, errors.ErrCraftCodecInvalidData.GenWithStack("invalid varint data")
+	}
+	if rd == 0 {
+		return bits, 0, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+	return bits[rd:], x, nil
+}
+
+func decodeUvarint(bits []byte) ([]byte, uint64, error) {
+	x, rd := binary.Uvarint(bits)
+	if rd < 0 {
+		return bits, 0, errors.ErrCraftCodecInvalidData.GenWithStack("invalid uvarint data")
+	}
+	if rd == 0 {
+		return bits, 0, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+	return bits[rd:], x, nil
+}
+
+func decodeUvarintReversed(bits []byte) (int, uint64, error) {
+	l := len(bits) - 1
+	var x uint64
+	var s uint
+	i := 0
+	for l >= 0 {
+		b := bits[l]
+		if b < 0x80 {
+			if i >= binary.MaxVarintLen64 || i == binary.MaxVarintLen64-1 && b > 1 {
+				return 0, 0, errors.ErrCraftCodecInvalidData.GenWithStack("invalid reversed uvarint data")
+			}
+			return i + 1, x | uint64(b)<<s, nil
+		}
+		x |= uint64(b&0x7f) << s
+		s += 7
+		l--
+		i++
+	}
+	return 0, 0, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+}
+
+func decodeUvarintLength(bits []byte) ([]byte, int, error) {
+	bits, l, err := decodeUvarint(bits)
+	if err != nil {
+		return bits, 0, err
+	}
+	if l > uint64(len(bits)) {
+		return bits, 0, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+	return bits, int(l), nil
+}
+
+func decodeFloat64(bits []byte) ([]byte, float64, error) {
+	if len(bits) < 8 {
+		return bits, 0, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+	return bits[8:], math.Float64frombits(binary.LittleEndian.Uint64(bits)), nil
+}
+
+func decodeBytes(bits []byte) ([]byte, []byte, error) {
+	newBits, l, err := decodeUvarintLength(bits)
+	if err != nil {
+		return bits, nil, err
+	}
+	return newBits[l:], newBits[:l], nil
+}
+
+func decodeString(bits []byte) ([]byte, string, error) {
+	bits, bytes, err := decodeBytes(bits)
+	if err == nil {
+		return bits, string(bytes), nil
+	}
+	return bits, "", err
+}
+
+// Chunk decoders
+func decodeStringChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []string, error) {
+	larray := allocator.intSlice(size)
+	newBits := bits
+	var bl int
+	var err error
+	for i := 0; i < size; i++ {
+		newBits, bl, err = decodeUvarintLength(newBits)
+		if err != nil {
+			return bits, nil, err
+		}
+		larray[i] = bl
+	}
+
+	data := allocator.stringSlice(size)
+	for i := 0; i < size; i++ {
+		if larray[i] > len(newBits) {
+			return bits, nil, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+		}
+		data[i] = string(newBits[:larray[i]])
+		newBits = newBits[larray[i]:]
+	}
+	return newBits, data, nil
+}
+
+func decodeNullableBytesChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, [][]byte, error) {
+	larray := allocator.int64Slice(size)
+	newBits := bits
+	var bl int64
+	var err error
+	for i := 0; i < size; i++ {
+		newBits, bl, err = decodeVarint(newBits)
+		if err != nil {
+			return bits, nil, err
+		}
+		larray[i] = bl
+	}
+
+	data := allocator.bytesSlice(size)
+	for i := 0; i < size; i++ {
+		if larray[i] == -1 {
+			continue
+		}
+		if larray[i] < 0 || larray[i] > int64(len(newBits)) {
+			return bits, nil, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+		}
+		data[i] = newBits[:larray[i]]
+		newBits = newBits[larray[i]:]
+	}
+	return newBits, data, nil
+}
+
+func decodeUvarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []uint64, error) {
+	array := allocator.uint64Slice(size)
+	newBits := bits
+	var i64 uint64
+	var err error
+	for i := 0; i < size; i++ {
+		newBits, i64, err = decodeUvarint(newBits)
+		if err != nil {
+			return bits, nil, err
+		}
+		array[i] = i64
+	}
+	return newBits, array, nil
+}
+
+func decodeDeltaVarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []int64, error) {
+	array := allocator.int64Slice(size)
+	newBits, first, err := decodeVarint(bits)
+	if err != nil {
+		return bits, nil, err
+	}
+	array[0] = first
+	for i := 1; i < size; i++ {
+		var delta int64
+		newBits, delta, err = decodeVarint(newBits)
+		if err != nil {
+			return bits, nil, err
+		}
+		array[i] = array[i-1] + delta
+	}
+	return newBits, array, nil
+}
+
+func decodeDeltaUvarintChunk(bits []byte, size int, allocator *SliceAllocator) ([]byte, []uint64, error) {
+	array := allocator.uint64Slice(size)
+	newBits, first, err := decodeUvarint(bits)
+	if err != nil {
+		return bits, nil, err
+	}
+	array[0] = first
+	for i := 1; i < size; i++ {
+		var delta uint64
+		newBits, delta, err = decodeUvarint(newBits)
+		if err != nil {
+			return bits, nil, err
+		}
+		array[i] = array[i-1] + delta
+	}
+	return newBits, array, nil
+}
+
+// size tables are always at end of serialized data, there is no unread bytes to return
+func decodeSizeTables(bits []byte, allocator *SliceAllocator) (int, [][]int64, error) {
+	nb, size, err := decodeUvarintReversed(bits)
+	if err != nil {
+		return 0, nil, err
+	}
+	sizeOffset := len(bits) - nb
+	if size > uint64(sizeOffset) {
+		return 0, nil, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+	tablesOffset := sizeOffset - int(size)
+	tables := bits[tablesOffset:sizeOffset]
+
+	tableSize := size + uint64(nb)
+	var result [][]int64
+	var table []int64
+	for len(tables) > 0 {
+		var l uint64
+		tables, l, err = decodeUvarint(tables)
+		if err != nil {
+			return 0, nil, err
+		}
+		if l > uint64(len(tables)) {
+			return 0, nil, errors.ErrCraftCodecInvalidData.GenWithStack("invalid size table")
+		}
+		if l == 0 {
+			result = append(result, nil)
+			continue
+		}
+		tables, table, err = decodeDeltaVarintChunk(tables, int(l), allocator)
+		if err != nil {
+			return 0, nil, err
+		}
+		result = append(result, table)
+	}
+
+	return int(tableSize), result, nil
+}
+
+// decodeColumnValue decodes the column value encoded by encodeColumnValue,
+// the returned value can be appended to the chunk directly.
+func decodeColumnValue(bits []byte, ty byte, flags uint64) (any, error) {
+	if bits == nil {
+		return nil, nil
+	}
+	switch ty {
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeNewDate, mysql.TypeTimestamp:
+		value := string(bits)
+		return types.ParseTime(types.DefaultStmtNoWarningContext, value, ty, fractionDigits(value))
+	case mysql.TypeDuration:
+		value := string(bits)
+		result, _, err := types.ParseDuration(types.DefaultStmtNoWarningContext, value, fractionDigits(value))
+		return result, err
+	case mysql.TypeJSON:
+		return types.ParseBinaryJSONFromString(string(bits))
+	case mysql.TypeNewDecimal:
+		result := new(types.MyDecimal)
+		err := result.FromString(bits)
+		return result, err
+	case mysql.TypeEnum:
+		_, value, err := decodeUvarint(bits)
+		return types.Enum{Value: value}, err
+	case mysql.TypeSet:
+		_, value, err := decodeUvarint(bits)
+		return types.Set{Value: value}, err
+	case mysql.TypeBit:
+		_, value, err := decodeUvarint(bits)
+		return types.NewBinaryLiteralFromUint(value, -1), err
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return bits, nil
+	case mysql.TypeFloat:
+		_, value, err := decodeFloat64(bits)
+		return float32(value), err
+	case mysql.TypeDouble:
+		_, value, err := decodeFloat64(bits)
+		return value, err
+	case mysql.TypeYear:
+		_, value, err := decodeVarint(bits)
+		return value, err
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeInt24:
+		if flags&unsignedFlag != 0 {
+			_, value, err := decodeUvarint(bits)
+			return value, err
+		}
+		_, value, err := decodeVarint(bits)
+		return value, err
+	case mysql.TypeTiDBVectorFloat32:
+		return types.ParseVectorFloat32(string(bits))
+	default:
+	}
+	return nil, errors.ErrCraftCodecInvalidData.GenWithStack("unknown column type %d", ty)
+}
+
+// fractionDigits returns the number of digits after the decimal point,
+// which is the fsp of the time and duration value.
+func fractionDigits(value string) int {
+	idx := strings.LastIndexByte(value, '.')
+	if idx < 0 {
+		return 0
+	}
+	return len(value) - idx - 1
+}
+
+// MessageDecoder decoder
+type MessageDecoder struct {
+	bits            []byte
+	sizeTables      [][]int64
+	metaSizeTable   []int64
+	bodySizeTable   []int64
+	bodyOffsetTable []int
+	headers         *Headers
+	dict            *termDictionary
+	allocator       *SliceAllocator
+}
+
+// NewMessageDecoder create a new message decode with bits and allocator
+func NewMessageDecoder(bits []byte, allocator *SliceAllocator) (*MessageDecoder, error) {
+	bits, version, err := decodeUvarint(bits)
+	if err != nil {
+		return nil, err
+	}
+	if version < Version1 {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("unexpected craft version")
+	}
+	sizeTablesSize, sizeTables, err := decodeSizeTables(bits, allocator)
+	if err != nil {
+		return nil, err
+	}
+	if len(sizeTables) < columnGroupSizeTableStartIndex {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("missing size tables")
+	}
+
+	// truncate tailing size tables
+	bits = bits[:len(bits)-sizeTablesSize]
+
+	metaSizeTable := sizeTables[metaSizeTableIndex]
+	if len(metaSizeTable) <= maxMetaSizeIndex {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("invalid meta size table")
+	}
+	headerSize := int(metaSizeTable[headerSizeIndex])
+	termDictionarySize := int(metaSizeTable[termDictionarySizeIndex])
+	if headerSize < 0 || termDictionarySize < 0 || headerSize+termDictionarySize > len(bits) {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+
+	// decode term dictionary from the end of the remaining bits
+	_, dict, err := decodeTermDictionary(bits[len(bits)-termDictionarySize:], allocator)
+	if err != nil {
+		return nil, err
+	}
+	bits = bits[:len(bits)-termDictionarySize]
+
+	// decode headers
+	headerBits, numHeaders, err := decodeUvarint(bits[:headerSize])
+	if err != nil {
+		return nil, err
+	}
+	headers, err := decodeHeaders(headerBits, int(numHeaders), allocator, dict)
+	if err != nil {
+		return nil, err
+	}
+
+	// calculate the offset of each body
+	bodySizeTable := sizeTables[bodySizeTableIndex]
+	if len(bodySizeTable) != headers.count {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("mismatched body count")
+	}
+	bodyOffsetTable := allocator.intSlice(len(bodySizeTable))
+	bodyOffset := headerSize
+	for i, l := range bodySizeTable {
+		bodyOffsetTable[i] = bodyOffset
+		bodyOffset += int(l)
+	}
+	if bodyOffset > len(bits) {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+	}
+
+	return &MessageDecoder{
+		bits:            bits,
+		sizeTables:      sizeTables,
+		metaSizeTable:   metaSizeTable,
+		bodySizeTable:   bodySizeTable,
+		bodyOffsetTable: bodyOffsetTable,
+		headers:         headers,
+		dict:            dict,
+		allocator:       allocator,
+	}, nil
+}
+
+// Headers returns headers of the message
+func (d *MessageDecoder) Headers() *Headers {
+	return d.headers
+}
+
+func (d *MessageDecoder) bodyBits(index int) []byte {
+	offset := d.bodyOffsetTable[index]
+	return d.bits[offset : offset+int(d.bodySizeTable[index])]
+}
+
+// DDLEvent returns the DDL type and query of the event at given index
+func (d *MessageDecoder) DDLEvent(index int) (byte, string, error) {
+	bits, ty, err := decodeUvarint(d.bodyBits(index))
+	if err != nil {
+		return 0, "", err
+	}
+	_, query, err := decodeString(bits)
+	if err != nil {
+		return 0, "", err
+	}
+	return byte(ty), query, nil
+}
+
+// RowChangedEvent returns the column groups of the row changed event at given index
+func (d *MessageDecoder) RowChangedEvent(index int) (rowChangedEvent, error) {
+	tableIndex := columnGroupSizeTableStartIndex + index
+	if tableIndex >= len(d.sizeTables) {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("column group size table not found")
+	}
+	bits := d.bodyBits(index)
+	sizeTable := d.sizeTables[tableIndex]
+	result := make(rowChangedEvent, 0, len(sizeTable))
+	for _, size := range sizeTable {
+		if size < 0 || size > int64(len(bits)) {
+			return nil, errors.ErrCraftCodecInvalidData.GenWithStack("buffer underflow")
+		}
+		group, err := decodeColumnGroup(bits[:size], d.allocator, d.dict)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, group)
+		bits = bits[size:]
+	}
+	return result, nil
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+// Primitive type encoders
+func encodeFloat64(bits []byte, data float64) []byte {
+	v := math.Float64bits(data)
+	return append(bits, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+}
+
+func encodeVarint(bits []byte, data int64) []byte {
+	udata := uint64(data) << 1
+	if data < 0 {
+		udata = ^udata
+	}
+	return encodeUvarint(bits, udata)
+}
+
+func encodeUvarint(bits []byte, data uint64) []byte {
+	// Encode uint64 in varint format that is used in protobuf
+	// Reference: https://developers.google.com/protocol-buffers/docs/encoding#varints
+	for data >= 0x80 {
+		bits = append(bits, byte(data)|0x80)
+		data >>= 7
+	}
+	return append(bits, byte(data))
+}
+
+func encodeUvarintReversed(bits []byte, data uint64) []byte {
+	// Encode uint64 in varint format that is similar to protobuf but with bytes order reversed,
+	// so that it can be decoded from the end of the buffer.
+	var buf [binary.MaxVarintLen64]byte
+	i := 0
+	for data >= 0x80 {
+		buf[i] = byte(data) | 0x80
+		data >>= 7
+		i++
+	}
+	buf[i] = byte(data)
+	for bi := i; bi >= 0; bi-- {
+		bits = append(bits, buf[bi])
+	}
+	return bits
+}
+
+func encodeString(bits []byte, data string) []byte {
+	bits = encodeUvarint(bits, uint64(len(data)))
+	return append(bits, data...)
+}
+
+// Chunk encoders
+func encodeStringChunk(bits []byte, data []string) []byte {
+	for _, s := range data {
+		bits = encodeUvarint(bits, uint64(len(s)))
+	}
+	for _, s := range data {
+		bits = append(bits, s...)
+	}
+	return bits
+}
+
+func encodeNullableBytesChunk(bits []byte, data [][]byte) []byte {
+	for _, b := range data {
+		var l int64 = -1
+		if b != nil {
+			l = int64(len(b))
+		}
+		bits = encodeVarint(bits, l)
+	}
+	for _, b := range data {
+		if b != nil {
+			bits = append(bits, b...)
+		}
+	}
+	return bits
+}
+
+func encodeUvarintChunk(bits []byte, data []uint64) []byte {
+	for _, v := range data {
+		bits = encodeUvarint(bits, v)
+	}
+	return bits
+}
+
+func encodeDeltaVarintChunk(bits []byte, data []int64) []byte {
+	if len(data) == 0 {
+		return bits
+	}
+	last := data[0]
+	bits = encodeVarint(bits, last)
+	for _, v := range data[1:] {
+		bits = encodeVarint(bits, v-last)
+		last = v
+	}
+	return bits
+}
+
+func encodeDeltaUvarintChunk(bits []byte, data []uint64) []byte {
+	last := data[0]
+	bits = encodeUvarint(bits, last)
+	for _, v := range data[1:] {
+		bits = encodeUvarint(bits, v-last)
+		last = v
+	}
+	return bits
+}
+
+func encodeSizeTables(bits []byte, tables [][]int64) []byte {
+	size := len(bits)
+	for _, table := range tables {
+		bits = encodeUvarint(bits, uint64(len(table)))
+		bits = encodeDeltaVarintChunk(bits, table)
+	}
+	return encodeUvarintReversed(bits, uint64(len(bits)-size))
+}
+
+// encodeColumnValue encodes the column value of the row into bytes, nil is returned if the value is NULL.
+func encodeColumnValue(allocator *SliceAllocator, row *chunk.Row, idx int, col *model.ColumnInfo) []byte {
+	if row.IsNull(idx) {
+		return nil
+	}
+	switch col.GetType() {
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeNewDate, mysql.TypeTimestamp:
+		return []byte(row.GetTime(idx).String())
+	case mysql.TypeDuration:
+		return []byte(row.GetDuration(idx, col.GetDecimal()).String())
+	case mysql.TypeJSON:
+		return []byte(row.GetJSON(idx).String())
+	case mysql.TypeNewDecimal:
+		return []byte(row.GetMyDecimal(idx).String())
+	case mysql.TypeEnum:
+		return encodeUvarint(allocator.byteSlice(binary.MaxVarintLen64)[:0], row.GetEnum(idx).Value)
+	case mysql.TypeSet:
+		return encodeUvarint(allocator.byteSlice(binary.MaxVarintLen64)[:0], row.GetSet(idx).Value)
+	case mysql.TypeBit:
+		d := row.GetDatum(idx, &col.FieldType)
+		value, err := d.GetMysqlBit().ToInt(types.DefaultStmtNoWarningContext)
+		if err != nil {
+			log.Panic("failed to convert bit to int", zap.Any("data", d), zap.Error(err))
+		}
+		return encodeUvarint(allocator.byteSlice(binary.MaxVarintLen64)[:0], value)
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if value := row.GetBytes(idx); value != nil {
+			return value
+		}
+		// nil stands for NULL, make sure the empty value is not nil.
+		return []byte{}
+	case mysql.TypeFloat:
+		return encodeFloat64(allocator.byteSlice(8)[:0], float64(row.GetFloat32(idx)))
+	case mysql.TypeDouble:
+		return encodeFloat64(allocator.byteSlice(8)[:0], row.GetFloat64(idx))
+	case mysql.TypeYear:
+		return encodeVarint(allocator.byteSlice(binary.MaxVarintLen64)[:0], row.GetInt64(idx))
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeInt24:
+		if mysql.HasUnsignedFlag(col.GetFlag()) {
+			return encodeUvarint(allocator.byteSlice(binary.MaxVarintLen64)[:0], row.GetUint64(idx))
+		}
+		return encodeVarint(allocator.byteSlice(binary.MaxVarintLen64)[:0], row.GetInt64(idx))
+	case mysql.TypeTiDBVectorFloat32:
+		return []byte(row.GetVectorFloat32(idx).String())
+	default:
+	}
+	return nil
+}
+
+// MessageEncoder is encoder for message
+type MessageEncoder struct {
+	bits           []byte
+	sizeTables     [][]int64
+	bodyLastOffset int
+	bodySize       []int64
+	bodySizeIndex  int
+	metaSizeTable  []int64
+
+	allocator *SliceAllocator
+	dict      *termDictionary
+}
+
+// NewMessageEncoder creates a new encoder with given allocator
+func NewMessageEncoder(allocator *SliceAllocator) *MessageEncoder {
+	return &MessageEncoder{
+		bits:      encodeUvarint(make([]byte, 0, DefaultBufferCapacity), Version1),
+		allocator: allocator,
+		dict:      newEncodingTermDictionary(),
+	}
+}
+
+func (e *MessageEncoder) encodeBodySize() *MessageEncoder {
+	e.bodySize[e.bodySizeIndex] = int64(len(e.bits) - e.bodyLastOffset)
+	e.bodyLastOffset = len(e.bits)
+	e.bodySizeIndex++
+	return e
+}
+
+func (e *MessageEncoder) encodeUvarint(u64 uint64) *MessageEncoder {
+	e.bits = encodeUvarint(e.bits, u64)
+	return e
+}
+
+func (e *MessageEncoder) encodeString(s string) *MessageEncoder {
+	e.bits = encodeString(e.bits, s)
+	return e
+}
+
+func (e *MessageEncoder) encodeHeaders(headers *Headers) *MessageEncoder {
+	oldSize := len(e.bits)
+	e.bodySize = e.allocator.int64Slice(headers.count)
+	e.bits = headers.encode(e.bits, e.dict)
+	e.bodyLastOffset = len(e.bits)
+	e.metaSizeTable = e.allocator.int64Slice(maxMetaSizeIndex + 1)
+	e.metaSizeTable[headerSizeIndex] = int64(len(e.bits) - oldSize)
+	e.sizeTables = append(e.sizeTables, e.metaSizeTable, e.bodySize)
+	return e
+}
+
+// Encode message into bits
+func (e *MessageEncoder) Encode() []byte {
+	offset := len(e.bits)
+	e.bits = encodeTermDictionary(e.bits, e.dict)
+	e.metaSizeTable[termDictionarySizeIndex] = int64(len(e.bits) - offset)
+	return encodeSizeTables(e.bits, e.sizeTables)
+}
+
+func (e *MessageEncoder) encodeRowChangeEvents(events []rowChangedEvent) *MessageEncoder {
+	sizeTables := e.sizeTables
+	for _, event := range events {
+		columnGroupSizeTable := e.allocator.int64Slice(len(event))
+		for gi, group := range event {
+			oldSize := len(e.bits)
+			e.bits = group.encode(e.bits, e.dict)
+			columnGroupSizeTable[gi] = int64(len(e.bits) - oldSize)
+		}
+		sizeTables = append(sizeTables, columnGroupSizeTable)
+		e.encodeBodySize()
+	}
+	e.sizeTables = sizeTables
+	return e
+}
+
+// NewResolvedEventEncoder creates a new encoder with given allocator and timestamp
+func NewResolvedEventEncoder(allocator *SliceAllocator, ts uint64) *MessageEncoder {
+	return NewMessageEncoder(allocator).encodeHeaders(&Headers{
+		ts:        allocator.oneUint64Slice(ts),
+		ty:        allocator.oneUint64Slice(uint64(common.MessageTypeResolved)),
+		partition: oneNullInt64Slice,
+		schema:    oneNullStringSlice,
+		table:     oneNullStringSlice,
+		count:     1,
+	}).encodeBodySize()
+}
+
+// NewDDLEventEncoder creates a new encoder with given allocator and DDL event
+func NewDDLEventEncoder(allocator *SliceAllocator, ev *commonEvent.DDLEvent) *MessageEncoder {
+	var schema, table *string
+	if len(ev.SchemaName) > 0 {
+		schema = &ev.SchemaName
+	}
+	if len(ev.TableName) > 0 {
+		table = &ev.TableName
+	}
+	return NewMessageEncoder(allocator).encodeHeaders(&Headers{
+		ts:        allocator.oneUint64Slice(ev.GetCommitTs()),
+		ty:        allocator.oneUint64Slice(uint64(common.MessageTypeDDL)),
+		partition: oneNullInt64Slice,
+		schema:    allocator.oneNullableStringSlice(schema),
+		table:     allocator.oneNullableStringSlice(table),
+		count:     1,
+	}).encodeUvarint(uint64(ev.Type)).encodeString(ev.Query).encodeBodySize()
+}
@@ -0,0 +1,531 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package craft
+
+import (
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+const (
+	// Version1 represents the version of craft format
+	Version1 uint64 = 1
+
+	// DefaultBufferCapacity is default buffer size
+	DefaultBufferCapacity = 1024
+
+	// Column group types
+	columnGroupTypeOld = 0x2
+	columnGroupTypeNew = 0x1
+
+	// Size tables index
+	metaSizeTableIndex             = 0
+	bodySizeTableIndex             = 1
+	columnGroupSizeTableStartIndex = 2
+
+	// meta size table index
+	headerSizeIndex         = 0
+	termDictionarySizeIndex = 1
+	maxMetaSizeIndex        = termDictionarySizeIndex
+
+	nullInt64 = -1
+)
+
+// Column flags carried by the column group, it's the same as the open protocol.
+const (
+	binaryFlag uint64 = 1 << iota
+	handleKeyFlag
+	generatedColumnFlag
+	primaryKeyFlag
+	uniqueKeyFlag
+	multipleKeyFlag
+	nullableFlag
+	unsignedFlag
+)
+
+var (
+	oneNullInt64Slice  = []int64{nullInt64}
+	oneNullStringSlice = []*string{nil}
+)
+
+// termDictionary stores the schema, table and column names of the message only once.
+type termDictionary struct {
+	term map[string]int
+	id   []string
+}
+
+func newEncodingTermDictionary() *termDictionary {
+	return &termDictionary{
+		term: make(map[string]int),
+		id:   make([]string, 0),
+	}
+}
+
+func (d *termDictionary) encodeNullable(s *string) int64 {
+	if s == nil {
+		return nullInt64
+	}
+	return d.encode(*s)
+}
+
+func (d *termDictionary) encode(s string) int64 {
+	id, ok := d.term[s]
+	if !ok {
+		id := len(d.id)
+		d.term[s] = id
+		d.id = append(d.id, s)
+		return int64(id)
+	}
+	return int64(id)
+}
+
+func (d *termDictionary) encodeNullableChunk(array []*string) []int64 {
+	result := make([]int64, len(array))
+	for idx, s := range array {
+		result[idx] = d.encodeNullable(s)
+	}
+	return result
+}
+
+func (d *termDictionary) encodeChunk(array []string) []int64 {
+	result := make([]int64, len(array))
+	for idx, s := range array {
+		result[idx] = d.encode(s)
+	}
+	return result
+}
+
+func (d *termDictionary) decode(id int64) (string, error) {
+	if id < 0 || id >= int64(len(d.id)) {
+		return "", errors.ErrCraftCodecInvalidData.GenWithStack("invalid term id")
+	}
+	return d.id[id], nil
+}
+
+func (d *termDictionary) decodeNullable(id int64) (*string, error) {
+	if id == nullInt64 {
+		return nil, nil
+	}
+	if id < nullInt64 {
+		return nil, errors.ErrCraftCodecInvalidData.GenWithStack("invalid term id")
+	}
+	s, err := d.decode(id)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (d *termDictionary) decodeChunk(array []int64) ([]string, error) {
+	result := make([]string, len(array))
+	for idx, id := range array {
+		t, err := d.decode(id)
+		if err != nil {
+			return nil, err
+		}
+		result[idx] = t
+	}
+	return result, nil
+}
+
+func (d *termDictionary) decodeNullableChunk(array []int64) ([]*string, error) {
+	result := make([]*string, len(array))
+	for idx, id := range array {
+		t, err := d.decodeNullable(id)
+		if err != nil {
+			return nil, err
+		}
+		result[idx] = t
+	}
+	return result, nil
+}
+
+func encodeTermDictionary(bits []byte, dict *termDictionary) []byte {
+	bits = encodeUvarint(bits, uint64(len(dict.id)))
+	bits = encodeStringChunk(bits, dict.id)
+	return bits
+}
+
+func decodeTermDictionary(bits []byte, allocator *SliceAllocator) ([]byte, *termDictionary, error) {
+	newBits, l, err := decodeUvarint(bits)
+	if err != nil {
+		return bits, nil, err
+	}
+	newBits, id, err := decodeStringChunk(newBits, int(l), allocator)
+	if err != nil {
+		return bits, nil, err
+	}
+	return newBits, &termDictionary{id: id}, nil
+}
+
+// Headers in columnar layout
+type Headers struct {
+	ts        []uint64
+	ty        []uint64
+	partition []int64
+	schema    []*string
+	table     []*string
+
+	count int
+}
+
+// Count returns number of headers
+func (h *Headers) Count() int {
+	return h.count
+}
+
+func (h *Headers) encode(bits []byte, dict *termDictionary) []byte {
+	bits = encodeUvarint(bits, uint64(h.count))
+	bits = encodeDeltaUvarintChunk(bits, h.ts[:h.count])
+	bits = encodeUvarintChunk(bits, h.ty[:h.count])
+	bits = encodeDeltaVarintChunk(bits, h.partition[:h.count])
+	bits = encodeDeltaVarintChunk(bits, dict.encodeNullableChunk(h.schema[:h.count]))
+	bits = encodeDeltaVarintChunk(bits, dict.encodeNullableChunk(h.table[:h.count]))
+	return bits
+}
+
+func (h *Headers) appendHeader(allocator *SliceAllocator, ts, ty uint64, partition int64, schema, table *string) int {
+	idx := h.count
+	if idx+1 > len(h.ty) {
+		size := newBufferSize(idx)
+		h.ts = allocator.resizeUint64Slice(h.ts, size)
+		h.ty = allocator.resizeUint64Slice(h.ty, size)
+		h.partition = allocator.resizeInt64Slice(h.partition, size)
+		h.schema = allocator.resizeNullableStringSlice(h.schema, size)
+		h.table = allocator.resizeNullableStringSlice(h.table, size)
+	}
+	h.ts[idx] = ts
+	h.ty[idx] = ty
+	h.partition[idx] = partition
+	h.schema[idx] = schema
+	h.table[idx] = table
+	h.count++
+
+	return 32 + len(*schema) + len(*table) /* 4 64-bits integers and two bytes array */
+}
+
+func (h *Headers) reset() {
+	h.ts = nil
+	h.ty = nil
+	h.partition = nil
+	h.schema = nil
+	h.table = nil
+	h.count = 0
+}
+
+// GetType returns type of event at given index
+func (h *Headers) GetType(index int) common.MessageType {
+	return common.MessageType(h.ty[index])
+}
+
+// GetTs returns timestamp of event at given index
+func (h *Headers) GetTs(index int) uint64 {
+	return h.ts[index]
+}
+
+// GetPartition returns partition of event at given index
+func (h *Headers) GetPartition(index int) int64 {
+	return h.partition[index]
+}
+
+// GetSchema returns schema of event at given index
+func (h *Headers) GetSchema(index int) string {
+	if h.schema[index] != nil {
+		return *h.schema[index]
+	}
+	return ""
+}
+
+// GetTable returns table of event at given index
+func (h *Headers) GetTable(index int) string {
+	if h.table[index] != nil {
+		return *h.table[index]
+	}
+	return ""
+}
+
+func decodeHeaders(bits []byte, numHeaders int, allocator *SliceAllocator, dict *termDictionary) (*Headers, error) {
+	var ts, ty []uint64
+	var partition, tmp []int64
+	var schema, table []*string
+	var err error
+	if bits, ts, err = decodeDeltaUvarintChunk(bits, numHeaders, allocator); err != nil {
+		return nil, err
+	}
+	if bits, ty, err = decodeUvarintChunk(bits, numHeaders, allocator); err != nil {
+		return nil, err
+	}
+	if bits, partition, err = decodeDeltaVarintChunk(bits, numHeaders, allocator); err != nil {
+		return nil, err
+	}
+	if bits, tmp, err = decodeDeltaVarintChunk(bits, numHeaders, allocator); err != nil {
+		return nil, err
+	}
+	if schema, err = dict.decodeNullableChunk(tmp); err != nil {
+		return nil, err
+	}
+	if _, tmp, err = decodeDeltaVarintChunk(bits, numHeaders, allocator); err != nil {
+		return nil, err
+	}
+	if table, err = dict.decodeNullableChunk(tmp); err != nil {
+		return nil, err
+	}
+	return &Headers{
+		ts:        ts,
+		ty:        ty,
+		partition: partition,
+		schema:    schema,
+		table:     table,
+		count:     numHeaders,
+	}, nil
+}
+
+// columnGroup holds all the columns of the row before or after the change in columnar layout.
+type columnGroup struct {
+	ty     byte
+	names  []string
+	types  []uint64
+	flags  []uint64
+	values [][]byte
+}
+
+func (g *columnGroup) encode(bits []byte, dict *termDictionary) []byte {
+	bits = append(bits, g.ty)
+	bits = encodeUvarint(bits, uint64(len(g.names)))
+	bits = encodeDeltaVarintChunk(bits, dict.encodeChunk(g.names))
+	bits = encodeUvarintChunk(bits, g.types)
+	bits = encodeUvarintChunk(bits, g.flags)
+	bits = encodeNullableBytesChunk(bits, g.values)
+	return bits
+}
+
+func decodeColumnGroup(bits []byte, allocator *SliceAllocator, dict *termDictionary) (*columnGroup, error) {
+	var numColumns uint64
+	var names []string
+	var tmp []int64
+	var values [][]byte
+	var types, flags []uint64
+	var ty byte
+	var err error
+	if bits, ty, err = decodeUint8(bits); err != nil {
+		return nil, err
+	}
+	if bits, numColumns, err = decodeUvarint(bits); err != nil {
+		return nil, err
+	}
+	if bits, tmp, err = decodeDeltaVarintChunk(bits, int(numColumns), allocator); err != nil {
+		return nil, err
+	}
+	if names, err = dict.decodeChunk(tmp); err != nil {
+		return nil, err
+	}
+	if bits, types, err = decodeUvarintChunk(bits, int(numColumns), allocator); err != nil {
+		return nil, err
+	}
+	if bits, flags, err = decodeUvarintChunk(bits, int(numColumns), allocator); err != nil {
+		return nil, err
+	}
+	if _, values, err = decodeNullableBytesChunk(bits, int(numColumns), allocator); err != nil {
+		return nil, err
+	}
+	return &columnGroup{
+		ty:     ty,
+		names:  names,
+		types:  types,
+		flags:  flags,
+		values: values,
+	}, nil
+}
+
+// newColumnGroup creates a column group from the row, only selected columns are included.
+// returns the estimated size of the column group.
+func newColumnGroup(
+	allocator *SliceAllocator, ty byte, row *chunk.Row,
+	tableInfo *commonType.TableInfo, selector commonEvent.Selector,
+) (int, *columnGroup) {
+	columns := tableInfo.GetColumns()
+	l := len(columns)
+	if l == 0 {
+		return 0, nil
+	}
+	values := allocator.bytesSlice(l)
+	names := allocator.stringSlice(l)
+	types := allocator.uint64Slice(l)
+	flags := allocator.uint64Slice(l)
+	estimatedSize := 0
+	idx := 0
+	for i, col := range columns {
+		if col == nil || !selector.Select(col) {
+			continue
+		}
+		names[idx] = col.Name.O
+		types[idx] = uint64(col.GetType())
+		flags[idx] = columnFlags(tableInfo, col)
+		value := encodeColumnValue(allocator, row, i, col)
+		values[idx] = value
+		estimatedSize += len(col.Name.O) + len(value) + 16 /* two 64-bits integers */
+		idx++
+	}
+	if idx > 0 {
+		return estimatedSize, &columnGroup{
+			ty:     ty,
+			names:  names[:idx],
+			types:  types[:idx],
+			flags:  flags[:idx],
+			values: values[:idx],
+		}
+	}
+	return estimatedSize, nil
+}
+
+// columnFlags returns the flags of the column carried by the message.
+func columnFlags(tableInfo *commonType.TableInfo, col *model.ColumnInfo) uint64 {
+	var flags uint64
+	flag := col.GetFlag()
+	if mysql.HasBinaryFlag(flag) {
+		flags |= binaryFlag
+	}
+	if tableInfo.IsHandleKey(col.ID) {
+		flags |= handleKeyFlag
+	}
+	if col.IsGenerated() {
+		flags |= generatedColumnFlag
+	}
+	if mysql.HasPriKeyFlag(flag) {
+		flags |= primaryKeyFlag
+	}
+	if mysql.HasUniKeyFlag(flag) {
+		flags |= uniqueKeyFlag
+	}
+	if mysql.HasMultipleKeyFlag(flag) {
+		flags |= multipleKeyFlag
+	}
+	if !mysql.HasNotNullFlag(flag) {
+		flags |= nullableFlag
+	}
+	if mysql.HasUnsignedFlag(flag) {
+		flags |= unsignedFlag
+	}
+	return flags
+}
+
+// rowChangedEvent is a row changed event in columnar layout,
+// which contains the column groups of the new and old value.
+type rowChangedEvent = []*columnGroup
+
+func newRowChangedMessage(allocator *SliceAllocator, ev *commonEvent.RowEvent) (int, rowChangedEvent) {
+	numGroups := 0
+	if !ev.IsInsert() {
+		numGroups++
+	}
+	if !ev.IsDelete() {
+		numGroups++
+	}
+	groups := allocator.columnGroupSlice(numGroups)[:0]
+	estimatedSize := 0
+	if !ev.IsDelete() {
+		size, group := newColumnGroup(allocator, columnGroupTypeNew, ev.GetRows(), ev.TableInfo, ev.ColumnSelector)
+		if group != nil {
+			groups = append(groups, group)
+			estimatedSize += size
+		}
+	}
+	if !ev.IsInsert() {
+		size, group := newColumnGroup(allocator, columnGroupTypeOld, ev.GetPreRows(), ev.TableInfo, ev.ColumnSelector)
+		if group != nil {
+			groups = append(groups, group)
+			estimatedSize += size
+		}
+	}
+	return estimatedSize, groups
+}
+
+// RowChangedEventBuffer is a buffer to save row changed events in batch
+type RowChangedEventBuffer struct {
+	headers *Headers
+
+	events        []rowChangedEvent
+	eventsCount   int
+	estimatedSize int
+
+	allocator *SliceAllocator
+}
+
+// NewRowChangedEventBuffer creates new row changed event buffer with given allocator
+func NewRowChangedEventBuffer(allocator *SliceAllocator) *RowChangedEventBuffer {
+	return &RowChangedEventBuffer{
+		headers:   &Headers{},
+		allocator: allocator,
+	}
+}
+
+// Encode row changed event buffer into bits
+func (b *RowChangedEventBuffer) Encode() []byte {
+	bits := NewMessageEncoder(b.allocator).encodeHeaders(b.headers).encodeRowChangeEvents(b.events[:b.eventsCount]).Encode()
+	b.Reset()
+	return bits
+}
+
+// appendRowChangedEvent append a new event and its column groups to buffer,
+// returns the number of rows and the estimated size of the buffer.
+func (b *RowChangedEventBuffer) appendRowChangedEvent(ev *commonEvent.RowEvent, size int, event rowChangedEvent) (int, int) {
+	var partition int64 = nullInt64
+	if ev.TableInfo.IsPartitionTable() {
+		partition = ev.GetTableID()
+	}
+
+	b.estimatedSize += b.headers.appendHeader(
+		b.allocator,
+		ev.CommitTs,
+		uint64(common.MessageTypeRow),
+		partition,
+		ev.TableInfo.GetSchemaNamePtr(),
+		ev.TableInfo.GetTableNamePtr(),
+	)
+	if b.eventsCount+1 > len(b.events) {
+		b.events = b.allocator.resizeRowChangedEventSlice(b.events, newBufferSize(b.eventsCount))
+	}
+	b.events[b.eventsCount] = event
+	b.eventsCount++
+	b.estimatedSize += size
+	return b.eventsCount, b.estimatedSize
+}
+
+// Reset buffer
+func (b *RowChangedEventBuffer) Reset() {
+	b.headers.reset()
+	b.events = nil
+	b.eventsCount = 0
+	b.estimatedSize = 0
+}
+
+// Size of buffer
+func (b *RowChangedEventBuffer) Size() int {
+	return b.estimatedSize
+}
+
+// RowsCount return Number of rows batched in this buffer
+func (b *RowChangedEventBuffer) RowsCount() int {
+	return b.eventsCount
+}
+
+// GetHeaders returns headers of buffer
+func (b *RowChangedEventBuffer) GetHeaders() *Headers {
+	return b.headers
+}
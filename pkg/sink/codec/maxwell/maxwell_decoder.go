@@ -0,0 +1,435 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxwell
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+// defaultMySQLType is used if the column type is unknown, which happens when the TiDB extension is disabled.
+const defaultMySQLType = "longtext"
+
+var tableIDAllocator = common.NewTableIDAllocator()
+
+type tableKey struct {
+	schema string
+	table  string
+}
+
+type decoder struct {
+	msg *maxwellMessage
+
+	config         *common.Config
+	tableInfoCache map[tableKey]*commonType.TableInfo
+}
+
+// NewDecoder return a decoder for the maxwell protocol.
+// The decoder requires the TiDB extension to restore the exact commitTs and column types.
+func NewDecoder(codecConfig *common.Config) common.Decoder {
+	tableIDAllocator.Clean()
+	return &decoder{
+		config:         codecConfig,
+		tableInfoCache: make(map[tableKey]*commonType.TableInfo),
+	}
+}
+
+// AddKeyValue implements the Decoder interface
+func (d *decoder) AddKeyValue(_, value []byte) {
+	if d.msg != nil {
+		log.Panic("add key value to the decoder which has undecoded message",
+			zap.Any("message", d.msg), zap.ByteString("value", value))
+	}
+	msg := new(maxwellMessage)
+	if err := json.Unmarshal(value, msg); err != nil {
+		log.Panic("unmarshal maxwell message failed", zap.ByteString("value", value), zap.Error(err))
+	}
+	d.msg = msg
+}
+
+// HasNext implements the Decoder interface
+func (d *decoder) HasNext() (common.MessageType, bool) {
+	if d.msg == nil {
+		return common.MessageTypeUnknown, false
+	}
+	switch d.msg.Type {
+	case typeTiDBWatermark:
+		return common.MessageTypeResolved, true
+	case typeInsert, typeUpdate, typeDelete:
+		return common.MessageTypeRow, true
+	default:
+	}
+	if isDDLType(d.msg.Type) {
+		return common.MessageTypeDDL, true
+	}
+	log.Panic("unknown maxwell message type", zap.String("type", d.msg.Type))
+	return common.MessageTypeUnknown, false
+}
+
+// NextResolvedEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *decoder) NextResolvedEvent() uint64 {
+	if d.msg == nil || d.msg.Type != typeTiDBWatermark || d.msg.TiDB == nil {
+		log.Panic("message type is not watermark", zap.Any("message", d.msg))
+	}
+	ts := d.msg.TiDB.WatermarkTs
+	d.msg = nil
+	return ts
+}
+
+// NextDDLEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *decoder) NextDDLEvent() *commonEvent.DDLEvent {
+	if d.msg == nil || !isDDLType(d.msg.Type) {
+		log.Panic("message type is not DDL Event", zap.Any("message", d.msg))
+	}
+	result := new(commonEvent.DDLEvent)
+	result.FinishedTs = getCommitTs(d.msg)
+	result.SchemaName = d.msg.Database
+	result.TableName = d.msg.Table
+	result.Query = d.msg.SQL
+	actionType := common.GetDDLActionType(result.Query)
+	result.Type = byte(actionType)
+	tableIDAllocator.AddBlockTableID(result.SchemaName, result.TableName,
+		tableIDAllocator.Allocate(result.SchemaName, result.TableName))
+	result.BlockedTables = common.GetBlockedTables(tableIDAllocator, result)
+
+	// if receive a table level DDL, just remove the table info to trigger create a new one.
+	delete(d.tableInfoCache, tableKey{schema: result.SchemaName, table: result.TableName})
+	d.msg = nil
+	return result
+}
+
+// NextDMLEvent implements the Decoder interface
+// `HasNext` should be called before this.
+func (d *decoder) NextDMLEvent() *commonEvent.DMLEvent {
+	if d.msg == nil {
+		log.Panic("message type is not row changed", zap.Any("message", d.msg))
+	}
+	msg := d.msg
+	d.msg = nil
+
+	tableInfo := d.queryTableInfo(msg)
+	commitTs := getCommitTs(msg)
+	result := new(commonEvent.DMLEvent)
+	result.TableInfo = tableInfo
+	result.StartTs = msg.Xid
+	result.CommitTs = commitTs
+	result.PhysicalTableID = tableInfo.TableName.TableID
+	result.Rows = chunk.NewChunkFromPoolWithCapacity(tableInfo.GetFieldSlice(), chunk.InitialCapacity)
+	result.AddPostFlushFunc(func() {
+		result.Rows.Destroy(chunk.InitialCapacity, tableInfo.GetFieldSlice())
+	})
+	result.Length++
+
+	columns := tableInfo.GetColumns()
+	data := formatAllColumnsValue(msg.Data, columns)
+	switch msg.Type {
+	case typeInsert:
+		common.AppendRow2Chunk(data, columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeInsert)
+	case typeDelete:
+		common.AppendRow2Chunk(data, columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeDelete)
+	case typeUpdate:
+		// the `old` field only contains the changed columns, others are the same as the `data`.
+		previous := formatAllColumnsValue(msg.Old, columns)
+		for name, value := range data {
+			if _, ok := msg.Old.get(name); !ok {
+				previous[name] = value
+			}
+		}
+		common.AppendRow2Chunk(previous, columns, result.Rows)
+		common.AppendRow2Chunk(data, columns, result.Rows)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeUpdate)
+		result.RowTypes = append(result.RowTypes, commonType.RowTypeUpdate)
+	default:
+		log.Panic("unknown event type for the DML event", zap.String("type", msg.Type))
+	}
+	return result
+}
+
+// getCommitTs returns the commitTs carried by the TiDB extension,
+// fallback to the maxwell ts if not found, which is not accurate.
+func getCommitTs(msg *maxwellMessage) uint64 {
+	if msg.TiDB != nil && msg.TiDB.CommitTs != 0 {
+		return msg.TiDB.CommitTs
+	}
+	return uint64(msg.Ts*1000) << 18
+}
+
+func (d *decoder) queryTableInfo(msg *maxwellMessage) *commonType.TableInfo {
+	cacheKey := tableKey{
+		schema: msg.Database,
+		table:  msg.Table,
+	}
+	tableInfo, ok := d.tableInfoCache[cacheKey]
+	if ok {
+		return tableInfo
+	}
+
+	tidbTableInfo := new(timodel.TableInfo)
+	tidbTableInfo.ID = tableIDAllocator.Allocate(msg.Database, msg.Table)
+	tableIDAllocator.AddBlockTableID(msg.Database, msg.Table, tidbTableInfo.ID)
+	tidbTableInfo.Name = ast.NewCIStr(msg.Table)
+
+	keys := make(map[string]struct{}, len(msg.PrimaryKeyColumns))
+	for _, name := range msg.PrimaryKeyColumns {
+		keys[name] = struct{}{}
+	}
+	columns := make([]*timodel.ColumnInfo, 0, len(msg.Data))
+	for idx, kv := range msg.Data {
+		mysqlType := defaultMySQLType
+		if msg.TiDB != nil {
+			if t, ok := msg.TiDB.MySQLType[kv.key]; ok {
+				mysqlType = t
+			}
+		}
+		_, isPrimaryKey := keys[kv.key]
+		columns = append(columns, newTiColumn(int64(idx), kv.key, mysqlType, isPrimaryKey))
+	}
+	tidbTableInfo.Columns = columns
+	tidbTableInfo.Indices = newTiIndices(columns)
+	tidbTableInfo.PKIsHandle = len(tidbTableInfo.Indices) != 0
+	tableInfo = commonType.NewTableInfo4Decoder(msg.Database, tidbTableInfo)
+	// the delete message may only contain the handle key columns, do not cache it.
+	if msg.Type != typeDelete {
+		d.tableInfoCache[cacheKey] = tableInfo
+	}
+	return tableInfo
+}
+
+func newTiColumn(id int64, name string, mysqlType string, isPrimaryKey bool) *timodel.ColumnInfo {
+	col := new(timodel.ColumnInfo)
+	col.ID = id
+	col.Name = ast.NewCIStr(name)
+	basicType := common.ExtractBasicMySQLType(mysqlType)
+	col.FieldType = *types.NewFieldType(basicType)
+	if common.IsBinaryMySQLType(mysqlType) {
+		col.AddFlag(mysql.BinaryFlag)
+		col.SetCharset("binary")
+		col.SetCollate("binary")
+	} else if types.IsString(basicType) {
+		col.SetCharset("utf8mb4")
+		col.SetCollate("utf8mb4_bin")
+	}
+	if isPrimaryKey {
+		col.AddFlag(mysql.PriKeyFlag)
+		col.AddFlag(mysql.UniqueKeyFlag)
+		col.AddFlag(mysql.NotNullFlag)
+	}
+	if common.IsUnsignedMySQLType(mysqlType) {
+		col.AddFlag(mysql.UnsignedFlag)
+	}
+	flen, decimal := common.ExtractFlenDecimal(mysqlType, col.GetType())
+	col.FieldType.SetFlen(flen)
+	col.FieldType.SetDecimal(decimal)
+	switch basicType {
+	case mysql.TypeEnum, mysql.TypeSet:
+		col.SetElems(common.ExtractElements(mysqlType))
+	case mysql.TypeDuration:
+		col.FieldType.SetDecimal(common.ExtractDecimal(mysqlType))
+	default:
+	}
+	return col
+}
+
+func newTiIndices(columns []*timodel.ColumnInfo) []*timodel.IndexInfo {
+	indexColumns := make([]*timodel.IndexColumn, 0)
+	for idx, col := range columns {
+		if mysql.HasPriKeyFlag(col.GetFlag()) {
+			indexColumns = append(indexColumns, &timodel.IndexColumn{
+				Name:   col.Name,
+				Offset: idx,
+			})
+		}
+	}
+	if len(indexColumns) == 0 {
+		return nil
+	}
+	return []*timodel.IndexInfo{{
+		ID:      1,
+		Name:    ast.NewCIStr("primary"),
+		Columns: indexColumns,
+		Primary: true,
+		Unique:  true,
+	}}
+}
+
+func formatAllColumnsValue(data orderedMap, columns []*timodel.ColumnInfo) map[string]any {
+	result := make(map[string]any, len(data))
+	for _, col := range columns {
+		raw, ok := data.get(col.Name.O)
+		if !ok {
+			continue
+		}
+		result[col.Name.O] = formatValue(raw, col.FieldType)
+	}
+	return result
+}
+
+// formatValue converts the json value decoded from the maxwell message to the value accepted by the chunk.
+func formatValue(value any, ft types.FieldType) any {
+	if value == nil {
+		return nil
+	}
+	switch ft.GetType() {
+	case mysql.TypeLonglong, mysql.TypeLong, mysql.TypeInt24, mysql.TypeShort, mysql.TypeTiny:
+		rawValue := stringValue(value)
+		if mysql.HasUnsignedFlag(ft.GetFlag()) {
+			data, err := strconv.ParseUint(rawValue, 10, 64)
+			if err != nil {
+				log.Panic("invalid column value for unsigned integer", zap.Any("rawValue", rawValue), zap.Error(err))
+			}
+			return data
+		}
+		data, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			log.Panic("invalid column value for integer", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return data
+	case mysql.TypeYear:
+		rawValue := stringValue(value)
+		result, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			log.Panic("invalid column value for year", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	case mysql.TypeFloat:
+		rawValue := stringValue(value)
+		result, err := strconv.ParseFloat(rawValue, 32)
+		if err != nil {
+			log.Panic("invalid column value for float", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return float32(result)
+	case mysql.TypeDouble:
+		rawValue := stringValue(value)
+		result, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil {
+			log.Panic("invalid column value for double", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeString,
+		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		rawValue := stringValue(value)
+		if mysql.HasBinaryFlag(ft.GetFlag()) {
+			result, err := base64.StdEncoding.DecodeString(rawValue)
+			if err != nil {
+				log.Panic("invalid column value for binary", zap.Any("rawValue", rawValue), zap.Error(err))
+			}
+			return result
+		}
+		return []byte(rawValue)
+	case mysql.TypeNewDecimal:
+		rawValue := stringValue(value)
+		result := new(types.MyDecimal)
+		err := result.FromString([]byte(rawValue))
+		if err != nil {
+			log.Panic("invalid column value for decimal", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		rawValue := stringValue(value)
+		result, err := types.ParseTime(types.DefaultStmtNoWarningContext, rawValue, ft.GetType(), ft.GetDecimal())
+		if err != nil {
+			log.Panic("invalid column value for time", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	case mysql.TypeDuration:
+		rawValue := stringValue(value)
+		result, _, err := types.ParseDuration(types.DefaultStmtNoWarningContext, rawValue, ft.GetDecimal())
+		if err != nil {
+			log.Panic("invalid column value for duration", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	case mysql.TypeEnum:
+		rawValue := stringValue(value)
+		result, err := types.ParseEnumName(ft.GetElems(), rawValue, ft.GetCollate())
+		if err != nil {
+			log.Panic("invalid column value for enum", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	case mysql.TypeSet:
+		elements, ok := value.([]any)
+		if !ok {
+			log.Panic("invalid column value for set", zap.Any("rawValue", value))
+		}
+		names := make([]string, 0, len(elements))
+		for _, element := range elements {
+			names = append(names, stringValue(element))
+		}
+		result, err := types.ParseSetName(ft.GetElems(), strings.Join(names, ","), ft.GetCollate())
+		if err != nil {
+			log.Panic("invalid column value for set", zap.Any("rawValue", value), zap.Error(err))
+		}
+		return result
+	case mysql.TypeBit:
+		rawValue := stringValue(value)
+		data, err := strconv.ParseUint(rawValue, 10, 64)
+		if err != nil {
+			log.Panic("invalid column value for bit", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		byteSize := (ft.GetFlen() + 7) >> 3
+		return types.NewBinaryLiteralFromUint(data, byteSize)
+	case mysql.TypeJSON:
+		rawValue, err := json.Marshal(value)
+		if err != nil {
+			log.Panic("invalid column value for json", zap.Any("rawValue", value), zap.Error(err))
+		}
+		result, err := types.ParseBinaryJSONFromString(string(rawValue))
+		if err != nil {
+			log.Panic("invalid column value for json", zap.ByteString("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	case mysql.TypeTiDBVectorFloat32:
+		rawValue := stringValue(value)
+		result, err := types.ParseVectorFloat32(rawValue)
+		if err != nil {
+			log.Panic("invalid column value for vector float32", zap.Any("rawValue", rawValue), zap.Error(err))
+		}
+		return result
+	default:
+	}
+	log.Panic("unknown column type", zap.Any("type", ft.GetType()), zap.Any("rawValue", value))
+	return nil
+}
+
+// stringValue returns the string representation of the json value.
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Panic("marshal the json value failed", zap.Any("value", value), zap.Error(err))
+	}
+	return string(data)
+}
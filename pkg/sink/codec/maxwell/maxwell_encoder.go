@@ -0,0 +1,207 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxwell
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+// BatchEncoder encodes the events into the maxwell format,
+// each row changed event is encoded as one message, the same as maxwell.
+type BatchEncoder struct {
+	messages []*common.Message
+	config   *common.Config
+}
+
+// NewBatchEncoder creates a new maxwell BatchEncoder.
+func NewBatchEncoder(config *common.Config) common.EventEncoder {
+	return &BatchEncoder{
+		config: config,
+	}
+}
+
+// EncodeCheckpointEvent implements the EventEncoder interface
+func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	// maxwell has no such a corresponding type to the checkpoint event,
+	// only send it if the TiDB extension is enabled.
+	if !d.config.EnableTiDBExtension {
+		return nil, nil
+	}
+	msg := &maxwellMessage{
+		Type: typeTiDBWatermark,
+		Ts:   convertToMaxwellTs(ts),
+		TiDB: &tidbExtension{WatermarkTs: ts},
+	}
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMaxwellEncodeFailed, err)
+	}
+	return common.NewMsg(nil, value), nil
+}
+
+// AppendRowChangedEvent implements the EventEncoder interface
+func (d *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *commonEvent.RowEvent,
+) error {
+	key, value, err := d.encodeRowChangedEvent(e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	length := len(key) + len(value) + common.MaxRecordOverhead
+	if length > d.config.MaxMessageBytes {
+		log.Warn("Single message is too large for maxwell",
+			zap.Int("maxMessageBytes", d.config.MaxMessageBytes),
+			zap.Int("length", length),
+			zap.Any("table", e.TableInfo.TableName))
+		return errors.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+
+	message := common.NewMsg(key, value)
+	message.SetRowsCount(1)
+	message.Callback = e.Callback
+	d.messages = append(d.messages, message)
+	return nil
+}
+
+// EncodeDDLEvent implements the EventEncoder interface
+func (d *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*common.Message, error) {
+	actionType := timodel.ActionType(e.Type)
+	msg := &maxwellMessage{
+		Database: e.GetSchemaName(),
+		Table:    e.GetTableName(),
+		Type:     ddlType(actionType),
+		Ts:       convertToMaxwellTs(e.GetCommitTs()),
+		SQL:      e.Query,
+	}
+	if e.TableInfo != nil && msg.Table != "" && msg.Type != typeTableDrop {
+		msg.Def = newTableDef(e.TableInfo)
+	}
+	if d.config.EnableTiDBExtension {
+		msg.TiDB = &tidbExtension{CommitTs: e.GetCommitTs()}
+	}
+
+	key, err := encodeKey(msg.Database, msg.Table, nil)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMaxwellEncodeFailed, err)
+	}
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMaxwellEncodeFailed, err)
+	}
+	return common.NewMsg(key, value), nil
+}
+
+// Build implements the EventEncoder interface
+func (d *BatchEncoder) Build() []*common.Message {
+	if len(d.messages) == 0 {
+		return nil
+	}
+	result := d.messages
+	d.messages = nil
+	return result
+}
+
+// Clean implements the EventEncoder interface
+func (d *BatchEncoder) Clean() {}
+
+func (d *BatchEncoder) encodeRowChangedEvent(e *commonEvent.RowEvent) ([]byte, []byte, error) {
+	msg := &maxwellMessage{
+		Database: e.TableInfo.GetSchemaName(),
+		Table:    e.TableInfo.GetTableName(),
+		Ts:       convertToMaxwellTs(e.CommitTs),
+		Xid:      e.StartTs,
+	}
+	if d.config.EnableTiDBExtension {
+		msg.TiDB = &tidbExtension{
+			CommitTs:  e.CommitTs,
+			MySQLType: make(map[string]string),
+		}
+	}
+
+	var pk orderedMap
+	switch {
+	case e.IsInsert():
+		msg.Type = typeInsert
+		msg.Data, pk = d.buildColumns(e, e.GetRows(), false, msg.TiDB)
+	case e.IsDelete():
+		msg.Type = typeDelete
+		msg.Data, pk = d.buildColumns(e, e.GetPreRows(), d.config.DeleteOnlyHandleKeyColumns, msg.TiDB)
+	default:
+		msg.Type = typeUpdate
+		msg.Data, pk = d.buildColumns(e, e.GetRows(), false, msg.TiDB)
+		previous, _ := d.buildColumns(e, e.GetPreRows(), false, nil)
+		// the `old` field only contains the columns which are changed.
+		for idx, kv := range previous {
+			// set and json values are not comparable by `==`, use reflect instead.
+			if !reflect.DeepEqual(kv.value, msg.Data[idx].value) {
+				msg.Old = append(msg.Old, kv)
+			}
+		}
+	}
+	for _, kv := range pk {
+		msg.PrimaryKeyColumns = append(msg.PrimaryKeyColumns, kv.key)
+	}
+
+	key, err := encodeKey(msg.Database, msg.Table, pk)
+	if err != nil {
+		return nil, nil, errors.WrapError(errors.ErrMaxwellEncodeFailed, err)
+	}
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, nil, errors.WrapError(errors.ErrMaxwellEncodeFailed, err)
+	}
+	return key, value, nil
+}
+
+// buildColumns returns all selected columns and the handle key columns of the row.
+func (d *BatchEncoder) buildColumns(
+	e *commonEvent.RowEvent, row *chunk.Row, onlyHandleKeyColumns bool, extension *tidbExtension,
+) (orderedMap, orderedMap) {
+	var (
+		tableInfo = e.TableInfo
+		columns   = make(orderedMap, 0, len(tableInfo.GetColumns()))
+		pk        orderedMap
+	)
+	for idx, col := range tableInfo.GetColumns() {
+		if col == nil || col.IsVirtualGenerated() || !e.ColumnSelector.Select(col) {
+			continue
+		}
+		isHandleKey := tableInfo.IsHandleKey(col.ID)
+		if onlyHandleKeyColumns && !isHandleKey {
+			continue
+		}
+		kv := keyValue{key: col.Name.O, value: formatColumnValue(row, idx, col)}
+		columns = append(columns, kv)
+		if isHandleKey {
+			pk = append(pk, kv)
+		}
+		if extension != nil {
+			extension.MySQLType[col.Name.O] = common.GetMySQLType(col, true)
+		}
+	}
+	return columns, pk
+}
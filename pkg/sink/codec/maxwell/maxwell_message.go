@@ -0,0 +1,299 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxwell
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+// The maxwell message types, see https://maxwells-daemon.io/dataformat/
+const (
+	typeInsert = "insert"
+	typeUpdate = "update"
+	typeDelete = "delete"
+
+	typeDatabaseCreate = "database-create"
+	typeDatabaseDrop   = "database-drop"
+	typeDatabaseAlter  = "database-alter"
+	typeTableCreate    = "table-create"
+	typeTableDrop      = "table-drop"
+	typeTableAlter     = "table-alter"
+
+	// typeTiDBWatermark is not defined by maxwell, it's only sent if the TiDB extension is enabled.
+	typeTiDBWatermark = "tidb-watermark"
+)
+
+// maxwellMessage is the value of a maxwell message, both row changes and DDLs share it.
+type maxwellMessage struct {
+	Database string `json:"database"`
+	Table    string `json:"table,omitempty"`
+	Type     string `json:"type"`
+	// Ts is the commit time of the event in seconds.
+	Ts int64 `json:"ts"`
+	// Xid is the transaction id, TiCDC fills it with the start ts of the transaction.
+	Xid               uint64     `json:"xid,omitempty"`
+	PrimaryKeyColumns []string   `json:"primary_key_columns,omitempty"`
+	Data              orderedMap `json:"data,omitempty"`
+	Old               orderedMap `json:"old,omitempty"`
+
+	// only set for DDL messages.
+	Def *tableDef `json:"def,omitempty"`
+	SQL string    `json:"sql,omitempty"`
+
+	TiDB *tidbExtension `json:"_tidb,omitempty"`
+}
+
+// tidbExtension carries the information which cannot be expressed by maxwell,
+// maxwell consumers ignore the unknown field.
+type tidbExtension struct {
+	CommitTs    uint64 `json:"commitTs,omitempty"`
+	WatermarkTs uint64 `json:"watermarkTs,omitempty"`
+	// MySQLType records each column's mysql type, which is used by the decoder to restore the value.
+	MySQLType map[string]string `json:"mysqlType,omitempty"`
+}
+
+// tableDef is the table definition attached to the table level DDL message.
+type tableDef struct {
+	Database   string      `json:"database"`
+	Charset    string      `json:"charset,omitempty"`
+	Table      string      `json:"table"`
+	Columns    []columnDef `json:"columns"`
+	PrimaryKey []string    `json:"primary-key"`
+}
+
+type columnDef struct {
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	Signed     *bool    `json:"signed,omitempty"`
+	Charset    string   `json:"charset,omitempty"`
+	EnumValues []string `json:"enum-values,omitempty"`
+}
+
+type keyValue struct {
+	key   string
+	value any
+}
+
+// orderedMap is a json object which keeps the order of the keys,
+// so that the columns are encoded in the same order as the table definition.
+type orderedMap []keyValue
+
+// MarshalJSON implements the json.Marshaler interface
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for idx, kv := range m {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(kv.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(kv.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface,
+// numbers are kept as json.Number to not lose the precision.
+func (m *orderedMap) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		*m = nil
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.ErrDecodeFailed.GenWithStackByArgs("maxwell data is not a json object")
+	}
+	result := make(orderedMap, 0)
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value any
+		if err = decoder.Decode(&value); err != nil {
+			return err
+		}
+		result = append(result, keyValue{key: token.(string), value: value})
+	}
+	*m = result
+	return nil
+}
+
+func (m orderedMap) get(key string) (any, bool) {
+	for _, kv := range m {
+		if kv.key == key {
+			return kv.value, true
+		}
+	}
+	return nil, false
+}
+
+// encodeKey returns the maxwell message key in the `hash` format,
+// such as `{"database":"test","table":"t","pk.id":1}`.
+func encodeKey(schema, table string, pk orderedMap) ([]byte, error) {
+	key := orderedMap{{key: "database", value: schema}, {key: "table", value: table}}
+	for _, kv := range pk {
+		key = append(key, keyValue{key: "pk." + kv.key, value: kv.value})
+	}
+	return json.Marshal(key)
+}
+
+// formatColumnValue returns the maxwell representation of the column value.
+func formatColumnValue(row *chunk.Row, idx int, columnInfo *model.ColumnInfo) any {
+	d := row.GetDatum(idx, &columnInfo.FieldType)
+	if d.IsNull() {
+		return nil
+	}
+	switch columnInfo.GetType() {
+	case mysql.TypeBit:
+		value, err := d.GetMysqlBit().ToInt(types.DefaultStmtNoWarningContext)
+		if err != nil {
+			log.Panic("failed to convert bit to int", zap.Any("data", d), zap.Error(err))
+		}
+		return value
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
+		mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString:
+		// binary values are encoded as base64 strings, the same as maxwell.
+		if mysql.HasBinaryFlag(columnInfo.GetFlag()) {
+			return d.GetBytes()
+		}
+		return string(d.GetBytes())
+	case mysql.TypeEnum:
+		enum, err := types.ParseEnumValue(columnInfo.GetElems(), d.GetMysqlEnum().Value)
+		if err != nil {
+			log.Panic("failed to parse enum value", zap.Any("data", d), zap.Error(err))
+		}
+		return enum.Name
+	case mysql.TypeSet:
+		set, err := types.ParseSetValue(columnInfo.GetElems(), d.GetMysqlSet().Value)
+		if err != nil {
+			log.Panic("failed to parse set value", zap.Any("data", d), zap.Error(err))
+		}
+		if set.Name == "" {
+			return []string{}
+		}
+		return strings.Split(set.Name, ",")
+	case mysql.TypeDate, mysql.TypeNewDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		return d.GetMysqlTime().String()
+	case mysql.TypeDuration:
+		return d.GetMysqlDuration().String()
+	case mysql.TypeJSON:
+		return json.RawMessage(d.GetMysqlJSON().String())
+	case mysql.TypeNewDecimal:
+		return json.Number(d.GetMysqlDecimal().String())
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(columnInfo.GetFlag()) {
+			return d.GetUint64()
+		}
+		return d.GetInt64()
+	case mysql.TypeYear:
+		return d.GetInt64()
+	case mysql.TypeFloat:
+		return d.GetFloat32()
+	case mysql.TypeDouble:
+		return d.GetFloat64()
+	case mysql.TypeTiDBVectorFloat32:
+		return d.GetVectorFloat32().String()
+	default:
+		return d.GetValue()
+	}
+}
+
+// ddlType returns the maxwell DDL type according to the action type.
+func ddlType(t model.ActionType) string {
+	switch t {
+	case model.ActionCreateSchema:
+		return typeDatabaseCreate
+	case model.ActionDropSchema:
+		return typeDatabaseDrop
+	case model.ActionModifySchemaCharsetAndCollate:
+		return typeDatabaseAlter
+	case model.ActionCreateTable, model.ActionCreateView:
+		return typeTableCreate
+	case model.ActionDropTable, model.ActionDropView:
+		return typeTableDrop
+	default:
+		return typeTableAlter
+	}
+}
+
+func isDDLType(t string) bool {
+	return strings.HasPrefix(t, "database-") || strings.HasPrefix(t, "table-")
+}
+
+// newTableDef builds the maxwell table definition by the table info.
+func newTableDef(tableInfo *commonType.TableInfo) *tableDef {
+	def := &tableDef{
+		Database:   tableInfo.GetSchemaName(),
+		Charset:    tableInfo.Charset,
+		Table:      tableInfo.GetTableName(),
+		Columns:    make([]columnDef, 0, len(tableInfo.GetColumns())),
+		PrimaryKey: tableInfo.GetPrimaryKeyColumnNames(),
+	}
+	if def.PrimaryKey == nil {
+		def.PrimaryKey = []string{}
+	}
+	for _, col := range tableInfo.GetColumns() {
+		if col == nil {
+			continue
+		}
+		column := columnDef{
+			Type: types.TypeToStr(col.GetType(), col.GetCharset()),
+			Name: col.Name.O,
+		}
+		switch col.GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+			signed := !mysql.HasUnsignedFlag(col.GetFlag())
+			column.Signed = &signed
+		case mysql.TypeEnum, mysql.TypeSet:
+			column.EnumValues = col.GetElems()
+		}
+		if col.GetCharset() != "" && col.GetCharset() != "binary" {
+			column.Charset = col.GetCharset()
+		}
+		def.Columns = append(def.Columns, column)
+	}
+	return def
+}
+
+// convertToMaxwellTs converts the tidb ts to the maxwell timestamp in seconds.
+func convertToMaxwellTs(ts uint64) int64 {
+	return int64(ts>>18) / 1000
+}
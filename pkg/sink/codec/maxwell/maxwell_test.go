@@ -0,0 +1,181 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxwell
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxwellRowEvents(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c decimal(10, 2), d datetime, e blob, f bit(10),
+		g json, h enum('a','b'), i set('a','b'), j bigint unsigned, k double, l time)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	insert := helper.DML2Event("test", "t", `insert into test.t values
+		(1, 'hello', 12.34, '2025-01-02 03:04:05', x'0102ff', b'1010101', '{"k": [1, "v"]}', 'b', 'a,b',
+		18446744073709551615, 3.1415, '12:34:56')`)
+	insertRow, ok := insert.GetNextRow()
+	require.True(t, ok)
+
+	columnSelector := columnselector.NewDefaultColumnSelector()
+	insertEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		StartTs:        insert.StartTs,
+		CommitTs:       insert.GetCommitTs(),
+		Event:          insertRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	update := helper.DML2Event("test", "t", `update test.t set b = null, c = 56.78, i = '' where a = 1`)
+	updateRow, ok := update.GetNextRow()
+	require.True(t, ok)
+	updateRow.PreRow = insertRow.Row
+	updateEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		StartTs:        update.StartTs,
+		CommitTs:       update.GetCommitTs(),
+		Event:          updateRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	deleteRow := updateRow
+	deleteRow.PreRow = updateRow.Row
+	deleteRow.Row = chunk.Row{}
+	deleteEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		StartTs:        update.StartTs + 1,
+		CommitTs:       update.GetCommitTs() + 1,
+		Event:          deleteRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	codecConfig := common.NewConfig(config.ProtocolMaxwell)
+	codecConfig.EnableTiDBExtension = true
+
+	ctx := context.Background()
+	encoder := NewBatchEncoder(codecConfig)
+	events := []*commonEvent.RowEvent{insertEvent, updateEvent, deleteEvent}
+	for _, event := range events {
+		err := encoder.AppendRowChangedEvent(ctx, "", event)
+		require.NoError(t, err)
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, len(events))
+	require.Equal(t, `{"database":"test","table":"t","pk.a":1}`, string(messages[0].Key))
+
+	// the update message only contains the changed columns in the `old` field.
+	var value map[string]any
+	require.NoError(t, json.Unmarshal(messages[1].Value, &value))
+	require.Equal(t, "update", value["type"])
+	require.Equal(t, "test", value["database"])
+	require.Equal(t, "t", value["table"])
+	require.Equal(t, map[string]any{"b": "hello", "c": 12.34, "i": []any{"a", "b"}}, value["old"])
+
+	decoder := NewDecoder(codecConfig)
+	for idx, event := range events {
+		require.Equal(t, 1, messages[idx].GetRowsCount())
+		decoder.AddKeyValue(messages[idx].Key, messages[idx].Value)
+		messageType, hasNext := decoder.HasNext()
+		require.True(t, hasNext)
+		require.Equal(t, common.MessageTypeRow, messageType)
+
+		decoded := decoder.NextDMLEvent()
+		require.Equal(t, event.CommitTs, decoded.GetCommitTs())
+		require.Equal(t, event.StartTs, decoded.StartTs)
+		change, ok := decoded.GetNextRow()
+		require.True(t, ok)
+		common.CompareRow(t, event.Event, event.TableInfo, change, decoded.TableInfo)
+
+		_, hasNext = decoder.HasNext()
+		require.False(t, hasNext)
+	}
+}
+
+func TestMaxwellDDLAndCheckpoint(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	codecConfig := common.NewConfig(config.ProtocolMaxwell)
+	encoder := NewBatchEncoder(codecConfig)
+
+	// checkpoint is not sent without the TiDB extension.
+	message, err := encoder.EncodeCheckpointEvent(1024)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	codecConfig.EnableTiDBExtension = true
+	encoder = NewBatchEncoder(codecConfig)
+	decoder := NewDecoder(codecConfig)
+
+	message, err = encoder.EncodeCheckpointEvent(1024)
+	require.NoError(t, err)
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext := decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeResolved, messageType)
+	require.Equal(t, uint64(1024), decoder.NextResolvedEvent())
+
+	createTable := helper.DDL2Event(`create table test.t(a int unsigned primary key, b enum('x','y'))`)
+	message, err = encoder.EncodeDDLEvent(createTable)
+	require.NoError(t, err)
+	require.Equal(t, `{"database":"test","table":"t"}`, string(message.Key))
+
+	msg := new(maxwellMessage)
+	require.NoError(t, json.Unmarshal(message.Value, msg))
+	require.Equal(t, typeTableCreate, msg.Type)
+	require.Equal(t, convertToMaxwellTs(createTable.GetCommitTs()), msg.Ts)
+	require.NotNil(t, msg.Def)
+	require.Equal(t, []string{"a"}, msg.Def.PrimaryKey)
+	require.Len(t, msg.Def.Columns, 2)
+	require.False(t, *msg.Def.Columns[0].Signed)
+	require.Equal(t, []string{"x", "y"}, msg.Def.Columns[1].EnumValues)
+
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext = decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeDDL, messageType)
+
+	decoded := decoder.NextDDLEvent()
+	require.Equal(t, createTable.Query, decoded.Query)
+	require.Equal(t, createTable.GetCommitTs(), decoded.GetCommitTs())
+	require.Equal(t, "test", decoded.GetSchemaName())
+	require.Equal(t, "t", decoded.GetTableName())
+	require.Equal(t, byte(timodel.ActionCreateTable), decoded.Type)
+
+	dropTable := helper.DDL2Event(`drop table test.t`)
+	message, err = encoder.EncodeDDLEvent(dropTable)
+	require.NoError(t, err)
+	msg = new(maxwellMessage)
+	require.NoError(t, json.Unmarshal(message.Value, msg))
+	require.Equal(t, typeTableDrop, msg.Type)
+	require.Nil(t, msg.Def)
+}
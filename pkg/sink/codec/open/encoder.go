@@ -109,7 +109,7 @@ func (d *batchEncoder) AppendRowChangedEvent(
 		if d.config.LargeMessageHandle.EnableClaimCheck() {
 			// send the large message to the external storage first, then
 			// create a new message contains the reference of the large message.
-			claimCheckFileName := claimcheck.NewFileName()
+			claimCheckFileName := d.claimCheck.NewFileName()
 			keyOutput, valueOutput := enhancedKeyValue(key, value)
 			err = d.claimCheck.WriteMessage(ctx, keyOutput, valueOutput, claimCheckFileName)
 			if err != nil {
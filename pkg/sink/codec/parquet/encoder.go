@@ -0,0 +1,252 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"time"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+// txnEventEncoder converts the rows of the txn events into the parquet values.
+// A parquet file can not be built by concatenating the messages, so the values
+// are kept in an intermediate row format, and the cloud storage sink builds
+// the file for all messages of one table task by BuildFile.
+type txnEventEncoder struct {
+	// schemas caches the parquet schema of each table, keyed by the physical table id.
+	schemas map[int64]*cachedSchema
+
+	valueBuf  *bytes.Buffer
+	batchSize int
+	callback  func()
+}
+
+type cachedSchema struct {
+	updateTS uint64
+	schema   *fileSchema
+}
+
+// NewTxnEventEncoder creates a new parquet TxnEventEncoder.
+func NewTxnEventEncoder(_ *common.Config) common.TxnEventEncoder {
+	return &txnEventEncoder{
+		schemas:  make(map[int64]*cachedSchema),
+		valueBuf: &bytes.Buffer{},
+	}
+}
+
+// AppendTxnEvent implements the TxnEventEncoder interface
+func (e *txnEventEncoder) AppendTxnEvent(event *commonEvent.DMLEvent) error {
+	schema := e.getSchema(event.PhysicalTableID, event.TableInfo)
+	values := make([]interface{}, len(schema.elements)-1)
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			event.Rewind()
+			break
+		}
+		var (
+			data *chunk.Row
+			op   string
+		)
+		switch row.RowType {
+		case commonType.RowTypeDelete:
+			data, op = &row.PreRow, operationDelete
+		case commonType.RowTypeInsert:
+			data, op = &row.Row, operationInsert
+		default:
+			data, op = &row.Row, operationUpdate
+		}
+		for i, col := range schema.columns {
+			value, err := columnValue(data, schema.offsets[i], col, schema.elements[i+1])
+			if err != nil {
+				return errors.Trace(err)
+			}
+			values[i] = value
+		}
+		values[len(values)-2] = op
+		values[len(values)-1] = int64(event.CommitTs)
+		e.valueBuf.Write(appendRow(nil, schema.elements[1:], values))
+		e.batchSize++
+	}
+	e.callback = event.PostFlush
+	return nil
+}
+
+// Build implements the TxnEventEncoder interface
+func (e *txnEventEncoder) Build() []*common.Message {
+	if e.batchSize == 0 {
+		return nil
+	}
+
+	value := make([]byte, e.valueBuf.Len())
+	copy(value, e.valueBuf.Bytes())
+	ret := common.NewMsg(nil, value)
+	ret.SetRowsCount(e.batchSize)
+	ret.Callback = e.callback
+	if e.valueBuf.Cap() > common.MemBufShrinkThreshold {
+		e.valueBuf = &bytes.Buffer{}
+	} else {
+		e.valueBuf.Reset()
+	}
+	e.callback = nil
+	e.batchSize = 0
+	return []*common.Message{ret}
+}
+
+func (e *txnEventEncoder) getSchema(physicalTableID int64, tableInfo *commonType.TableInfo) *fileSchema {
+	cached, ok := e.schemas[physicalTableID]
+	if ok && cached.updateTS == tableInfo.GetUpdateTS() {
+		return cached.schema
+	}
+	cached = &cachedSchema{
+		updateTS: tableInfo.GetUpdateTS(),
+		schema:   newFileSchema(tableInfo),
+	}
+	e.schemas[physicalTableID] = cached
+	return cached.schema
+}
+
+// columnValue converts the column value to the parquet value of the type mapped by newColumnElement.
+func columnValue(
+	row *chunk.Row, idx int, col *timodel.ColumnInfo, element *parquet.SchemaElement,
+) (interface{}, error) {
+	if row.IsNull(idx) {
+		return nil, nil
+	}
+
+	unsigned := mysql.HasUnsignedFlag(col.GetFlag())
+	switch col.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong:
+		if unsigned {
+			return int32(uint32(row.GetUint64(idx))), nil
+		}
+		return int32(row.GetInt64(idx)), nil
+	case mysql.TypeLonglong:
+		if unsigned {
+			return int64(row.GetUint64(idx)), nil
+		}
+		return row.GetInt64(idx), nil
+	case mysql.TypeYear:
+		return int32(row.GetInt64(idx)), nil
+	case mysql.TypeBit:
+		d := row.GetDatum(idx, &col.FieldType)
+		// Encode bits as integers to avoid pingcap/tidb#10988 (which also affects MySQL itself)
+		value, err := d.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrParquetEncodeFailed, err)
+		}
+		return int64(value), nil
+	case mysql.TypeFloat:
+		return row.GetFloat32(idx), nil
+	case mysql.TypeDouble:
+		return row.GetFloat64(idx), nil
+	case mysql.TypeNewDecimal:
+		return decimalValue(row.GetMyDecimal(idx), element)
+	case mysql.TypeDate, mysql.TypeNewDate:
+		t := row.GetTime(idx)
+		// the zero date can not be represented by the parquet date.
+		if t.Month() == 0 || t.Day() == 0 {
+			return nil, nil
+		}
+		days := time.Date(t.Year(), time.Month(t.Month()), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+		return int32(days), nil
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		t := row.GetTime(idx)
+		// the zero datetime can not be represented by the parquet timestamp.
+		if t.Month() == 0 || t.Day() == 0 {
+			return nil, nil
+		}
+		return time.Date(t.Year(), time.Month(t.Month()), t.Day(),
+			t.Hour(), t.Minute(), t.Second(), t.Microsecond()*1000, time.UTC).UnixMicro(), nil
+	case mysql.TypeDuration:
+		return row.GetDuration(idx, col.GetDecimal()).Duration.Microseconds(), nil
+	case mysql.TypeJSON:
+		return row.GetJSON(idx).String(), nil
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return string(row.GetBytes(idx)), nil
+	case mysql.TypeEnum:
+		enumVar, err := types.ParseEnumValue(col.GetElems(), row.GetEnum(idx).Value)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrParquetEncodeFailed, err)
+		}
+		return enumVar.Name, nil
+	case mysql.TypeSet:
+		setVar, err := types.ParseSetValue(col.GetElems(), row.GetSet(idx).Value)
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrParquetEncodeFailed, err)
+		}
+		return setVar.Name, nil
+	case mysql.TypeTiDBVectorFloat32:
+		return row.GetVectorFloat32(idx).String(), nil
+	default:
+		d := row.GetDatum(idx, &col.FieldType)
+		value, err := d.ToString()
+		if err != nil {
+			return nil, errors.WrapError(errors.ErrParquetEncodeFailed, err)
+		}
+		return value, nil
+	}
+}
+
+// decimalValue returns the unscaled value of the decimal in the physical type of the element.
+func decimalValue(d *types.MyDecimal, element *parquet.SchemaElement) (interface{}, error) {
+	scale := int(element.GetScale())
+	str := string(d.ToString())
+	integer, fraction, _ := strings.Cut(str, ".")
+	if len(fraction) < scale {
+		fraction += strings.Repeat("0", scale-len(fraction))
+	}
+	unscaled, ok := new(big.Int).SetString(integer+fraction[:scale], 10)
+	if !ok {
+		return nil, errors.ErrParquetEncodeFailed.GenWithStack("invalid decimal value %s", str)
+	}
+	switch element.GetType() {
+	case parquet.Type_INT32:
+		return int32(unscaled.Int64()), nil
+	case parquet.Type_INT64:
+		return unscaled.Int64(), nil
+	default:
+		return string(twosComplement(unscaled)), nil
+	}
+}
+
+// twosComplement returns the minimal big-endian two's complement representation of the value.
+func twosComplement(v *big.Int) []byte {
+	if v.Sign() >= 0 {
+		b := v.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+	// -v-1 is the bitwise complement of v, its bit length determines the length with the sign bit.
+	n := new(big.Int).Not(v).BitLen()/8 + 1
+	b := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), uint(n*8)), v).Bytes()
+	for len(b) < n {
+		b = append([]byte{0xff}, b...)
+	}
+	return b
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestBuildFile(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c decimal(10, 2), d decimal(30, 5), e datetime(3), f date,
+		g time(2), h json, i blob, j enum('a','b'), k set('a','b'), l bit(10), m bigint unsigned,
+		n tinyint unsigned, o double, p int as (a + 1) virtual)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	event := helper.DML2Event("test", "t",
+		`insert into test.t(a,b,c,d,e,f,g,h,i,j,k,l,m,n,o) values (1, 'hello', -12.34,
+		-123456789012345678901.5, '2025-01-02 03:04:05.678', '2025-01-02', '-12:34:56.78',
+		'{"k": [1, "v"]}', x'0102ff', 'b', 'a,b', b'1010101', 18446744073709551615, 255, 3.5)`,
+		`insert into test.t(a) values (2)`,
+		`insert into test.t(a,b) values (20, 'world')`)
+	// the second and the third rows make up an update event.
+	event.RowTypes[1] = commonType.RowTypeUpdate
+	event.RowTypes[2] = commonType.RowTypeUpdate
+	var called int
+	event.AddPostFlushFunc(func() { called++ })
+
+	deleteEvent := helper.DML2Event("test", "t", `insert into test.t(a) values (30)`)
+	deleteEvent.RowTypes[0] = commonType.RowTypeDelete
+	deleteEvent.CommitTs = event.CommitTs + 1
+
+	encoder := NewTxnEventEncoder(common.NewConfig(config.ProtocolParquet))
+	var messages []*common.Message
+	for _, e := range []*commonEvent.DMLEvent{event, deleteEvent} {
+		require.NoError(t, encoder.AppendTxnEvent(e))
+		built := encoder.Build()
+		require.Len(t, built, 1)
+		messages = append(messages, built...)
+	}
+	require.Equal(t, 2, messages[0].GetRowsCount())
+	require.Equal(t, 1, messages[1].GetRowsCount())
+	messages[0].Callback()
+	require.Equal(t, 1, called)
+	require.Nil(t, encoder.Build())
+
+	data, err := BuildFile(tableInfo, messages)
+	require.NoError(t, err)
+
+	file, err := buffer.NewBufferFile(data)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Len(t, pr.Footer.RowGroups, 1)
+	require.Equal(t, int64(3), pr.GetNumRows())
+
+	// the virtual generated column is skipped, the metadata columns are appended.
+	elements := pr.Footer.Schema[1:]
+	require.Len(t, elements, 17)
+	// the reader renames the elements, the original names are kept in the schema handler.
+	infos := pr.SchemaHandler.Infos[1:]
+	require.Equal(t, "a", infos[0].ExName)
+	require.Equal(t, operationColumn, infos[15].ExName)
+	require.Equal(t, commitTsColumn, infos[16].ExName)
+
+	require.Equal(t, parquet.Type_INT64, elements[2].GetType())
+	require.Equal(t, int32(10), elements[2].GetLogicalType().DECIMAL.Precision)
+	require.Equal(t, int32(2), elements[2].GetLogicalType().DECIMAL.Scale)
+	require.Equal(t, parquet.Type_BYTE_ARRAY, elements[3].GetType())
+	require.Equal(t, int32(30), elements[3].GetLogicalType().DECIMAL.Precision)
+	require.False(t, elements[4].GetLogicalType().TIMESTAMP.IsAdjustedToUTC)
+	require.True(t, elements[5].GetLogicalType().IsSetDATE())
+	require.True(t, elements[6].GetLogicalType().IsSetTIME())
+	require.True(t, elements[7].GetLogicalType().IsSetJSON())
+	require.Nil(t, elements[8].GetLogicalType())
+	require.True(t, elements[9].GetLogicalType().IsSetSTRING())
+	require.False(t, elements[12].GetLogicalType().INTEGER.IsSigned)
+
+	readColumn := func(index int) []interface{} {
+		values, _, _, err := pr.ReadColumnByIndex(int64(index), 3)
+		require.NoError(t, err)
+		require.Len(t, values, 3)
+		return values
+	}
+	require.Equal(t, []interface{}{int32(1), int32(20), int32(30)}, readColumn(0))
+	require.Equal(t, []interface{}{"hello", "world", nil}, readColumn(1))
+	require.Equal(t, int64(-1234), readColumn(2)[0])
+	unscaled, _ := new(big.Int).SetString("-12345678901234567890150000", 10)
+	require.Equal(t, string(twosComplement(unscaled)), readColumn(3)[0])
+	datetime := time.Date(2025, 1, 2, 3, 4, 5, 678000000, time.UTC)
+	require.Equal(t, datetime.UnixMicro(), readColumn(4)[0])
+	require.Equal(t, int32(datetime.Unix()/86400), readColumn(5)[0])
+	require.Equal(t, -(12*time.Hour + 34*time.Minute + 56780*time.Millisecond).Microseconds(), readColumn(6)[0])
+	require.Equal(t, `{"k": [1, "v"]}`, readColumn(7)[0])
+	require.Equal(t, "\x01\x02\xff", readColumn(8)[0])
+	require.Equal(t, "b", readColumn(9)[0])
+	require.Equal(t, "a,b", readColumn(10)[0])
+	require.Equal(t, int64(85), readColumn(11)[0])
+	require.Equal(t, int64(-1), readColumn(12)[0])
+	require.Equal(t, int32(255), readColumn(13)[0])
+	require.Equal(t, 3.5, readColumn(14)[0])
+	require.Equal(t, []interface{}{operationInsert, operationUpdate, operationDelete}, readColumn(15))
+	commitTs := int64(event.CommitTs)
+	require.Equal(t, []interface{}{commitTs, commitTs, commitTs + 1}, readColumn(16))
+}
+
+func TestTwosComplement(t *testing.T) {
+	for _, c := range []struct {
+		value    int64
+		expected []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x00, 0x80}},
+		{-1, []byte{0xff}},
+		{-128, []byte{0x80}},
+		{-129, []byte{0xff, 0x7f}},
+	} {
+		require.Equal(t, c.expected, twosComplement(big.NewInt(c.value)), c.value)
+	}
+}
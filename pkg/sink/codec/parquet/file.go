@@ -0,0 +1,138 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	valueNull    byte = 0
+	valuePresent byte = 1
+)
+
+// BuildFile builds one parquet file from the messages encoded by the parquet TxnEventEncoder.
+// All the rows are written into one row group.
+func BuildFile(tableInfo *commonType.TableInfo, msgs []*common.Message) ([]byte, error) {
+	schema := newFileSchema(tableInfo)
+	buf := &bytes.Buffer{}
+	pw, err := writer.NewParquetWriterFromWriter(buf, schema.elements, 1)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrParquetEncodeFailed, err)
+	}
+	pw.MarshalFunc = marshal.MarshalCSV
+	pw.RowGroupSize = math.MaxInt64
+
+	elements := schema.elements[1:]
+	for _, msg := range msgs {
+		data := msg.Value
+		for len(data) > 0 {
+			values := make([]interface{}, len(elements))
+			data, err = readRow(data, elements, values)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err = pw.Write(values); err != nil {
+				return nil, errors.WrapError(errors.ErrParquetEncodeFailed, err)
+			}
+		}
+	}
+	if err = pw.WriteStop(); err != nil {
+		return nil, errors.WrapError(errors.ErrParquetEncodeFailed, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// appendRow appends the values of one row in the intermediate row format to the buffer,
+// each value is prefixed by a flag which indicates whether it's null,
+// and encoded according to the physical type of the element.
+func appendRow(buf []byte, elements []*parquet.SchemaElement, values []interface{}) []byte {
+	for i, value := range values {
+		if value == nil {
+			buf = append(buf, valueNull)
+			continue
+		}
+		buf = append(buf, valuePresent)
+		switch elements[i].GetType() {
+		case parquet.Type_INT32:
+			buf = binary.AppendVarint(buf, int64(value.(int32)))
+		case parquet.Type_INT64:
+			buf = binary.AppendVarint(buf, value.(int64))
+		case parquet.Type_FLOAT:
+			buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(value.(float32)))
+		case parquet.Type_DOUBLE:
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(value.(float64)))
+		default:
+			str := value.(string)
+			buf = binary.AppendUvarint(buf, uint64(len(str)))
+			buf = append(buf, str...)
+		}
+	}
+	return buf
+}
+
+// readRow reads the values of one row from the intermediate row format, and returns the remaining data.
+func readRow(data []byte, elements []*parquet.SchemaElement, values []interface{}) ([]byte, error) {
+	for i, element := range elements {
+		if len(data) == 0 {
+			return nil, errors.ErrParquetEncodeFailed.GenWithStack("unexpected end of the row")
+		}
+		flag := data[0]
+		data = data[1:]
+		if flag == valueNull {
+			values[i] = nil
+			continue
+		}
+		var n int
+		switch element.GetType() {
+		case parquet.Type_INT32:
+			var v int64
+			v, n = binary.Varint(data)
+			values[i] = int32(v)
+		case parquet.Type_INT64:
+			values[i], n = binary.Varint(data)
+		case parquet.Type_FLOAT:
+			if len(data) >= 4 {
+				values[i], n = math.Float32frombits(binary.LittleEndian.Uint32(data)), 4
+			}
+		case parquet.Type_DOUBLE:
+			if len(data) >= 8 {
+				values[i], n = math.Float64frombits(binary.LittleEndian.Uint64(data)), 8
+			}
+		default:
+			var length uint64
+			length, n = binary.Uvarint(data)
+			if n > 0 && uint64(len(data)-n) >= length {
+				values[i] = string(data[n : n+int(length)])
+				n += int(length)
+			} else {
+				n = 0
+			}
+		}
+		if n <= 0 {
+			return nil, errors.ErrParquetEncodeFailed.GenWithStack("invalid value of the column %s", element.GetName())
+		}
+		data = data[n:]
+	}
+	return data, nil
+}
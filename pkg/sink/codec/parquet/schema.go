@@ -0,0 +1,238 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+const (
+	// operationColumn is the name of the column which holds the operation type of the row.
+	operationColumn = "_tidb_op"
+	// commitTsColumn is the name of the column which holds the commit ts of the row.
+	commitTsColumn = "_tidb_commit_ts"
+
+	operationInsert = "I"
+	operationUpdate = "U"
+	operationDelete = "D"
+
+	// decimals are stored as the unscaled value in INT32 or INT64 if the precision fits,
+	// otherwise as the big-endian two's complement bytes of the unscaled value.
+	maxInt32DecimalPrecision = 9
+	maxInt64DecimalPrecision = 18
+)
+
+// fileSchema is the parquet schema of one table version.
+type fileSchema struct {
+	// elements contains the root element, the table columns and the metadata columns.
+	elements []*parquet.SchemaElement
+	// columns are the table columns written to the file, virtual generated columns are skipped.
+	columns []*timodel.ColumnInfo
+	// offsets are the offsets of the columns in the row of the chunk.
+	offsets []int
+}
+
+// newFileSchema maps the columns of the table to the parquet schema elements.
+// All table columns are optional, since the schema may be changed by the DDL,
+// the metadata columns are appended to the end and always required.
+func newFileSchema(tableInfo *commonType.TableInfo) *fileSchema {
+	columns := tableInfo.GetColumns()
+	schema := &fileSchema{
+		elements: make([]*parquet.SchemaElement, 1, len(columns)+3),
+		columns:  make([]*timodel.ColumnInfo, 0, len(columns)),
+		offsets:  make([]int, 0, len(columns)),
+	}
+	for i, col := range columns {
+		if col == nil || col.IsVirtualGenerated() {
+			continue
+		}
+		element := newColumnElement(col)
+		element.Name = col.Name.O
+		element.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+		schema.elements = append(schema.elements, element)
+		schema.columns = append(schema.columns, col)
+		schema.offsets = append(schema.offsets, i)
+	}
+
+	op := newStringElement()
+	op.Name = operationColumn
+	op.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	commitTs := newIntElement(64, false)
+	commitTs.Name = commitTsColumn
+	commitTs.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	schema.elements = append(schema.elements, op, commitTs)
+
+	numChildren := int32(len(schema.elements) - 1)
+	schema.elements[0] = &parquet.SchemaElement{
+		Name:           tableInfo.GetTableName(),
+		RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED),
+		NumChildren:    &numChildren,
+	}
+	return schema
+}
+
+// newColumnElement returns the schema element of the column, without the name and the repetition type.
+func newColumnElement(col *timodel.ColumnInfo) *parquet.SchemaElement {
+	unsigned := mysql.HasUnsignedFlag(col.GetFlag())
+	switch col.GetType() {
+	case mysql.TypeTiny:
+		return newIntElement(8, !unsigned)
+	case mysql.TypeShort:
+		return newIntElement(16, !unsigned)
+	case mysql.TypeInt24, mysql.TypeLong:
+		return newIntElement(32, !unsigned)
+	case mysql.TypeLonglong:
+		return newIntElement(64, !unsigned)
+	case mysql.TypeYear:
+		return newIntElement(16, true)
+	case mysql.TypeBit:
+		return newIntElement(64, false)
+	case mysql.TypeFloat:
+		return &parquet.SchemaElement{Type: parquet.TypePtr(parquet.Type_FLOAT)}
+	case mysql.TypeDouble:
+		return &parquet.SchemaElement{Type: parquet.TypePtr(parquet.Type_DOUBLE)}
+	case mysql.TypeNewDecimal:
+		return newDecimalElement(col)
+	case mysql.TypeDate, mysql.TypeNewDate:
+		logicalType := parquet.NewLogicalType()
+		logicalType.DATE = parquet.NewDateType()
+		return &parquet.SchemaElement{
+			Type:          parquet.TypePtr(parquet.Type_INT32),
+			ConvertedType: parquet.ConvertedTypePtr(parquet.ConvertedType_DATE),
+			LogicalType:   logicalType,
+		}
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		// the timestamp is stored in UTC, while the datetime has no time zone.
+		logicalType := parquet.NewLogicalType()
+		logicalType.TIMESTAMP = parquet.NewTimestampType()
+		logicalType.TIMESTAMP.IsAdjustedToUTC = col.GetType() == mysql.TypeTimestamp
+		logicalType.TIMESTAMP.Unit = newMicrosTimeUnit()
+		return &parquet.SchemaElement{
+			Type:          parquet.TypePtr(parquet.Type_INT64),
+			ConvertedType: parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MICROS),
+			LogicalType:   logicalType,
+		}
+	case mysql.TypeDuration:
+		// the duration is written as is, it may be negative or exceed one day.
+		logicalType := parquet.NewLogicalType()
+		logicalType.TIME = parquet.NewTimeType()
+		logicalType.TIME.Unit = newMicrosTimeUnit()
+		return &parquet.SchemaElement{
+			Type:          parquet.TypePtr(parquet.Type_INT64),
+			ConvertedType: parquet.ConvertedTypePtr(parquet.ConvertedType_TIME_MICROS),
+			LogicalType:   logicalType,
+		}
+	case mysql.TypeJSON:
+		logicalType := parquet.NewLogicalType()
+		logicalType.JSON = parquet.NewJsonType()
+		return &parquet.SchemaElement{
+			Type:          parquet.TypePtr(parquet.Type_BYTE_ARRAY),
+			ConvertedType: parquet.ConvertedTypePtr(parquet.ConvertedType_JSON),
+			LogicalType:   logicalType,
+		}
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if mysql.HasBinaryFlag(col.GetFlag()) {
+			return &parquet.SchemaElement{Type: parquet.TypePtr(parquet.Type_BYTE_ARRAY)}
+		}
+		return newStringElement()
+	default:
+		// enum and set are written by the names, the others are written by the string representation.
+		return newStringElement()
+	}
+}
+
+func newIntElement(bitWidth int8, signed bool) *parquet.SchemaElement {
+	physicalType := parquet.Type_INT32
+	if bitWidth == 64 {
+		physicalType = parquet.Type_INT64
+	}
+	var convertedType parquet.ConvertedType
+	switch {
+	case bitWidth == 8 && signed:
+		convertedType = parquet.ConvertedType_INT_8
+	case bitWidth == 8:
+		convertedType = parquet.ConvertedType_UINT_8
+	case bitWidth == 16 && signed:
+		convertedType = parquet.ConvertedType_INT_16
+	case bitWidth == 16:
+		convertedType = parquet.ConvertedType_UINT_16
+	case bitWidth == 32 && signed:
+		convertedType = parquet.ConvertedType_INT_32
+	case bitWidth == 32:
+		convertedType = parquet.ConvertedType_UINT_32
+	case signed:
+		convertedType = parquet.ConvertedType_INT_64
+	default:
+		convertedType = parquet.ConvertedType_UINT_64
+	}
+	logicalType := parquet.NewLogicalType()
+	logicalType.INTEGER = parquet.NewIntType()
+	logicalType.INTEGER.BitWidth = bitWidth
+	logicalType.INTEGER.IsSigned = signed
+	return &parquet.SchemaElement{
+		Type:          parquet.TypePtr(physicalType),
+		ConvertedType: parquet.ConvertedTypePtr(convertedType),
+		LogicalType:   logicalType,
+	}
+}
+
+func newDecimalElement(col *timodel.ColumnInfo) *parquet.SchemaElement {
+	precision, scale := col.GetFlen(), col.GetDecimal()
+	defaultPrecision, defaultScale := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeNewDecimal)
+	if precision == types.UnspecifiedLength {
+		precision = defaultPrecision
+	}
+	if scale == types.UnspecifiedLength {
+		scale = defaultScale
+	}
+	physicalType := parquet.Type_BYTE_ARRAY
+	switch {
+	case precision <= maxInt32DecimalPrecision:
+		physicalType = parquet.Type_INT32
+	case precision <= maxInt64DecimalPrecision:
+		physicalType = parquet.Type_INT64
+	}
+	logicalType := parquet.NewLogicalType()
+	logicalType.DECIMAL = parquet.NewDecimalType()
+	logicalType.DECIMAL.Precision = int32(precision)
+	logicalType.DECIMAL.Scale = int32(scale)
+	return &parquet.SchemaElement{
+		Type:          parquet.TypePtr(physicalType),
+		ConvertedType: parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL),
+		LogicalType:   logicalType,
+		Precision:     &logicalType.DECIMAL.Precision,
+		Scale:         &logicalType.DECIMAL.Scale,
+	}
+}
+
+func newStringElement() *parquet.SchemaElement {
+	logicalType := parquet.NewLogicalType()
+	logicalType.STRING = parquet.NewStringType()
+	return &parquet.SchemaElement{
+		Type:          parquet.TypePtr(parquet.Type_BYTE_ARRAY),
+		ConvertedType: parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8),
+		LogicalType:   logicalType,
+	}
+}
+
+func newMicrosTimeUnit() *parquet.TimeUnit {
+	unit := parquet.NewTimeUnit()
+	unit.MICROS = parquet.NewMicroSeconds()
+	return unit
+}
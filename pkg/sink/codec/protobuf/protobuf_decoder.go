@@ -0,0 +1,402 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/avro"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var tableIDAllocator = common.NewTableIDAllocator()
+
+type decoder struct {
+	idx    int
+	config *common.Config
+
+	client *avro.ConfluentRegistryClient
+	// schemas caches the compiled message descriptor by the schema ID.
+	schemas map[int]protoreflect.MessageDescriptor
+
+	key   []byte
+	value []byte
+}
+
+// NewDecoder return a protobuf decoder
+func NewDecoder(
+	ctx context.Context, config *common.Config, idx int,
+) (common.Decoder, error) {
+	client, err := avro.NewConfluentRegistryClient(ctx, config.AvroConfluentSchemaRegistry, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tableIDAllocator.Clean()
+	return &decoder{
+		idx:     idx,
+		config:  config,
+		client:  client,
+		schemas: make(map[int]protoreflect.MessageDescriptor),
+	}, nil
+}
+
+// AddKeyValue implements the Decoder interface
+func (d *decoder) AddKeyValue(key, value []byte) {
+	if d.key != nil || d.value != nil {
+		log.Panic("add key/value to the decoder failed, since it's already set")
+	}
+	d.key = key
+	d.value = value
+}
+
+// HasNext implements the Decoder interface
+func (d *decoder) HasNext() (common.MessageType, bool) {
+	if d.key == nil && d.value == nil {
+		return common.MessageTypeUnknown, false
+	}
+
+	// it must a row event.
+	if d.key != nil {
+		return common.MessageTypeRow, true
+	}
+	if len(d.value) < 1 {
+		log.Panic("protobuf invalid data, the length of value is less than 1", zap.Any("data", d.value))
+	}
+	switch d.value[0] {
+	case magicByte:
+		return common.MessageTypeRow, true
+	case ddlByte:
+		return common.MessageTypeDDL, true
+	case checkpointByte:
+		return common.MessageTypeResolved, true
+	default:
+	}
+	log.Panic("protobuf invalid data, the first byte is not magic byte or ddl byte")
+	return common.MessageTypeUnknown, false
+}
+
+// NextResolvedEvent returns the next resolved event if exists
+func (d *decoder) NextResolvedEvent() uint64 {
+	if len(d.value) < 9 {
+		log.Panic("value is too short, cannot found the resolved-ts", zap.Any("value", d.value))
+	}
+	ts := binary.BigEndian.Uint64(d.value[1:])
+	d.value = nil
+	return ts
+}
+
+// NextDDLEvent returns the next DDL event if exists
+func (d *decoder) NextDDLEvent() *commonEvent.DDLEvent {
+	if len(d.value) == 0 || d.value[0] != ddlByte {
+		log.Panic("protobuf invalid data, the first byte is not ddl byte", zap.Any("value", d.value))
+	}
+
+	var event ddlEvent
+	if err := json.Unmarshal(d.value[1:], &event); err != nil {
+		log.Panic("unmarshal ddl event failed", zap.Any("value", d.value), zap.Error(err))
+	}
+	d.value = nil
+
+	result := new(commonEvent.DDLEvent)
+	result.SchemaName = event.Schema
+	result.TableName = event.Table
+	result.Query = event.Query
+	result.FinishedTs = event.CommitTs
+	actionType := common.GetDDLActionType(result.Query)
+	result.Type = byte(actionType)
+
+	if d.idx == 0 {
+		tableIDAllocator.AddBlockTableID(result.SchemaName, result.TableName,
+			tableIDAllocator.Allocate(result.SchemaName, result.TableName))
+		result.BlockedTables = common.GetBlockedTables(tableIDAllocator, result)
+	}
+	return result
+}
+
+// NextDMLEvent returns the next row changed event if exists
+func (d *decoder) NextDMLEvent() *commonEvent.DMLEvent {
+	ctx := context.Background()
+	key, err := d.decodeMessage(ctx, d.key)
+	if err != nil {
+		log.Panic("decode key failed", zap.Error(err))
+	}
+
+	// for the delete event, only have key part, it holds the primary key columns.
+	// for the insert / update, extract the value part, it holds all columns.
+	isDelete := len(d.value) == 0
+	value := key
+	if !isDelete {
+		value, err = d.decodeMessage(ctx, d.value)
+		if err != nil {
+			log.Panic("decode value failed", zap.Error(err))
+		}
+	}
+	d.key = nil
+	d.value = nil
+
+	event, err := assembleEvent(key, value, isDelete)
+	if err != nil {
+		log.Panic("assemble event failed", zap.Error(err))
+	}
+	return event
+}
+
+// decodeMessage extracts the schema ID from the header, and unmarshal the data by the schema.
+func (d *decoder) decodeMessage(ctx context.Context, data []byte) (*dynamicpb.Message, error) {
+	// magic byte + schema ID + message indexes
+	if len(data) < 6 || data[0] != magicByte {
+		return nil, errors.ErrProtobufInvalidMessage.GenWithStackByArgs("invalid header")
+	}
+	schemaID := int(binary.BigEndian.Uint32(data[1:5]))
+	// only the first message is defined in the schema, so the message indexes is always `[0]`.
+	if data[5] != 0 {
+		return nil, errors.ErrProtobufInvalidMessage.GenWithStackByArgs("unexpected message indexes")
+	}
+
+	desc, ok := d.schemas[schemaID]
+	if !ok {
+		schema, err := d.client.LookupSchema(ctx, schemaID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		desc, err = compileSchema(ctx, schema)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		d.schemas[schemaID] = desc
+	}
+
+	message := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(data[6:], message); err != nil {
+		return nil, errors.WrapError(errors.ErrProtobufInvalidMessage, err, "unmarshal failed")
+	}
+	return message, nil
+}
+
+// assembleEvent return a row changed event, the key holds the primary key columns,
+// the value holds all columns.
+func assembleEvent(key, value *dynamicpb.Message, isDelete bool) (*commonEvent.DMLEvent, error) {
+	keyDesc := key.Descriptor()
+	desc := value.Descriptor()
+	fields := desc.Fields()
+
+	columns := make([]*timodel.ColumnInfo, 0, fields.Len())
+	data := make(map[string]any, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		mysqlType, ok := getTiDBType(field)
+		// the TiDB extension fields are not real columns.
+		if !ok {
+			continue
+		}
+		name := string(field.Name())
+		isPrimaryKey := keyDesc.Fields().ByName(field.Name()) != nil
+		col := newTiColumn(int64(len(columns)), name, mysqlType, isPrimaryKey)
+		columns = append(columns, col)
+
+		if !value.Has(field) {
+			data[name] = nil
+			continue
+		}
+		v, err := protoValueToColumnValue(value.Get(field), col)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data[name] = v
+	}
+
+	var commitTs int64
+	if field := fields.ByName(tidbCommitTs); field != nil {
+		commitTs = value.Get(field).Int()
+	}
+
+	// "keyspace.schema"
+	schemaName := string(desc.ParentFile().Package())
+	if idx := strings.LastIndex(schemaName, "."); idx != -1 {
+		schemaName = schemaName[idx+1:]
+	}
+	tableName := string(desc.Name())
+
+	tableInfo := newTableInfo(schemaName, tableName, columns)
+	event := new(commonEvent.DMLEvent)
+	event.TableInfo = tableInfo
+	event.StartTs = uint64(commitTs)
+	event.CommitTs = uint64(commitTs)
+	event.PhysicalTableID = tableInfo.TableName.TableID
+	event.Rows = chunk.NewChunkFromPoolWithCapacity(tableInfo.GetFieldSlice(), chunk.InitialCapacity)
+	event.AddPostFlushFunc(func() {
+		event.Rows.Destroy(chunk.InitialCapacity, tableInfo.GetFieldSlice())
+	})
+	event.Length++
+	common.AppendRow2Chunk(data, tableInfo.GetColumns(), event.Rows)
+
+	rowType := commonType.RowTypeInsert
+	if isDelete {
+		rowType = commonType.RowTypeDelete
+	}
+	event.RowTypes = append(event.RowTypes, rowType)
+	return event, nil
+}
+
+func newTableInfo(schemaName, tableName string, columns []*timodel.ColumnInfo) *commonType.TableInfo {
+	tidbTableInfo := new(timodel.TableInfo)
+	tidbTableInfo.ID = tableIDAllocator.Allocate(schemaName, tableName)
+	tableIDAllocator.AddBlockTableID(schemaName, tableName, tidbTableInfo.ID)
+	tidbTableInfo.Name = ast.NewCIStr(tableName)
+	tidbTableInfo.Columns = columns
+
+	indexColumns := make([]*timodel.IndexColumn, 0)
+	for idx, col := range columns {
+		if mysql.HasPriKeyFlag(col.GetFlag()) {
+			indexColumns = append(indexColumns, &timodel.IndexColumn{
+				Name:   col.Name,
+				Offset: idx,
+			})
+		}
+	}
+	if len(indexColumns) != 0 {
+		tidbTableInfo.Indices = []*timodel.IndexInfo{{
+			ID:      1,
+			Name:    ast.NewCIStr("primary"),
+			Columns: indexColumns,
+			Primary: true,
+			Unique:  true,
+			State:   timodel.StatePublic,
+		}}
+		tidbTableInfo.PKIsHandle = true
+	}
+	return commonType.NewTableInfo4Decoder(schemaName, tidbTableInfo)
+}
+
+// newTiColumn restores the column by the type carried in the schema, such as `int(11) unsigned`.
+func newTiColumn(id int64, name string, mysqlType string, isPrimaryKey bool) *timodel.ColumnInfo {
+	col := new(timodel.ColumnInfo)
+	col.ID = id
+	col.Name = ast.NewCIStr(name)
+	col.State = timodel.StatePublic
+	basicType := common.ExtractBasicMySQLType(mysqlType)
+	col.FieldType = *types.NewFieldType(basicType)
+	if common.IsBinaryMySQLType(mysqlType) {
+		col.AddFlag(mysql.BinaryFlag)
+		col.SetCharset("binary")
+		col.SetCollate("binary")
+	} else if types.IsString(basicType) {
+		col.SetCharset("utf8mb4")
+		col.SetCollate("utf8mb4_bin")
+	}
+	if isPrimaryKey {
+		col.AddFlag(mysql.PriKeyFlag)
+		col.AddFlag(mysql.UniqueKeyFlag)
+		col.AddFlag(mysql.NotNullFlag)
+	}
+	if common.IsUnsignedMySQLType(mysqlType) {
+		col.AddFlag(mysql.UnsignedFlag)
+	}
+	flen, decimal := common.ExtractFlenDecimal(mysqlType, col.GetType())
+	col.FieldType.SetFlen(flen)
+	col.FieldType.SetDecimal(decimal)
+	switch basicType {
+	case mysql.TypeEnum, mysql.TypeSet:
+		col.SetElems(common.ExtractElements(mysqlType))
+	case mysql.TypeDuration:
+		col.FieldType.SetDecimal(common.ExtractDecimal(mysqlType))
+	default:
+	}
+	return col
+}
+
+// protoValueToColumnValue converts the protobuf value to the value accepted by the chunk.
+func protoValueToColumnValue(value protoreflect.Value, col *timodel.ColumnInfo) (any, error) {
+	switch col.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(col.GetFlag()) {
+			return value.Uint(), nil
+		}
+		return value.Int(), nil
+	case mysql.TypeYear:
+		return value.Int(), nil
+	case mysql.TypeFloat:
+		return float32(value.Float()), nil
+	case mysql.TypeDouble:
+		return value.Float(), nil
+	case mysql.TypeBit:
+		return types.BinaryLiteral(value.Bytes()), nil
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if mysql.HasBinaryFlag(col.GetFlag()) {
+			return value.Bytes(), nil
+		}
+		return []byte(value.String()), nil
+	case mysql.TypeNewDecimal:
+		result := new(types.MyDecimal)
+		if err := result.FromString([]byte(value.String())); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return result, nil
+	case mysql.TypeEnum:
+		enum, err := types.ParseEnum(col.GetElems(), value.String(), "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return enum, nil
+	case mysql.TypeSet:
+		set, err := types.ParseSet(col.GetElems(), value.String(), "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return set, nil
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		t, err := types.ParseTime(types.DefaultStmtNoWarningContext, value.String(), col.GetType(), col.GetDecimal())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return t, nil
+	case mysql.TypeDuration:
+		duration, _, err := types.ParseDuration(types.DefaultStmtNoWarningContext, value.String(), col.GetDecimal())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return duration, nil
+	case mysql.TypeJSON:
+		result, err := types.ParseBinaryJSONFromString(value.String())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return result, nil
+	case mysql.TypeTiDBVectorFloat32:
+		result, err := types.ParseVectorFloat32(value.String())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return result, nil
+	default:
+		return nil, errors.ErrProtobufInvalidMessage.GenWithStackByArgs("unknown mysql type")
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/avro"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type schemaCacheEntry struct {
+	tableVersion uint64
+	message      protoreflect.MessageDescriptor
+	header       []byte
+}
+
+// BatchEncoder converts the row changed events to the protobuf messages,
+// the schema of each table is registered to the confluent schema registry.
+type BatchEncoder struct {
+	keyspace string
+	client   *avro.ConfluentRegistryClient
+	// cache is keyed by the schema subject.
+	cache  map[string]*schemaCacheEntry
+	result []*common.Message
+
+	config *common.Config
+}
+
+// NewBatchEncoder return a protobuf encoder.
+func NewBatchEncoder(ctx context.Context, config *common.Config) (common.EventEncoder, error) {
+	client, err := avro.NewConfluentRegistryClient(ctx, config.AvroConfluentSchemaRegistry, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newBatchEncoder(config, client), nil
+}
+
+func newBatchEncoder(config *common.Config, client *avro.ConfluentRegistryClient) *BatchEncoder {
+	return &BatchEncoder{
+		keyspace: config.ChangefeedID.Keyspace(),
+		client:   client,
+		cache:    make(map[string]*schemaCacheEntry),
+		result:   make([]*common.Message, 0, 1),
+		config:   config,
+	}
+}
+
+// AppendRowChangedEvent appends a row change event to the encoder
+// NOTE: the encoder can only store one RowChangedEvent!
+func (e *BatchEncoder) AppendRowChangedEvent(
+	ctx context.Context,
+	topic string,
+	event *commonEvent.RowEvent,
+) error {
+	topic = sanitizeTopic(topic)
+
+	key, err := e.encodeKey(ctx, topic, event)
+	if err != nil {
+		log.Error("protobuf encoding key failed", zap.Error(err), zap.Any("event", event))
+		return errors.Trace(err)
+	}
+
+	value, err := e.encodeValue(ctx, topic, event)
+	if err != nil {
+		log.Error("protobuf encoding value failed", zap.Error(err), zap.Any("event", event))
+		return errors.Trace(err)
+	}
+
+	message := common.NewMsg(key, value)
+	message.Callback = event.Callback
+	message.IncRowsCount()
+
+	if message.Length() > e.config.MaxMessageBytes {
+		log.Warn("Single message is too large for protobuf",
+			zap.Int("maxMessageBytes", e.config.MaxMessageBytes),
+			zap.Int("length", message.Length()),
+			zap.Any("table", event.TableInfo.TableName))
+		return errors.ErrMessageTooLarge.GenWithStackByArgs(message.Length())
+	}
+
+	e.result = append(e.result, message)
+	return nil
+}
+
+// EncodeCheckpointEvent only encode checkpoint event if the watermark event is enabled
+// it's only used for the testing purpose.
+func (e *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	if !e.config.EnableTiDBExtension || !e.config.AvroEnableWatermark {
+		return nil, nil
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteByte(checkpointByte)
+	_ = binary.Write(buf, binary.BigEndian, ts)
+	return common.NewMsg(nil, buf.Bytes()), nil
+}
+
+type ddlEvent struct {
+	Query    string             `json:"query"`
+	Type     timodel.ActionType `json:"type"`
+	Schema   string             `json:"schema"`
+	Table    string             `json:"table"`
+	CommitTs uint64             `json:"commitTs"`
+}
+
+// EncodeDDLEvent only encode DDL event if the watermark event is enabled
+// it's only used for the testing purpose.
+func (e *BatchEncoder) EncodeDDLEvent(event *commonEvent.DDLEvent) (*common.Message, error) {
+	if !e.config.EnableTiDBExtension || !e.config.AvroEnableWatermark {
+		return nil, nil
+	}
+	data, err := json.Marshal(&ddlEvent{
+		Query:    event.Query,
+		Type:     event.GetDDLType(),
+		Schema:   event.GetSchemaName(),
+		Table:    event.GetTableName(),
+		CommitTs: event.GetCommitTs(),
+	})
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrProtobufEncodeFailed, err)
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteByte(ddlByte)
+	buf.Write(data)
+	return common.NewMsg(nil, buf.Bytes()), nil
+}
+
+// Build Messages
+func (e *BatchEncoder) Build() []*common.Message {
+	result := e.result
+	e.result = nil
+	return result
+}
+
+// Clean implements the EventEncoder interface
+func (e *BatchEncoder) Clean() {}
+
+func (e *BatchEncoder) encodeKey(ctx context.Context, topic string, event *commonEvent.RowEvent) ([]byte, error) {
+	index, colInfos := event.PrimaryKeyColumn()
+	// result may be nil if the event has no handle key columns, this may happen in the force replicate mode.
+	if len(index) == 0 {
+		return nil, nil
+	}
+	subject := topic + keySchemaSuffix
+	entry, err := e.getCachedOrRegister(ctx, subject, &event.TableInfo.TableName,
+		event.TableInfo.GetUpdateTS(), colInfos, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// the delete event only has the pre row.
+	row := event.GetRows()
+	if event.IsDelete() {
+		row = event.GetPreRows()
+	}
+	message, err := e.columns2Message(entry.message, row, index, colInfos)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return e.toEnvelope(entry.header, message)
+}
+
+func (e *BatchEncoder) encodeValue(ctx context.Context, topic string, event *commonEvent.RowEvent) ([]byte, error) {
+	// the delete event is sent as a tombstone message.
+	if event.IsDelete() {
+		return nil, nil
+	}
+	columns := event.TableInfo.GetColumns()
+	index := make([]int, 0, len(columns))
+	colInfos := make([]*timodel.ColumnInfo, 0, len(columns))
+	for i, col := range columns {
+		if col == nil || !event.ColumnSelector.Select(col) {
+			continue
+		}
+		index = append(index, i)
+		colInfos = append(colInfos, col)
+	}
+	subject := topic + valueSchemaSuffix
+	entry, err := e.getCachedOrRegister(ctx, subject, &event.TableInfo.TableName,
+		event.TableInfo.GetUpdateTS(), colInfos, e.config.EnableTiDBExtension)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	message, err := e.columns2Message(entry.message, event.GetRows(), index, colInfos)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if e.config.EnableTiDBExtension {
+		fields := entry.message.Fields()
+		message.Set(fields.ByName(tidbOp), protoreflect.ValueOfString(getOperation(event)))
+		message.Set(fields.ByName(tidbCommitTs), protoreflect.ValueOfInt64(int64(event.CommitTs)))
+		message.Set(fields.ByName(tidbPhysicalTime),
+			protoreflect.ValueOfInt64(oracle.ExtractPhysical(event.CommitTs)))
+	}
+	return e.toEnvelope(entry.header, message)
+}
+
+// getCachedOrRegister returns the cached schema of the subject if the table is not changed,
+// otherwise generates, registers and caches a new one.
+// Re-registering an existing schema shall return the same id, so the cache can be rebuilt safely.
+func (e *BatchEncoder) getCachedOrRegister(
+	ctx context.Context,
+	subject string,
+	tableName *commonType.TableName,
+	tableVersion uint64,
+	columns []*timodel.ColumnInfo,
+	enableTiDBExtension bool,
+) (*schemaCacheEntry, error) {
+	if entry, ok := e.cache[subject]; ok && entry.tableVersion == tableVersion {
+		return entry, nil
+	}
+
+	schema, err := columns2ProtoSchema(e.keyspace, tableName, columns, enableTiDBExtension)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	message, err := compileSchema(ctx, schema)
+	if err != nil {
+		log.Error("protobuf: compiling the generated schema failed",
+			zap.String("subject", subject), zap.String("schema", schema), zap.Error(err))
+		return nil, errors.Trace(err)
+	}
+	id, err := e.client.RegisterSchema(ctx, subject, schemaType, schema)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	entry := &schemaCacheEntry{
+		tableVersion: tableVersion,
+		message:      message,
+		header:       getMsgHeader(id),
+	}
+	e.cache[subject] = entry
+	log.Info("protobuf schema registered",
+		zap.String("subject", subject),
+		zap.Uint64("tableVersion", tableVersion),
+		zap.Int("schemaID", id))
+	return entry, nil
+}
+
+func (e *BatchEncoder) columns2Message(
+	desc protoreflect.MessageDescriptor,
+	row *chunk.Row,
+	index []int,
+	colInfos []*timodel.ColumnInfo,
+) (*dynamicpb.Message, error) {
+	message := dynamicpb.NewMessage(desc)
+	fields := desc.Fields()
+	for i, col := range colInfos {
+		if row.IsNull(index[i]) {
+			continue
+		}
+		field := fields.ByName(protoreflect.Name(common.SanitizeName(col.Name.O)))
+		if field == nil {
+			return nil, errors.ErrProtobufEncodeFailed.GenWithStack("field not found for column %s", col.Name.O)
+		}
+		value, err := columnToProtoValue(row, index[i], col)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		message.Set(field, value)
+	}
+	return message, nil
+}
+
+func columnToProtoValue(row *chunk.Row, idx int, col *timodel.ColumnInfo) (protoreflect.Value, error) {
+	d := row.GetDatum(idx, &col.FieldType)
+	unsigned := mysql.HasUnsignedFlag(col.GetFlag())
+	switch col.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong:
+		if unsigned {
+			return protoreflect.ValueOfUint32(uint32(d.GetUint64())), nil
+		}
+		return protoreflect.ValueOfInt32(int32(d.GetInt64())), nil
+	case mysql.TypeLonglong:
+		if unsigned {
+			return protoreflect.ValueOfUint64(d.GetUint64()), nil
+		}
+		return protoreflect.ValueOfInt64(d.GetInt64()), nil
+	case mysql.TypeYear:
+		return protoreflect.ValueOfInt32(int32(d.GetInt64())), nil
+	case mysql.TypeFloat:
+		return protoreflect.ValueOfFloat32(d.GetFloat32()), nil
+	case mysql.TypeDouble:
+		return protoreflect.ValueOfFloat64(d.GetFloat64()), nil
+	case mysql.TypeBit:
+		return protoreflect.ValueOfBytes(d.GetMysqlBit()), nil
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if mysql.HasBinaryFlag(col.GetFlag()) {
+			return protoreflect.ValueOfBytes(d.GetBytes()), nil
+		}
+		return protoreflect.ValueOfString(d.GetString()), nil
+	case mysql.TypeNewDecimal:
+		return protoreflect.ValueOfString(d.GetMysqlDecimal().String()), nil
+	case mysql.TypeEnum:
+		elements := col.GetElems()
+		number := d.GetMysqlEnum().Value
+		enumVar, err := types.ParseEnumValue(elements, number)
+		if err != nil {
+			log.Info("protobuf encoder parse enum value failed",
+				zap.Strings("elements", elements), zap.Uint64("number", number))
+			return protoreflect.Value{}, errors.WrapError(errors.ErrProtobufEncodeFailed, err)
+		}
+		return protoreflect.ValueOfString(enumVar.Name), nil
+	case mysql.TypeSet:
+		elements := col.GetElems()
+		number := d.GetMysqlSet().Value
+		setVar, err := types.ParseSetValue(elements, number)
+		if err != nil {
+			log.Info("protobuf encoder parse set value failed",
+				zap.Strings("elements", elements), zap.Uint64("number", number))
+			return protoreflect.Value{}, errors.WrapError(errors.ErrProtobufEncodeFailed, err)
+		}
+		return protoreflect.ValueOfString(setVar.Name), nil
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration,
+		mysql.TypeJSON, mysql.TypeTiDBVectorFloat32:
+		return protoreflect.ValueOfString(fmt.Sprintf("%v", d.GetValue())), nil
+	default:
+		log.Error("unknown mysql type", zap.Any("value", d.GetValue()), zap.Any("mysqlType", col.GetType()))
+		return protoreflect.Value{}, errors.ErrProtobufEncodeFailed.GenWithStack("unknown mysql type")
+	}
+}
+
+func (e *BatchEncoder) toEnvelope(header []byte, message *dynamicpb.Message) ([]byte, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrProtobufEncodeFailed, err)
+	}
+	result := make([]byte, 0, len(header)+len(data))
+	result = append(result, header...)
+	return append(result, data...), nil
+}
+
+// getMsgHeader returns the header of the confluent protobuf wire format,
+// which is the magic byte, the schema ID, and the message indexes.
+// The message indexes is `[0]` since only the first message is defined, it's encoded as a single 0.
+func getMsgHeader(schemaID int) []byte {
+	header := make([]byte, 6)
+	header[0] = magicByte
+	binary.BigEndian.PutUint32(header[1:5], uint32(schemaID))
+	header[5] = 0
+	return header
+}
+
+func getOperation(event *commonEvent.RowEvent) string {
+	if event.IsInsert() {
+		return insertOperation
+	} else if event.IsUpdate() {
+		return updateOperation
+	}
+	return ""
+}
+
+// sanitizeTopic escapes ".", it may have special meanings for sink connectors
+func sanitizeTopic(name string) string {
+	return strings.ReplaceAll(name, ".", "_")
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/avro"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+const registryURL = "http://127.0.0.1:8081"
+
+func newCodecConfig() *common.Config {
+	codecConfig := common.NewConfig(config.ProtocolProtobuf)
+	codecConfig.AvroConfluentSchemaRegistry = registryURL
+	codecConfig.EnableTiDBExtension = true
+	codecConfig.AvroEnableWatermark = true
+	codecConfig.ChangefeedID = commonType.NewChangefeedID4Test(commonType.DefaultKeyspaceNamme, "test")
+	return codecConfig
+}
+
+func TestProtobufRowEvents(t *testing.T) {
+	avro.StartConfluentRegistry4Testing()
+	defer avro.StopConfluentRegistry4Testing()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c decimal(10, 2), d datetime, e blob, f bit(10),
+		g json, h enum('a','b'), i set('a','b'), j bigint unsigned, k double, l time,
+		m tinyint unsigned, n float, o year, p date, q varbinary(16))`)
+	tableInfo := helper.GetTableInfo(job)
+
+	insert := helper.DML2Event("test", "t", `insert into test.t values
+		(1, 'hello', 12.34, '2025-01-02 03:04:05', x'0102ff', b'1010101', '{"k": [1, "v"]}', 'b', 'a,b',
+		18446744073709551615, 3.1415, '12:34:56', 255, 1.5, 2025, '2025-01-02', x'00ff')`)
+	insertRow, ok := insert.GetNextRow()
+	require.True(t, ok)
+
+	columnSelector := columnselector.NewDefaultColumnSelector()
+	insertEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		StartTs:        insert.StartTs,
+		CommitTs:       insert.GetCommitTs(),
+		Event:          insertRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	update := helper.DML2Event("test", "t", `update test.t set b = null, c = 56.78, i = '' where a = 1`)
+	updateRow, ok := update.GetNextRow()
+	require.True(t, ok)
+	updateRow.PreRow = insertRow.Row
+	updateEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		StartTs:        update.StartTs,
+		CommitTs:       update.GetCommitTs(),
+		Event:          updateRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	deleteRow := updateRow
+	deleteRow.PreRow = updateRow.Row
+	deleteRow.Row = chunk.Row{}
+	deleteEvent := &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		StartTs:        update.StartTs + 1,
+		CommitTs:       update.GetCommitTs() + 1,
+		Event:          deleteRow,
+		ColumnSelector: columnSelector,
+		Callback:       func() {},
+	}
+
+	ctx := context.Background()
+	codecConfig := newCodecConfig()
+	encoder, err := NewBatchEncoder(ctx, codecConfig)
+	require.NoError(t, err)
+	events := []*commonEvent.RowEvent{insertEvent, updateEvent, deleteEvent}
+	for _, event := range events {
+		err = encoder.AppendRowChangedEvent(ctx, "test.t", event)
+		require.NoError(t, err)
+	}
+	messages := encoder.Build()
+	require.Len(t, messages, len(events))
+
+	// the key and value are registered to different subjects, and the schema is reused by the update event.
+	keyID := binary.BigEndian.Uint32(messages[0].Key[1:5])
+	valueID := binary.BigEndian.Uint32(messages[0].Value[1:5])
+	require.NotEqual(t, keyID, valueID)
+	require.Equal(t, messages[0].Key[:6], messages[1].Key[:6])
+	require.Equal(t, messages[0].Value[:6], messages[1].Value[:6])
+	require.Equal(t, byte(0), messages[0].Value[5])
+	// the delete event is sent as a tombstone message.
+	require.Nil(t, messages[2].Value)
+
+	client, err := avro.NewConfluentRegistryClient(ctx, registryURL, nil)
+	require.NoError(t, err)
+	schema, err := client.LookupSchema(ctx, int(valueID))
+	require.NoError(t, err)
+	require.Contains(t, schema, "package default.test;")
+	require.Contains(t, schema, "message t {")
+	require.Contains(t, schema, "// tidb_type: bigint(20) unsigned\n  optional uint64 j = 10;")
+	require.Contains(t, schema, "int64 _tidb_commit_ts = 19;")
+
+	decoder, err := NewDecoder(ctx, codecConfig, 0)
+	require.NoError(t, err)
+	for idx, event := range events {
+		require.Equal(t, 1, messages[idx].GetRowsCount())
+		decoder.AddKeyValue(messages[idx].Key, messages[idx].Value)
+		messageType, hasNext := decoder.HasNext()
+		require.True(t, hasNext)
+		require.Equal(t, common.MessageTypeRow, messageType)
+
+		decoded := decoder.NextDMLEvent()
+		require.Equal(t, "test", decoded.TableInfo.GetSchemaName())
+		require.Equal(t, "t", decoded.TableInfo.GetTableName())
+		change, ok := decoded.GetNextRow()
+		require.True(t, ok)
+
+		if event.IsDelete() {
+			// only the primary key columns are sent for the delete event.
+			require.Equal(t, commonType.RowTypeDelete, change.RowType)
+			require.Len(t, decoded.TableInfo.GetColumns(), 1)
+			require.Equal(t, int64(1), change.PreRow.GetInt64(0))
+			continue
+		}
+		require.Equal(t, event.CommitTs, decoded.GetCommitTs())
+		require.Equal(t, commonType.RowTypeInsert, change.RowType)
+		common.CompareRow(t, commonEvent.RowChange{Row: *event.GetRows()},
+			event.TableInfo, change, decoded.TableInfo)
+
+		_, hasNext = decoder.HasNext()
+		require.False(t, hasNext)
+	}
+}
+
+func TestProtobufSchemaChange(t *testing.T) {
+	avro.StartConfluentRegistry4Testing()
+	defer avro.StopConfluentRegistry4Testing()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(a int primary key, b int)`)
+	tableInfo := helper.GetTableInfo(job)
+	insert := helper.DML2Event("test", "t", `insert into test.t values (1, 2)`)
+	row, ok := insert.GetNextRow()
+	require.True(t, ok)
+
+	ctx := context.Background()
+	encoder, err := NewBatchEncoder(ctx, newCodecConfig())
+	require.NoError(t, err)
+	err = encoder.AppendRowChangedEvent(ctx, "t", &commonEvent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       insert.GetCommitTs(),
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+
+	helper.DDL2Job(`alter table test.t add column c varchar(10) default 'x'`)
+	insert = helper.DML2Event("test", "t", `insert into test.t values (2, 3, 'y')`)
+	row, ok = insert.GetNextRow()
+	require.True(t, ok)
+	err = encoder.AppendRowChangedEvent(ctx, "t", &commonEvent.RowEvent{
+		TableInfo:      insert.TableInfo,
+		CommitTs:       insert.GetCommitTs(),
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+	})
+	require.NoError(t, err)
+
+	// a new schema version is registered after the table changed.
+	messages := encoder.Build()
+	require.Len(t, messages, 2)
+	require.NotEqual(t, messages[0].Value[:6], messages[1].Value[:6])
+	require.Equal(t, messages[0].Key[:6], messages[1].Key[:6])
+}
+
+func TestProtobufDDLAndCheckpoint(t *testing.T) {
+	avro.StartConfluentRegistry4Testing()
+	defer avro.StopConfluentRegistry4Testing()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	ctx := context.Background()
+	codecConfig := newCodecConfig()
+	codecConfig.AvroEnableWatermark = false
+	encoder, err := NewBatchEncoder(ctx, codecConfig)
+	require.NoError(t, err)
+
+	// the watermark is not sent by default.
+	message, err := encoder.EncodeCheckpointEvent(1024)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	codecConfig.AvroEnableWatermark = true
+	decoder, err := NewDecoder(ctx, codecConfig, 0)
+	require.NoError(t, err)
+
+	message, err = encoder.EncodeCheckpointEvent(1024)
+	require.NoError(t, err)
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext := decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeResolved, messageType)
+	require.Equal(t, uint64(1024), decoder.NextResolvedEvent())
+
+	createTable := helper.DDL2Event(`create table test.t(a int primary key, b int)`)
+	message, err = encoder.EncodeDDLEvent(createTable)
+	require.NoError(t, err)
+	decoder.AddKeyValue(message.Key, message.Value)
+	messageType, hasNext = decoder.HasNext()
+	require.True(t, hasNext)
+	require.Equal(t, common.MessageTypeDDL, messageType)
+
+	decoded := decoder.NextDDLEvent()
+	require.Equal(t, createTable.Query, decoded.Query)
+	require.Equal(t, createTable.GetCommitTs(), decoded.GetCommitTs())
+	require.Equal(t, "test", decoded.GetSchemaName())
+	require.Equal(t, "t", decoded.GetTableName())
+	require.Equal(t, byte(timodel.ActionCreateTable), decoded.Type)
+}
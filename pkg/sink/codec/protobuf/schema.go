@@ -0,0 +1,175 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protobuf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	keySchemaSuffix   = "-key"
+	valueSchemaSuffix = "-value"
+
+	// schemaType is the type registered to the confluent schema registry.
+	schemaType = "PROTOBUF"
+	// schemaFileName is the name of the generated proto file, it's only used for compiling.
+	schemaFileName = "table.proto"
+)
+
+const (
+	// tidbTypePrefix is the prefix of the comment attached to each column field,
+	// the comment holds the column type, which is used to restore the column by the decoder.
+	tidbTypePrefix = "tidb_type: "
+
+	tidbOp           = "_tidb_op"
+	tidbCommitTs     = "_tidb_commit_ts"
+	tidbPhysicalTime = "_tidb_commit_physical_time"
+)
+
+const (
+	insertOperation = "c"
+	updateOperation = "u"
+)
+
+const (
+	// the wire format of confluent protobuf message, the first byte is always 0
+	// https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+	magicByte = uint8(0)
+	// protobuf does not send ddl and checkpoint message, the following 2 field is used to distinguish
+	// TiCDC DDL event and checkpoint event, only used for testing purpose, not for production
+	ddlByte        = uint8(1)
+	checkpointByte = uint8(2)
+)
+
+// columnToProtoType returns the protobuf scalar type of the column.
+func columnToProtoType(col *timodel.ColumnInfo) (string, error) {
+	unsigned := mysql.HasUnsignedFlag(col.GetFlag())
+	switch col.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong:
+		if unsigned {
+			return "uint32", nil
+		}
+		return "int32", nil
+	case mysql.TypeLonglong:
+		if unsigned {
+			return "uint64", nil
+		}
+		return "int64", nil
+	case mysql.TypeYear:
+		return "int32", nil
+	case mysql.TypeFloat:
+		return "float", nil
+	case mysql.TypeDouble:
+		return "double", nil
+	case mysql.TypeBit:
+		return "bytes", nil
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if mysql.HasBinaryFlag(col.GetFlag()) {
+			return "bytes", nil
+		}
+		return "string", nil
+	case mysql.TypeNewDecimal, mysql.TypeEnum, mysql.TypeSet,
+		mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration,
+		mysql.TypeJSON, mysql.TypeTiDBVectorFloat32:
+		return "string", nil
+	default:
+		log.Error("unknown mysql type", zap.Any("mysqlType", col.GetType()))
+		return "", errors.ErrProtobufEncodeFailed.GenWithStack("unknown mysql type %d", col.GetType())
+	}
+}
+
+// getPackageName returns the proto package, which is `<keyspace>.<schema>`.
+func getPackageName(keyspace string, schema string) string {
+	ns := common.SanitizeName(keyspace)
+	s := common.SanitizeName(schema)
+	if s != "" {
+		return ns + "." + s
+	}
+	return ns
+}
+
+// columns2ProtoSchema generates the proto file which has only one message for the table,
+// each column is an optional field numbered by its order, so that the NULL value can be distinguished.
+func columns2ProtoSchema(
+	keyspace string,
+	tableName *commonType.TableName,
+	columns []*timodel.ColumnInfo,
+	enableTiDBExtension bool,
+) (string, error) {
+	var b strings.Builder
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", getPackageName(keyspace, tableName.Schema))
+	fmt.Fprintf(&b, "message %s {\n", common.SanitizeName(tableName.Table))
+
+	number := 1
+	for _, col := range columns {
+		tp, err := columnToProtoType(col)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		fmt.Fprintf(&b, "  // %s%s\n", tidbTypePrefix, common.GetMySQLType(col, true))
+		fmt.Fprintf(&b, "  optional %s %s = %d;\n", tp, common.SanitizeName(col.Name.O), number)
+		number++
+	}
+	if enableTiDBExtension {
+		fmt.Fprintf(&b, "  string %s = %d;\n", tidbOp, number)
+		fmt.Fprintf(&b, "  int64 %s = %d;\n", tidbCommitTs, number+1)
+		fmt.Fprintf(&b, "  int64 %s = %d;\n", tidbPhysicalTime, number+2)
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// compileSchema compiles the proto file, and returns the descriptor of the first message.
+func compileSchema(ctx context.Context, schema string) (protoreflect.MessageDescriptor, error) {
+	compiler := protocompile.Compiler{
+		Resolver: &protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{schemaFileName: schema}),
+		},
+		// the source info holds the comments, which carry the column type.
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	files, err := compiler.Compile(ctx, schemaFileName)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrProtobufInvalidMessage, err, "compile schema failed")
+	}
+	messages := files[0].Messages()
+	if messages.Len() == 0 {
+		return nil, errors.ErrProtobufInvalidMessage.GenWithStackByArgs("no message found in the schema")
+	}
+	return messages.Get(0), nil
+}
+
+// getTiDBType returns the column type carried by the leading comment of the field.
+func getTiDBType(field protoreflect.FieldDescriptor) (string, bool) {
+	comments := field.ParentFile().SourceLocations().ByDescriptor(field).LeadingComments
+	comments = strings.TrimSpace(comments)
+	if !strings.HasPrefix(comments, tidbTypePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(comments, tidbTypePrefix), true
+}
@@ -82,7 +82,7 @@ func (e *Encoder) AppendRowChangedEvent(ctx context.Context, _ string, event *co
 
 	var claimCheckLocation string
 	if e.config.LargeMessageHandle.EnableClaimCheck() {
-		fileName := claimcheck.NewFileName()
+		fileName := e.claimCheck.NewFileName()
 		claimCheckLocation = e.claimCheck.FileNameWithPrefix(fileName)
 		if err = e.claimCheck.WriteMessage(ctx, result.Key, result.Value, fileName); err != nil {
 			return errors.Trace(err)
@@ -0,0 +1,94 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simple
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+type txnDecoder struct {
+	data []byte
+	msg  *message
+
+	config *common.Config
+	// tableInfo is the table schema of the file, it's read from the schema file,
+	// since no bootstrap message is written to the storage.
+	tableInfo *commonType.TableInfo
+}
+
+// NewTxnDecoder return a new decoder for the files written by the simple txn encoder.
+func NewTxnDecoder(codecConfig *common.Config, tableInfo *commonType.TableInfo) common.Decoder {
+	return &txnDecoder{
+		config:    codecConfig,
+		tableInfo: tableInfo,
+	}
+}
+
+// AddKeyValue set the key value to the decoder
+func (d *txnDecoder) AddKeyValue(_, value []byte) {
+	d.data = value
+}
+
+// HasNext return true if there is any event can be returned.
+func (d *txnDecoder) HasNext() (common.MessageType, bool) {
+	var encodedData []byte
+	for len(encodedData) == 0 {
+		if len(d.data) == 0 {
+			return common.MessageTypeUnknown, false
+		}
+		idx := bytes.Index(d.data, []byte(d.config.Terminator))
+		if idx >= 0 {
+			encodedData = d.data[:idx]
+			d.data = d.data[idx+len(d.config.Terminator):]
+		} else {
+			encodedData = d.data
+			d.data = nil
+		}
+	}
+
+	msg := new(message)
+	if err := json.Unmarshal(encodedData, msg); err != nil {
+		log.Panic("simple txn decoder unmarshal data failed",
+			zap.Error(err), zap.ByteString("data", encodedData))
+	}
+	d.msg = msg
+	return common.MessageTypeRow, true
+}
+
+// NextDMLEvent implements the Decoder interface
+func (d *txnDecoder) NextDMLEvent() *commonEvent.DMLEvent {
+	if d.msg == nil || (d.msg.Data == nil && d.msg.Old == nil) {
+		log.Panic("invalid data for the DML event", zap.Any("message", d.msg))
+	}
+	result := buildDMLEvent(d.msg, d.tableInfo, d.config.EnableRowChecksum, nil)
+	d.msg = nil
+	return result
+}
+
+// NextResolvedEvent implements the Decoder interface
+func (d *txnDecoder) NextResolvedEvent() uint64 {
+	return 0
+}
+
+// NextDDLEvent implements the Decoder interface
+func (d *txnDecoder) NextDDLEvent() *commonEvent.DDLEvent {
+	return nil
+}
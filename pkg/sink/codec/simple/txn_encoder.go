@@ -0,0 +1,111 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simple
+
+import (
+	"bytes"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// TxnEventEncoder encodes the txn event into the simple protocol,
+// each row is a JSON message, and separated by the terminator.
+type TxnEventEncoder struct {
+	config     *common.Config
+	marshaller *jsonMarshaller
+
+	// the symbol separating two lines
+	terminator []byte
+	valueBuf   *bytes.Buffer
+	batchSize  int
+	callback   func()
+
+	columnSelector commonEvent.Selector
+}
+
+// NewTxnEventEncoder creates a new TxnEventEncoder,
+// only the json encoding format is supported, since the rows are separated by the terminator.
+func NewTxnEventEncoder(config *common.Config) (common.TxnEventEncoder, error) {
+	if config.EncodingFormat != common.EncodingFormatJSON {
+		return nil, errors.ErrCodecInvalidConfig.GenWithStack(
+			"unsupported encoding format type: %s for the simple protocol txn encoder", config.EncodingFormat)
+	}
+	return &TxnEventEncoder{
+		config:         config,
+		marshaller:     newJSONMarshaller(config),
+		terminator:     []byte(config.Terminator),
+		valueBuf:       &bytes.Buffer{},
+		columnSelector: columnselector.NewDefaultColumnSelector(),
+	}, nil
+}
+
+// AppendTxnEvent appends a txn event to the encoder.
+func (e *TxnEventEncoder) AppendTxnEvent(event *commonEvent.DMLEvent) error {
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			event.Rewind()
+			break
+		}
+		value, err := e.marshaller.MarshalRowChangedEvent(&commonEvent.RowEvent{
+			PhysicalTableID: event.PhysicalTableID,
+			TableInfo:       event.TableInfo,
+			CommitTs:        event.CommitTs,
+			Event:           row,
+			ColumnSelector:  e.columnSelector,
+			Checksum:        row.Checksum,
+		}, false, "")
+		if err != nil {
+			return err
+		}
+		length := len(value) + common.MaxRecordOverhead
+		// For single message that is longer than max-message-bytes, do not send it.
+		if length > e.config.MaxMessageBytes {
+			log.Warn("Single message is too large for simple",
+				zap.Int("maxMessageBytes", e.config.MaxMessageBytes),
+				zap.Int("length", length),
+				zap.Any("table", event.TableInfo.TableName))
+			return errors.ErrMessageTooLarge.GenWithStackByArgs()
+		}
+		e.valueBuf.Write(value)
+		e.valueBuf.Write(e.terminator)
+		e.batchSize++
+	}
+	e.callback = event.PostFlush
+	return nil
+}
+
+// Build builds a message from the encoder and resets the encoder.
+func (e *TxnEventEncoder) Build() []*common.Message {
+	if e.batchSize == 0 {
+		return nil
+	}
+
+	ret := common.NewMsg(nil, e.valueBuf.Bytes())
+	ret.SetRowsCount(e.batchSize)
+	ret.Callback = e.callback
+	if e.valueBuf.Cap() > common.MemBufShrinkThreshold {
+		e.valueBuf = &bytes.Buffer{}
+	} else {
+		e.valueBuf.Reset()
+	}
+	e.callback = nil
+	e.batchSize = 0
+	return []*common.Message{ret}
+}
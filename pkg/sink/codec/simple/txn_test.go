@@ -0,0 +1,87 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simple
+
+import (
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxnEventEncoder(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job(`create table test.t(
+		a int primary key, b varchar(32), c decimal(10, 2), d datetime(3), e blob, f bit(10),
+		g json, h enum('a','b'), i set('a','b'), j bigint unsigned, k time, l year)`)
+	tableInfo := helper.GetTableInfo(job)
+
+	event := helper.DML2Event("test", "t",
+		`insert into test.t values (1, 'hello', 12.34, '2025-01-02 03:04:05.678', x'0102ff', b'1010101',
+		'{"k": [1, "v"]}', 'b', 'a,b', 18446744073709551615, '-12:34:56', 2025)`,
+		`insert into test.t values (2, null, null, null, null, null, null, null, null, null, null, null)`,
+		`insert into test.t values (3, 'null', 0, '2025-01-02 03:04:05', x'', b'0', '[]', 'a', '', 0,
+		'00:00:00', 1970)`,
+		`insert into test.t values (4, 'world', 1.5, null, null, null, null, null, null, null, null, null)`)
+	// the second and the third rows make up an update event, the last row is a delete event.
+	event.RowTypes[1] = commonType.RowTypeUpdate
+	event.RowTypes[2] = commonType.RowTypeUpdate
+	event.RowTypes[3] = commonType.RowTypeDelete
+	var called int
+	event.AddPostFlushFunc(func() { called++ })
+
+	codecConfig := common.NewConfig(config.ProtocolSimple)
+	codecConfig.Terminator = config.CRLF
+	encoder, err := NewTxnEventEncoder(codecConfig)
+	require.NoError(t, err)
+	require.NoError(t, encoder.AppendTxnEvent(event))
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 3, messages[0].GetRowsCount())
+	messages[0].Callback()
+	require.Equal(t, 1, called)
+	require.Nil(t, encoder.Build())
+
+	decoder := NewTxnDecoder(codecConfig, tableInfo)
+	decoder.AddKeyValue(nil, messages[0].Value)
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		messageType, hasNext := decoder.HasNext()
+		require.True(t, hasNext)
+		require.Equal(t, common.MessageTypeRow, messageType)
+
+		decoded := decoder.NextDMLEvent()
+		require.Equal(t, event.CommitTs, decoded.GetCommitTs())
+		change, ok := decoded.GetNextRow()
+		require.True(t, ok)
+		require.Equal(t, row.RowType, change.RowType)
+		common.CompareRow(t, row, tableInfo, change, decoded.TableInfo)
+	}
+	_, hasNext := decoder.HasNext()
+	require.False(t, hasNext)
+
+	codecConfig.EncodingFormat = common.EncodingFormatAvro
+	_, err = NewTxnEventEncoder(codecConfig)
+	require.True(t, errors.ErrCodecInvalidConfig.Equal(err))
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+const (
+	// ActionIndex creates or replaces the document with the given id.
+	ActionIndex = "index"
+	// ActionDelete deletes the document with the given id.
+	ActionDelete = "delete"
+
+	// maxReportedFailures is the max number of failed items carried by the error.
+	maxReportedFailures = 3
+)
+
+// BulkItem is one operation of the bulk request.
+type BulkItem struct {
+	Action string
+	Index  string
+	ID     string
+	// Document is the JSON encoded document, it's empty for the delete action.
+	Document []byte
+}
+
+// Client sends requests to the elasticsearch cluster through the REST API.
+type Client struct {
+	cfg        *Config
+	httpClient *http.Client
+}
+
+// NewClient creates a client by the given config.
+func NewClient(cfg *Config) *Client {
+	return &Client{
+		cfg: cfg,
+		httpClient: &http.Client{
+			Timeout: cfg.RequestTimeout,
+		},
+	}
+}
+
+// Ping checks whether the elasticsearch cluster is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodGet, "/", "", nil)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+type bulkResponse struct {
+	Errors bool                                `json:"errors"`
+	Items  []map[string]bulkResponseItemResult `json:"items"`
+}
+
+type bulkResponseItemResult struct {
+	Index  string `json:"_index"`
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error,omitempty"`
+}
+
+// Bulk sends the items by one bulk request, the items are applied in order for the same document.
+// ErrElasticsearchRequestFailed is returned if the whole request failed or
+// some items are rejected temporarily, it's safe to retry since all the actions are idempotent.
+// ErrElasticsearchBulkFailed is returned if some items failed permanently,
+// and ErrElasticsearchRequestRejected is returned if the request is invalid.
+func (c *Client) Bulk(ctx context.Context, items []*BulkItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	body, err := encodeBulkBody(items)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result bulkResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return cerror.WrapError(cerror.ErrElasticsearchRequestFailed, err)
+	}
+	if !result.Errors {
+		return nil
+	}
+
+	var (
+		failures  []string
+		retryable = true
+	)
+	for _, item := range result.Items {
+		for action, r := range item {
+			if r.Error == nil {
+				continue
+			}
+			// too many requests, the item can be retried later.
+			if r.Status != http.StatusTooManyRequests {
+				retryable = false
+			}
+			if len(failures) < maxReportedFailures {
+				failures = append(failures, fmt.Sprintf("%s %s/%s: %d %s %s",
+					action, r.Index, r.ID, r.Status, r.Error.Type, r.Error.Reason))
+			}
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	if retryable {
+		return cerror.ErrElasticsearchRequestFailed.GenWithStack(
+			"bulk request is rejected: %s", strings.Join(failures, "; "))
+	}
+	return cerror.ErrElasticsearchBulkFailed.GenWithStackByArgs(strings.Join(failures, "; "))
+}
+
+// encodeBulkBody encodes the items to the newline delimited JSON.
+func encodeBulkBody(items []*BulkItem) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, item := range items {
+		meta := map[string]map[string]string{
+			item.Action: {"_index": item.Index, "_id": item.ID},
+		}
+		line, err := json.Marshal(meta)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		if item.Action == ActionDelete {
+			continue
+		}
+		buf.Write(item.Document)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Client) do(
+	ctx context.Context, method, path, contentType string, body []byte,
+) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.Endpoint+path, reader)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrElasticsearchRequestFailed, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrElasticsearchRequestFailed, err)
+	}
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("%s %s returns status %d: %s", method, path, resp.StatusCode, message)
+	// the client errors except too many requests can not be fixed by retrying.
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusTooManyRequests {
+		return nil, cerror.WrapError(cerror.ErrElasticsearchRequestRejected, err, err.Error())
+	}
+	return nil, cerror.WrapError(cerror.ErrElasticsearchRequestFailed, err)
+}
+
+// IsRetryableError returns true if the request can be retried.
+func IsRetryableError(err error) bool {
+	if !cerror.IsRetryableError(err) {
+		return false
+	}
+	return !cerror.ErrElasticsearchBulkFailed.Equal(err) &&
+		!cerror.ErrElasticsearchRequestRejected.Equal(err)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/util"
@@ -39,6 +40,8 @@ const (
 type ClaimCheck struct {
 	storage  storage.ExternalStorage
 	rawValue bool
+	// compression is the compression of the raw value, it decides the file extension.
+	compression string
 
 	changefeedID commonType.ChangeFeedID
 	// metricSendMessageDuration tracks the time duration
@@ -80,6 +83,7 @@ func New(ctx context.Context, config *config.LargeMessageHandleConfig, changefee
 		changefeedID:              changefeedID,
 		storage:                   externalStorage,
 		rawValue:                  config.ClaimCheckRawValue,
+		compression:               config.LargeMessageHandleCompression,
 		metricSendMessageDuration: claimCheckSendMessageDuration.WithLabelValues(changefeedID.Keyspace(), changefeedID.Name()),
 		metricSendMessageCount:    claimCheckSendMessageCount.WithLabelValues(changefeedID.Keyspace(), changefeedID.Name()),
 	}, nil
@@ -122,6 +126,12 @@ func (c *ClaimCheck) CleanMetrics() {
 // UUID V4 is used to generate random and unique file names.
 // This should not exceed the S3 object name length limit.
 // ref https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-keys.html
-func NewFileName() string {
-	return uuid.NewString() + ".json"
+// If the raw value is compressed, the extension of the compression is appended,
+// so that the consumer can recognize the file content.
+func (c *ClaimCheck) NewFileName() string {
+	fileName := uuid.NewString() + ".json"
+	if c.rawValue {
+		fileName += compression.FileExtension(c.compression)
+	}
+	return fileName
 }
@@ -0,0 +1,82 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claimcheck
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/compression"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/util/s3mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimCheckDisabled(t *testing.T) {
+	claimCheck, err := New(context.Background(), config.NewDefaultLargeMessageHandleConfig(),
+		commonType.NewChangefeedID4Test("test", "test"))
+	require.NoError(t, err)
+	require.Nil(t, claimCheck)
+}
+
+func TestWriteMessageToS3(t *testing.T) {
+	ctx := context.Background()
+	s3Server := s3mock.NewServer(t, "bucket")
+
+	handleConfig := config.NewDefaultLargeMessageHandleConfig()
+	handleConfig.LargeMessageHandleOption = config.LargeMessageHandleOptionClaimCheck
+	handleConfig.ClaimCheckStorageURI = s3Server.URI("bucket", "claim-check")
+
+	// the faults are recovered by the retryer of the external storage.
+	s3Server.InjectInternalError(s3mock.OpHeadObject, 1)
+	claimCheck, err := New(ctx, handleConfig, commonType.NewChangefeedID4Test("test", "test"))
+	require.NoError(t, err)
+	defer claimCheck.CleanMetrics()
+
+	fileName := claimCheck.NewFileName()
+	require.True(t, strings.HasSuffix(fileName, ".json"))
+	require.Contains(t, claimCheck.FileNameWithPrefix(fileName), "s3://bucket/claim-check/"+fileName)
+
+	s3Server.InjectThrottling(s3mock.OpPutObject, 1)
+	require.NoError(t, claimCheck.WriteMessage(ctx, []byte("key"), []byte("value"), fileName))
+	require.Equal(t, 2, s3Server.RequestCount(s3mock.OpPutObject))
+
+	data, ok := s3Server.Object("bucket", "claim-check/"+fileName)
+	require.True(t, ok)
+	var message common.ClaimCheckMessage
+	require.NoError(t, json.Unmarshal(data, &message))
+	require.Equal(t, []byte("key"), message.Key)
+	require.Equal(t, []byte("value"), message.Value)
+
+	// the raw value is written as is.
+	claimCheck.rawValue = true
+	fileName = claimCheck.NewFileName()
+	require.NoError(t, claimCheck.WriteMessage(ctx, []byte("key"), []byte("value"), fileName))
+	data, ok = s3Server.Object("bucket", "claim-check/"+fileName)
+	require.True(t, ok)
+	require.Equal(t, "value", string(data))
+
+	// the compressed raw value has the extension of the compression.
+	claimCheck.compression = compression.Zstd
+	require.True(t, strings.HasSuffix(claimCheck.NewFileName(), ".json.zst"))
+
+	// the storage can not be created if the bucket does not exist.
+	handleConfig.ClaimCheckStorageURI = s3Server.URI("missing", "claim-check")
+	_, err = New(ctx, handleConfig, commonType.NewChangefeedID4Test("test", "test"))
+	require.Error(t, err)
+}
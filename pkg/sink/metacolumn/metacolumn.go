@@ -0,0 +1,300 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metacolumn
+
+import (
+	"sync"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+const (
+	opColumnLength               = 16
+	sourceChangefeedColumnLength = 255
+)
+
+type metaColumnKind int
+
+const (
+	kindCommitTs metaColumnKind = iota
+	kindOp
+	kindSourceChangefeed
+)
+
+type metaColumn struct {
+	kind metaColumnKind
+	name string
+}
+
+type cachedTableInfo struct {
+	source *common.TableInfo
+	target *common.TableInfo
+}
+
+// Appender appends the metadata columns to the row changes, and turns the updates
+// and deletes into inserts in the append-only mode. The rows are located by all the
+// columns if the table has no primary key or not null unique key, so the metadata
+// columns are only appended to these tables in the append-only mode.
+//
+// A nil Appender keeps the row changes unchanged.
+type Appender struct {
+	columns    []metaColumn
+	appendOnly bool
+	changefeed string
+
+	mu sync.Mutex
+	// tableInfos caches the table info with the metadata columns of each table.
+	tableInfos map[int64]cachedTableInfo
+}
+
+// New creates an Appender by the config, it returns nil if no metadata column is configured.
+func New(cfg *config.MetadataColumnsConfig, changefeedID common.ChangeFeedID) (*Appender, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	var columns []metaColumn
+	for _, c := range []struct {
+		kind metaColumnKind
+		name *string
+	}{
+		{kind: kindCommitTs, name: cfg.CommitTs},
+		{kind: kindOp, name: cfg.Op},
+		{kind: kindSourceChangefeed, name: cfg.SourceChangefeed},
+	} {
+		if name := util.GetOrZero(c.name); name != "" {
+			columns = append(columns, metaColumn{kind: c.kind, name: name})
+		}
+	}
+	appendOnly := util.GetOrZero(cfg.AppendOnly)
+	if len(columns) == 0 && !appendOnly {
+		return nil, nil
+	}
+	return &Appender{
+		columns:    columns,
+		appendOnly: appendOnly,
+		changefeed: changefeedID.String(),
+		tableInfos: make(map[int64]cachedTableInfo),
+	}, nil
+}
+
+// AppendOnly returns whether the updates and deletes are turned into inserts.
+func (a *Appender) AppendOnly() bool {
+	return a != nil && a.appendOnly
+}
+
+// Enabled returns whether the metadata columns are appended to the table.
+func (a *Appender) Enabled(tableInfo *common.TableInfo) bool {
+	return a != nil && tableInfo != nil && (a.appendOnly || tableInfo.HasPKOrNotNullUK)
+}
+
+// CommitTsColumn returns the name of the commit-ts metadata column, it's empty if not configured.
+func (a *Appender) CommitTsColumn() string {
+	if a == nil {
+		return ""
+	}
+	for _, col := range a.columns {
+		if col.kind == kindCommitTs {
+			return col.name
+		}
+	}
+	return ""
+}
+
+// ColumnNames returns the names of the metadata columns.
+func (a *Appender) ColumnNames() []string {
+	if a == nil {
+		return nil
+	}
+	names := make([]string, 0, len(a.columns))
+	for _, col := range a.columns {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+// ColumnInfos returns the column infos of the metadata columns, their IDs and offsets are not set.
+func (a *Appender) ColumnInfos() []*model.ColumnInfo {
+	if a == nil {
+		return nil
+	}
+	result := make([]*model.ColumnInfo, 0, len(a.columns))
+	for _, col := range a.columns {
+		info := &model.ColumnInfo{
+			Name:  ast.NewCIStr(col.name),
+			State: model.StatePublic,
+		}
+		switch col.kind {
+		case kindCommitTs:
+			info.FieldType = *types.NewFieldType(mysql.TypeLonglong)
+			info.AddFlag(mysql.UnsignedFlag)
+			info.SetFlen(mysql.MaxIntWidth)
+		default:
+			info.FieldType = *types.NewFieldType(mysql.TypeVarchar)
+			info.SetCharset(charset.CharsetUTF8MB4)
+			info.SetCollate(charset.CollationUTF8MB4)
+			if col.kind == kindOp {
+				info.SetFlen(opColumnLength)
+			} else {
+				info.SetFlen(sourceChangefeedColumnLength)
+			}
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// TableInfo returns the table info with the metadata columns appended. In the append-only
+// mode, the primary key and unique keys are turned into normal indexes, since one row may be
+// written many times.
+func (a *Appender) TableInfo(tableInfo *common.TableInfo) *common.TableInfo {
+	if !a.Enabled(tableInfo) {
+		return tableInfo
+	}
+	tableID := tableInfo.TableName.TableID
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if cached, ok := a.tableInfos[tableID]; ok && (cached.source == tableInfo || cached.target == tableInfo) {
+		return cached.target
+	}
+
+	info := tableInfo.ToTiDBTableInfo()
+	info.UpdateTS = tableInfo.UpdateTS
+	columns := make([]*model.ColumnInfo, 0, len(info.Columns)+len(a.columns))
+	var maxColumnID int64
+	for _, col := range info.Columns {
+		if a.appendOnly {
+			col = col.Clone()
+			col.DelFlag(mysql.PriKeyFlag | mysql.UniqueKeyFlag)
+		}
+		maxColumnID = max(maxColumnID, col.ID)
+		columns = append(columns, col)
+	}
+	for i, col := range a.ColumnInfos() {
+		col.ID = maxColumnID + int64(i) + 1
+		col.Offset = len(columns)
+		columns = append(columns, col)
+	}
+	info.Columns = columns
+	if a.appendOnly {
+		info.PKIsHandle = false
+		indices := make([]*model.IndexInfo, 0, len(info.Indices))
+		for _, index := range info.Indices {
+			index = index.Clone()
+			index.Primary = false
+			index.Unique = false
+			indices = append(indices, index)
+		}
+		info.Indices = indices
+	}
+
+	target := common.WrapTableInfo(tableInfo.GetSchemaName(), info)
+	target.TableName.IsPartition = tableInfo.TableName.IsPartition
+	target.InitPrivateFields()
+	a.tableInfos[tableID] = cachedTableInfo{source: tableInfo, target: target}
+	return target
+}
+
+// Apply appends the metadata columns to the rows of the event in place. The rows of the
+// event are copied into a new chunk since the chunk may be shared by other events.
+// In the append-only mode, the update is turned into the insert of its post-image and
+// the delete is turned into the insert of its pre-image.
+func (a *Appender) Apply(event *commonEvent.DMLEvent) {
+	if !a.Enabled(event.TableInfo) || event.Rows == nil {
+		return
+	}
+	target := a.TableInfo(event.TableInfo)
+	if target == event.TableInfo {
+		// the event has been applied.
+		return
+	}
+
+	sourceFields := event.TableInfo.GetFieldSlice()
+	rows := chunk.NewChunkWithCapacity(target.GetFieldSlice(), len(event.RowTypes))
+	appendRow := func(offset int, rowType common.RowType, isPreImage bool) {
+		row := event.Rows.GetRow(event.PreviousTotalOffset + offset)
+		for i, ft := range sourceFields {
+			d := row.GetDatum(i, ft)
+			rows.AppendDatum(i, &d)
+		}
+		for i, col := range a.columns {
+			d := a.value(col, rowType, event.CommitTs, isPreImage)
+			rows.AppendDatum(len(sourceFields)+i, &d)
+		}
+	}
+
+	var (
+		rowTypes []common.RowType
+		rowKeys  [][]byte
+	)
+	for offset := 0; offset < len(event.RowTypes); {
+		rowType := event.RowTypes[offset]
+		step := 1
+		if rowType == common.RowTypeUpdate {
+			step = 2
+		}
+		if !a.appendOnly {
+			for i := 0; i < step; i++ {
+				// the pre-image of the update is located by the handle key, its metadata columns are not used.
+				appendRow(offset+i, rowType, rowType == common.RowTypeUpdate && i == 0)
+			}
+			offset += step
+			continue
+		}
+		if rowType == common.RowTypeUpdate {
+			appendRow(offset+1, rowType, false)
+		} else {
+			appendRow(offset, rowType, false)
+		}
+		rowTypes = append(rowTypes, common.RowTypeInsert)
+		if len(event.RowKeys) > offset {
+			rowKeys = append(rowKeys, event.RowKeys[offset])
+		}
+		offset += step
+	}
+	if a.appendOnly {
+		event.RowTypes = rowTypes
+		if len(event.RowKeys) > 0 {
+			event.RowKeys = rowKeys
+		}
+	}
+	event.Rows = rows
+	event.PreviousTotalOffset = 0
+	event.TableInfo = target
+}
+
+func (a *Appender) value(col metaColumn, rowType common.RowType, commitTs uint64, isPreImage bool) types.Datum {
+	if isPreImage {
+		return types.NewDatum(nil)
+	}
+	switch col.kind {
+	case kindCommitTs:
+		return types.NewUintDatum(commitTs)
+	case kindOp:
+		return types.NewStringDatum(rowType.String())
+	default:
+		return types.NewStringDatum(a.changefeed)
+	}
+}
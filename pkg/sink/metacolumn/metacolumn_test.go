@@ -0,0 +1,144 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metacolumn
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAppender(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("default", "test")
+	appender, err := New(nil, changefeedID)
+	require.NoError(t, err)
+	require.Nil(t, appender)
+	appender, err = New(&config.MetadataColumnsConfig{}, changefeedID)
+	require.NoError(t, err)
+	require.Nil(t, appender)
+	require.False(t, appender.AppendOnly())
+	require.Empty(t, appender.CommitTsColumn())
+
+	appender, err = New(&config.MetadataColumnsConfig{
+		CommitTs:         util.AddressOf("_commit_ts"),
+		SourceChangefeed: util.AddressOf("_source"),
+	}, changefeedID)
+	require.NoError(t, err)
+	require.Len(t, appender.columns, 2)
+	require.Equal(t, kindCommitTs, appender.columns[0].kind)
+	require.Equal(t, kindSourceChangefeed, appender.columns[1].kind)
+	require.Equal(t, "_commit_ts", appender.CommitTsColumn())
+	require.Equal(t, []string{"_commit_ts", "_source"}, appender.ColumnNames())
+
+	for _, cfg := range []*config.MetadataColumnsConfig{
+		{CommitTs: util.AddressOf("_meta"), Op: util.AddressOf("_META")},
+		{CommitTs: util.AddressOf("_commit_ts"), AppendOnly: util.AddressOf(true)},
+	} {
+		_, err = New(cfg, changefeedID)
+		require.Error(t, err)
+	}
+}
+
+func TestAppenderApply(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	helper.DDL2Job("create table t1 (id int, name varchar(32))")
+
+	changefeedID := common.NewChangefeedID4Test("default", "test")
+	appender, err := New(&config.MetadataColumnsConfig{
+		CommitTs: util.AddressOf("_commit_ts"),
+		Op:       util.AddressOf("_op"),
+	}, changefeedID)
+	require.NoError(t, err)
+
+	event, _ := helper.DML2UpdateEvent("test", "t",
+		"insert into t values (1, 'a')", "update t set name = 'b' where id = 1")
+	source := event.TableInfo
+	appender.Apply(event)
+	require.Len(t, event.TableInfo.GetColumns(), 4)
+	require.Equal(t, "_commit_ts", event.TableInfo.GetColumns()[2].Name.O)
+	require.Equal(t, "_op", event.TableInfo.GetColumns()[3].Name.O)
+	require.Same(t, event.TableInfo, appender.TableInfo(source))
+	require.True(t, event.TableInfo.HasPKOrNotNullUK)
+	row, ok := event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, common.RowTypeUpdate, row.RowType)
+	require.Equal(t, "a", row.PreRow.GetString(1))
+	require.True(t, row.PreRow.IsNull(2))
+	require.Equal(t, "b", row.Row.GetString(1))
+	require.Equal(t, event.CommitTs, row.Row.GetUint64(2))
+	require.Equal(t, "update", row.Row.GetString(3))
+
+	// the event has been applied is not changed.
+	rows := event.Rows
+	appender.Apply(event)
+	require.Same(t, rows, event.Rows)
+
+	// the table without primary key or not null unique key is not changed.
+	event = helper.DML2Event("test", "t1", "insert into t1 values (1, 'a')")
+	rows = event.Rows
+	appender.Apply(event)
+	require.Same(t, rows, event.Rows)
+	require.Len(t, event.TableInfo.GetColumns(), 2)
+}
+
+func TestAppenderApplyAppendOnly(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32), unique key uk (name))")
+
+	changefeedID := common.NewChangefeedID4Test("default", "test")
+	appender, err := New(&config.MetadataColumnsConfig{
+		Op:               util.AddressOf("_op"),
+		SourceChangefeed: util.AddressOf("_source"),
+		AppendOnly:       util.AddressOf(true),
+	}, changefeedID)
+	require.NoError(t, err)
+
+	// the primary key and unique key are turned into normal indexes.
+	event, _ := helper.DML2UpdateEvent("test", "t",
+		"insert into t values (1, 'a')", "update t set name = 'b' where id = 1")
+	appender.Apply(event)
+	require.False(t, event.TableInfo.HasPKOrNotNullUK)
+	for _, index := range event.TableInfo.GetIndices() {
+		require.False(t, index.Primary)
+		require.False(t, index.Unique)
+	}
+	require.Equal(t, []common.RowType{common.RowTypeInsert}, event.RowTypes)
+	row, ok := event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, common.RowTypeInsert, row.RowType)
+	require.Equal(t, "b", row.Row.GetString(1))
+	require.Equal(t, "update", row.Row.GetString(2))
+	require.Equal(t, changefeedID.String(), row.Row.GetString(3))
+
+	// the delete is turned into the insert of its pre-image.
+	event = helper.DML2DeleteEvent("test", "t", "insert into t values (2, 'c')", "delete from t where id = 2")
+	appender.Apply(event)
+	row, ok = event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, common.RowTypeInsert, row.RowType)
+	require.Equal(t, int64(2), row.Row.GetInt64(0))
+	require.Equal(t, "c", row.Row.GetString(1))
+	require.Equal(t, "delete", row.Row.GetString(2))
+	_, ok = event.GetNextRow()
+	require.False(t, ok)
+}
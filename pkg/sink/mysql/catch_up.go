@@ -0,0 +1,173 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultCatchUpMaxTxnRow is the default max number of rows in a transaction in the catch-up mode.
+	defaultCatchUpMaxTxnRow = 16 * DefaultMaxTxnRow
+	// The upper limit of max txn rows in the catch-up mode.
+	maxCatchUpMaxTxnRow = 65536
+)
+
+func (c *Config) applyCatchUp(cfg *config.CatchUpConfig) error {
+	if cfg == nil || cfg.LagThreshold == nil || *cfg.LagThreshold == "" {
+		return nil
+	}
+	threshold, err := time.ParseDuration(*cfg.LagThreshold)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if threshold <= 0 {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"invalid catch-up lag-threshold %s, which must be greater than 0", *cfg.LagThreshold)
+	}
+	// the conflicts are resolved row by row, which can not be coalesced.
+	if c.ConflictPolicy != "" {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack("catch-up can not be used with conflict-resolution")
+	}
+	maxTxnRow := defaultCatchUpMaxTxnRow
+	if cfg.MaxTxnRow != nil {
+		maxTxnRow = *cfg.MaxTxnRow
+	}
+	if maxTxnRow <= 0 {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"invalid catch-up max-txn-row %d, which must be greater than 0", maxTxnRow)
+	}
+	if maxTxnRow > maxCatchUpMaxTxnRow {
+		log.Warn("catch-up max-txn-row too large",
+			zap.Int("original", maxTxnRow), zap.Int("override", maxCatchUpMaxTxnRow))
+		maxTxnRow = maxCatchUpMaxTxnRow
+	}
+	c.CatchUpLagThreshold = threshold
+	c.CatchUpMaxTxnRow = max(maxTxnRow, c.MaxTxnRow)
+	return nil
+}
+
+// UpdateCatchUpMode enters the catch-up mode when the lag of the commitTs exceeds the threshold,
+// and leaves it once the lag drops below half of the threshold, so that the writer does not
+// switch the mode back and forth around the threshold. It returns whether the writer is in
+// the catch-up mode.
+func (w *Writer) UpdateCatchUpMode(commitTs uint64) bool {
+	if w.cfg.CatchUpLagThreshold <= 0 || w.cfg.ConflictPolicy != "" {
+		return false
+	}
+	lag := time.Since(oracle.GetTimeFromTS(commitTs))
+	if !w.inCatchUpMode && lag > w.cfg.CatchUpLagThreshold {
+		w.inCatchUpMode = true
+		log.Info("mysql writer enters the catch-up mode",
+			zap.String("changefeed", w.ChangefeedID.String()), zap.Int("writerID", w.id), zap.Duration("lag", lag))
+	} else if w.inCatchUpMode && lag < w.cfg.CatchUpLagThreshold/2 {
+		w.inCatchUpMode = false
+		log.Info("mysql writer leaves the catch-up mode",
+			zap.String("changefeed", w.ChangefeedID.String()), zap.Int("writerID", w.id), zap.Duration("lag", lag))
+	}
+	return w.inCatchUpMode
+}
+
+// prepareCatchUpDMLs coalesces the rows of the events by the handle key, and writes only the
+// final image of every row by the multi-row REPLACE and DELETE statements. The tables without
+// the primary key or not null unique key can not be coalesced, their rows are written one by one.
+//
+// LOAD DATA LOCAL INFILE is not used. It requires local_infile on the downstream and the local
+// file permission of the driver, it can't delete rows, and the values would be encoded as text
+// rather than passed as the typed args of the prepared statements.
+func (w *Writer) prepareCatchUpDMLs(events []*commonEvent.DMLEvent) *preparedDMLs {
+	dmls := dmlsPool.Get().(*preparedDMLs)
+	dmls.reset()
+
+	for _, event := range events {
+		dmls.rowCount += int(event.Len())
+		if len(dmls.tsPairs) == 0 || dmls.tsPairs[len(dmls.tsPairs)-1].startTs != event.StartTs {
+			dmls.tsPairs = append(dmls.tsPairs, tsPair{startTs: event.StartTs, commitTs: event.CommitTs})
+		}
+		dmls.approximateSize += event.GetSize()
+	}
+
+	var (
+		queryList []string
+		argsList  [][]interface{}
+	)
+	for _, sortedEventGroups := range groupEventsByTable(events) {
+		for _, eventsInGroup := range sortedEventGroups {
+			tableInfo := eventsInGroup[0].TableInfo
+			if !tableInfo.HasPKOrNotNullUK || tableInfo.HasVirtualColumns() {
+				queryList, argsList = w.generateNormalSQLs(eventsInGroup)
+			} else {
+				routedTableInfo := w.routeTableInfo(tableInfo)
+				rows := coalesceRows(eventsInGroup, routedTableInfo)
+				queryList, argsList = w.batchSingleTxnDmls(rows, routedTableInfo, true)
+			}
+			dmls.sqls = append(dmls.sqls, queryList...)
+			dmls.values = append(dmls.values, argsList...)
+		}
+	}
+	dmls.LogDebug(events, w.id)
+	return dmls
+}
+
+// coalesceRows keeps only the final image of every row of the events, the rows are located
+// by the handle key. The row which exists at last is turned into an insert, and the row which
+// is deleted at last is turned into a delete.
+func coalesceRows(events []*commonEvent.DMLEvent, tableInfo *common.TableInfo) []*commonEvent.RowChange {
+	var (
+		rows  []*commonEvent.RowChange
+		index = make(map[string]int)
+	)
+	set := func(key []byte, row *commonEvent.RowChange) {
+		if i, ok := index[string(key)]; ok {
+			rows[i] = row
+			return
+		}
+		index[string(key)] = len(rows)
+		rows = append(rows, row)
+	}
+	for _, event := range events {
+		for {
+			row, ok := event.GetNextRow()
+			if !ok {
+				event.Rewind()
+				break
+			}
+			switch row.RowType {
+			case common.RowTypeInsert:
+				_, key := genKeyAndHash(&row.Row, tableInfo)
+				set(key, &commonEvent.RowChange{Row: row.Row, RowType: common.RowTypeInsert})
+			case common.RowTypeDelete:
+				_, key := genKeyAndHash(&row.PreRow, tableInfo)
+				set(key, &commonEvent.RowChange{PreRow: row.PreRow, RowType: common.RowTypeDelete})
+			case common.RowTypeUpdate:
+				_, preKey := genKeyAndHash(&row.PreRow, tableInfo)
+				_, key := genKeyAndHash(&row.Row, tableInfo)
+				// the row is moved to another handle key, the old one is deleted.
+				if !compareKeys(preKey, key) {
+					set(preKey, &commonEvent.RowChange{PreRow: row.PreRow, RowType: common.RowTypeDelete})
+				}
+				set(key, &commonEvent.RowChange{Row: row.Row, RowType: common.RowTypeInsert})
+			}
+		}
+	}
+	return rows
+}
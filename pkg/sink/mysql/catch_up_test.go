@@ -0,0 +1,112 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestApplyCatchUp(t *testing.T) {
+	cfg := New()
+	require.NoError(t, cfg.applyCatchUp(nil))
+	require.Zero(t, cfg.CatchUpLagThreshold)
+
+	cfg = New()
+	require.NoError(t, cfg.applyCatchUp(&config.CatchUpConfig{LagThreshold: util.AddressOf("10m")}))
+	require.Equal(t, 10*time.Minute, cfg.CatchUpLagThreshold)
+	require.Equal(t, defaultCatchUpMaxTxnRow, cfg.CatchUpMaxTxnRow)
+
+	cfg = New()
+	require.NoError(t, cfg.applyCatchUp(&config.CatchUpConfig{
+		LagThreshold: util.AddressOf("1h"),
+		MaxTxnRow:    util.AddressOf(maxCatchUpMaxTxnRow + 1),
+	}))
+	require.Equal(t, maxCatchUpMaxTxnRow, cfg.CatchUpMaxTxnRow)
+
+	for _, c := range []*config.CatchUpConfig{
+		{LagThreshold: util.AddressOf("10")},
+		{LagThreshold: util.AddressOf("-1m")},
+		{LagThreshold: util.AddressOf("1m"), MaxTxnRow: util.AddressOf(0)},
+	} {
+		require.Error(t, New().applyCatchUp(c))
+	}
+	cfg = New()
+	cfg.ConflictPolicy = conflictPolicyUpstreamWins
+	require.Error(t, cfg.applyCatchUp(&config.CatchUpConfig{LagThreshold: util.AddressOf("10m")}))
+}
+
+func TestMysqlWriterCatchUp(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.CachePrepStmts = false
+	writer.cfg.DMLMaxRetry = 1
+	writer.cfg.CatchUpLagThreshold = 10 * time.Minute
+
+	// the writer enters the catch-up mode when the lag is large, and leaves
+	// it only after the lag drops below half of the threshold.
+	require.True(t, writer.UpdateCatchUpMode(oracle.GoTimeToTS(time.Now().Add(-time.Hour))))
+	require.True(t, writer.UpdateCatchUpMode(oracle.GoTimeToTS(time.Now().Add(-8*time.Minute))))
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	helper.DDL2Job("create table t1 (id int, name varchar(32))")
+
+	// only the final image of every row is written.
+	updateEvent, _ := helper.DML2UpdateEvent("test", "t",
+		"insert into t values (1, 'a')", "update t set name = 'c' where id = 1")
+	deleteEvent := helper.DML2DeleteEvent("test", "t", "insert into t values (3, 'd')", "delete from t where id = 3")
+	deleteEvent2 := helper.DML2DeleteEvent("test", "t", "insert into t values (4, 'f')", "delete from t where id = 4")
+	insertEvent := helper.DML2Event("test", "t", "insert into t values (2, 'b')", "insert into t values (3, 'e')")
+	mock.ExpectExec("BEGIN;DELETE FROM `test`.`t` WHERE (`id` = ?);"+
+		"REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?),(?,?);COMMIT;").
+		WithArgs(4, 1, "c", 3, "e", 2, "b").
+		WillReturnResult(sqlmock.NewResult(3, 3))
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{updateEvent, deleteEvent, deleteEvent2, insertEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the rows of the table without primary key are written one by one.
+	event := helper.DML2Event("test", "t1", "insert into t1 values (1, 'a')")
+	mock.ExpectExec("BEGIN;INSERT INTO `test`.`t1` (`id`,`name`) VALUES (?,?);COMMIT;").
+		WithArgs(1, "a").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the dmls are coalesced again when retrying them after the duplicate entry error.
+	updateEvent, _ = helper.DML2UpdateEvent("test", "t",
+		"insert into t values (6, 'x')", "update t set name = 'y' where id = 6")
+	insertEvent = helper.DML2Event("test", "t", "insert into t values (7, 'z')")
+	catchUpSQL := "BEGIN;REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?);COMMIT;"
+	mock.ExpectExec(catchUpSQL).
+		WithArgs(6, "y", 7, "z").
+		WillReturnError(fmt.Errorf("Error 1062: Duplicate entry '7' for key 'PRIMARY'"))
+	mock.ExpectExec(catchUpSQL).
+		WithArgs(6, "y", 7, "z").
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{updateEvent, insertEvent}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.False(t, writer.UpdateCatchUpMode(oracle.GoTimeToTS(time.Now())))
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"go.uber.org/atomic"
+)
+
+// defaultTargetFlushLatency is the default flush latency above which the worker count is decreased.
+const defaultTargetFlushLatency = time.Second
+
+// activeWorkerCounts records the count of the active workers of the mysql sink of each
+// changefeed on this server, it's used by the open api.
+var activeWorkerCounts sync.Map // common.ChangeFeedDisplayName -> *atomic.Int64
+
+// GetActiveWorkerCount returns the count of the active workers of the mysql sink of the changefeed
+// on this server, it returns 0 if the changefeed has no mysql sink on this server.
+func GetActiveWorkerCount(changefeed common.ChangeFeedDisplayName) int64 {
+	if count, ok := activeWorkerCounts.Load(changefeed); ok {
+		return count.(*atomic.Int64).Load()
+	}
+	return 0
+}
+
+// SetActiveWorkerCount records the count of the active workers of the mysql sink of the changefeed.
+func SetActiveWorkerCount(changefeedID common.ChangeFeedID, count int) {
+	value, _ := activeWorkerCounts.LoadOrStore(changefeedID.DisplayName, atomic.NewInt64(0))
+	value.(*atomic.Int64).Store(int64(count))
+	metrics.ActiveWorkerCountGauge.WithLabelValues(changefeedID.Keyspace(), changefeedID.Name()).Set(float64(count))
+}
+
+// RemoveActiveWorkerCount removes the count of the active workers when the mysql sink is closed.
+func RemoveActiveWorkerCount(changefeedID common.ChangeFeedID) {
+	activeWorkerCounts.Delete(changefeedID.DisplayName)
+	metrics.ActiveWorkerCountGauge.DeleteLabelValues(changefeedID.Keyspace(), changefeedID.Name())
+}
+
+func (c *Config) applyAdaptiveConcurrency(cfg *config.AdaptiveConcurrencyConfig) error {
+	if cfg == nil || cfg.Enable == nil || !*cfg.Enable {
+		return nil
+	}
+	minCount, maxCount := 1, min(2*c.WorkerCount, maxWorkerCount)
+	if cfg.MinWorkerCount != nil {
+		minCount = *cfg.MinWorkerCount
+	}
+	if cfg.MaxWorkerCount != nil {
+		maxCount = *cfg.MaxWorkerCount
+	}
+	if maxCount > maxWorkerCount {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"invalid adaptive-concurrency max-worker-count %d, which must be at most %d",
+			maxCount, maxWorkerCount)
+	}
+	if minCount <= 0 || minCount > maxCount {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"invalid adaptive-concurrency min-worker-count %d, which must be in [1, %d]",
+			minCount, maxCount)
+	}
+	targetLatency := defaultTargetFlushLatency
+	if cfg.TargetLatency != nil && *cfg.TargetLatency != "" {
+		var err error
+		targetLatency, err = time.ParseDuration(*cfg.TargetLatency)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		if targetLatency <= 0 {
+			return cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"invalid adaptive-concurrency target-latency %s, which must be greater than 0", *cfg.TargetLatency)
+		}
+	}
+	c.AdaptiveConcurrency = true
+	c.MinWorkerCount, c.MaxWorkerCount = minCount, maxCount
+	c.WorkerCount = max(minCount, min(c.WorkerCount, maxCount))
+	c.TargetFlushLatency = targetLatency
+	return nil
+}
+
+// FlushStats is the statistics of the flushes of a writer, it's the feedback of the
+// downstream used to adjust the worker concurrency.
+type FlushStats struct {
+	FlushCount    int64
+	FlushDuration time.Duration
+	SlowQueries   int64
+	Errors        int64
+}
+
+// Add returns the sum of the two statistics.
+func (s FlushStats) Add(other FlushStats) FlushStats {
+	return FlushStats{
+		FlushCount:    s.FlushCount + other.FlushCount,
+		FlushDuration: s.FlushDuration + other.FlushDuration,
+		SlowQueries:   s.SlowQueries + other.SlowQueries,
+		Errors:        s.Errors + other.Errors,
+	}
+}
+
+// AvgFlushDuration returns the average duration of the flushes.
+func (s FlushStats) AvgFlushDuration() time.Duration {
+	if s.FlushCount == 0 {
+		return 0
+	}
+	return s.FlushDuration / time.Duration(s.FlushCount)
+}
+
+type flushStats struct {
+	flushCount    atomic.Int64
+	flushDuration atomic.Int64
+	slowQueries   atomic.Int64
+	errors        atomic.Int64
+}
+
+func (s *flushStats) observe(duration time.Duration) {
+	s.flushCount.Inc()
+	s.flushDuration.Add(int64(duration))
+}
+
+// TakeFlushStats returns the statistics of the flushes since the last call, it's safe
+// to be called concurrently with Flush.
+func (w *Writer) TakeFlushStats() FlushStats {
+	return FlushStats{
+		FlushCount:    w.flushStats.flushCount.Swap(0),
+		FlushDuration: time.Duration(w.flushStats.flushDuration.Swap(0)),
+		SlowQueries:   w.flushStats.slowQueries.Swap(0),
+		Errors:        w.flushStats.errors.Swap(0),
+	}
+}
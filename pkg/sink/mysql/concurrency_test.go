@@ -0,0 +1,75 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyAdaptiveConcurrency(t *testing.T) {
+	cfg := New()
+	require.NoError(t, cfg.applyAdaptiveConcurrency(&config.AdaptiveConcurrencyConfig{Enable: util.AddressOf(false)}))
+	require.False(t, cfg.AdaptiveConcurrency)
+
+	cfg = New()
+	require.NoError(t, cfg.applyAdaptiveConcurrency(&config.AdaptiveConcurrencyConfig{Enable: util.AddressOf(true)}))
+	require.True(t, cfg.AdaptiveConcurrency)
+	require.Equal(t, 1, cfg.MinWorkerCount)
+	require.Equal(t, 2*DefaultWorkerCount, cfg.MaxWorkerCount)
+	require.Equal(t, DefaultWorkerCount, cfg.WorkerCount)
+	require.Equal(t, defaultTargetFlushLatency, cfg.TargetFlushLatency)
+
+	// the initial worker count is bounded by the min and max worker count.
+	cfg = New()
+	require.NoError(t, cfg.applyAdaptiveConcurrency(&config.AdaptiveConcurrencyConfig{
+		Enable:         util.AddressOf(true),
+		MinWorkerCount: util.AddressOf(4),
+		MaxWorkerCount: util.AddressOf(16),
+		TargetLatency:  util.AddressOf("500ms"),
+	}))
+	require.Equal(t, 16, cfg.WorkerCount)
+	require.Equal(t, 500*time.Millisecond, cfg.TargetFlushLatency)
+
+	for _, c := range []*config.AdaptiveConcurrencyConfig{
+		{MinWorkerCount: util.AddressOf(0)},
+		{MinWorkerCount: util.AddressOf(8), MaxWorkerCount: util.AddressOf(4)},
+		{MaxWorkerCount: util.AddressOf(maxWorkerCount + 1)},
+		{TargetLatency: util.AddressOf("1")},
+		{TargetLatency: util.AddressOf("-1s")},
+	} {
+		c.Enable = util.AddressOf(true)
+		require.Error(t, New().applyAdaptiveConcurrency(c))
+	}
+}
+
+func TestActiveWorkerCount(t *testing.T) {
+	changefeedID := common.NewChangefeedID4Test("default", "test")
+	require.Zero(t, GetActiveWorkerCount(changefeedID.DisplayName))
+	SetActiveWorkerCount(changefeedID, 8)
+	require.Equal(t, int64(8), GetActiveWorkerCount(changefeedID.DisplayName))
+	RemoveActiveWorkerCount(changefeedID)
+	require.Zero(t, GetActiveWorkerCount(changefeedID.DisplayName))
+
+	stats := FlushStats{FlushCount: 2, FlushDuration: 3 * time.Second}.Add(FlushStats{FlushCount: 1, Errors: 1})
+	require.Equal(t, int64(3), stats.FlushCount)
+	require.Equal(t, int64(1), stats.Errors)
+	require.Equal(t, time.Second, stats.AvgFlushDuration())
+	require.Zero(t, FlushStats{}.AvgFlushDuration())
+}
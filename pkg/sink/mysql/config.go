@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/pingcap/ticdc/pkg/sink/metacolumn"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/br/pkg/version"
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
@@ -131,6 +132,55 @@ type Config struct {
 
 	HasVectorType bool // HasVectorType is true if the column is vector type
 
+	// router routes the tables to the downstream tables by the routing rules,
+	// it's nil if there is no routing rule.
+	router *router
+	// SharedTargetDDLPolicy decides how to handle the ddl which drops or truncates
+	// a downstream table shared by several upstream tables.
+	SharedTargetDDLPolicy string
+	// metadataColumns appends the metadata columns to the rows, it's nil if there is no metadata column.
+	metadataColumns *metacolumn.Appender
+
+	// ErrorPolicy decides how to handle the rows which can not be written to the downstream,
+	// it can be `fail` or `dead-letter`.
+	ErrorPolicy string
+	// DeadLetterSchema and DeadLetterTable are the downstream table of the dead-letter rows,
+	// they are only used when DeadLetterStorageURI is empty.
+	DeadLetterSchema     string
+	DeadLetterTable      string
+	DeadLetterStorageURI string
+
+	// ConflictPolicy is the policy to resolve the conflicts in BDR mode, the conflicts
+	// are not detected if it's empty.
+	ConflictPolicy string
+	// ConflictCompareCommitTs and ConflictVersionColumn are used by the `last-writer-wins` policy,
+	// the version column is the commit-ts metadata column if the commit-ts is compared,
+	// otherwise it's the timestamp column of the user.
+	ConflictCompareCommitTs bool
+	ConflictVersionColumn   string
+
+	// CatchUpLagThreshold is the lag above which the writers enter the catch-up mode,
+	// the catch-up mode is disabled if it's 0.
+	CatchUpLagThreshold time.Duration
+	// CatchUpMaxTxnRow is the max number of rows in a transaction in the catch-up mode.
+	CatchUpMaxTxnRow int
+
+	// AdaptiveConcurrency enables adjusting the active worker count at runtime between
+	// MinWorkerCount and MaxWorkerCount, WorkerCount is the initial active worker count.
+	AdaptiveConcurrency bool
+	MinWorkerCount      int
+	MaxWorkerCount      int
+	// TargetFlushLatency is the flush latency above which the worker count is decreased.
+	TargetFlushLatency time.Duration
+
+	// Endpoints are the candidate downstream addresses listed in the host of the sink URI,
+	// SRVName is the DNS SRV name resolved to the candidates. The sink connects to the first
+	// writable candidate, so that it can follow the primary after a downstream failover.
+	Endpoints []string
+	SRVName   string
+	// failover connects to the current primary of the downstream, it's nil if the failover is not enabled.
+	failover *failoverConnector
+
 	// DryRun is used to enable dry-run mode. In dry-run mode, the writer will not write data to the downstream.
 	DryRun bool
 	// DryRunDelay is the delay time for dry-run mode, it is used to simulate the delay time of real write.
@@ -164,6 +214,8 @@ func New() *Config {
 		HasVectorType:          defaultHasVectorType,
 		EnableDDLTs:            defaultEnableDDLTs,
 		SlowQuery:              slowQuery,
+		ErrorPolicy:            errorPolicyFail,
+		SharedTargetDDLPolicy:  sharedTargetDDLPolicySkip,
 	}
 }
 
@@ -259,6 +311,35 @@ func (c *Config) Apply(
 	if err = getEnableDDLTs(query, &c.EnableDDLTs); err != nil {
 		return err
 	}
+	c.Endpoints = parseEndpoints(sinkURI)
+	c.SRVName = query.Get("srv-name")
+	if cfg.SinkConfig != nil {
+		c.metadataColumns, err = metacolumn.New(cfg.SinkConfig.MetadataColumns, changefeedID)
+		if err != nil {
+			return err
+		}
+	}
+	if cfg.SinkConfig != nil && cfg.SinkConfig.MySQLConfig != nil {
+		c.router, err = newRouter(cfg.SinkConfig.MySQLConfig.RoutingRules, cfg.SinkConfig.CaseSensitive)
+		if err != nil {
+			return err
+		}
+		if err = c.applySharedTargetDDLPolicy(cfg.SinkConfig.MySQLConfig); err != nil {
+			return err
+		}
+		if err = c.applyErrorPolicy(cfg.SinkConfig.MySQLConfig); err != nil {
+			return err
+		}
+		if err = c.applyConflictResolution(cfg.SinkConfig.MySQLConfig.ConflictResolution, cfg.BDRMode); err != nil {
+			return err
+		}
+		if err = c.applyCatchUp(cfg.SinkConfig.MySQLConfig.CatchUp); err != nil {
+			return err
+		}
+		if err = c.applyAdaptiveConcurrency(cfg.SinkConfig.MySQLConfig.AdaptiveConcurrency); err != nil {
+			return err
+		}
+	}
 
 	// c.EnableOldValue = config.EnableOldValue
 	// Note: The TiDBSourceID should never be 0 here, but we have found that
@@ -293,7 +374,12 @@ func NewMysqlConfigAndDB(
 		return nil, nil, err
 	}
 
-	db, err := CreateMysqlDBConn(dsnStr)
+	var db *sql.DB
+	if cfg.failoverEnabled() {
+		db, err = cfg.openFailoverDB(dsnStr)
+	} else {
+		db, err = CreateMysqlDBConn(dsnStr)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -314,8 +400,14 @@ func NewMysqlConfigAndDB(
 	// This issue is less likely to occur when the connection pool is larger,
 	// as there are more connections available for use.
 	// Adding an extra connection to the connection pool solves the connection exhaustion issue.
-	db.SetMaxIdleConns(cfg.WorkerCount + 1)
-	db.SetMaxOpenConns(cfg.WorkerCount + 1)
+	// With the adaptive concurrency, the connections are sized by the max worker count,
+	// and the mysql sink shrinks them to the active worker count at runtime.
+	workerCount := cfg.WorkerCount
+	if cfg.AdaptiveConcurrency {
+		workerCount = cfg.MaxWorkerCount
+	}
+	db.SetMaxIdleConns(workerCount + 1)
+	db.SetMaxOpenConns(workerCount + 1)
 
 	// Inherit the default value of the prepared statement cache from the SinkURI Options
 	cachePrepStmts := cfg.CachePrepStmts
@@ -213,6 +213,7 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	require.Nil(t, err)
 
 	expected.sinkURI = uri
+	expected.Endpoints = []string{"127.0.0.1:3306"}
 	require.Equal(t, expected, cfg)
 }
 
@@ -0,0 +1,413 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/ticdc/pkg/sink/sqlmodel"
+	"go.uber.org/zap"
+)
+
+const (
+	// conflictPolicyLastWriterWins keeps the row change with the larger commit-ts or timestamp column.
+	conflictPolicyLastWriterWins = "last-writer-wins"
+	// conflictPolicyUpstreamWins always overwrites the downstream row with the row change.
+	conflictPolicyUpstreamWins = "upstream-wins"
+	// conflictPolicyLogAndSkip keeps the downstream row and skips the row change.
+	conflictPolicyLogAndSkip = "log-and-skip"
+
+	conflictCompareByCommitTs        = "commit-ts"
+	conflictCompareByTimestampColumn = "timestamp-column"
+
+	conflictLogTable = "conflict_log_v1"
+
+	// conflictResolutionApplied means the row change is written to the downstream.
+	conflictResolutionApplied = "applied"
+	// conflictResolutionSkipped means the downstream row is kept.
+	conflictResolutionSkipped = "skipped"
+)
+
+func (c *Config) applyConflictResolution(cfg *config.ConflictResolutionConfig, bdrMode bool) error {
+	if cfg == nil || cfg.Policy == nil || *cfg.Policy == "" {
+		return nil
+	}
+	if !bdrMode {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack("conflict-resolution is only available in bdr mode")
+	}
+	if c.metadataColumns.AppendOnly() {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack("conflict-resolution is not available in the append-only mode")
+	}
+	// the row changes are resolved one by one, a failed row fails the whole transaction
+	// instead of being isolated to the dead-letter destination.
+	if c.ErrorPolicy == errorPolicyDeadLetter {
+		return cerror.ErrMySQLInvalidConfig.GenWithStack("conflict-resolution can not be used with the %s error-policy",
+			errorPolicyDeadLetter)
+	}
+	policy := strings.ToLower(*cfg.Policy)
+	switch policy {
+	case conflictPolicyUpstreamWins, conflictPolicyLogAndSkip:
+		c.ConflictPolicy = policy
+		return nil
+	case conflictPolicyLastWriterWins:
+	default:
+		return cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"invalid conflict-resolution policy %s, which must be %s, %s or %s",
+			policy, conflictPolicyLastWriterWins, conflictPolicyUpstreamWins, conflictPolicyLogAndSkip)
+	}
+
+	compareBy := conflictCompareByCommitTs
+	if cfg.CompareBy != nil && *cfg.CompareBy != "" {
+		compareBy = strings.ToLower(*cfg.CompareBy)
+	}
+	switch compareBy {
+	case conflictCompareByCommitTs:
+		// the commit-ts is kept in the metadata column written by the sink, so the user data is never changed.
+		// Note the rows written by the applications on the downstream keep the stale commit-ts of the
+		// metadata column, so they lose to the row changes replicated later, and the commit-ts of both
+		// clusters are allocated by different PDs, so they are only comparable if the clocks are close.
+		// Use the timestamp column maintained by the applications if the local writes must win.
+		commitTsColumn := c.metadataColumns.CommitTsColumn()
+		if commitTsColumn == "" {
+			return cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"the commit-ts metadata column is required by the %s policy compared by %s",
+				conflictPolicyLastWriterWins, conflictCompareByCommitTs)
+		}
+		c.ConflictCompareCommitTs = true
+		c.ConflictVersionColumn = commitTsColumn
+	case conflictCompareByTimestampColumn:
+		if cfg.TimestampColumn == nil || *cfg.TimestampColumn == "" {
+			return cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"conflict-resolution timestamp-column is required by the %s policy compared by %s",
+				conflictPolicyLastWriterWins, conflictCompareByTimestampColumn)
+		}
+		c.ConflictVersionColumn = *cfg.TimestampColumn
+	default:
+		return cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"invalid conflict-resolution compare-by %s, which must be %s or %s",
+			compareBy, conflictCompareByCommitTs, conflictCompareByTimestampColumn)
+	}
+	c.ConflictPolicy = policy
+	return nil
+}
+
+// conflict is a conflict detected when writing a row change to the downstream.
+type conflict struct {
+	schema     string
+	table      string
+	commitTs   uint64
+	rowType    common.RowType
+	preValues  []interface{}
+	postValues []interface{}
+	resolution string
+}
+
+// flushWithConflictResolution writes the row changes one by one in one transaction,
+// the conflicts are resolved by the conflict policy and recorded in the conflict log
+// table in the same transaction. The statements are idempotent, so the safe mode is not
+// needed, and the dead-letter error policy and the catch-up mode are rejected with the
+// conflict resolution by the config.
+func (w *Writer) flushWithConflictResolution(events []*commonEvent.DMLEvent) error {
+	if !w.conflictLogTableInit {
+		if err := w.createConflictLogTable(); err != nil {
+			return errors.Trace(err)
+		}
+		w.conflictLogTableInit = true
+	}
+
+	var (
+		rowCount        int
+		approximateSize int64
+		conflicts       []*conflict
+	)
+	for _, event := range events {
+		rowCount += int(event.Len())
+		approximateSize += event.GetSize()
+	}
+	tryExec := func() (int, int64, error) {
+		tx, err := w.db.BeginTx(w.ctx, nil)
+		if err != nil {
+			return 0, 0, errors.Trace(err)
+		}
+		conflicts, err = w.resolveEvents(tx, events)
+		if err == nil && len(conflicts) > 0 {
+			err = w.writeConflictLog(tx, conflicts)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && errors.Cause(rbErr) != context.Canceled {
+				log.Warn("failed to rollback txn", zap.Error(rbErr), zap.Int("writerID", w.id))
+			}
+			return 0, 0, err
+		}
+		if err = tx.Commit(); err != nil {
+			return 0, 0, errors.Trace(err)
+		}
+		return rowCount, approximateSize, nil
+	}
+	err := retry.Do(w.ctx, func() error {
+		err := w.statistics.RecordBatchExecution(tryExec)
+		if err != nil {
+			err = w.cfg.reconnectIfReadOnly(w.ctx, err)
+			log.Warn("execute dmls with conflict resolution failed",
+				zap.String("changefeed", w.ChangefeedID.String()),
+				zap.Int("writerID", w.id),
+				zap.Int("rowCount", rowCount),
+				zap.Error(err))
+			return errors.Trace(err)
+		}
+		return nil
+	}, retry.WithBackoffBaseDelay(BackoffBaseDelay.Milliseconds()),
+		retry.WithBackoffMaxDelay(BackoffMaxDelay.Milliseconds()),
+		retry.WithMaxTries(w.cfg.DMLMaxRetry),
+		retry.WithIsRetryableErr(isRetryableDMLError))
+	if err != nil {
+		return err
+	}
+	for _, c := range conflicts {
+		log.Info("conflict resolved",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Int("writerID", w.id),
+			zap.String("schema", c.schema),
+			zap.String("table", c.table),
+			zap.Uint64("commitTs", c.commitTs),
+			zap.Stringer("rowType", c.rowType),
+			zap.String("policy", w.cfg.ConflictPolicy),
+			zap.String("resolution", c.resolution))
+	}
+	return nil
+}
+
+func (w *Writer) resolveEvents(tx *sql.Tx, events []*commonEvent.DMLEvent) ([]*conflict, error) {
+	var conflicts []*conflict
+	for _, event := range events {
+		event.Rewind()
+		// the event replayed after a restart may be written to the downstream already, the
+		// downstream row written by itself is not a conflict, so the conflicts of the replayed
+		// events are resolved by the policy but not recorded.
+		replayed := event.CommitTs < event.ReplicatingTs
+		tableInfo := w.routeTableInfo(event.TableInfo)
+		versionOffset := -1
+		if w.cfg.ConflictVersionColumn != "" {
+			for i, col := range tableInfo.GetColumns() {
+				if strings.EqualFold(col.Name.O, w.cfg.ConflictVersionColumn) {
+					versionOffset = i
+					break
+				}
+			}
+		}
+		for {
+			row, ok := event.GetNextRow()
+			if !ok {
+				event.Rewind()
+				break
+			}
+			var preValues, postValues []interface{}
+			if !row.PreRow.IsEmpty() {
+				preValues = getArgsWithGeneratedColumn(&row.PreRow, tableInfo)
+			}
+			if !row.Row.IsEmpty() {
+				postValues = getArgsWithGeneratedColumn(&row.Row, tableInfo)
+			}
+			change := sqlmodel.NewRowChange(&tableInfo.TableName, nil, preValues, postValues, tableInfo, nil, nil)
+
+			var version interface{} = event.CommitTs
+			if !w.cfg.ConflictCompareCommitTs && versionOffset >= 0 && preValues != nil {
+				version = preValues[versionOffset]
+			}
+			resolution, conflicted, err := w.resolveRow(tx, change, version)
+			if err != nil {
+				event.Rewind()
+				return nil, err
+			}
+			if conflicted && !replayed {
+				conflicts = append(conflicts, &conflict{
+					schema:     event.TableInfo.GetSchemaName(),
+					table:      event.TableInfo.GetTableName(),
+					commitTs:   event.CommitTs,
+					rowType:    row.RowType,
+					preValues:  preValues,
+					postValues: postValues,
+					resolution: resolution,
+				})
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// resolveRow writes the row change if the downstream row is not changed by others,
+// otherwise the conflict is resolved by the conflict policy. The version is compared
+// with the version column of the downstream row when deleting by the `last-writer-wins` policy.
+func (w *Writer) resolveRow(tx *sql.Tx, change *sqlmodel.RowChange, version interface{}) (string, bool, error) {
+	// the metadata columns of the pre-image are not set, so they are not compared.
+	skipColumns := w.cfg.metadataColumns.ColumnNames()
+	var (
+		query string
+		args  []interface{}
+	)
+	switch change.Type() {
+	case sqlmodel.RowChangeInsert:
+		query, args = change.GenInsertIfNotExistsSQL()
+	case sqlmodel.RowChangeUpdate:
+		query, args = change.GenUpdateIfUnchangedSQL(skipColumns...)
+	default:
+		query, args = change.GenDeleteIfUnchangedSQL(skipColumns...)
+	}
+	affected, err := w.execWithAffectedRows(tx, query, args)
+	if err != nil || affected > 0 {
+		return "", false, err
+	}
+	if change.Type() == sqlmodel.RowChangeUpdate {
+		// the update affects no row if the row is not changed by it, which is not a conflict.
+		query, args = change.GenSelectIfUnchangedSQL(skipColumns...)
+		exists, err := w.queryRowExists(tx, query, args)
+		if err != nil || exists {
+			return "", false, err
+		}
+	}
+
+	isDelete := change.Type() == sqlmodel.RowChangeDelete
+	switch w.cfg.ConflictPolicy {
+	case conflictPolicyLogAndSkip:
+		return conflictResolutionSkipped, true, nil
+	case conflictPolicyUpstreamWins:
+		if isDelete {
+			query, args = change.GenSQL(sqlmodel.DMLDelete)
+		} else {
+			query, args = change.GenSQL(sqlmodel.DMLInsertOnDuplicateUpdate)
+		}
+	default:
+		if isDelete {
+			query, args = change.GenLastWriterWinsDeleteSQL(w.cfg.ConflictVersionColumn, version)
+		} else {
+			query, args = change.GenLastWriterWinsUpsertSQL(w.cfg.ConflictVersionColumn, supportRowAlias(w.cfg))
+		}
+	}
+	affected, err = w.execWithAffectedRows(tx, query, args)
+	if err != nil {
+		return "", false, err
+	}
+	if affected > 0 {
+		return conflictResolutionApplied, true, nil
+	}
+	return conflictResolutionSkipped, true, nil
+}
+
+func (w *Writer) execWithAffectedRows(tx *sql.Tx, query string, args []interface{}) (int64, error) {
+	writeTimeout, _ := time.ParseDuration(w.cfg.WriteTimeout)
+	ctx, cancel := context.WithTimeout(w.ctx, writeTimeout+networkDriftDuration)
+	defer cancel()
+	log.Debug("exec row", zap.String("sql", query), zap.Any("args", args), zap.Int("writerID", w.id))
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, fmt.Sprintf("Failed to execute DMLs, query info:%s, args:%v; ", query, args)))
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	return affected, nil
+}
+
+func (w *Writer) queryRowExists(tx *sql.Tx, query string, args []interface{}) (bool, error) {
+	writeTimeout, _ := time.ParseDuration(w.cfg.WriteTimeout)
+	ctx, cancel := context.WithTimeout(w.ctx, writeTimeout+networkDriftDuration)
+	defer cancel()
+	log.Debug("query row", zap.String("sql", query), zap.Any("args", args), zap.Int("writerID", w.id))
+	var exists int
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, fmt.Sprintf("Failed to query row, query info:%s, args:%v; ", query, args)))
+	}
+	return true, nil
+}
+
+func (w *Writer) createConflictLogTable() error {
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		id bigint AUTO_INCREMENT,
+		ticdc_cluster_id varchar(255),
+		changefeed varchar(255),
+		schema_name varchar(255),
+		table_name varchar(255),
+		commit_ts bigint unsigned,
+		row_type varchar(16),
+		policy varchar(32),
+		resolution varchar(16),
+		pre_values longtext,
+		post_values longtext,
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		INDEX (changefeed, commit_ts),
+		PRIMARY KEY (id)
+	);`
+	query = fmt.Sprintf(query, conflictLogTable)
+	return w.createTable(filter.TiCDCSystemSchema, conflictLogTable, query)
+}
+
+// writeConflictLog records the conflicts in the conflict log table.
+func (w *Writer) writeConflictLog(tx *sql.Tx, conflicts []*conflict) error {
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO ")
+	builder.WriteString(common.QuoteSchema(filter.TiCDCSystemSchema, conflictLogTable))
+	builder.WriteString(" (ticdc_cluster_id, changefeed, schema_name, table_name, commit_ts, " +
+		"row_type, policy, resolution, pre_values, post_values) VALUES ")
+	args := make([]interface{}, 0, len(conflicts)*10)
+	for i, c := range conflicts {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("(?,?,?,?,?,?,?,?,?,?)")
+		preValues, err := marshalRowValues(c.preValues)
+		if err != nil {
+			return err
+		}
+		postValues, err := marshalRowValues(c.postValues)
+		if err != nil {
+			return err
+		}
+		args = append(args, config.GetGlobalServerConfig().ClusterID, w.ChangefeedID.String(), c.schema,
+			c.table, c.commitTs, c.rowType.String(), w.cfg.ConflictPolicy, c.resolution, preValues, postValues)
+	}
+	_, err := w.execWithAffectedRows(tx, builder.String(), args)
+	return err
+}
+
+func marshalRowValues(values []interface{}) (interface{}, error) {
+	if values == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLTxnError, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/metacolumn"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/tidb/br/pkg/version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConflictResolution(t *testing.T) {
+	cfg := New()
+	require.NoError(t, cfg.applyConflictResolution(nil, true))
+	require.Empty(t, cfg.ConflictPolicy)
+
+	// the commit-ts is compared by the commit-ts metadata column, the timestamp column is not required.
+	cfg = New()
+	cfg.metadataColumns = newTestCommitTsAppender(t)
+	require.NoError(t, cfg.applyConflictResolution(&config.ConflictResolutionConfig{
+		Policy: util.AddressOf("Last-Writer-Wins"),
+	}, true))
+	require.Equal(t, conflictPolicyLastWriterWins, cfg.ConflictPolicy)
+	require.True(t, cfg.ConflictCompareCommitTs)
+	require.Equal(t, "_commit_ts", cfg.ConflictVersionColumn)
+
+	cfg = New()
+	require.NoError(t, cfg.applyConflictResolution(&config.ConflictResolutionConfig{
+		Policy:          util.AddressOf(conflictPolicyLastWriterWins),
+		CompareBy:       util.AddressOf(conflictCompareByTimestampColumn),
+		TimestampColumn: util.AddressOf("updated_at"),
+	}, true))
+	require.False(t, cfg.ConflictCompareCommitTs)
+	require.Equal(t, "updated_at", cfg.ConflictVersionColumn)
+
+	cfg = New()
+	require.NoError(t, cfg.applyConflictResolution(&config.ConflictResolutionConfig{
+		Policy: util.AddressOf(conflictPolicyUpstreamWins),
+	}, true))
+	require.Equal(t, conflictPolicyUpstreamWins, cfg.ConflictPolicy)
+
+	require.Error(t, New().applyConflictResolution(&config.ConflictResolutionConfig{
+		Policy: util.AddressOf(conflictPolicyLogAndSkip),
+	}, false))
+	cfg = New()
+	cfg.ErrorPolicy = errorPolicyDeadLetter
+	require.Error(t, cfg.applyConflictResolution(&config.ConflictResolutionConfig{
+		Policy: util.AddressOf(conflictPolicyLogAndSkip),
+	}, true))
+	for _, c := range []*config.ConflictResolutionConfig{
+		{Policy: util.AddressOf("downstream-wins")},
+		// the commit-ts metadata column is not set.
+		{Policy: util.AddressOf(conflictPolicyLastWriterWins), TimestampColumn: util.AddressOf("updated_at")},
+		{Policy: util.AddressOf(conflictPolicyLastWriterWins), CompareBy: util.AddressOf(conflictCompareByTimestampColumn)},
+		{
+			Policy:          util.AddressOf(conflictPolicyLastWriterWins),
+			CompareBy:       util.AddressOf("version"),
+			TimestampColumn: util.AddressOf("updated_at"),
+		},
+	} {
+		require.Error(t, New().applyConflictResolution(c, true))
+	}
+}
+
+func newTestCommitTsAppender(t *testing.T) *metacolumn.Appender {
+	appender, err := metacolumn.New(&config.MetadataColumnsConfig{
+		CommitTs: util.AddressOf("_commit_ts"),
+	}, common.NewChangefeedID4Test("test", "test"))
+	require.NoError(t, err)
+	return appender
+}
+
+func TestMysqlWriterConflictResolution(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.CachePrepStmts = false
+	writer.cfg.DMLMaxRetry = 1
+	writer.conflictLogTableInit = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32), ts bigint)")
+	logSQL := "INSERT INTO `tidb_cdc`.`conflict_log_v1` (ticdc_cluster_id, changefeed, schema_name, table_name, " +
+		"commit_ts, row_type, policy, resolution, pre_values, post_values) VALUES (?,?,?,?,?,?,?,?,?,?)"
+	insertSQL := "INSERT INTO `test`.`t` (`id`,`name`,`ts`) VALUES (?,?,?)"
+
+	// last-writer-wins compared by commit-ts, the downstream row is newer than the row change.
+	// The commit-ts is written to the metadata column, the timestamp column of the user is kept.
+	writer.cfg.metadataColumns = newTestCommitTsAppender(t)
+	writer.cfg.ConflictPolicy = conflictPolicyLastWriterWins
+	writer.cfg.ConflictCompareCommitTs = true
+	writer.cfg.ConflictVersionColumn = "_commit_ts"
+	event := helper.DML2Event("test", "t", "insert into t values (1, 'a', 0)", "insert into t values (2, 'b', 0)")
+	var flushed int
+	event.AddPostFlushFunc(func() { flushed++ })
+	commitTs := event.CommitTs
+	metaInsertSQL := "INSERT INTO `test`.`t` (`id`,`name`,`ts`,`_commit_ts`) VALUES (?,?,?,?)"
+	newer := "`_commit_ts` IS NULL OR `_commit_ts` <= VALUES(`_commit_ts`)"
+	mock.ExpectBegin()
+	mock.ExpectExec(metaInsertSQL+" ON DUPLICATE KEY UPDATE `id`=`id`").
+		WithArgs(1, "a", 0, commitTs).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(metaInsertSQL+" ON DUPLICATE KEY UPDATE "+
+		"`id`=IF("+newer+",VALUES(`id`),`id`),"+
+		"`name`=IF("+newer+",VALUES(`name`),`name`),"+
+		"`ts`=IF("+newer+",VALUES(`ts`),`ts`),"+
+		"`_commit_ts`=IF("+newer+",VALUES(`_commit_ts`),`_commit_ts`)").
+		WithArgs(1, "a", 0, commitTs).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(metaInsertSQL+" ON DUPLICATE KEY UPDATE `id`=`id`").
+		WithArgs(2, "b", 0, commitTs).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(logSQL).
+		WithArgs(config.GetGlobalServerConfig().ClusterID, writer.ChangefeedID.String(), "test", "t",
+			commitTs, "insert", conflictPolicyLastWriterWins, conflictResolutionSkipped,
+			nil, fmt.Sprintf(`[1,"a",0,%d]`, commitTs)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.Equal(t, 1, flushed)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// upstream-wins, the downstream row is changed by others.
+	writer.cfg.metadataColumns = nil
+	writer.cfg.ConflictPolicy = conflictPolicyUpstreamWins
+	writer.cfg.ConflictCompareCommitTs = false
+	writer.cfg.ConflictVersionColumn = ""
+	event, _ = helper.DML2UpdateEvent("test", "t",
+		"insert into t values (3, 'c', 1)", "update t set name = 'cc' where id = 3")
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `test`.`t` SET `id` = ?, `name` = ?, `ts` = ? WHERE `id` = ? "+
+		"AND `id` <=> ? AND `name` <=> ? AND `ts` <=> ? LIMIT 1").
+		WithArgs(3, "cc", 1, 3, 3, "c", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT 1 FROM `test`.`t` WHERE `id` = ? "+
+		"AND `id` <=> ? AND `name` <=> ? AND `ts` <=> ? LIMIT 1 FOR UPDATE").
+		WithArgs(3, 3, "c", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectExec(insertSQL+" ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`name`=VALUES(`name`),`ts`=VALUES(`ts`)").
+		WithArgs(3, "cc", 1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(logSQL).
+		WithArgs(config.GetGlobalServerConfig().ClusterID, writer.ChangefeedID.String(), "test", "t",
+			event.CommitTs, "update", conflictPolicyUpstreamWins, conflictResolutionApplied,
+			`[3,"c",1]`, `[3,"cc",1]`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// last-writer-wins compared by the timestamp column, the row change is newer.
+	writer.cfg.ConflictPolicy = conflictPolicyLastWriterWins
+	writer.cfg.ConflictVersionColumn = "ts"
+	event = helper.DML2DeleteEvent("test", "t", "insert into t values (4, 'd', 5)", "delete from t where id = 4")
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `test`.`t` WHERE `id` = ? AND `id` <=> ? AND `name` <=> ? AND `ts` <=> ? LIMIT 1").
+		WithArgs(4, 4, "d", 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM `test`.`t` WHERE `id` = ? AND (`ts` IS NULL OR `ts` <= ?) LIMIT 1").
+		WithArgs(4, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(logSQL).
+		WithArgs(config.GetGlobalServerConfig().ClusterID, writer.ChangefeedID.String(), "test", "t",
+			event.CommitTs, "delete", conflictPolicyLastWriterWins, conflictResolutionApplied,
+			`[4,"d",5]`, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the update affects no row if the downstream row is not changed by it, it's not a conflict.
+	writer.cfg.ConflictPolicy = conflictPolicyLogAndSkip
+	writer.cfg.ConflictVersionColumn = ""
+	event, _ = helper.DML2UpdateEvent("test", "t",
+		"insert into t values (5, 'e', 1)", "update t set name = 'ee' where id = 5")
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `test`.`t` SET `id` = ?, `name` = ?, `ts` = ? WHERE `id` = ? "+
+		"AND `id` <=> ? AND `name` <=> ? AND `ts` <=> ? LIMIT 1").
+		WithArgs(5, "ee", 1, 5, 5, "e", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT 1 FROM `test`.`t` WHERE `id` = ? "+
+		"AND `id` <=> ? AND `name` <=> ? AND `ts` <=> ? LIMIT 1 FOR UPDATE").
+		WithArgs(5, 5, "e", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the event replayed after a restart finds the row written by itself, it's not recorded.
+	event = helper.DML2Event("test", "t", "insert into t values (6, 'f', 1)")
+	event.ReplicatingTs = event.CommitTs + 1
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL+" ON DUPLICATE KEY UPDATE `id`=`id`").
+		WithArgs(6, "f", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the inserted row is referred by the row alias if the downstream is MySQL 8.0.19 or later.
+	writer.cfg.ServerInfo = version.ParseServerInfo("8.0.30")
+	writer.cfg.ConflictPolicy = conflictPolicyLastWriterWins
+	writer.cfg.ConflictVersionColumn = "ts"
+	event = helper.DML2Event("test", "t", "insert into t values (7, 'g', 2)")
+	newer = "`ts` IS NULL OR `ts` <= new.`ts`"
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL+" ON DUPLICATE KEY UPDATE `id`=`id`").
+		WithArgs(7, "g", 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insertSQL+" AS new ON DUPLICATE KEY UPDATE "+
+		"`id`=IF("+newer+",new.`id`,`id`),"+
+		"`name`=IF("+newer+",new.`name`,`name`),"+
+		"`ts`=IF("+newer+",new.`ts`,`ts`)").
+		WithArgs(7, "g", 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(logSQL).
+		WithArgs(config.GetGlobalServerConfig().ClusterID, writer.ChangefeedID.String(), "test", "t",
+			event.CommitTs, "insert", conflictPolicyLastWriterWins, conflictResolutionApplied,
+			nil, `[7,"g",2]`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,438 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"go.uber.org/zap"
+)
+
+const (
+	// errorPolicyFail fails the changefeed when a row can not be written to the downstream.
+	errorPolicyFail = "fail"
+	// errorPolicyDeadLetter writes the rows which can not be written to the downstream
+	// to the dead-letter destination, and keeps replicating the other rows.
+	errorPolicyDeadLetter = "dead-letter"
+
+	defaultDeadLetterTable = "dead_letter_v1"
+)
+
+// QueryDeadLetterRowCount returns the count of the rows written to the dead-letter destination
+// by the changefeed. The count is read from the dead-letter table or files, so it covers all the
+// captures and survives the restarts and the table moves. It returns 0 if the changefeed doesn't
+// use the `dead-letter` error policy.
+func QueryDeadLetterRowCount(
+	ctx context.Context, changefeedID common.ChangeFeedID, cfg *config.ChangefeedConfig,
+) (int64, error) {
+	sinkURI, err := url.Parse(cfg.SinkURI)
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	c := New()
+	if err = c.Apply(sinkURI, changefeedID, cfg); err != nil {
+		return 0, err
+	}
+	if c.ErrorPolicy != errorPolicyDeadLetter {
+		return 0, nil
+	}
+	if c.DeadLetterStorageURI != "" {
+		deadLetterStorage, err := util.GetExternalStorageWithDefaultTimeout(ctx, c.DeadLetterStorageURI)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		defer deadLetterStorage.Close()
+		return countDeadLetterFileRows(ctx, deadLetterStorage, changefeedID)
+	}
+
+	dsnStr, err := GenerateDSN(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	db, err := CreateMysqlDBConn(dsnStr)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	return queryDeadLetterTableRowCount(ctx, db, c, changefeedID)
+}
+
+// queryDeadLetterTableRowCount counts the rows of the changefeed in the dead-letter table,
+// the table is created when the first dead-letter row is written.
+func queryDeadLetterTableRowCount(
+	ctx context.Context, db *sql.DB, cfg *Config, changefeedID common.ChangeFeedID,
+) (int64, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE changefeed = ?",
+		common.QuoteSchema(cfg.DeadLetterSchema, cfg.DeadLetterTable))
+	var count int64
+	err := db.QueryRowContext(ctx, query, changefeedID.String()).Scan(&count)
+	if err != nil {
+		if errCode, ok := getSQLErrCode(err); ok && (errCode == mysql.ErrNoSuchTable || errCode == mysql.ErrBadDB) {
+			return 0, nil
+		}
+		return 0, cerror.WrapError(cerror.ErrMySQLQueryError,
+			errors.WithMessage(err, fmt.Sprintf("failed to query dead-letter table; Query is %s", query)))
+	}
+	return count, nil
+}
+
+// countDeadLetterFileRows counts the lines of the dead-letter files of the changefeed,
+// each line is the record of a row.
+func countDeadLetterFileRows(
+	ctx context.Context, deadLetterStorage storage.ExternalStorage, changefeedID common.ChangeFeedID,
+) (int64, error) {
+	var count int64
+	opt := &storage.WalkOption{SubDir: deadLetterFileDir(changefeedID)}
+	err := deadLetterStorage.WalkDir(ctx, opt, func(path string, _ int64) error {
+		data, err := deadLetterStorage.ReadFile(ctx, path)
+		if err != nil {
+			return err
+		}
+		count += int64(bytes.Count(data, []byte{'\n'}))
+		return nil
+	})
+	return count, errors.Trace(err)
+}
+
+func deadLetterFileDir(changefeedID common.ChangeFeedID) string {
+	return changefeedID.Keyspace() + "/" + changefeedID.Name()
+}
+
+// RemoveDeadLetterMetrics removes the metrics of the dead-letter rows when the mysql sink is closed.
+func RemoveDeadLetterMetrics(changefeedID common.ChangeFeedID) {
+	metrics.DeadLetterRowCounter.DeleteLabelValues(changefeedID.Keyspace(), changefeedID.Name())
+}
+
+func (c *Config) applyErrorPolicy(cfg *config.MySQLConfig) error {
+	if cfg.ErrorPolicy != nil {
+		c.ErrorPolicy = strings.ToLower(*cfg.ErrorPolicy)
+	}
+	switch c.ErrorPolicy {
+	case errorPolicyFail:
+		return nil
+	case errorPolicyDeadLetter:
+	default:
+		return cerror.ErrMySQLInvalidConfig.GenWithStack(
+			"invalid error-policy %s, which must be %s or %s", c.ErrorPolicy, errorPolicyFail, errorPolicyDeadLetter)
+	}
+
+	c.DeadLetterSchema, c.DeadLetterTable = filter.TiCDCSystemSchema, defaultDeadLetterTable
+	if cfg.DeadLetter == nil {
+		return nil
+	}
+	if cfg.DeadLetter.StorageURI != nil && *cfg.DeadLetter.StorageURI != "" {
+		if cfg.DeadLetter.Table != nil && *cfg.DeadLetter.Table != "" {
+			return cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"dead-letter table and storage-uri can not be set at the same time")
+		}
+		if _, err := storage.ParseBackend(*cfg.DeadLetter.StorageURI, nil); err != nil {
+			return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		c.DeadLetterStorageURI = *cfg.DeadLetter.StorageURI
+		return nil
+	}
+	if cfg.DeadLetter.Table != nil && *cfg.DeadLetter.Table != "" {
+		names := strings.Split(*cfg.DeadLetter.Table, ".")
+		switch {
+		case len(names) == 1 && names[0] != "":
+			c.DeadLetterTable = names[0]
+		case len(names) == 2 && names[0] != "" && names[1] != "":
+			c.DeadLetterSchema, c.DeadLetterTable = names[0], names[1]
+		default:
+			return cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"invalid dead-letter table %s, which must be `table` or `schema.table`", *cfg.DeadLetter.Table)
+		}
+	}
+	return nil
+}
+
+// isDeadLetterError returns true if the error is caused by the data of some rows,
+// the other rows in the same batch can be written to the downstream without them.
+func isDeadLetterError(err error) bool {
+	errCode, ok := getSQLErrCode(err)
+	if !ok {
+		return false
+	}
+	switch errCode {
+	case mysql.ErrDataTooLong, mysql.ErrDupEntry, mysql.ErrBadNull, mysql.ErrNoDefaultForField,
+		mysql.ErrNoReferencedRow, mysql.ErrNoReferencedRow2, mysql.ErrRowIsReferenced, mysql.ErrRowIsReferenced2,
+		mysql.WarnDataTruncated, mysql.ErrWarnDataOutOfRange, mysql.ErrDataOutOfRange,
+		mysql.ErrTruncatedWrongValue, mysql.ErrTruncatedWrongValueForField, errno.ErrCheckConstraintViolated:
+		return true
+	}
+	return false
+}
+
+// deadLetterRow is a row change and the statements generated for it, the statements
+// of a row are always executed together, so the row is the smallest unit to be isolated.
+type deadLetterRow struct {
+	schema   string
+	table    string
+	commitTs uint64
+	rowType  common.RowType
+	sqls     []string
+	values   [][]interface{}
+	err      error
+}
+
+// deadLetterRecord is the record of a dead-letter row written to the storage.
+type deadLetterRecord struct {
+	Changefeed string        `json:"changefeed"`
+	Schema     string        `json:"schema"`
+	Table      string        `json:"table"`
+	CommitTs   uint64        `json:"commit_ts"`
+	RowType    string        `json:"row_type"`
+	SQL        string        `json:"sql"`
+	Args       []interface{} `json:"args"`
+	Error      string        `json:"error"`
+}
+
+func (w *Writer) newDeadLetterRecord(row *deadLetterRow) deadLetterRecord {
+	var args []interface{}
+	for _, values := range row.values {
+		args = append(args, values...)
+	}
+	return deadLetterRecord{
+		Changefeed: w.ChangefeedID.String(),
+		Schema:     row.schema,
+		Table:      row.table,
+		CommitTs:   row.commitTs,
+		RowType:    row.rowType.String(),
+		SQL:        strings.Join(row.sqls, "; "),
+		Args:       args,
+		Error:      row.err.Error(),
+	}
+}
+
+// flushWithDeadLetter isolates the rows which make the batch fail by bisecting the batch,
+// the isolated rows are written to the dead-letter destination, and the other rows are
+// written to the downstream.
+func (w *Writer) flushWithDeadLetter(events []*commonEvent.DMLEvent, cause error) error {
+	rows := w.prepareDeadLetterRows(events)
+	var deadRows []*deadLetterRow
+	if err := w.bisect(rows, cause, &deadRows); err != nil {
+		return err
+	}
+	if len(deadRows) == 0 {
+		return nil
+	}
+
+	var err error
+	if w.cfg.DeadLetterStorageURI != "" {
+		err = w.writeDeadLetterFile(deadRows)
+	} else {
+		err = w.writeDeadLetterTable(deadRows)
+	}
+	if err != nil {
+		return err
+	}
+	metrics.DeadLetterRowCounter.WithLabelValues(w.ChangefeedID.Keyspace(), w.ChangefeedID.Name()).
+		Add(float64(len(deadRows)))
+	// the rows are summarized in one log to avoid flooding the log when many rows are dead,
+	// the details of each row can be found in the dead-letter destination.
+	firstRow := deadRows[0]
+	log.Warn("write rows to the dead-letter destination",
+		zap.String("changefeed", w.ChangefeedID.String()),
+		zap.Int("writerID", w.id),
+		zap.Int("rowCount", len(deadRows)),
+		zap.String("firstSchema", firstRow.schema),
+		zap.String("firstTable", firstRow.table),
+		zap.Uint64("firstCommitTs", firstRow.commitTs),
+		zap.Stringer("firstRowType", firstRow.rowType),
+		zap.Error(firstRow.err))
+	return nil
+}
+
+// prepareDeadLetterRows generates the statements of each row in the events.
+func (w *Writer) prepareDeadLetterRows(events []*commonEvent.DMLEvent) []*deadLetterRow {
+	var rows []*deadLetterRow
+	for _, event := range events {
+		event.Rewind()
+		tableInfo := w.routeTableInfo(event.TableInfo)
+		inSafeMode := w.cfg.SafeMode || w.isInErrorCausedSafeMode || event.CommitTs < event.ReplicatingTs
+		for {
+			row, ok := event.GetNextRow()
+			if !ok {
+				event.Rewind()
+				break
+			}
+			sqls, values := generateRowSQL(tableInfo, row, inSafeMode)
+			if len(sqls) == 0 {
+				continue
+			}
+			rows = append(rows, &deadLetterRow{
+				schema:   event.TableInfo.GetSchemaName(),
+				table:    event.TableInfo.GetTableName(),
+				commitTs: event.CommitTs,
+				rowType:  row.RowType,
+				sqls:     sqls,
+				values:   values,
+			})
+		}
+	}
+	return rows
+}
+
+// bisect executes the rows, if they fail with a dead-letter error, they are split into
+// two halves and executed in order, until the failed rows are isolated.
+// The cause is the error of executing all the rows, nil means the rows are not executed yet.
+func (w *Writer) bisect(rows []*deadLetterRow, cause error, deadRows *[]*deadLetterRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if cause == nil {
+		cause = w.execDeadLetterRows(rows)
+		if cause == nil {
+			return nil
+		}
+		if !isDeadLetterError(cause) {
+			return errors.Trace(cause)
+		}
+	}
+	if len(rows) == 1 {
+		rows[0].err = cause
+		*deadRows = append(*deadRows, rows[0])
+		return nil
+	}
+	mid := len(rows) / 2
+	if err := w.bisect(rows[:mid], nil, deadRows); err != nil {
+		return err
+	}
+	return w.bisect(rows[mid:], nil, deadRows)
+}
+
+// execDeadLetterRows executes the statements of the rows in one transaction without retry.
+func (w *Writer) execDeadLetterRows(rows []*deadLetterRow) error {
+	dmls := &preparedDMLs{}
+	for _, row := range rows {
+		dmls.sqls = append(dmls.sqls, row.sqls...)
+		dmls.values = append(dmls.values, row.values...)
+	}
+	writeTimeout, _ := time.ParseDuration(w.cfg.WriteTimeout)
+	writeTimeout += networkDriftDuration
+	return w.statistics.RecordBatchExecution(func() (int, int64, error) {
+		tx, err := w.db.BeginTx(w.ctx, nil)
+		if err != nil {
+			return 0, 0, errors.Trace(err)
+		}
+		if err = w.sequenceExecute(dmls, tx, writeTimeout); err != nil {
+			return 0, 0, err
+		}
+		if err = tx.Commit(); err != nil {
+			return 0, 0, errors.Trace(err)
+		}
+		return len(rows), 0, nil
+	})
+}
+
+func (w *Writer) createDeadLetterTable() error {
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		id bigint AUTO_INCREMENT,
+		ticdc_cluster_id varchar(255),
+		changefeed varchar(255),
+		schema_name varchar(255),
+		table_name varchar(255),
+		commit_ts bigint unsigned,
+		row_type varchar(16),
+		statements longtext,
+		args longtext,
+		error text,
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		INDEX (changefeed, commit_ts),
+		PRIMARY KEY (id)
+	);`
+	query = fmt.Sprintf(query, common.QuoteName(w.cfg.DeadLetterTable))
+	return w.createTable(common.QuoteName(w.cfg.DeadLetterSchema), w.cfg.DeadLetterTable, query)
+}
+
+// writeDeadLetterTable writes the dead-letter rows to the downstream table.
+func (w *Writer) writeDeadLetterTable(rows []*deadLetterRow) error {
+	if !w.deadLetterTableInit {
+		if err := w.createDeadLetterTable(); err != nil {
+			return errors.Trace(err)
+		}
+		w.deadLetterTableInit = true
+	}
+
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO ")
+	builder.WriteString(common.QuoteSchema(w.cfg.DeadLetterSchema, w.cfg.DeadLetterTable))
+	builder.WriteString(" (ticdc_cluster_id, changefeed, schema_name, table_name, commit_ts, row_type, statements, args, error) VALUES ")
+	args := make([]interface{}, 0, len(rows)*9)
+	for i, row := range rows {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("(?,?,?,?,?,?,?,?,?)")
+		record := w.newDeadLetterRecord(row)
+		recordArgs, err := json.Marshal(record.Args)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+		}
+		args = append(args, config.GetGlobalServerConfig().ClusterID, record.Changefeed, record.Schema,
+			record.Table, record.CommitTs, record.RowType, record.SQL, string(recordArgs), record.Error)
+	}
+	query := builder.String()
+	writeTimeout, _ := time.ParseDuration(w.cfg.WriteTimeout)
+	ctx, cancel := context.WithTimeout(w.ctx, writeTimeout+networkDriftDuration)
+	defer cancel()
+	if _, err := w.db.ExecContext(ctx, query, args...); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError,
+			errors.WithMessage(err, fmt.Sprintf("failed to write dead-letter table; Query is %s", query)))
+	}
+	return nil
+}
+
+// writeDeadLetterFile writes the dead-letter rows to a new file in the storage,
+// each line of the file is a json record of a row.
+func (w *Writer) writeDeadLetterFile(rows []*deadLetterRow) error {
+	if w.deadLetterStorage == nil {
+		deadLetterStorage, err := util.GetExternalStorageWithDefaultTimeout(w.ctx, w.cfg.DeadLetterStorageURI)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.deadLetterStorage = deadLetterStorage
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, row := range rows {
+		if err := encoder.Encode(w.newDeadLetterRecord(row)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	name := fmt.Sprintf("%s/dead_letter_%d_%d_%d.jsonl", deadLetterFileDir(w.ChangefeedID),
+		rows[0].commitTs, w.id, time.Now().UnixNano())
+	return errors.Trace(w.deadLetterStorage.WriteFile(w.ctx, name, buf.Bytes()))
+}
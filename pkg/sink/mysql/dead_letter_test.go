@@ -0,0 +1,188 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyErrorPolicy(t *testing.T) {
+	cfg := New()
+	require.NoError(t, cfg.applyErrorPolicy(&config.MySQLConfig{}))
+	require.Equal(t, errorPolicyFail, cfg.ErrorPolicy)
+
+	cfg = New()
+	require.NoError(t, cfg.applyErrorPolicy(&config.MySQLConfig{ErrorPolicy: util.AddressOf("Dead-Letter")}))
+	require.Equal(t, errorPolicyDeadLetter, cfg.ErrorPolicy)
+	require.Equal(t, "tidb_cdc", cfg.DeadLetterSchema)
+	require.Equal(t, defaultDeadLetterTable, cfg.DeadLetterTable)
+
+	cfg = New()
+	require.NoError(t, cfg.applyErrorPolicy(&config.MySQLConfig{
+		ErrorPolicy: util.AddressOf(errorPolicyDeadLetter),
+		DeadLetter:  &config.DeadLetterConfig{Table: util.AddressOf("dlq.rows")},
+	}))
+	require.Equal(t, "dlq", cfg.DeadLetterSchema)
+	require.Equal(t, "rows", cfg.DeadLetterTable)
+
+	cfg = New()
+	require.NoError(t, cfg.applyErrorPolicy(&config.MySQLConfig{
+		ErrorPolicy: util.AddressOf(errorPolicyDeadLetter),
+		DeadLetter:  &config.DeadLetterConfig{StorageURI: util.AddressOf("s3://bucket/dlq")},
+	}))
+	require.Equal(t, "s3://bucket/dlq", cfg.DeadLetterStorageURI)
+
+	for _, c := range []*config.MySQLConfig{
+		{ErrorPolicy: util.AddressOf("skip")},
+		{
+			ErrorPolicy: util.AddressOf(errorPolicyDeadLetter),
+			DeadLetter:  &config.DeadLetterConfig{Table: util.AddressOf("a.b.c")},
+		},
+		{
+			ErrorPolicy: util.AddressOf(errorPolicyDeadLetter),
+			DeadLetter: &config.DeadLetterConfig{
+				Table:      util.AddressOf("dlq"),
+				StorageURI: util.AddressOf("file:///tmp/dlq"),
+			},
+		},
+	} {
+		require.Error(t, New().applyErrorPolicy(c))
+	}
+}
+
+func TestMysqlWriterDeadLetterTable(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.CachePrepStmts = false
+	writer.cfg.DMLMaxRetry = 1
+	writer.cfg.ErrorPolicy = errorPolicyDeadLetter
+	writer.cfg.DeadLetterSchema, writer.cfg.DeadLetterTable = "tidb_cdc", defaultDeadLetterTable
+	writer.deadLetterTableInit = true
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+
+	event := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a')", "insert into t values (2, 'b')",
+		"insert into t values (3, 'c')", "insert into t values (4, 'd')")
+	event.DispatcherID = common.NewDispatcherID()
+	var flushed int
+	event.AddPostFlushFunc(func() { flushed++ })
+	tooLong := &dmysql.MySQLError{Number: mysql.ErrDataTooLong, Message: "Data too long for column 'name'"}
+	insertSQL := "INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)"
+
+	// the batch fails, and the rows are bisected to find the failed row.
+	mock.ExpectExec("BEGIN;INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?),(?,?),(?,?);COMMIT;").
+		WithArgs(1, "a", 2, "b", 3, "c", 4, "d").
+		WillReturnError(tooLong)
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WithArgs(1, "a").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(insertSQL).WithArgs(2, "b").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WithArgs(3, "c").WillReturnError(tooLong)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WithArgs(3, "c").WillReturnError(tooLong)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSQL).WithArgs(4, "d").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("INSERT INTO `tidb_cdc`.`dead_letter_v1` (ticdc_cluster_id, changefeed, schema_name, "+
+		"table_name, commit_ts, row_type, statements, args, error) VALUES (?,?,?,?,?,?,?,?,?)").
+		WithArgs(config.GetGlobalServerConfig().ClusterID, writer.ChangefeedID.String(), "test", "t",
+			event.CommitTs, "insert", insertSQL, `[3,"c"]`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.Equal(t, 1, flushed)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the count of the dead-letter rows is read from the dead-letter table.
+	countSQL := "SELECT COUNT(*) FROM `tidb_cdc`.`dead_letter_v1` WHERE changefeed = ?"
+	mock.ExpectQuery(countSQL).WithArgs(writer.ChangefeedID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	count, err := queryDeadLetterTableRowCount(context.Background(), db, writer.cfg, writer.ChangefeedID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	mock.ExpectQuery(countSQL).WithArgs(writer.ChangefeedID.String()).
+		WillReturnError(&dmysql.MySQLError{Number: mysql.ErrNoSuchTable, Message: "table doesn't exist"})
+	count, err = queryDeadLetterTableRowCount(context.Background(), db, writer.cfg, writer.ChangefeedID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the error which is not caused by the rows fails the flush.
+	writer.cfg.ErrorPolicy = errorPolicyFail
+	event = helper.DML2Event("test", "t", "insert into t values (5, 'e')")
+	mock.ExpectExec("BEGIN;"+insertSQL+";COMMIT;").WithArgs(5, "e").WillReturnError(tooLong)
+	require.Error(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlWriterDeadLetterStorage(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	dir := t.TempDir()
+	writer.cfg.CachePrepStmts = false
+	writer.cfg.DMLMaxRetry = 1
+	writer.cfg.ErrorPolicy = errorPolicyDeadLetter
+	writer.cfg.DeadLetterStorageURI = "file://" + dir
+	defer writer.Close()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+
+	event := helper.DML2Event("test", "t", "insert into t values (1, 'a')")
+	mock.ExpectExec("BEGIN;INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);COMMIT;").
+		WithArgs(1, "a").
+		WillReturnError(&dmysql.MySQLError{Number: mysql.ErrNoReferencedRow2, Message: "foreign key constraint fails"})
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the count of the dead-letter rows is read from the dead-letter files.
+	count, err := countDeadLetterFileRows(context.Background(), writer.deadLetterStorage, writer.ChangefeedID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	files, err := filepath.Glob(filepath.Join(dir, writer.ChangefeedID.Keyspace(), writer.ChangefeedID.Name(), "dead_letter_*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	var record deadLetterRecord
+	require.NoError(t, json.Unmarshal(data, &record))
+	require.Equal(t, "test", record.Schema)
+	require.Equal(t, "t", record.Table)
+	require.Equal(t, event.CommitTs, record.CommitTs)
+	require.Equal(t, []interface{}{float64(1), "a"}, record.Args)
+	require.Contains(t, record.Error, "foreign key constraint fails")
+}
@@ -0,0 +1,330 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+const defaultPort = "4000"
+
+// The downstream failover works as follows:
+//  1. the sink connects to the first writable candidate when it's created.
+//  2. once the primary is turned into read-only, the write fails with the read-only error, and the
+//     writable candidate is selected again. The new connections are made to the new primary, and the
+//     connections to the old one are discarded when they are returned to the pool, so the failed
+//     statements are retried on the new primary in place.
+//  3. if the primary is down or no writable candidate is found, the error is reported to restart
+//     the changefeed, then the sink is created again and connects to the new primary. The dispatchers
+//     resume from the ddl-ts table on the new primary, so the finished DDLs are not executed again.
+
+// these functions are variables to be replaced in tests.
+var (
+	lookupSRV    = net.DefaultResolver.LookupSRV
+	getTestDB    = GetTestDB
+	newConnector = func(dsn *dmysql.Config) (driver.Connector, error) { return dmysql.NewConnector(dsn) }
+)
+
+// parseEndpoints returns the candidate addresses listed in the host of the sink URI,
+// such as `mysql://root@host1:3306,host2:3306/`.
+func parseEndpoints(sinkURI *url.URL) []string {
+	hosts := strings.Split(sinkURI.Host, ",")
+	endpoints := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		hostName, port, err := net.SplitHostPort(host)
+		if err != nil {
+			// the port is missing.
+			hostName, port = strings.Trim(host, "[]"), ""
+		}
+		if port == "" {
+			port = defaultPort
+		}
+		// This will handle the IPv6 address format.
+		endpoints = append(endpoints, net.JoinHostPort(hostName, port))
+	}
+	return endpoints
+}
+
+func (c *Config) failoverEnabled() bool {
+	return len(c.Endpoints) > 1 || c.SRVName != ""
+}
+
+// resolveEndpoints returns the candidate addresses of the downstream, the SRV name is
+// resolved every time, so that the sink follows the changes of the DNS records.
+func (c *Config) resolveEndpoints(ctx context.Context) ([]string, error) {
+	if c.SRVName == "" {
+		return c.Endpoints, nil
+	}
+	_, records, err := lookupSRV(ctx, "", "", c.SRVName)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLConnectionError, err)
+	}
+	// the records are sorted by the priority and randomized by the weight.
+	endpoints := make([]string, 0, len(records))
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		endpoints = append(endpoints, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+	if len(endpoints) == 0 {
+		return nil, cerror.ErrMySQLConnectionError.GenWithStack("no downstream found by the SRV name %s", c.SRVName)
+	}
+	return endpoints, nil
+}
+
+// selectPrimary sets the address of the dsn to the first writable candidate.
+func selectPrimary(ctx context.Context, cfg *Config, dsn *dmysql.Config) error {
+	endpoints, err := cfg.resolveEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range endpoints {
+		candidate := dsn.Clone()
+		candidate.Addr = endpoint
+		writable, err := checkWritable(ctx, candidate)
+		if err != nil {
+			log.Warn("fail to check the downstream, try the next one",
+				zap.String("address", endpoint), zap.Error(err))
+			continue
+		}
+		if !writable {
+			log.Info("downstream is read-only, try the next one", zap.String("address", endpoint))
+			continue
+		}
+		log.Info("select the writable downstream", zap.String("address", endpoint), zap.Strings("candidates", endpoints))
+		dsn.Addr = endpoint
+		// the password may be decoded by the test db.
+		dsn.Passwd = candidate.Passwd
+		return nil
+	}
+	return cerror.ErrMySQLConnectionError.GenWithStack("no writable downstream found in %v", endpoints)
+}
+
+func checkWritable(ctx context.Context, dsn *dmysql.Config) (bool, error) {
+	testDB, err := getTestDB(dsn)
+	if err != nil {
+		return false, err
+	}
+	defer testDB.Close()
+	return isWritable(ctx, testDB)
+}
+
+// isWritable checks the read-only variables of the downstream, the variables which are
+// not supported by the downstream are ignored.
+func isWritable(ctx context.Context, db *sql.DB) (bool, error) {
+	rows, err := db.QueryContext(ctx,
+		"SHOW GLOBAL VARIABLES WHERE Variable_name IN ('read_only', 'super_read_only', 'tidb_super_read_only')")
+	if err != nil {
+		return false, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, value string
+		if err = rows.Scan(&name, &value); err != nil {
+			return false, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		if value == "ON" || value == "1" {
+			return false, nil
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return false, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return true, nil
+}
+
+// isReadOnlyError checks whether the error is caused by writing a read-only downstream,
+// such an error is not retried, so that the sink is recreated to connect to the new primary.
+func isReadOnlyError(err error) bool {
+	errCode, ok := getSQLErrCode(err)
+	if !ok {
+		return false
+	}
+	return errCode == mysql.ErrOptionPreventsStatement || errCode == mysql.ErrReadOnlyMode
+}
+
+// failoverConnector connects to the current primary of the downstream, it's used to open
+// the db of the sink when the failover is enabled.
+type failoverConnector struct {
+	cfg *Config
+	dsn *dmysql.Config
+
+	mu   sync.Mutex
+	addr string
+	// generation is increased when the primary is switched, the connections
+	// made in the previous generations are discarded.
+	generation atomic.Int64
+}
+
+// openFailoverDB opens the db whose connections are made to the current primary of the downstream.
+func (c *Config) openFailoverDB(dsnStr string) (*sql.DB, error) {
+	dsn, err := dmysql.ParseDSN(dsnStr)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLConnectionError, err)
+	}
+	c.failover = &failoverConnector{cfg: c, dsn: dsn, addr: dsn.Addr}
+	db := sql.OpenDB(c.failover)
+	if err = db.PingContext(context.Background()); err != nil {
+		// close db to recycle resources
+		if closeErr := db.Close(); closeErr != nil {
+			log.Warn("close db failed", zap.Error(closeErr))
+		}
+		return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
+	}
+	return db, nil
+}
+
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	dsn := c.dsn.Clone()
+	dsn.Addr = c.addr
+	generation := c.generation.Load()
+	c.mu.Unlock()
+
+	connector, err := newConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &failoverConn{Conn: conn, connector: c, generation: generation}, nil
+}
+
+func (c *failoverConnector) Driver() driver.Driver {
+	return &dmysql.MySQLDriver{}
+}
+
+// switchPrimary selects the writable candidate again, and returns its address.
+func (c *failoverConnector) switchPrimary(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dsn := c.dsn.Clone()
+	if err := selectPrimary(ctx, c.cfg, dsn); err != nil {
+		return "", err
+	}
+	if dsn.Addr != c.addr {
+		log.Info("switch the downstream to the new primary",
+			zap.String("oldAddress", c.addr), zap.String("newAddress", dsn.Addr))
+		c.addr = dsn.Addr
+		c.generation.Inc()
+	}
+	return c.addr, nil
+}
+
+// reconnectIfReadOnly switches the connections to the new primary in place if the error is caused
+// by writing the read-only downstream, and returns a connection error to retry the statements on
+// the new primary. Otherwise, the error is returned unchanged.
+func (c *Config) reconnectIfReadOnly(ctx context.Context, err error) error {
+	if c.failover == nil || !isReadOnlyError(err) {
+		return err
+	}
+	addr, switchErr := c.failover.switchPrimary(ctx)
+	if switchErr != nil {
+		log.Warn("fail to switch the downstream after it becomes read-only",
+			zap.Error(err), zap.NamedError("switchError", switchErr))
+		return err
+	}
+	return errors.Annotatef(driver.ErrBadConn, "reconnect to the downstream %s after %s", addr, err.Error())
+}
+
+// failoverConn is a connection made by the failoverConnector, it's discarded by
+// the pool once the primary is switched.
+type failoverConn struct {
+	driver.Conn
+	connector  *failoverConnector
+	generation int64
+}
+
+func (c *failoverConn) stale() bool {
+	return c.generation != c.connector.generation.Load()
+}
+
+func (c *failoverConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if conn, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return conn.BeginTx(ctx, opts)
+	}
+	//nolint:staticcheck
+	return c.Conn.Begin()
+}
+
+func (c *failoverConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if conn, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return conn.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *failoverConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if conn, ok := c.Conn.(driver.ExecerContext); ok {
+		return conn.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *failoverConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if conn, ok := c.Conn.(driver.QueryerContext); ok {
+		return conn.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *failoverConn) Ping(ctx context.Context) error {
+	if conn, ok := c.Conn.(driver.Pinger); ok {
+		return conn.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *failoverConn) CheckNamedValue(value *driver.NamedValue) error {
+	if conn, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return conn.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+func (c *failoverConn) ResetSession(ctx context.Context) error {
+	if c.stale() {
+		return driver.ErrBadConn
+	}
+	if conn, ok := c.Conn.(driver.SessionResetter); ok {
+		return conn.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *failoverConn) IsValid() bool {
+	if c.stale() {
+		return false
+	}
+	if conn, ok := c.Conn.(driver.Validator); ok {
+		return conn.IsValid()
+	}
+	return true
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+const checkWritableSQL = "SHOW GLOBAL VARIABLES WHERE Variable_name IN ('read_only', 'super_read_only', 'tidb_super_read_only')"
+
+func TestParseEndpoints(t *testing.T) {
+	for uri, expected := range map[string][]string{
+		"mysql://root@127.0.0.1:3306/":         {"127.0.0.1:3306"},
+		"mysql://root@127.0.0.1/":              {"127.0.0.1:4000"},
+		"mysql://root@[::1]:3306/":             {"[::1]:3306"},
+		"mysql://root@h1:3306,h2,h3:3307/?a=b": {"h1:3306", "h2:4000", "h3:3307"},
+	} {
+		sinkURI, err := url.Parse(uri)
+		require.NoError(t, err)
+		require.Equal(t, expected, parseEndpoints(sinkURI), uri)
+	}
+}
+
+func TestResolveEndpoints(t *testing.T) {
+	defer func(fn func(context.Context, string, string, string) (string, []*net.SRV, error)) {
+		lookupSRV = fn
+	}(lookupSRV)
+
+	cfg := New()
+	cfg.Endpoints = []string{"h1:3306"}
+	require.False(t, cfg.failoverEnabled())
+	endpoints, err := cfg.resolveEndpoints(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"h1:3306"}, endpoints)
+
+	cfg.SRVName = "_mysql._tcp.example.com"
+	require.True(t, cfg.failoverEnabled())
+	lookupSRV = func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		require.Equal(t, "_mysql._tcp.example.com", name)
+		return "", []*net.SRV{{Target: "db1.example.com.", Port: 3306}, {Target: "db2.example.com.", Port: 3307}}, nil
+	}
+	endpoints, err = cfg.resolveEndpoints(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"db1.example.com:3306", "db2.example.com:3307"}, endpoints)
+
+	lookupSRV = func(context.Context, string, string, string) (string, []*net.SRV, error) {
+		return "", nil, nil
+	}
+	_, err = cfg.resolveEndpoints(context.Background())
+	require.Error(t, err)
+}
+
+// mockCheckWritable replaces the test db to report whether each downstream is read-only,
+// the downstreams not in the map are unreachable.
+func mockCheckWritable(t *testing.T, readOnly map[string]string) {
+	getTestDB = func(dsn *dmysql.Config) (*sql.DB, error) {
+		value, ok := readOnly[dsn.Addr]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		mock.ExpectQuery(checkWritableSQL).WillReturnRows(
+			sqlmock.NewRows([]string{"Variable_name", "Value"}).
+				AddRow("read_only", value).
+				AddRow("super_read_only", "OFF"))
+		mock.ExpectClose()
+		return db, nil
+	}
+}
+
+func TestSelectPrimary(t *testing.T) {
+	defer func(fn func(*dmysql.Config) (*sql.DB, error)) {
+		getTestDB = fn
+	}(getTestDB)
+
+	readOnly := map[string]string{"h1:3306": "ON", "h3:3306": "OFF"}
+	mockCheckWritable(t, readOnly)
+
+	cfg := New()
+	cfg.Endpoints = []string{"h1:3306", "h2:3306", "h3:3306"}
+	dsn, err := dmysql.ParseDSN("root:123456@tcp(h1:3306)/")
+	require.NoError(t, err)
+	require.NoError(t, selectPrimary(context.Background(), cfg, dsn))
+	require.Equal(t, "h3:3306", dsn.Addr)
+
+	readOnly["h3:3306"] = "ON"
+	require.Error(t, selectPrimary(context.Background(), cfg, dsn))
+}
+
+func TestIsReadOnlyError(t *testing.T) {
+	err := &dmysql.MySQLError{Number: mysql.ErrOptionPreventsStatement}
+	require.True(t, isReadOnlyError(err))
+	require.False(t, isRetryableDMLError(err))
+	require.False(t, isRetryableDDLError(err))
+	require.True(t, isReadOnlyError(&dmysql.MySQLError{Number: mysql.ErrReadOnlyMode}))
+	require.False(t, isReadOnlyError(&dmysql.MySQLError{Number: mysql.ErrDupEntry}))
+	require.False(t, isReadOnlyError(dmysql.ErrInvalidConn))
+}
+
+type mockConnector struct {
+	drv driver.Driver
+	dsn string
+}
+
+func (c *mockConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.drv.Open(c.dsn)
+}
+
+func (c *mockConnector) Driver() driver.Driver {
+	return c.drv
+}
+
+func TestMysqlWriterReconnectAfterReadOnly(t *testing.T) {
+	defer func(fn func(*dmysql.Config) (*sql.DB, error)) {
+		getTestDB = fn
+	}(getTestDB)
+	defer func(fn func(*dmysql.Config) (driver.Connector, error)) {
+		newConnector = fn
+	}(newConnector)
+
+	// each downstream is a mock db whose dsn is the address.
+	mocks := make(map[string]sqlmock.Sqlmock)
+	for _, addr := range []string{"h1:3306", "h2:3306"} {
+		db, mock, err := sqlmock.NewWithDSN(addr, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+		mocks[addr] = mock
+		newConnector = func(dsn *dmysql.Config) (driver.Connector, error) {
+			return &mockConnector{drv: db.Driver(), dsn: dsn.Addr}, nil
+		}
+	}
+	readOnly := map[string]string{"h1:3306": "OFF", "h2:3306": "OFF"}
+	mockCheckWritable(t, readOnly)
+
+	writer, mockDB, _ := newTestMysqlWriter(t)
+	defer mockDB.Close()
+	writer.cfg.CachePrepStmts = false
+	writer.cfg.Endpoints = []string{"h1:3306", "h2:3306"}
+	db, err := writer.cfg.openFailoverDB("root@tcp(h1:3306)/")
+	require.NoError(t, err)
+	defer db.Close()
+	writer.db = db
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	insertSQL := "INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)"
+
+	// h1 becomes read-only, the writer switches to h2 and retries the dmls on it,
+	// and the connection to h1 is discarded.
+	readOnly["h1:3306"] = "ON"
+	mocks["h1:3306"].ExpectExec("BEGIN;"+insertSQL+";COMMIT;").WithArgs(1, "a").
+		WillReturnError(&dmysql.MySQLError{Number: mysql.ErrOptionPreventsStatement, Message: "read-only"})
+	mocks["h1:3306"].ExpectClose()
+	// the dmls are executed in the sequence way after the multi statements fail.
+	mocks["h2:3306"].ExpectBegin()
+	mocks["h2:3306"].ExpectExec(insertSQL).WithArgs(1, "a").WillReturnResult(sqlmock.NewResult(1, 1))
+	mocks["h2:3306"].ExpectCommit()
+	event := helper.DML2Event("test", "t", "insert into t values (1, 'a')")
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.Equal(t, "h2:3306", writer.cfg.failover.addr)
+
+	// the dispatchers resume from the ddl-ts table on the new primary.
+	mocks["h2:3306"].ExpectQuery("SELECT table_id, ddl_ts, finished, is_syncpoint FROM tidb_cdc.ddl_ts_v1 " +
+		"WHERE (ticdc_cluster_id, changefeed, table_id) IN (('default', 'test/test', 1))").
+		WillReturnRows(sqlmock.NewRows([]string{"table_id", "ddl_ts", "finished", "is_syncpoint"}).
+			AddRow(1, 100, true, false))
+	startTsList, _, _, err := writer.GetTableRecoveryInfo([]int64{1})
+	require.NoError(t, err)
+	require.Equal(t, []int64{100}, startTsList)
+	for _, mock := range mocks {
+		require.NoError(t, mock.ExpectationsWereMet())
+	}
+
+	// the error is returned if no writable downstream is found.
+	readOnly["h2:3306"] = "ON"
+	err = &dmysql.MySQLError{Number: mysql.ErrReadOnlyMode, Message: "read-only"}
+	require.Equal(t, err, writer.cfg.reconnectIfReadOnly(context.Background(), err))
+	readOnly["h2:3306"] = "OFF"
+	require.True(t, isRetryableDDLError(writer.cfg.reconnectIfReadOnly(context.Background(), err)))
+}
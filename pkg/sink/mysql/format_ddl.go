@@ -15,12 +15,17 @@ package mysql
 
 import (
 	"bytes"
+	"strings"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
 	"go.uber.org/zap"
 )
 
@@ -62,3 +67,230 @@ func formatQuery(sql string) string {
 	}
 	return buf.String()
 }
+
+// routeVisitor rewrites the schema and table names in the ddl by the router,
+// the table without schema is considered to be in the current schema.
+type routeVisitor struct {
+	router        *router
+	currentSchema string
+	changed       bool
+}
+
+func (v *routeVisitor) Enter(n ast.Node) (node ast.Node, skipChildren bool) {
+	switch t := n.(type) {
+	case *ast.TableName:
+		schema := t.Schema.O
+		if schema == "" {
+			schema = v.currentSchema
+		}
+		targetSchema, targetTable := v.router.route(schema, t.Name.O)
+		if targetSchema != schema || targetTable != t.Name.O {
+			v.changed = true
+		}
+		// always qualify the table since the current schema may be routed to another one.
+		t.Schema = ast.NewCIStr(targetSchema)
+		t.Name = ast.NewCIStr(targetTable)
+	case *ast.CreateDatabaseStmt:
+		t.Name = v.routeSchema(t.Name)
+	case *ast.AlterDatabaseStmt:
+		t.Name = v.routeSchema(t.Name)
+	case *ast.DropDatabaseStmt:
+		t.Name = v.routeSchema(t.Name)
+	}
+	return n, false
+}
+
+func (v *routeVisitor) routeSchema(name ast.CIStr) ast.CIStr {
+	if name.O == "" {
+		return name
+	}
+	target := v.router.routeSchema(name.O)
+	if target == name.O {
+		return name
+	}
+	v.changed = true
+	return ast.NewCIStr(target)
+}
+
+func (v *routeVisitor) Leave(n ast.Node) (node ast.Node, ok bool) {
+	return n, true
+}
+
+// routeQuery rewrites the schema and table names in the ddl query by the router,
+// the query is returned as it is if no name is changed.
+func routeQuery(sql string, currentSchema string, r *router) (string, error) {
+	return rewriteQueries(sql, func(query string) (string, error) {
+		return routeOneQuery(query, currentSchema, r)
+	})
+}
+
+func routeOneQuery(sql string, currentSchema string, r *router) (string, error) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt(sql, "", "")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	v := &routeVisitor{router: r, currentSchema: currentSchema}
+	stmt.Accept(v)
+	if !v.changed {
+		return sql, nil
+	}
+
+	buf := new(bytes.Buffer)
+	restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, buf)
+	if err = stmt.Restore(restoreCtx); err != nil {
+		return "", errors.Trace(err)
+	}
+	return buf.String(), nil
+}
+
+// metadataColumnsVisitor appends the metadata columns to the created table. In the append-only
+// mode, the primary key and unique keys are turned into normal indexes since one row may be
+// written many times.
+type metadataColumnsVisitor struct {
+	columns    []*model.ColumnInfo
+	appendOnly bool
+	changed    bool
+}
+
+func (v *metadataColumnsVisitor) Enter(n ast.Node) (node ast.Node, skipChildren bool) {
+	switch t := n.(type) {
+	case *ast.CreateTableStmt:
+		// the table created by `CREATE TABLE ... LIKE` already has the metadata columns.
+		if t.ReferTable != nil {
+			return n, true
+		}
+		if v.appendOnly {
+			for _, col := range t.Cols {
+				if v.removeKeyOptions(col) {
+					t.Constraints = append(t.Constraints, &ast.Constraint{
+						Tp:   ast.ConstraintIndex,
+						Keys: []*ast.IndexPartSpecification{{Column: col.Name, Length: types.UnspecifiedLength}},
+					})
+				}
+			}
+			for _, constraint := range t.Constraints {
+				v.toNormalIndex(constraint)
+			}
+		}
+		for _, col := range v.columns {
+			t.Cols = append(t.Cols, &ast.ColumnDef{
+				Name: &ast.ColumnName{Name: col.Name},
+				Tp:   col.FieldType.Clone(),
+			})
+			v.changed = true
+		}
+	case *ast.AlterTableStmt:
+		if !v.appendOnly {
+			break
+		}
+		for _, spec := range t.Specs {
+			if spec.Constraint != nil {
+				v.toNormalIndex(spec.Constraint)
+			}
+			for _, col := range spec.NewColumns {
+				v.removeKeyOptions(col)
+			}
+		}
+	case *ast.CreateIndexStmt:
+		if v.appendOnly && t.KeyType == ast.IndexKeyTypeUnique {
+			t.KeyType = ast.IndexKeyTypeNone
+			v.changed = true
+		}
+	}
+	return n, false
+}
+
+// removeKeyOptions removes the primary key and unique key options of the column,
+// it returns true if any option is removed.
+func (v *metadataColumnsVisitor) removeKeyOptions(col *ast.ColumnDef) bool {
+	options := col.Options[:0]
+	removed := false
+	for _, option := range col.Options {
+		if option.Tp == ast.ColumnOptionPrimaryKey || option.Tp == ast.ColumnOptionUniqKey {
+			removed = true
+			continue
+		}
+		options = append(options, option)
+	}
+	col.Options = options
+	v.changed = v.changed || removed
+	return removed
+}
+
+func (v *metadataColumnsVisitor) toNormalIndex(constraint *ast.Constraint) {
+	switch constraint.Tp {
+	case ast.ConstraintPrimaryKey, ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+		constraint.Tp = ast.ConstraintIndex
+		if constraint.Option != nil {
+			constraint.Option.PrimaryKeyTp = ast.PrimaryKeyTypeDefault
+			constraint.Option.Global = false
+		}
+		v.changed = true
+	}
+}
+
+func (v *metadataColumnsVisitor) Leave(n ast.Node) (node ast.Node, ok bool) {
+	return n, true
+}
+
+// addMetadataColumns adds the metadata columns to the ddl query, and turns the primary key
+// and unique keys into normal indexes in the append-only mode. The query is returned as it
+// is if nothing is changed.
+func addMetadataColumns(sql string, columns []*model.ColumnInfo, appendOnly bool) (string, error) {
+	return rewriteQueries(sql, func(query string) (string, error) {
+		return addMetadataColumnsToOneQuery(query, columns, appendOnly)
+	})
+}
+
+func addMetadataColumnsToOneQuery(sql string, columns []*model.ColumnInfo, appendOnly bool) (string, error) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt(sql, "", "")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	v := &metadataColumnsVisitor{columns: columns, appendOnly: appendOnly}
+	stmt.Accept(v)
+	if !v.changed {
+		return sql, nil
+	}
+
+	buf := new(bytes.Buffer)
+	restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, buf)
+	if err = stmt.Restore(restoreCtx); err != nil {
+		return "", errors.Trace(err)
+	}
+	return buf.String(), nil
+}
+
+// rewriteQueries applies the rewrite to each statement of the ddl query, since the query of
+// `CREATE TABLES` contains several statements. The query is returned as it is if nothing is changed.
+func rewriteQueries(sql string, rewrite func(string) (string, error)) (string, error) {
+	queries, err := commonEvent.SplitQueries(sql)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(queries) <= 1 {
+		return rewrite(sql)
+	}
+	var (
+		buf     strings.Builder
+		changed bool
+	)
+	for _, query := range queries {
+		newQuery, err := rewrite(query)
+		if err != nil {
+			return "", err
+		}
+		// the split query ends with a semicolon, but the restored one doesn't.
+		if newQuery != query {
+			changed = true
+			newQuery += ";"
+		}
+		buf.WriteString(newQuery)
+	}
+	if !changed {
+		return sql, nil
+	}
+	return buf.String(), nil
+}
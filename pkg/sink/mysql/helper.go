@@ -18,7 +18,6 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
-	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -112,16 +111,11 @@ func GenBasicDSN(cfg *Config) (*dmysql.Config, error) {
 	}
 	password, _ := cfg.sinkURI.User.Password()
 
-	hostName := cfg.sinkURI.Hostname()
-	port := cfg.sinkURI.Port()
-	if port == "" {
-		port = "4000"
-	}
-
-	// This will handle the IPv6 address format.
+	// the first candidate is used by default, it's replaced by the writable one
+	// if the failover is enabled.
 	var dsn *dmysql.Config
 	var err error
-	host := net.JoinHostPort(hostName, port)
+	host := parseEndpoints(cfg.sinkURI)[0]
 	dsnStr := fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, host, cfg.TLS)
 	if dsn, err = dmysql.ParseDSN(dsnStr); err != nil {
 		return nil, errors.Trace(err)
@@ -317,6 +311,11 @@ func GenerateDSN(ctx context.Context, cfg *Config) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if cfg.failoverEnabled() {
+		if err = selectPrimary(ctx, cfg, dsn); err != nil {
+			return "", err
+		}
+	}
 
 	var testDB *sql.DB
 	testDB, err = GetTestDB(dsn)
@@ -431,6 +430,15 @@ func shouldFormatVectorType(cfg *Config) bool {
 	return false
 }
 
+// supportRowAlias returns true if the downstream supports the row alias in
+// INSERT ... ON DUPLICATE KEY UPDATE, which is only supported by MySQL.
+func supportRowAlias(cfg *Config) bool {
+	if cfg.ServerInfo.ServerType != version.ServerTypeMySQL || cfg.ServerInfo.ServerVersion == nil {
+		return false
+	}
+	return !cfg.ServerInfo.ServerVersion.LessThan(*semver.New(defaultSupportRowAliasMySQLVersion))
+}
+
 // getCheckRunningAddIndexSQL return different sql according to tidb version
 func getCheckRunningAddIndexSQL(cfg *Config) string {
 	ver := semver.New(defaultRunningAddIndexNewSQLVersion)
@@ -443,7 +451,7 @@ func getCheckRunningAddIndexSQL(cfg *Config) string {
 }
 
 func isRetryableDMLError(err error) bool {
-	if !cerror.IsRetryableError(err) {
+	if !cerror.IsRetryableError(err) || isReadOnlyError(err) {
 		return false
 	}
 
@@ -460,6 +468,10 @@ func isRetryableDMLError(err error) bool {
 	return true
 }
 
+func isRetryableDDLError(err error) bool {
+	return !isReadOnlyError(err) && cerror.IsRetryableDDLError(err)
+}
+
 func getSQLErrCode(err error) (errors.ErrCode, bool) {
 	mysqlErr, ok := errors.Cause(err).(*dmysql.MySQLError)
 	if !ok {
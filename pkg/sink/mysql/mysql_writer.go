@@ -27,6 +27,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"go.uber.org/zap"
 )
 
@@ -40,6 +41,10 @@ const (
 
 	defaultRunningAddIndexNewSQLVersion = "8.5.0"
 
+	// defaultSupportRowAliasMySQLVersion is the version of MySQL which supports the row alias
+	// in INSERT ... ON DUPLICATE KEY UPDATE.
+	defaultSupportRowAliasMySQLVersion = "8.0.19"
+
 	defaultErrorCausedSafeModeDuration = 5 * time.Second
 )
 
@@ -63,6 +68,21 @@ type Writer struct {
 
 	statistics *metrics.Statistics
 
+	// deadLetterTableInit and deadLetterStorage are initialized when the first
+	// dead-letter row is written, they are only used by the `dead-letter` error policy.
+	deadLetterTableInit bool
+	deadLetterStorage   storage.ExternalStorage
+
+	// conflictLogTableInit is only used when the conflict policy is set in BDR mode.
+	conflictLogTableInit bool
+
+	// inCatchUpMode is true if the lag exceeds the catch-up threshold, the rows are
+	// coalesced and written in large batches in the catch-up mode.
+	inCatchUpMode bool
+
+	// flushStats is the feedback of the downstream used by the adaptive concurrency.
+	flushStats flushStats
+
 	// When encountered an `Duplicate entry` error, we will set the `isInErrorCausedSafeMode` to true,
 	// and set the `lastErrorCausedSafeModeTime` to the current time.
 	// After the `errorCausedSafeModeDuration`, we will set the `isInErrorCausedSafeMode` to false.
@@ -138,6 +158,7 @@ func (w *Writer) FlushDDLEvent(event *commonEvent.DDLEvent) error {
 			return err
 		}
 	}
+	w.cfg.router.forgetTables(event)
 	return nil
 }
 
@@ -181,8 +202,28 @@ func (w *Writer) FlushSyncPointEvent(event *commonEvent.SyncPointEvent) error {
 
 func (w *Writer) Flush(events []*commonEvent.DMLEvent) error {
 	w.updateIsInErrorCausedSafeMode()
+	for _, event := range events {
+		w.cfg.metadataColumns.Apply(event)
+	}
+
+	if w.cfg.ConflictPolicy != "" && !w.cfg.DryRun {
+		if err := w.flushWithConflictResolution(events); err != nil {
+			return errors.Trace(err)
+		}
+		for _, event := range events {
+			event.PostFlush()
+		}
+		return nil
+	}
 
-	dmls, err := w.prepareDMLs(events)
+	// the dmls are prepared again by the same way when retrying them in the safe mode.
+	prepare := w.prepareDMLs
+	if w.inCatchUpMode {
+		prepare = func(events []*commonEvent.DMLEvent) (*preparedDMLs, error) {
+			return w.prepareCatchUpDMLs(events), nil
+		}
+	}
+	dmls, err := prepare(events)
 	defer dmlsPool.Put(dmls) // Return dmls to pool after use
 	if err != nil {
 		return errors.Trace(err)
@@ -192,20 +233,28 @@ func (w *Writer) Flush(events []*commonEvent.DMLEvent) error {
 	}
 
 	if !w.cfg.DryRun {
+		start := time.Now()
 		err = w.execDMLWithMaxRetries(dmls)
+		w.flushStats.observe(time.Since(start))
 		// If the error is a duplicate entry error, we will retry the dmls.
 		if w.checkIsDuplicateEntryError(err) {
 			log.Info("Meet Duplicate Entry Error, retry the dmls in safemode", zap.Error(err))
 			for _, event := range events {
 				event.Rewind()
 			}
-			dmls, err = w.prepareDMLs(events)
+			dmls, err = prepare(events)
 			if err != nil {
 				return errors.Trace(err)
 			}
 			err = w.execDMLWithMaxRetries(dmls)
 		}
-
+		// If the batch fails due to the data of some rows, write them to the dead-letter
+		// destination, so that the other rows can be written to the downstream.
+		if err != nil && w.cfg.ErrorPolicy == errorPolicyDeadLetter && isDeadLetterError(err) {
+			log.Warn("Meet row-level error, isolate the failed rows by the dead-letter error policy",
+				zap.String("changefeed", w.ChangefeedID.String()), zap.Int("writerID", w.id), zap.Error(err))
+			err = w.flushWithDeadLetter(events, err)
+		}
 	} else {
 		w.tryDryRunBlock()
 		err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
@@ -268,4 +317,7 @@ func (w *Writer) Close() {
 	if w.blockerTicker != nil {
 		w.blockerTicker.Stop()
 	}
+	if w.deadLetterStorage != nil {
+		w.deadLetterStorage.Close()
+	}
 }
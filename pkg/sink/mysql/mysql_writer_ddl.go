@@ -30,7 +30,9 @@ import (
 	"go.uber.org/zap"
 )
 
-func (w *Writer) execDDL(event *commonEvent.DDLEvent) error {
+// execDDL executes the query routed from the ddl event, and switches to the routed schema
+// before executing it if needed.
+func (w *Writer) execDDL(event *commonEvent.DDLEvent, query, schemaName string) error {
 	if w.cfg.DryRun {
 		log.Info("Dry run DDL", zap.String("sql", event.GetDDLQuery()))
 		// use RecordDDLExecution to record the metrics of execute ddl
@@ -58,14 +60,6 @@ func (w *Writer) execDDL(event *commonEvent.DDLEvent) error {
 	ctx := w.ctx
 	shouldSwitchDB := needSwitchDB(event)
 
-	// Convert vector type to string type for unsupport database
-	if w.cfg.HasVectorType {
-		if newQuery := formatQuery(event.Query); newQuery != event.Query {
-			log.Info("format ddl query", zap.String("newQuery", newQuery), zap.String("query", event.Query))
-			event.Query = newQuery
-		}
-	}
-
 	failpoint.Inject("MySQLSinkExecDDLDelay", func() {
 		select {
 		case <-ctx.Done():
@@ -81,7 +75,7 @@ func (w *Writer) execDDL(event *commonEvent.DDLEvent) error {
 	}
 
 	if shouldSwitchDB {
-		_, err = tx.ExecContext(ctx, "USE "+common.QuoteName(event.GetSchemaName())+";")
+		_, err = tx.ExecContext(ctx, "USE "+common.QuoteName(schemaName)+";")
 		if err != nil {
 			if rbErr := tx.Rollback(); rbErr != nil {
 				log.Error("Failed to rollback", zap.Error(err))
@@ -90,18 +84,17 @@ func (w *Writer) execDDL(event *commonEvent.DDLEvent) error {
 		}
 	}
 
-	query := event.GetDDLQuery()
 	_, err = tx.ExecContext(ctx, query)
 	if err != nil {
 		log.Error("Fail to ExecContext", zap.Any("err", err), zap.Any("query", query))
 		if rbErr := tx.Rollback(); rbErr != nil {
-			log.Error("Failed to rollback", zap.String("sql", event.GetDDLQuery()), zap.Error(err))
+			log.Error("Failed to rollback", zap.String("sql", query), zap.Error(err))
 		}
 		return err
 	}
 
 	if err = tx.Commit(); err != nil {
-		return errors.WrapError(errors.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("Query info: %s; ", event.GetDDLQuery())))
+		return errors.WrapError(errors.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("Query info: %s; ", query)))
 	}
 
 	return nil
@@ -112,10 +105,41 @@ func (w *Writer) execDDL(event *commonEvent.DDLEvent) error {
 // If the downstream is TiDB, it will query the DDL and wait until it finishes.
 // For 'add index' ddl, it will return immediately without waiting and will query it during the next DDL execution.
 func (w *Writer) execDDLWithMaxRetries(event *commonEvent.DDLEvent) error {
+	// the routed table may be shared by several upstream tables, dropping or truncating it
+	// for one upstream table would wipe the data of the others.
+	if w.isDestructiveDDLOnSharedTarget(event) {
+		switch w.cfg.SharedTargetDDLPolicy {
+		case sharedTargetDDLPolicySkip:
+			log.Warn("Skip the DDL which drops or truncates the table shared by several upstream tables",
+				zap.String("changefeed", w.ChangefeedID.String()),
+				zap.Uint64("commitTs", event.GetCommitTs()), zap.String("sql", event.GetDDLQuery()))
+			return nil
+		case sharedTargetDDLPolicyFail:
+			return errors.ErrExecDDLFailed.GenWithStack(
+				"the DDL drops or truncates the table shared by several upstream tables, "+
+					"set shared-target-ddl-policy to skip or execute it; Query info: %s", event.GetDDLQuery())
+		}
+	}
+
+	// Convert vector type to string type for unsupport database
+	if w.cfg.HasVectorType {
+		if newQuery := formatQuery(event.Query); newQuery != event.Query {
+			log.Info("format ddl query", zap.String("newQuery", newQuery), zap.String("query", event.Query))
+			event.Query = newQuery
+		}
+	}
+
+	// the query is routed once, and both the execution and the asynchronous ddl checking use it.
+	query, schemaName, err := w.routeDDL(event)
+	if err != nil {
+		return err
+	}
+
 	ddlCreateTime := getDDLCreateTime(w.ctx, w.db)
 	return retry.Do(w.ctx, func() error {
-		err := w.statistics.RecordDDLExecution(func() error { return w.execDDL(event) })
+		err := w.statistics.RecordDDLExecution(func() error { return w.execDDL(event, query, schemaName) })
 		if err != nil {
+			err = w.cfg.reconnectIfReadOnly(w.ctx, err)
 			if errors.IsIgnorableMySQLDDLError(err) {
 				// NOTE: don't change the log, some tests depend on it.
 				log.Info("Execute DDL failed, but error can be ignored",
@@ -127,7 +151,7 @@ func (w *Writer) execDDLWithMaxRetries(event *commonEvent.DDLEvent) error {
 			if w.cfg.IsTiDB && ddlCreateTime != "" && errors.Cause(err) == mysql.ErrInvalidConn {
 				log.Warn("Wait the asynchronous ddl to synchronize", zap.String("ddl", event.Query), zap.String("ddlCreateTime", ddlCreateTime),
 					zap.String("readTimeout", w.cfg.ReadTimeout), zap.Error(err))
-				return w.waitDDLDone(w.ctx, event, ddlCreateTime)
+				return w.waitDDLDone(w.ctx, event, query, ddlCreateTime)
 			}
 			log.Warn("Execute DDL with error, retry later",
 				zap.String("ddl", event.Query),
@@ -142,17 +166,17 @@ func (w *Writer) execDDLWithMaxRetries(event *commonEvent.DDLEvent) error {
 	}, retry.WithBackoffBaseDelay(BackoffBaseDelay.Milliseconds()),
 		retry.WithBackoffMaxDelay(BackoffMaxDelay.Milliseconds()),
 		retry.WithMaxTries(defaultDDLMaxRetry),
-		retry.WithIsRetryableErr(errors.IsRetryableDDLError))
+		retry.WithIsRetryableErr(isRetryableDDLError))
 }
 
-// waitDDLDone wait current ddl
-func (w *Writer) waitDDLDone(ctx context.Context, ddl *commonEvent.DDLEvent, ddlCreateTime string) error {
+// waitDDLDone wait current ddl, the query is the routed one executed downstream.
+func (w *Writer) waitDDLDone(ctx context.Context, ddl *commonEvent.DDLEvent, query string, ddlCreateTime string) error {
 	ticker := time.NewTicker(5 * time.Second)
 	ticker1 := time.NewTicker(10 * time.Minute)
 	defer ticker.Stop()
 	defer ticker1.Stop()
 	for {
-		state, err := getDDLStateFromTiDB(ctx, w.db, ddl.Query, ddlCreateTime)
+		state, err := getDDLStateFromTiDB(ctx, w.db, query, ddlCreateTime)
 		if err != nil {
 			log.Error("Error when getting DDL state from TiDB", zap.Error(err))
 		}
@@ -199,7 +223,8 @@ func (w *Writer) waitAsyncDDLDone(event *commonEvent.DDLEvent) {
 	for _, blockedTable := range event.GetBlockedTableNames() {
 		// query the downstream,
 		// if the ddl is still running, we should wait for it.
-		err := w.checkAndWaitAsyncDDLDoneDownstream(blockedTable.SchemaName, blockedTable.TableName)
+		schemaName, tableName := w.cfg.router.route(blockedTable.SchemaName, blockedTable.TableName)
+		err := w.checkAndWaitAsyncDDLDoneDownstream(schemaName, tableName)
 		if err != nil {
 			log.Error("check previous asynchronous ddl failed",
 				zap.String("keyspace", w.ChangefeedID.Keyspace()),
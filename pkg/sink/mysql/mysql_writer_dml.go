@@ -253,7 +253,7 @@ func (w *Writer) generateBatchSQL(events []*commonEvent.DMLEvent) ([]string, [][
 }
 
 func (w *Writer) generateSQLForSingleEvent(event *commonEvent.DMLEvent, inDataSafeMode bool) ([]string, [][]interface{}) {
-	tableInfo := event.TableInfo
+	tableInfo := w.routeTableInfo(event.TableInfo)
 	rowLists := make([]*commonEvent.RowChange, 0, event.Len())
 	for {
 		row, ok := event.GetNextRow()
@@ -284,7 +284,7 @@ func (w *Writer) generateBatchSQLsPerEvent(events []*commonEvent.DMLEvent) ([]st
 }
 
 func (w *Writer) generateBatchSQLInUnSafeMode(events []*commonEvent.DMLEvent) ([]string, [][]interface{}) {
-	tableInfo := events[0].TableInfo
+	tableInfo := w.routeTableInfo(events[0].TableInfo)
 	type RowChangeWithKeys struct {
 		RowChange  *commonEvent.RowChange
 		RowKeys    []byte
@@ -457,7 +457,7 @@ func (w *Writer) generateBatchSQLInUnSafeMode(events []*commonEvent.DMLEvent) ([
 }
 
 func (w *Writer) generateBatchSQLInSafeMode(events []*commonEvent.DMLEvent) ([]string, [][]interface{}) {
-	tableInfo := events[0].TableInfo
+	tableInfo := w.routeTableInfo(events[0].TableInfo)
 
 	// step 1. divide update row to delete row and insert row, and set into map based on the key hash
 	rowsMap := make(map[uint64][]*commonEvent.RowChange)
@@ -568,6 +568,7 @@ func (w *Writer) generateNormalSQLs(events []*commonEvent.DMLEvent) ([]string, [
 }
 
 func (w *Writer) generateNormalSQL(event *commonEvent.DMLEvent) ([]string, [][]interface{}) {
+	tableInfo := w.routeTableInfo(event.TableInfo)
 	inSafeMode := w.cfg.SafeMode || w.isInErrorCausedSafeMode || event.CommitTs < event.ReplicatingTs
 
 	log.Debug("inSafeMode",
@@ -588,34 +589,44 @@ func (w *Writer) generateNormalSQL(event *commonEvent.DMLEvent) ([]string, [][]i
 		if !ok {
 			break
 		}
-		var (
-			query string
-			args  []interface{}
-		)
-		switch row.RowType {
-		case common.RowTypeUpdate:
-			// For MySQL Sink, in safe mode, all update events will be split into inserts and deletes.
-			// TODO(Should we still split all update events in safe mode? we already split the events which update uk in event broker)
-			if inSafeMode {
-				query, args = buildDelete(event.TableInfo, row)
-				if query != "" {
-					queries = append(queries, query)
-					argsList = append(argsList, args)
-				}
-				query, args = buildInsert(event.TableInfo, row, inSafeMode)
-			} else {
-				query, args = buildUpdate(event.TableInfo, row)
+		rowQueries, rowArgs := generateRowSQL(tableInfo, row, inSafeMode)
+		queries = append(queries, rowQueries...)
+		argsList = append(argsList, rowArgs...)
+	}
+	return queries, argsList
+}
+
+// generateRowSQL generates the statements of a single row.
+func generateRowSQL(tableInfo *common.TableInfo, row commonEvent.RowChange, inSafeMode bool) ([]string, [][]interface{}) {
+	var (
+		queries  []string
+		argsList [][]interface{}
+		query    string
+		args     []interface{}
+	)
+	switch row.RowType {
+	case common.RowTypeUpdate:
+		// For MySQL Sink, in safe mode, all update events will be split into inserts and deletes.
+		// TODO(Should we still split all update events in safe mode? we already split the events which update uk in event broker)
+		if inSafeMode {
+			query, args = buildDelete(tableInfo, row)
+			if query != "" {
+				queries = append(queries, query)
+				argsList = append(argsList, args)
 			}
-		case common.RowTypeDelete:
-			query, args = buildDelete(event.TableInfo, row)
-		case common.RowTypeInsert:
-			query, args = buildInsert(event.TableInfo, row, inSafeMode)
+			query, args = buildInsert(tableInfo, row, inSafeMode)
+		} else {
+			query, args = buildUpdate(tableInfo, row)
 		}
+	case common.RowTypeDelete:
+		query, args = buildDelete(tableInfo, row)
+	case common.RowTypeInsert:
+		query, args = buildInsert(tableInfo, row, inSafeMode)
+	}
 
-		if query != "" {
-			queries = append(queries, query)
-			argsList = append(argsList, args)
-		}
+	if query != "" {
+		queries = append(queries, query)
+		argsList = append(argsList, args)
 	}
 	return queries, argsList
 }
@@ -636,6 +647,7 @@ func (w *Writer) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 		start := time.Now()
 		defer func() {
 			if time.Since(start) > w.cfg.SlowQuery {
+				w.flushStats.slowQueries.Inc()
 				log.Info("Slow Query", zap.Any("sql", dmls.LogWithoutValues()), zap.Any("writerID", w.id))
 			}
 		}()
@@ -688,6 +700,8 @@ func (w *Writer) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 
 		err := w.statistics.RecordBatchExecution(tryExec)
 		if err != nil {
+			w.flushStats.errors.Inc()
+			err = w.cfg.reconnectIfReadOnly(w.ctx, err)
 			w.logDMLTxnErr(err, time.Now(), w.ChangefeedID.String(), dmls)
 			return errors.Trace(err)
 		}
@@ -0,0 +1,307 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	tableFilter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"go.uber.org/zap"
+)
+
+const (
+	schemaPlaceholder = "{schema}"
+	tablePlaceholder  = "{table}"
+
+	// sharedTargetDDLPolicySkip skips the ddl which drops or truncates a shared target and logs a warning.
+	sharedTargetDDLPolicySkip = "skip"
+	// sharedTargetDDLPolicyExecute executes the ddl which drops or truncates a shared target.
+	sharedTargetDDLPolicyExecute = "execute"
+	// sharedTargetDDLPolicyFail fails the changefeed when a ddl drops or truncates a shared target.
+	sharedTargetDDLPolicyFail = "fail"
+)
+
+// placeholderRE matches the placeholders in the routing target.
+var placeholderRE = regexp.MustCompile(`\{[^{}]*\}`)
+
+type routingRule struct {
+	filter       tableFilter.Filter
+	targetSchema string
+	targetTable  string
+	// shared is true if the rule may route several upstream tables to the same downstream table,
+	// such as merging the sharded tables `shop_01.orders` ... `shop_32.orders` into one table.
+	shared bool
+}
+
+// router routes the upstream schema and table names to the downstream ones by the routing rules,
+// it's used by both the dml and ddl generation to make them write to the same downstream table.
+// The ddl-ts table and the syncpoint table are keyed by the upstream table ID and written
+// to the TiCDC system schema, so they are never routed.
+//
+// A nil router keeps all the names unchanged.
+type router struct {
+	rules []routingRule
+	// tableInfos caches the routed table info of each table, it's shared by the dml writers
+	// and the ddl writer, which removes the tables dropped, truncated or renamed by the ddls.
+	tableInfos sync.Map // int64 -> routedTableInfo
+}
+
+// newRouter creates a router by the routing rules, it returns nil if there is no rule.
+func newRouter(rules []*config.RoutingRule, caseSensitive bool) (*router, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	r := &router{rules: make([]routingRule, 0, len(rules))}
+	for _, rule := range rules {
+		if len(rule.Matcher) == 0 {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack("routing rule must have a matcher")
+		}
+		f, err := tableFilter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, rule.Matcher)
+		}
+		if !caseSensitive {
+			f = tableFilter.CaseInsensitive(f)
+		}
+		if rule.TargetSchema == "" && rule.TargetTable == "" {
+			return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"routing rule %v must have target-schema or target-table", rule.Matcher)
+		}
+		for _, target := range []string{rule.TargetSchema, rule.TargetTable} {
+			for _, placeholder := range placeholderRE.FindAllString(target, -1) {
+				if placeholder != schemaPlaceholder && placeholder != tablePlaceholder {
+					return nil, cerror.ErrMySQLInvalidConfig.GenWithStack(
+						"unknown placeholder %s in routing rule %v", placeholder, rule.Matcher)
+				}
+			}
+		}
+		r.rules = append(r.rules, routingRule{
+			filter:       f,
+			targetSchema: rule.TargetSchema,
+			targetTable:  rule.TargetTable,
+			shared:       !dependsOnName(rule) && !matchSingleTable(rule.Matcher),
+		})
+	}
+	// the rules which route single tables to the same static target also share the target.
+	staticTargets := make(map[string][]int)
+	for i, rule := range r.rules {
+		if !placeholderRE.MatchString(rule.targetSchema) && !placeholderRE.MatchString(rule.targetTable) {
+			key := rule.targetSchema + "." + rule.targetTable
+			staticTargets[key] = append(staticTargets[key], i)
+		}
+	}
+	for _, indexes := range staticTargets {
+		if len(indexes) > 1 {
+			for _, i := range indexes {
+				r.rules[i].shared = true
+			}
+		}
+	}
+	return r, nil
+}
+
+// dependsOnName returns whether the target of the rule depends on both the upstream schema
+// and table name, so different upstream tables are always routed to different targets.
+func dependsOnName(rule *config.RoutingRule) bool {
+	dependsOnSchema := rule.TargetSchema == "" ||
+		strings.Contains(rule.TargetSchema, schemaPlaceholder) || strings.Contains(rule.TargetTable, schemaPlaceholder)
+	dependsOnTable := rule.TargetTable == "" ||
+		strings.Contains(rule.TargetTable, tablePlaceholder) || strings.Contains(rule.TargetSchema, tablePlaceholder)
+	return dependsOnSchema && dependsOnTable
+}
+
+// matchSingleTable returns whether the matcher only matches one table, such as `db.t`.
+func matchSingleTable(matcher []string) bool {
+	return len(matcher) == 1 && !strings.ContainsAny(matcher[0], "*?[]!@\\")
+}
+
+// route returns the downstream schema and table name of the upstream table,
+// the first matched rule is used.
+func (r *router) route(schema, table string) (string, string) {
+	if r == nil {
+		return schema, table
+	}
+	for _, rule := range r.rules {
+		if !rule.filter.MatchTable(schema, table) {
+			continue
+		}
+		return substitute(rule.targetSchema, schema, table, schema),
+			substitute(rule.targetTable, schema, table, table)
+	}
+	return schema, table
+}
+
+// routeSchema returns the downstream schema name of the upstream schema, it's used by
+// the database level ddl. The first rule which may match the tables in the schema is used,
+// the rules whose target schema depends on the table name are skipped.
+func (r *router) routeSchema(schema string) string {
+	if r == nil {
+		return schema
+	}
+	for _, rule := range r.rules {
+		if strings.Contains(rule.targetSchema, tablePlaceholder) || !rule.filter.MatchSchema(schema) {
+			continue
+		}
+		return substitute(rule.targetSchema, schema, "", schema)
+	}
+	return schema
+}
+
+// isSharedTarget returns whether the upstream table is routed to a downstream table
+// which may be shared with other upstream tables.
+func (r *router) isSharedTarget(schema, table string) bool {
+	if r == nil {
+		return false
+	}
+	for _, rule := range r.rules {
+		if rule.filter.MatchTable(schema, table) {
+			return rule.shared
+		}
+	}
+	return false
+}
+
+func substitute(target, schema, table, origin string) string {
+	if target == "" {
+		return origin
+	}
+	return strings.NewReplacer(schemaPlaceholder, schema, tablePlaceholder, table).Replace(target)
+}
+
+type routedTableInfo struct {
+	source *common.TableInfo
+	target *common.TableInfo
+}
+
+// routeTableInfo returns the table info whose name is routed by the routing rules,
+// the sql builders and the sqlmodel use its name and pre sqls to generate the dmls.
+func (w *Writer) routeTableInfo(tableInfo *common.TableInfo) *common.TableInfo {
+	r := w.cfg.router
+	if r == nil {
+		return tableInfo
+	}
+	tableID := tableInfo.TableName.TableID
+	if cached, ok := r.tableInfos.Load(tableID); ok && cached.(routedTableInfo).source == tableInfo {
+		return cached.(routedTableInfo).target
+	}
+	schema, table := r.route(tableInfo.GetSchemaName(), tableInfo.GetTableName())
+	target := tableInfo
+	if schema != tableInfo.GetSchemaName() || table != tableInfo.GetTableName() {
+		target = tableInfo.CloneWithTableName(schema, table)
+	}
+	r.tableInfos.Store(tableID, routedTableInfo{source: tableInfo, target: target})
+	return target
+}
+
+// forgetTables removes the routed table infos of the tables which are dropped, truncated
+// or renamed by the ddl, their table infos are never used again.
+func (r *router) forgetTables(event *commonEvent.DDLEvent) {
+	if r == nil {
+		return
+	}
+	dropSchema := false
+	switch model.ActionType(event.Type) {
+	case model.ActionDropTable, model.ActionTruncateTable, model.ActionRenameTable, model.ActionRenameTables:
+	case model.ActionDropSchema:
+		dropSchema = true
+	default:
+		return
+	}
+	tableIDs := make(map[int64]struct{})
+	for _, tables := range []*commonEvent.InfluencedTables{event.BlockedTables, event.NeedDroppedTables} {
+		if tables != nil && tables.InfluenceType == commonEvent.InfluenceTypeNormal {
+			for _, id := range tables.TableIDs {
+				tableIDs[id] = struct{}{}
+			}
+		}
+	}
+	// the physical table IDs of a partitioned table don't contain its logical table ID.
+	if event.TableInfo != nil {
+		tableIDs[event.TableInfo.TableName.TableID] = struct{}{}
+	}
+	r.tableInfos.Range(func(key, value any) bool {
+		_, ok := tableIDs[key.(int64)]
+		if ok || (dropSchema && value.(routedTableInfo).source.GetSchemaName() == event.GetSchemaName()) {
+			r.tableInfos.Delete(key)
+		}
+		return true
+	})
+}
+
+// routeDDL returns the ddl query and the schema to switch to, which are routed by the routing rules.
+// The metadata columns are added to the query before it's routed.
+func (w *Writer) routeDDL(event *commonEvent.DDLEvent) (string, string, error) {
+	query, schema := event.GetDDLQuery(), event.GetSchemaName()
+	if appender := w.cfg.metadataColumns; appender.AppendOnly() || appender.Enabled(event.TableInfo) {
+		var columns []*model.ColumnInfo
+		if appender.Enabled(event.TableInfo) {
+			columns = appender.ColumnInfos()
+		}
+		newQuery, err := addMetadataColumns(query, columns, appender.AppendOnly())
+		if err != nil {
+			return "", "", err
+		}
+		if newQuery != query {
+			log.Info("add metadata columns to ddl query", zap.String("query", query), zap.String("newQuery", newQuery))
+			query = newQuery
+		}
+	}
+	if w.cfg.router == nil {
+		return query, schema, nil
+	}
+	routedQuery, err := routeQuery(query, schema, w.cfg.router)
+	if err != nil {
+		return "", "", err
+	}
+	if table := event.GetTableName(); table != "" {
+		schema, _ = w.cfg.router.route(schema, table)
+	} else {
+		schema = w.cfg.router.routeSchema(schema)
+	}
+	if routedQuery != query {
+		log.Info("route ddl query", zap.String("query", query), zap.String("routedQuery", routedQuery))
+	}
+	return routedQuery, schema, nil
+}
+
+func (c *Config) applySharedTargetDDLPolicy(cfg *config.MySQLConfig) error {
+	if cfg.SharedTargetDDLPolicy != nil {
+		c.SharedTargetDDLPolicy = strings.ToLower(*cfg.SharedTargetDDLPolicy)
+	}
+	switch c.SharedTargetDDLPolicy {
+	case sharedTargetDDLPolicySkip, sharedTargetDDLPolicyExecute, sharedTargetDDLPolicyFail:
+		return nil
+	}
+	return cerror.ErrMySQLInvalidConfig.GenWithStack(
+		"invalid shared-target-ddl-policy %s, which must be %s, %s or %s", c.SharedTargetDDLPolicy,
+		sharedTargetDDLPolicySkip, sharedTargetDDLPolicyExecute, sharedTargetDDLPolicyFail)
+}
+
+// isDestructiveDDLOnSharedTarget returns whether the ddl drops or truncates a downstream table which
+// may be shared by several upstream tables, executing it would wipe the data of the other tables.
+func (w *Writer) isDestructiveDDLOnSharedTarget(event *commonEvent.DDLEvent) bool {
+	switch model.ActionType(event.Type) {
+	case model.ActionDropTable, model.ActionTruncateTable:
+		return w.cfg.router.isSharedTarget(event.GetSchemaName(), event.GetTableName())
+	}
+	return false
+}
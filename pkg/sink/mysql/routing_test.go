@@ -0,0 +1,284 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/metacolumn"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T) *router {
+	r, err := newRouter([]*config.RoutingRule{
+		{Matcher: []string{"shop_*.orders"}, TargetSchema: "shop", TargetTable: "orders_all"},
+		{Matcher: []string{"shop_*.*"}, TargetSchema: "shop"},
+		{Matcher: []string{"log.*"}, TargetTable: "{schema}_{table}"},
+	}, false)
+	require.NoError(t, err)
+	return r
+}
+
+func TestNewRouter(t *testing.T) {
+	r, err := newRouter(nil, false)
+	require.NoError(t, err)
+	require.Nil(t, r)
+	schema, table := r.route("test", "t")
+	require.Equal(t, "test", schema)
+	require.Equal(t, "t", table)
+
+	for _, rule := range []*config.RoutingRule{
+		{TargetSchema: "a"},
+		{Matcher: []string{"a.b.c"}, TargetSchema: "a"},
+		{Matcher: []string{"*.*"}},
+		{Matcher: []string{"*.*"}, TargetSchema: "{database}"},
+	} {
+		_, err = newRouter([]*config.RoutingRule{rule}, false)
+		require.Error(t, err, rule)
+	}
+}
+
+func TestApplySharedTargetDDLPolicy(t *testing.T) {
+	cfg := New()
+	require.NoError(t, cfg.applySharedTargetDDLPolicy(&config.MySQLConfig{}))
+	require.Equal(t, sharedTargetDDLPolicySkip, cfg.SharedTargetDDLPolicy)
+
+	require.NoError(t, cfg.applySharedTargetDDLPolicy(&config.MySQLConfig{SharedTargetDDLPolicy: util.AddressOf("Fail")}))
+	require.Equal(t, sharedTargetDDLPolicyFail, cfg.SharedTargetDDLPolicy)
+
+	require.Error(t, cfg.applySharedTargetDDLPolicy(&config.MySQLConfig{SharedTargetDDLPolicy: util.AddressOf("ignore")}))
+}
+
+func TestRoute(t *testing.T) {
+	r := newTestRouter(t)
+	for _, c := range []struct {
+		schema, table             string
+		targetSchema, targetTable string
+	}{
+		{"shop_01", "orders", "shop", "orders_all"},
+		{"SHOP_02", "Orders", "shop", "orders_all"},
+		{"shop_01", "users", "shop", "users"},
+		{"log", "access", "log", "log_access"},
+		{"test", "t", "test", "t"},
+	} {
+		schema, table := r.route(c.schema, c.table)
+		require.Equal(t, c.targetSchema, schema)
+		require.Equal(t, c.targetTable, table)
+	}
+	require.Equal(t, "shop", r.routeSchema("shop_01"))
+	require.Equal(t, "log", r.routeSchema("log"))
+	require.Equal(t, "test", r.routeSchema("test"))
+
+	// the sharded tables are merged into the shared targets.
+	require.True(t, r.isSharedTarget("shop_01", "orders"))
+	require.True(t, r.isSharedTarget("shop_01", "users"))
+	require.False(t, r.isSharedTarget("log", "access"))
+	require.False(t, r.isSharedTarget("test", "t"))
+
+	r, err := newRouter([]*config.RoutingRule{
+		{Matcher: []string{"db.t1"}, TargetSchema: "db2", TargetTable: "t"},
+		{Matcher: []string{"db.t2"}, TargetSchema: "db2", TargetTable: "t"},
+		{Matcher: []string{"db.t3"}, TargetSchema: "db3", TargetTable: "t"},
+		{Matcher: []string{"db.t4", "db.t5"}, TargetTable: "t45"},
+	}, false)
+	require.NoError(t, err)
+	require.True(t, r.isSharedTarget("db", "t1"))
+	require.True(t, r.isSharedTarget("db", "t2"))
+	require.False(t, r.isSharedTarget("db", "t3"))
+	require.True(t, r.isSharedTarget("db", "t4"))
+}
+
+func TestRouteQuery(t *testing.T) {
+	r := newTestRouter(t)
+	for _, c := range []struct {
+		query, schema, expected string
+	}{
+		{
+			"create table orders (id int primary key)", "shop_01",
+			"CREATE TABLE `shop`.`orders_all` (`id` INT PRIMARY KEY)",
+		},
+		{
+			"alter table shop_02.users add column age int", "test",
+			"ALTER TABLE `shop`.`users` ADD COLUMN `age` INT",
+		},
+		{
+			"rename table shop_01.t1 to log.t2", "shop_01",
+			"RENAME TABLE `shop`.`t1` TO `log`.`log_t2`",
+		},
+		{"create database shop_03", "shop_03", "CREATE DATABASE `shop`"},
+		{"drop database if exists shop_03", "shop_03", "DROP DATABASE IF EXISTS `shop`"},
+		// the query is not changed if no table is routed.
+		{"create table t (id int)", "test", "create table t (id int)"},
+		// each statement of the query of `CREATE TABLES` is routed.
+		{
+			"create table shop_01.orders (id int primary key); create table test.t (id int);", "test",
+			"CREATE TABLE `shop`.`orders_all` (`id` INT PRIMARY KEY);CREATE TABLE `test`.`t` (`id` INT);",
+		},
+		{
+			"create table test.t1 (id int); create table test.t2 (id int);", "test",
+			"create table test.t1 (id int); create table test.t2 (id int);",
+		},
+	} {
+		query, err := routeQuery(c.query, c.schema, r)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, query)
+	}
+	_, err := routeQuery("create tablee t", "test", r)
+	require.Error(t, err)
+}
+
+func TestAddMetadataColumns(t *testing.T) {
+	appender, err := metacolumn.New(&config.MetadataColumnsConfig{
+		CommitTs: util.AddressOf("_commit_ts"),
+		Op:       util.AddressOf("_op"),
+	}, common.NewChangefeedID4Test("default", "test"))
+	require.NoError(t, err)
+	columns := appender.ColumnInfos()
+	for _, c := range []struct {
+		query      string
+		columns    bool
+		appendOnly bool
+		expected   string
+	}{
+		{
+			"create table t (id int primary key, name varchar(32))", true, false,
+			"CREATE TABLE `t` (`id` INT PRIMARY KEY,`name` VARCHAR(32),`_commit_ts` BIGINT(20) UNSIGNED," +
+				"`_op` VARCHAR(16) CHARACTER SET UTF8MB4 COLLATE utf8mb4_bin)",
+		},
+		{
+			"create table t (id int, name varchar(32), primary key (id), unique key uk (name))", true, true,
+			"CREATE TABLE `t` (`id` INT,`name` VARCHAR(32),`_commit_ts` BIGINT(20) UNSIGNED," +
+				"`_op` VARCHAR(16) CHARACTER SET UTF8MB4 COLLATE utf8mb4_bin,INDEX(`id`),INDEX `uk`(`name`))",
+		},
+		{
+			"alter table t add unique index uk (name)", false, true,
+			"ALTER TABLE `t` ADD INDEX `uk`(`name`)",
+		},
+		{"create unique index uk on t (name)", false, true, "CREATE INDEX `uk` ON `t` (`name`)"},
+		// the query is not changed if nothing is changed.
+		{"alter table t add column age int", true, false, "alter table t add column age int"},
+		{"create table t1 like t", true, true, "create table t1 like t"},
+		// the columns are added to each statement of the query of `CREATE TABLES`.
+		{
+			"create table t1 (id int); create table t2 (id int);", true, false,
+			"CREATE TABLE `t1` (`id` INT,`_commit_ts` BIGINT(20) UNSIGNED," +
+				"`_op` VARCHAR(16) CHARACTER SET UTF8MB4 COLLATE utf8mb4_bin);" +
+				"CREATE TABLE `t2` (`id` INT,`_commit_ts` BIGINT(20) UNSIGNED," +
+				"`_op` VARCHAR(16) CHARACTER SET UTF8MB4 COLLATE utf8mb4_bin);",
+		},
+	} {
+		var cols []*model.ColumnInfo
+		if c.columns {
+			cols = columns
+		}
+		query, err := addMetadataColumns(c.query, cols, c.appendOnly)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, query)
+	}
+}
+
+func TestMysqlWriterRouting(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+	writer.cfg.EnableDDLTs = false
+	writer.cfg.router = newTestRouter(t)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("create database shop_01")
+	helper.Tk().MustExec("use shop_01")
+	job := helper.DDL2Job("create table orders (id int primary key, name varchar(32))")
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `shop`;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("CREATE TABLE `shop`.`orders_all` (`id` INT PRIMARY KEY,`name` VARCHAR(32))").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.FlushDDLEvent(ddlEvent))
+	// the event is not changed by the routing.
+	require.Equal(t, job.Query, ddlEvent.Query)
+
+	dmlEvent := helper.DML2Event("shop_01", "orders", "insert into orders values (1, 'a')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+	dmlEvent.DispatcherID = common.NewDispatcherID()
+	dmlEvent2 := helper.DML2Event("shop_01", "orders", "insert into orders values (2, 'b')")
+	dmlEvent2.CommitTs = 3
+	dmlEvent2.ReplicatingTs = 1
+	dmlEvent2.DispatcherID = dmlEvent.DispatcherID
+	mock.ExpectExec("BEGIN;INSERT INTO `shop`.`orders_all` (`id`,`name`) VALUES (?,?),(?,?);COMMIT;").
+		WithArgs(1, "a", 2, "b").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{dmlEvent, dmlEvent2}))
+	require.Equal(t, "shop_01", dmlEvent.TableInfo.GetSchemaName())
+
+	// the routed table info is reused by the events with the same table info.
+	routed := writer.routeTableInfo(dmlEvent.TableInfo)
+	require.Same(t, routed, writer.routeTableInfo(dmlEvent.TableInfo))
+	require.Equal(t, "orders_all", routed.GetTableName())
+
+	tableID := dmlEvent.TableInfo.TableName.TableID
+	newDropEvent := func(query string) *commonEvent.DDLEvent {
+		job := helper.DDL2Job(query)
+		return &commonEvent.DDLEvent{
+			Type:       byte(job.Type),
+			Query:      job.Query,
+			SchemaName: job.SchemaName,
+			TableName:  job.TableName,
+			FinishedTs: job.BinlogInfo.FinishedTS,
+			BlockedTables: &commonEvent.InfluencedTables{
+				InfluenceType: commonEvent.InfluenceTypeNormal,
+				TableIDs:      []int64{tableID, 0},
+			},
+		}
+	}
+	// the shared target is not dropped or truncated by the ddl of one upstream table,
+	// and the routed table info of the dropped or truncated table is removed.
+	for _, query := range []string{"truncate table orders", "drop table orders"} {
+		writer.routeTableInfo(dmlEvent.TableInfo)
+		require.NoError(t, writer.FlushDDLEvent(newDropEvent(query)))
+		_, ok := writer.cfg.router.tableInfos.Load(tableID)
+		require.False(t, ok)
+	}
+
+	helper.Tk().MustExec("create table orders (id int primary key, name varchar(32))")
+	writer.cfg.SharedTargetDDLPolicy = sharedTargetDDLPolicyFail
+	err := writer.FlushDDLEvent(newDropEvent("drop table orders"))
+	require.ErrorContains(t, err, "shared by several upstream tables")
+
+	helper.Tk().MustExec("create table orders (id int primary key, name varchar(32))")
+	writer.cfg.SharedTargetDDLPolicy = sharedTargetDDLPolicyExecute
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `shop`;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DROP TABLE `shop`.`orders_all`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.FlushDDLEvent(newDropEvent("drop table orders")))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/sqlmodel"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"go.uber.org/zap"
+)
+
+// translateDDL translates the ddl to the PostgreSQL statements, only a subset of ddl is supported:
+//   - CREATE / DROP DATABASE
+//   - CREATE / DROP / TRUNCATE TABLE
+//   - ALTER TABLE ADD / DROP COLUMN, ADD INDEX
+//   - CREATE / DROP INDEX
+//
+// The statements are generated by the table info after the ddl, so the MySQL specific
+// options are dropped. The unsupported ddl and alter table specs are skipped with a warning.
+func translateDDL(event *commonEvent.DDLEvent) ([]string, error) {
+	var result []string
+	p := parser.New()
+	for _, e := range event.GetEvents() {
+		stmt, err := p.ParseOneStmt(e.Query, "", "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		t := &ddlTranslator{event: e}
+		stmts, err := t.translate(stmt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stmts...)
+	}
+	return result, nil
+}
+
+type ddlTranslator struct {
+	event *commonEvent.DDLEvent
+}
+
+func (t *ddlTranslator) translate(stmt ast.StmtNode) ([]string, error) {
+	switch s := stmt.(type) {
+	case *ast.CreateDatabaseStmt:
+		return []string{"CREATE SCHEMA IF NOT EXISTS " + sqlmodel.QuotePostgresName(s.Name.O)}, nil
+	case *ast.DropDatabaseStmt:
+		return []string{"DROP SCHEMA IF EXISTS " + sqlmodel.QuotePostgresName(s.Name.O) + " CASCADE"}, nil
+	case *ast.CreateTableStmt:
+		tableInfo, err := t.tableInfo()
+		if err != nil {
+			return nil, err
+		}
+		return createTable(tableInfo), nil
+	case *ast.DropTableStmt:
+		if s.IsView {
+			break
+		}
+		stmts := make([]string, 0, len(s.Tables))
+		for _, table := range s.Tables {
+			stmts = append(stmts, "DROP TABLE IF EXISTS "+t.quoteTable(table))
+		}
+		return stmts, nil
+	case *ast.TruncateTableStmt:
+		return []string{"TRUNCATE TABLE " + t.quoteTable(s.Table)}, nil
+	case *ast.AlterTableStmt:
+		return t.alterTable(s)
+	case *ast.CreateIndexStmt:
+		tableInfo, err := t.tableInfo()
+		if err != nil {
+			return nil, err
+		}
+		if stmt := createIndex(tableInfo, tableInfo.GetIndex(s.IndexName)); stmt != "" {
+			return []string{stmt}, nil
+		}
+		return nil, nil
+	case *ast.DropIndexStmt:
+		schema := s.Table.Schema.O
+		if schema == "" {
+			schema = t.event.GetSchemaName()
+		}
+		return []string{"DROP INDEX IF EXISTS " + sqlmodel.QuotePostgresName(schema) + "." +
+			indexName(s.Table.Name.O, s.IndexName)}, nil
+	}
+	log.Warn("skip the ddl which is not supported by postgres sink",
+		zap.String("query", t.event.GetDDLQuery()))
+	return nil, nil
+}
+
+func (t *ddlTranslator) tableInfo() (*common.TableInfo, error) {
+	if t.event.TableInfo == nil {
+		return nil, cerror.ErrPostgresUnsupportedDDL.GenWithStack(
+			"ddl %s has no table info", t.event.GetDDLQuery())
+	}
+	return t.event.TableInfo, nil
+}
+
+func (t *ddlTranslator) quoteTable(table *ast.TableName) string {
+	schema := table.Schema.O
+	if schema == "" {
+		schema = t.event.GetSchemaName()
+	}
+	return sqlmodel.QuotePostgresTable(&common.TableName{Schema: schema, Table: table.Name.O})
+}
+
+func (t *ddlTranslator) alterTable(s *ast.AlterTableStmt) ([]string, error) {
+	tableInfo, err := t.tableInfo()
+	if err != nil {
+		return nil, err
+	}
+	table := t.quoteTable(s.Table)
+	var stmts []string
+	for _, spec := range s.Specs {
+		switch spec.Tp {
+		case ast.AlterTableAddColumns:
+			for _, def := range spec.NewColumns {
+				col := findColumn(tableInfo, def.Name.Name.L)
+				if col == nil || col.IsGenerated() {
+					continue
+				}
+				// the NOT NULL constraint is kept only if there is a default value,
+				// otherwise the existing rows can not be filled.
+				stmts = append(stmts, "ALTER TABLE "+table+" ADD COLUMN IF NOT EXISTS "+columnDef(col, true))
+			}
+		case ast.AlterTableDropColumn:
+			stmts = append(stmts, "ALTER TABLE "+table+" DROP COLUMN IF EXISTS "+
+				sqlmodel.QuotePostgresName(spec.OldColumnName.Name.O))
+		case ast.AlterTableAddConstraint:
+			switch spec.Constraint.Tp {
+			case ast.ConstraintIndex, ast.ConstraintKey,
+				ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+				if stmt := createIndex(tableInfo, tableInfo.GetIndex(spec.Constraint.Name)); stmt != "" {
+					stmts = append(stmts, stmt)
+				}
+			default:
+				log.Warn("skip the alter table spec which is not supported by postgres sink",
+					zap.String("query", t.event.GetDDLQuery()))
+			}
+		default:
+			log.Warn("skip the alter table spec which is not supported by postgres sink",
+				zap.String("query", t.event.GetDDLQuery()))
+		}
+	}
+	return stmts, nil
+}
+
+func findColumn(tableInfo *common.TableInfo, name string) *model.ColumnInfo {
+	for _, col := range tableInfo.GetColumns() {
+		if col.Name.L == name {
+			return col
+		}
+	}
+	return nil
+}
+
+// columnDef returns the column definition, the NOT NULL constraint is dropped
+// if the column has no default value and notNullNeedsDefault is true.
+func columnDef(col *model.ColumnInfo, notNullNeedsDefault bool) string {
+	var b strings.Builder
+	b.WriteString(sqlmodel.QuotePostgresName(col.Name.O))
+	b.WriteByte(' ')
+	b.WriteString(columnType(col))
+	def := defaultValue(col)
+	if mysql.HasNotNullFlag(col.GetFlag()) && (def != "" || !notNullNeedsDefault) {
+		b.WriteString(" NOT NULL")
+	}
+	if def != "" {
+		b.WriteString(" DEFAULT ")
+		b.WriteString(def)
+	}
+	return b.String()
+}
+
+// createTable generates the CREATE TABLE statement and the CREATE INDEX statements
+// of the secondary indexes. The generated columns are not created since their
+// values are not replicated.
+func createTable(tableInfo *common.TableInfo) []string {
+	table := sqlmodel.QuotePostgresTable(&tableInfo.TableName)
+	var b strings.Builder
+	b.WriteString("CREATE TABLE IF NOT EXISTS ")
+	b.WriteString(table)
+	b.WriteString(" (")
+	written := false
+	for _, col := range tableInfo.GetColumns() {
+		if col.IsGenerated() || col.Hidden {
+			continue
+		}
+		if written {
+			b.WriteString(", ")
+		}
+		written = true
+		b.WriteString(columnDef(col, false))
+	}
+	if pk := primaryKeyColumns(tableInfo); len(pk) > 0 {
+		b.WriteString(", PRIMARY KEY (")
+		b.WriteString(quoteNames(pk))
+		b.WriteByte(')')
+	}
+	b.WriteByte(')')
+
+	stmts := []string{b.String()}
+	for _, idx := range tableInfo.GetIndices() {
+		if idx.Primary {
+			continue
+		}
+		if stmt := createIndex(tableInfo, idx); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+func primaryKeyColumns(tableInfo *common.TableInfo) []string {
+	if tableInfo.PKIsHandle() {
+		if pk := tableInfo.GetPkColInfo(); pk != nil {
+			return []string{pk.Name.O}
+		}
+	}
+	for _, idx := range tableInfo.GetIndices() {
+		if !idx.Primary {
+			continue
+		}
+		columns := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			columns = append(columns, col.Name.O)
+		}
+		return columns
+	}
+	return nil
+}
+
+// createIndex generates the CREATE INDEX statement, the index name is prefixed by the
+// table name since the index name is unique in the schema of PostgreSQL.
+// It returns empty string if the index can not be created, such as the expression index.
+func createIndex(tableInfo *common.TableInfo, idx *model.IndexInfo) string {
+	if idx == nil || idx.Primary {
+		return ""
+	}
+	columns := make([]string, 0, len(idx.Columns))
+	for _, idxCol := range idx.Columns {
+		col := findColumn(tableInfo, idxCol.Name.L)
+		if col == nil || col.Hidden || col.IsGenerated() {
+			log.Warn("skip the index which is not supported by postgres sink",
+				zap.String("table", tableInfo.TableName.String()),
+				zap.String("index", idx.Name.O))
+			return ""
+		}
+		columns = append(columns, col.Name.O)
+	}
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if idx.Unique {
+		b.WriteString("UNIQUE ")
+	}
+	b.WriteString("INDEX IF NOT EXISTS ")
+	b.WriteString(indexName(tableInfo.GetTableName(), idx.Name.O))
+	b.WriteString(" ON ")
+	b.WriteString(sqlmodel.QuotePostgresTable(&tableInfo.TableName))
+	b.WriteString(" (")
+	b.WriteString(quoteNames(columns))
+	b.WriteByte(')')
+	return b.String()
+}
+
+func indexName(table, index string) string {
+	return sqlmodel.QuotePostgresName(table + "_" + index)
+}
+
+func quoteNames(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, sqlmodel.QuotePostgresName(name))
+	}
+	return strings.Join(quoted, ",")
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
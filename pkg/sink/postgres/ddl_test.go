@@ -0,0 +1,77 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateDDL(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	for _, c := range []struct {
+		ddl      string
+		expected []string
+	}{
+		{
+			"create database shop",
+			[]string{`CREATE SCHEMA IF NOT EXISTS "shop"`},
+		},
+		{
+			"create table t (id bigint unsigned primary key, name varchar(32) not null default 'a''b', " +
+				"price decimal(10,2), created datetime(3), status enum('on','off'), doc json, data blob, " +
+				"age int generated always as (id + 1) virtual, unique key uk_name(name), key idx_price(price))",
+			[]string{
+				`CREATE TABLE IF NOT EXISTS "test"."t" ("id" NUMERIC(20) NOT NULL, ` +
+					`"name" VARCHAR(32) NOT NULL DEFAULT 'a''b', "price" NUMERIC(10,2), ` +
+					`"created" TIMESTAMP(3), "status" TEXT, "doc" JSONB, "data" BYTEA, PRIMARY KEY ("id"))`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS "t_uk_name" ON "test"."t" ("name")`,
+				`CREATE INDEX IF NOT EXISTS "t_idx_price" ON "test"."t" ("price")`,
+			},
+		},
+		{
+			"alter table t add column c1 int not null default 1, add column c2 char(4) not null, drop column price",
+			[]string{
+				`ALTER TABLE "test"."t" ADD COLUMN IF NOT EXISTS "c1" INTEGER NOT NULL DEFAULT '1'`,
+				`ALTER TABLE "test"."t" ADD COLUMN IF NOT EXISTS "c2" CHAR(4)`,
+				`ALTER TABLE "test"."t" DROP COLUMN IF EXISTS "price"`,
+			},
+		},
+		{
+			"alter table t add index idx_c1(c1, c2)",
+			[]string{`CREATE INDEX IF NOT EXISTS "t_idx_c1" ON "test"."t" ("c1","c2")`},
+		},
+		{
+			"create unique index uk_c2 on t(c2)",
+			[]string{`CREATE UNIQUE INDEX IF NOT EXISTS "t_uk_c2" ON "test"."t" ("c2")`},
+		},
+		{
+			"drop index uk_c2 on t",
+			[]string{`DROP INDEX IF EXISTS "test"."t_uk_c2"`},
+		},
+		// the unsupported ddl is skipped.
+		{"alter table t comment 'abc'", nil},
+		{"truncate table t", []string{`TRUNCATE TABLE "test"."t"`}},
+		{"drop table t", []string{`DROP TABLE IF EXISTS "test"."t"`}},
+	} {
+		stmts, err := translateDDL(helper.DDL2Event(c.ddl))
+		require.NoError(t, err, c.ddl)
+		require.Equal(t, c.expected, stmts, c.ddl)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// columnType returns the PostgreSQL type of the TiDB column.
+// The unsigned integers are mapped to the wider types since PostgreSQL has no
+// unsigned integer, the enum and set are stored by their names.
+func columnType(col *model.ColumnInfo) string {
+	unsigned := mysql.HasUnsignedFlag(col.GetFlag())
+	switch col.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort:
+		if unsigned && col.GetType() == mysql.TypeShort {
+			return "INTEGER"
+		}
+		return "SMALLINT"
+	case mysql.TypeInt24, mysql.TypeLong:
+		if unsigned && col.GetType() == mysql.TypeLong {
+			return "BIGINT"
+		}
+		return "INTEGER"
+	case mysql.TypeLonglong:
+		if unsigned {
+			return "NUMERIC(20)"
+		}
+		return "BIGINT"
+	case mysql.TypeYear:
+		return "SMALLINT"
+	case mysql.TypeFloat:
+		return "REAL"
+	case mysql.TypeDouble:
+		return "DOUBLE PRECISION"
+	case mysql.TypeNewDecimal:
+		flen, decimal := col.GetFlen(), col.GetDecimal()
+		if flen <= 0 {
+			return "NUMERIC"
+		}
+		return fmt.Sprintf("NUMERIC(%d,%d)", flen, max(decimal, 0))
+	case mysql.TypeBit:
+		if col.GetFlen() >= 64 {
+			return "NUMERIC(20)"
+		}
+		return "BIGINT"
+	case mysql.TypeDate, mysql.TypeNewDate:
+		return "DATE"
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		return fmt.Sprintf("TIMESTAMP(%d)", max(col.GetDecimal(), 0))
+	case mysql.TypeDuration:
+		// TIME of MySQL ranges from -838:59:59 to 838:59:59, which exceeds the TIME of PostgreSQL.
+		return "INTERVAL"
+	case mysql.TypeString:
+		if isBinary(col) {
+			return "BYTEA"
+		}
+		return fmt.Sprintf("CHAR(%d)", max(col.GetFlen(), 1))
+	case mysql.TypeVarchar, mysql.TypeVarString:
+		if isBinary(col) {
+			return "BYTEA"
+		}
+		return fmt.Sprintf("VARCHAR(%d)", max(col.GetFlen(), 1))
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if isBinary(col) {
+			return "BYTEA"
+		}
+		return "TEXT"
+	case mysql.TypeJSON:
+		return "JSONB"
+	case mysql.TypeEnum, mysql.TypeSet, mysql.TypeTiDBVectorFloat32:
+		return "TEXT"
+	}
+	return "TEXT"
+}
+
+func isBinary(col *model.ColumnInfo) bool {
+	return col.GetCharset() == charset.CharsetBin
+}
+
+// extractValue returns the value of the column which can be written to PostgreSQL.
+func extractValue(row *chunk.Row, col *model.ColumnInfo, idx int) interface{} {
+	if row.IsNull(idx) {
+		return nil
+	}
+	switch col.GetType() {
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeNewDate, mysql.TypeTimestamp:
+		t := row.GetTime(idx)
+		// PostgreSQL does not accept the zero date, write it as NULL.
+		if t.IsZero() || t.InvalidZero() {
+			return nil
+		}
+		return t.String()
+	case mysql.TypeEnum:
+		return row.GetEnum(idx).Name
+	case mysql.TypeSet:
+		return row.GetSet(idx).Name
+	}
+	v := common.ExtractColVal(row, col, idx)
+	// the driver does not support the uint64 value which exceeds the int64.
+	if u, ok := v.(uint64); ok && u > math.MaxInt64 {
+		return strconv.FormatUint(u, 10)
+	}
+	return v
+}
+
+// getArgs returns the values of all the columns of the row, including the generated columns.
+func getArgs(row *chunk.Row, tableInfo *common.TableInfo) []interface{} {
+	args := make([]interface{}, 0, len(tableInfo.GetColumns()))
+	for i, col := range tableInfo.GetColumns() {
+		if col == nil {
+			continue
+		}
+		args = append(args, extractValue(row, col, i))
+	}
+	return args
+}
+
+// defaultValue returns the literal of the column default value, it returns
+// empty string if the default value can not be translated.
+func defaultValue(col *model.ColumnInfo) string {
+	if col.DefaultIsExpr {
+		return ""
+	}
+	v := col.GetDefaultValue()
+	if v == nil {
+		return ""
+	}
+	s, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	switch col.GetType() {
+	case mysql.TypeBit, mysql.TypeJSON, mysql.TypeTiDBVectorFloat32,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return ""
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeNewDate, mysql.TypeTimestamp:
+		if strings.HasPrefix(s, types.ZeroDateStr) {
+			return ""
+		}
+		if strings.HasPrefix(strings.ToUpper(s), "CURRENT_TIMESTAMP") {
+			return strings.ToUpper(s)
+		}
+	}
+	return quoteLiteral(s)
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/ticdc/pkg/sink/sqlmodel"
+	"go.uber.org/zap"
+)
+
+const defaultDDLMaxRetry uint64 = 20
+
+// The error classes which can not be fixed by retrying,
+// see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	errClassDataException        pq.ErrorClass = "22"
+	errClassIntegrityViolation   pq.ErrorClass = "23"
+	errClassSyntaxOrAccessDenied pq.ErrorClass = "42"
+)
+
+type preparedDMLs struct {
+	sqls            []string
+	values          [][]interface{}
+	rowCount        int
+	approximateSize int64
+}
+
+func (d *preparedDMLs) String() string {
+	return fmt.Sprintf("sqls: %v, rowCount: %d", d.sqls, d.rowCount)
+}
+
+type cachedWhereHandle struct {
+	tableInfo *common.TableInfo
+	handle    *sqlmodel.WhereHandle
+}
+
+// Writer writes the events to the postgres downstream, each Flush is executed in one transaction.
+type Writer struct {
+	id           int
+	ctx          context.Context
+	db           *sql.DB
+	cfg          *Config
+	ChangefeedID common.ChangeFeedID
+
+	// whereHandles caches the where handle of each table.
+	whereHandles map[int64]cachedWhereHandle
+	statistics   *metrics.Statistics
+}
+
+// NewWriter creates a postgres writer.
+func NewWriter(
+	ctx context.Context,
+	id int,
+	db *sql.DB,
+	cfg *Config,
+	changefeedID common.ChangeFeedID,
+	statistics *metrics.Statistics,
+) *Writer {
+	return &Writer{
+		id:           id,
+		ctx:          ctx,
+		db:           db,
+		cfg:          cfg,
+		ChangefeedID: changefeedID,
+		whereHandles: make(map[int64]cachedWhereHandle),
+		statistics:   statistics,
+	}
+}
+
+// Flush writes the dml events in one transaction.
+func (w *Writer) Flush(events []*commonEvent.DMLEvent) error {
+	dmls := w.prepareDMLs(events)
+	if dmls.rowCount > 0 {
+		if err := w.execDMLWithMaxRetries(dmls); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, event := range events {
+		event.PostFlush()
+	}
+	return nil
+}
+
+// dmlKind is the kind of the statement generated for a batch of rows.
+type dmlKind int
+
+const (
+	dmlInsert dmlKind = iota
+	dmlUpsert
+	dmlUpdate
+	dmlDelete
+)
+
+// prepareDMLs generates the statements of the events, the successive rows of the
+// same kind in an event are merged into one statement to keep the order of rows.
+// In safe mode, the insert is written as upsert, and the update is split into
+// a delete and an upsert, so the rows can be replicated repeatedly.
+func (w *Writer) prepareDMLs(events []*commonEvent.DMLEvent) *preparedDMLs {
+	dmls := &preparedDMLs{}
+	for _, event := range events {
+		dmls.rowCount += int(event.Len())
+		dmls.approximateSize += event.GetSize()
+
+		tableInfo := event.TableInfo
+		inSafeMode := w.cfg.SafeMode || event.CommitTs < event.ReplicatingTs
+		var (
+			kind  dmlKind
+			batch []*sqlmodel.RowChange
+		)
+		flush := func() {
+			if len(batch) == 0 {
+				return
+			}
+			query, args := genSQL(kind, batch)
+			dmls.sqls = append(dmls.sqls, query)
+			dmls.values = append(dmls.values, args)
+			batch = batch[:0]
+		}
+		add := func(k dmlKind, row *sqlmodel.RowChange) {
+			// the update statement can not be merged.
+			if len(batch) > 0 && (k != kind || k == dmlUpdate || len(batch) >= w.cfg.MaxTxnRow) {
+				flush()
+			}
+			kind = k
+			batch = append(batch, row)
+		}
+		for {
+			row, ok := event.GetNextRow()
+			if !ok {
+				break
+			}
+			switch row.RowType {
+			case common.RowTypeInsert:
+				post := w.newRowChange(tableInfo, nil, getArgs(&row.Row, tableInfo))
+				if inSafeMode {
+					add(dmlUpsert, post)
+				} else {
+					add(dmlInsert, post)
+				}
+			case common.RowTypeUpdate:
+				pre, post := getArgs(&row.PreRow, tableInfo), getArgs(&row.Row, tableInfo)
+				if inSafeMode {
+					add(dmlDelete, w.newRowChange(tableInfo, pre, nil))
+					add(dmlUpsert, w.newRowChange(tableInfo, nil, post))
+				} else {
+					add(dmlUpdate, w.newRowChange(tableInfo, pre, post))
+				}
+			case common.RowTypeDelete:
+				add(dmlDelete, w.newRowChange(tableInfo, getArgs(&row.PreRow, tableInfo), nil))
+			}
+		}
+		flush()
+	}
+	return dmls
+}
+
+func (w *Writer) newRowChange(tableInfo *common.TableInfo, pre, post []interface{}) *sqlmodel.RowChange {
+	change := sqlmodel.NewRowChange(&tableInfo.TableName, nil, pre, post, tableInfo, nil, nil)
+	tableID := tableInfo.TableName.TableID
+	cached, ok := w.whereHandles[tableID]
+	if !ok || cached.tableInfo != tableInfo {
+		cached = cachedWhereHandle{
+			tableInfo: tableInfo,
+			handle:    sqlmodel.GetWhereHandle(tableInfo, tableInfo),
+		}
+		w.whereHandles[tableID] = cached
+	}
+	change.SetWhereHandle(cached.handle)
+	return change
+}
+
+func genSQL(kind dmlKind, rows []*sqlmodel.RowChange) (string, []interface{}) {
+	switch kind {
+	case dmlInsert:
+		return sqlmodel.GenPostgresInsertSQL(false, rows...)
+	case dmlUpsert:
+		return sqlmodel.GenPostgresInsertSQL(true, rows...)
+	case dmlUpdate:
+		return rows[0].GenPostgresUpdateSQL()
+	default:
+		return sqlmodel.GenPostgresDeleteSQL(rows...)
+	}
+}
+
+func (w *Writer) execDMLWithMaxRetries(dmls *preparedDMLs) error {
+	tryExec := func() (int, int64, error) {
+		err := w.execInTxn(dmls.sqls, dmls.values)
+		if err != nil {
+			return 0, 0, err
+		}
+		return dmls.rowCount, dmls.approximateSize, nil
+	}
+	return retry.Do(w.ctx, func() error {
+		err := w.statistics.RecordBatchExecution(tryExec)
+		if err != nil {
+			log.Warn("execute dmls failed",
+				zap.String("changefeed", w.ChangefeedID.String()),
+				zap.Int("writerID", w.id),
+				zap.Stringer("dmls", dmls),
+				zap.Error(err))
+			return errors.Trace(err)
+		}
+		return nil
+	}, retry.WithBackoffBaseDelay(BackoffBaseDelay.Milliseconds()),
+		retry.WithBackoffMaxDelay(BackoffMaxDelay.Milliseconds()),
+		retry.WithMaxTries(w.cfg.DMLMaxRetry),
+		retry.WithIsRetryableErr(isRetryableError))
+}
+
+// execInTxn executes the statements in one transaction.
+func (w *Writer) execInTxn(sqls []string, values [][]interface{}) error {
+	tx, err := w.db.BeginTx(w.ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrPostgresTxnError, err)
+	}
+	for i, query := range sqls {
+		var args []interface{}
+		if values != nil {
+			args = values[i]
+		}
+		log.Debug("exec sql", zap.String("sql", query), zap.Any("args", args), zap.Int("writerID", w.id))
+		ctx, cancel := context.WithTimeout(w.ctx, w.cfg.WriteTimeout)
+		_, err = tx.ExecContext(ctx, query, args...)
+		cancel()
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && errors.Cause(rbErr) != context.Canceled {
+				log.Warn("failed to rollback txn", zap.Error(rbErr), zap.Int("writerID", w.id))
+			}
+			return cerror.WrapError(cerror.ErrPostgresTxnError,
+				errors.WithMessage(err, fmt.Sprintf("Failed to execute %s; ", query)))
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return cerror.WrapError(cerror.ErrPostgresTxnError, err)
+	}
+	return nil
+}
+
+// FlushDDLEvent translates the ddl to the PostgreSQL statements and executes them in one transaction.
+func (w *Writer) FlushDDLEvent(event *commonEvent.DDLEvent) error {
+	stmts, err := translateDDL(event)
+	if err != nil {
+		return err
+	}
+	if len(stmts) == 0 {
+		return nil
+	}
+	return retry.Do(w.ctx, func() error {
+		err := w.statistics.RecordDDLExecution(func() error { return w.execInTxn(stmts, nil) })
+		if err != nil {
+			log.Warn("execute ddl failed",
+				zap.String("changefeed", w.ChangefeedID.String()),
+				zap.String("ddl", event.GetDDLQuery()),
+				zap.Strings("statements", stmts),
+				zap.Error(err))
+			return err
+		}
+		log.Info("execute ddl succeeded",
+			zap.String("changefeed", w.ChangefeedID.String()),
+			zap.Uint64("commitTs", event.GetCommitTs()),
+			zap.String("ddl", event.GetDDLQuery()),
+			zap.String("statements", strings.Join(stmts, "; ")))
+		return nil
+	}, retry.WithBackoffBaseDelay(BackoffBaseDelay.Milliseconds()),
+		retry.WithBackoffMaxDelay(BackoffMaxDelay.Milliseconds()),
+		retry.WithMaxTries(defaultDDLMaxRetry),
+		retry.WithIsRetryableErr(isRetryableError))
+}
+
+// Close closes the writer.
+func (w *Writer) Close() {}
+
+// isRetryableError returns false if the error is caused by the data or the schema,
+// which can not be fixed by retrying.
+func isRetryableError(err error) bool {
+	if !cerror.IsRetryableError(err) {
+		return false
+	}
+	pqErr, ok := errors.Cause(err).(*pq.Error)
+	if !ok {
+		return true
+	}
+	switch pqErr.Code.Class() {
+	case errClassDataException, errClassIntegrityViolation, errClassSyntaxOrAccessDenied:
+		return false
+	}
+	return true
+}
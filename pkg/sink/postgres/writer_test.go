@@ -0,0 +1,119 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWriter(t *testing.T) (*Writer, *sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	cfg := NewConfig()
+	cfg.DMLMaxRetry = 1
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	statistics := metrics.NewStatistics(changefeedID, "PostgresSink")
+	t.Cleanup(statistics.Close)
+	return NewWriter(context.Background(), 0, db, cfg, changefeedID, statistics), db, mock
+}
+
+func TestWriterFlush(t *testing.T) {
+	writer, db, mock := newTestWriter(t)
+	defer db.Close()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	ddlEvent := helper.DDL2Event("create table t (id int primary key, name varchar(32), flag bit(8))")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS "test"."t" ("id" INTEGER NOT NULL, "name" VARCHAR(32), ` +
+		`"flag" BIGINT, PRIMARY KEY ("id"))`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	require.NoError(t, writer.FlushDDLEvent(ddlEvent))
+
+	// the rows of the same kind are merged.
+	event := helper.DML2Event("test", "t", "insert into t values (1, 'a', b'1')", "insert into t values (2, null, b'11')")
+	var flushed int
+	event.AddPostFlushFunc(func() { flushed++ })
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "test"."t" ("id","name","flag") VALUES ($1,$2,$3),($4,$5,$6)`).
+		WithArgs(1, "a", 1, 2, nil, 3).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.Equal(t, 1, flushed)
+
+	// the update is split into delete and upsert in safe mode.
+	event, _ = helper.DML2UpdateEvent("test", "t",
+		"insert into t values (3, 'c', b'0')", "update t set name = 'd' where id = 3")
+	writer.cfg.SafeMode = true
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "test"."t" WHERE ("id" = $1)`).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "test"."t" ("id","name","flag") VALUES ($1,$2,$3) `+
+		`ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name","flag"=EXCLUDED."flag"`).
+		WithArgs(3, "d", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+
+	event, _ = helper.DML2UpdateEvent("test", "t",
+		"insert into t values (5, 'e', b'0')", "update t set name = null where id = 5")
+	writer.cfg.SafeMode = false
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "test"."t" SET "id" = $1, "name" = $2, "flag" = $3 WHERE "id" = $4`).
+		WithArgs(5, nil, 0, 5).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	require.NoError(t, writer.Flush([]*commonEvent.DMLEvent{event}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWriterFlushFailed(t *testing.T) {
+	writer, db, mock := newTestWriter(t)
+	defer db.Close()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t (id int, name varchar(32))")
+
+	// the integrity constraint violation is not retried.
+	event := helper.DML2Event("test", "t", "insert into t values (1, 'a')")
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "test"."t" ("id","name") VALUES ($1,$2)`).
+		WithArgs(1, "a").
+		WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
+	mock.ExpectRollback()
+	err := writer.Flush([]*commonEvent.DMLEvent{event})
+	require.ErrorContains(t, err, "duplicate key value")
+	require.False(t, isRetryableError(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.True(t, isRetryableError(cerror.WrapError(cerror.ErrPostgresTxnError,
+		&pq.Error{Code: "40001", Message: "could not serialize access"})))
+	require.False(t, isRetryableError(context.Canceled))
+}
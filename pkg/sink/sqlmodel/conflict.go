@@ -0,0 +1,210 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlmodel
+
+import (
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"go.uber.org/zap"
+)
+
+// The statements in this file are used to detect and resolve the conflicts of the
+// concurrent writes to the same row on both clusters in BDR mode. The conflict is
+// detected by the affected rows of the statements:
+//   - GenInsertIfNotExistsSQL affects no row if the row exists.
+//   - GenUpdateIfUnchangedSQL and GenDeleteIfUnchangedSQL affect no row if the
+//     downstream row is not the same as the pre-image of the row change. Since the
+//     UPDATE also affects no row if the row is not changed by it, the row is checked
+//     by GenSelectIfUnchangedSQL again in that case.
+//   - GenLastWriterWinsUpsertSQL and GenLastWriterWinsDeleteSQL affect no row if
+//     the downstream row is newer than the row change.
+
+// GenInsertIfNotExistsSQL generates the INSERT SQL which does nothing if the row exists,
+// it's like `INSERT INTO t (a,b) VALUES (?,?) ON DUPLICATE KEY UPDATE a=a`.
+func (r *RowChange) GenInsertIfNotExistsSQL() (string, []interface{}) {
+	if r.tp == RowChangeDelete {
+		log.L().DPanic("illegal type for GenInsertIfNotExistsSQL",
+			zap.String("sourceTable", r.sourceTable.String()),
+			zap.Stringer("changeType", r.tp))
+		return "", nil
+	}
+	query, args := GenInsertSQL(DMLInsert, r)
+	colName := common.QuoteName(r.writableColumns()[0])
+	return query + " ON DUPLICATE KEY UPDATE " + colName + "=" + colName, args
+}
+
+// GenUpdateIfUnchangedSQL generates the UPDATE SQL which only updates the row if all the
+// comparable columns of the downstream row equal to the pre-image, the skipColumns are not compared.
+func (r *RowChange) GenUpdateIfUnchangedSQL(skipColumns ...string) (string, []interface{}) {
+	query, args := r.genUpdateSQL()
+	if query == "" {
+		return "", nil
+	}
+	return r.withUnchangedWhere(query, args, skipColumns)
+}
+
+// GenDeleteIfUnchangedSQL generates the DELETE SQL which only deletes the row if all the
+// comparable columns of the downstream row equal to the pre-image, the skipColumns are not compared.
+func (r *RowChange) GenDeleteIfUnchangedSQL(skipColumns ...string) (string, []interface{}) {
+	query, args := r.genDeleteSQL()
+	if query == "" {
+		return "", nil
+	}
+	return r.withUnchangedWhere(query, args, skipColumns)
+}
+
+// GenSelectIfUnchangedSQL generates the SELECT SQL which returns a row if all the comparable
+// columns of the downstream row equal to the pre-image, the skipColumns are not compared.
+// The row is locked by `FOR UPDATE` until the transaction ends.
+func (r *RowChange) GenSelectIfUnchangedSQL(skipColumns ...string) (string, []interface{}) {
+	if r.tp == RowChangeInsert {
+		log.L().DPanic("illegal type for GenSelectIfUnchangedSQL",
+			zap.String("sourceTable", r.sourceTable.String()),
+			zap.Stringer("changeType", r.tp))
+		return "", nil
+	}
+	var buf strings.Builder
+	buf.Grow(1024)
+	buf.WriteString("SELECT 1 FROM ")
+	buf.WriteString(r.targetTable.QuoteString())
+	buf.WriteString(" WHERE ")
+	args := r.genWhere(&buf)
+	buf.WriteString(" LIMIT 1")
+	query, args := r.withUnchangedWhere(buf.String(), args, skipColumns)
+	return query + " FOR UPDATE", args
+}
+
+// withUnchangedWhere appends the null-safe comparison of the pre-image to the WHERE clause
+// of the query generated by genUpdateSQL or genDeleteSQL, which ends with ` LIMIT 1`.
+// The float, double and json columns are not compared since their values can not be
+// compared exactly.
+func (r *RowChange) withUnchangedWhere(query string, args []interface{}, skipColumns []string) (string, []interface{}) {
+	var buf strings.Builder
+	buf.Grow(len(query) + 256)
+	buf.WriteString(strings.TrimSuffix(query, " LIMIT 1"))
+	generatedColumns := generatedColumnsNameSet(r.targetTableInfo.GetColumns())
+	for i, col := range r.sourceTableInfo.GetColumns() {
+		if _, ok := generatedColumns[col.Name.L]; ok || containsFold(skipColumns, col.Name.O) {
+			continue
+		}
+		switch col.GetType() {
+		case mysql.TypeFloat, mysql.TypeDouble, mysql.TypeJSON, mysql.TypeTiDBVectorFloat32:
+			continue
+		}
+		buf.WriteString(" AND ")
+		buf.WriteString(common.QuoteName(col.Name.O))
+		buf.WriteString(" <=> ?")
+		args = append(args, r.preValues[i])
+	}
+	buf.WriteString(" LIMIT 1")
+	return buf.String(), args
+}
+
+// GenLastWriterWinsUpsertSQL generates the INSERT SQL which only overwrites the existing row
+// if its version column is not newer than the row change, it's like
+// `INSERT INTO t (a,v) VALUES (?,?) ON DUPLICATE KEY UPDATE a=IF(<newer>,VALUES(a),a),v=IF(<newer>,VALUES(v),v)`,
+// where <newer> is `v IS NULL OR v <= VALUES(v)`. If rowAlias is true, the inserted row is referred
+// by the row alias like `INSERT INTO t (a,v) VALUES (?,?) AS new ON DUPLICATE KEY UPDATE a=IF(<newer>,new.a,a),...`
+// instead of the VALUES() function, which is deprecated since MySQL 8.0.20. The version column is
+// assigned at last because the assignments are evaluated from left to right. The row change always
+// overwrites the existing row if the table has no version column.
+func (r *RowChange) GenLastWriterWinsUpsertSQL(versionColumn string, rowAlias bool) (string, []interface{}) {
+	if r.tp == RowChangeDelete {
+		log.L().DPanic("illegal type for GenLastWriterWinsUpsertSQL",
+			zap.String("sourceTable", r.sourceTable.String()),
+			zap.Stringer("changeType", r.tp))
+		return "", nil
+	}
+	columns := r.writableColumns()
+	version := ""
+	for _, col := range columns {
+		if strings.EqualFold(col, versionColumn) {
+			version = col
+			break
+		}
+	}
+	if version == "" {
+		return GenInsertSQL(DMLInsertOnDuplicateUpdate, r)
+	}
+
+	query, args := GenInsertSQL(DMLInsert, r)
+	var buf strings.Builder
+	buf.Grow(len(query) + len(columns)*64)
+	buf.WriteString(query)
+	inserted := func(colName string) string {
+		return "VALUES(" + colName + ")"
+	}
+	if rowAlias {
+		buf.WriteString(" AS new")
+		inserted = func(colName string) string {
+			return "new." + colName
+		}
+	}
+	buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+	quotedVersion := common.QuoteName(version)
+	newer := quotedVersion + " IS NULL OR " + quotedVersion + " <= " + inserted(quotedVersion)
+	for _, col := range columns {
+		if col == version {
+			continue
+		}
+		colName := common.QuoteName(col)
+		buf.WriteString(colName + "=IF(" + newer + "," + inserted(colName) + "," + colName + "),")
+	}
+	buf.WriteString(quotedVersion + "=IF(" + newer + "," + inserted(quotedVersion) + "," + quotedVersion + ")")
+	return buf.String(), args
+}
+
+// GenLastWriterWinsDeleteSQL generates the DELETE SQL which only deletes the row if its
+// version column is not newer than the given version. The row is always deleted if the
+// table has no version column.
+func (r *RowChange) GenLastWriterWinsDeleteSQL(versionColumn string, version interface{}) (string, []interface{}) {
+	query, args := r.genDeleteSQL()
+	if query == "" {
+		return "", nil
+	}
+	for _, col := range r.writableColumns() {
+		if strings.EqualFold(col, versionColumn) {
+			quotedVersion := common.QuoteName(col)
+			query = strings.TrimSuffix(query, " LIMIT 1") +
+				" AND (" + quotedVersion + " IS NULL OR " + quotedVersion + " <= ?) LIMIT 1"
+			return query, append(args, version)
+		}
+	}
+	return query, args
+}
+
+// writableColumns returns the names of the columns which are not generated in the target table.
+func (r *RowChange) writableColumns() []string {
+	generatedColumns := generatedColumnsNameSet(r.targetTableInfo.GetColumns())
+	columns := make([]string, 0, len(r.sourceTableInfo.GetColumns()))
+	for _, col := range r.sourceTableInfo.GetColumns() {
+		if _, ok := generatedColumns[col.Name.L]; ok {
+			continue
+		}
+		columns = append(columns, col.Name.O)
+	}
+	return columns
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlmodel
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"go.uber.org/zap"
+)
+
+// The functions in this file generate the DMLs in the PostgreSQL dialect, the
+// identifiers are quoted by double quotes and the placeholders are numbered,
+// like `$1`, `$2`.
+
+// QuotePostgresName quotes the identifier in the PostgreSQL dialect.
+func QuotePostgresName(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// QuotePostgresTable returns the quoted full table name in the PostgreSQL dialect.
+func QuotePostgresTable(t *common.TableName) string {
+	if t.Schema == "" {
+		return QuotePostgresName(t.Table)
+	}
+	return QuotePostgresName(t.Schema) + "." + QuotePostgresName(t.Table)
+}
+
+// placeholders generates the numbered placeholders of PostgreSQL.
+type placeholders struct {
+	next int
+}
+
+func (p *placeholders) write(buf *strings.Builder) {
+	p.next++
+	buf.WriteByte('$')
+	buf.WriteString(strconv.Itoa(p.next))
+}
+
+// genPostgresWhere generates the WHERE clause to identify the row,
+// the SQL part is written to `buf` and the args part is returned.
+func (r *RowChange) genPostgresWhere(buf *strings.Builder, p *placeholders) []interface{} {
+	whereColumns, whereValues := r.whereColumnsAndValues()
+
+	args := make([]interface{}, 0, len(whereValues))
+	for i, col := range whereColumns {
+		if i != 0 {
+			buf.WriteString(" AND ")
+		}
+		buf.WriteString(QuotePostgresName(col))
+		// `= NULL` never matches, and `IS $1` is not allowed in PostgreSQL.
+		if whereValues[i] == nil {
+			buf.WriteString(" IS NULL")
+			continue
+		}
+		buf.WriteString(" = ")
+		p.write(buf)
+		args = append(args, whereValues[i])
+	}
+	return args
+}
+
+// conflictColumns returns the columns of the PK or NOT NULL UK,
+// which are used as the conflict target of the upsert.
+func (r *RowChange) conflictColumns() []string {
+	r.lazyInitWhereHandle()
+	idx := r.whereHandle.UniqueNotNullIdx
+	if idx == nil {
+		return nil
+	}
+	columns := make([]string, 0, len(idx.Columns))
+	for _, col := range idx.Columns {
+		columns = append(columns, col.Name.O)
+	}
+	return columns
+}
+
+// GenPostgresDeleteSQL generates the DELETE SQL of PostgreSQL and its arguments.
+// Input `changes` should have same target table, otherwise the behaviour is undefined.
+func GenPostgresDeleteSQL(changes ...*RowChange) (string, []interface{}) {
+	if len(changes) == 0 {
+		log.L().DPanic("row changes is empty")
+		return "", nil
+	}
+
+	var (
+		buf strings.Builder
+		p   placeholders
+	)
+	buf.Grow(1024)
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(QuotePostgresTable(changes[0].targetTable))
+	buf.WriteString(" WHERE (")
+
+	allArgs := make([]interface{}, 0, len(changes)*CommonIndexColumnsCount)
+	for i, c := range changes {
+		if i > 0 {
+			buf.WriteString(") OR (")
+		}
+		allArgs = append(allArgs, c.genPostgresWhere(&buf, &p)...)
+	}
+	buf.WriteString(")")
+	return buf.String(), allArgs
+}
+
+// GenPostgresUpdateSQL generates the UPDATE SQL of PostgreSQL and its arguments.
+// PostgreSQL does not support `UPDATE ... LIMIT`, so the row is identified by the
+// WHERE clause only.
+func (r *RowChange) GenPostgresUpdateSQL() (string, []interface{}) {
+	if r.tp != RowChangeUpdate {
+		log.L().DPanic("illegal type for GenPostgresUpdateSQL",
+			zap.String("sourceTable", r.sourceTable.String()),
+			zap.Stringer("changeType", r.tp))
+		return "", nil
+	}
+
+	var (
+		buf strings.Builder
+		p   placeholders
+	)
+	buf.Grow(2048)
+	buf.WriteString("UPDATE ")
+	buf.WriteString(QuotePostgresTable(r.targetTable))
+	buf.WriteString(" SET ")
+
+	generatedColumns := generatedColumnsNameSet(r.targetTableInfo.GetColumns())
+	args := make([]interface{}, 0, len(r.preValues)+len(r.postValues))
+	writtenFirstCol := false
+	for i, col := range r.sourceTableInfo.GetColumns() {
+		if _, ok := generatedColumns[col.Name.L]; ok {
+			continue
+		}
+		if writtenFirstCol {
+			buf.WriteString(", ")
+		}
+		writtenFirstCol = true
+		buf.WriteString(QuotePostgresName(col.Name.O))
+		buf.WriteString(" = ")
+		p.write(&buf)
+		args = append(args, r.postValues[i])
+	}
+
+	buf.WriteString(" WHERE ")
+	args = append(args, r.genPostgresWhere(&buf, &p)...)
+	return buf.String(), args
+}
+
+// GenPostgresInsertSQL generates the INSERT SQL of PostgreSQL and its arguments.
+// If upsert is true, `ON CONFLICT (...) DO UPDATE` is appended to overwrite the
+// existing row which has the same PK or NOT NULL UK, it's the counterpart of
+// the REPLACE of MySQL. The upsert falls back to a normal INSERT if the table
+// has no PK or NOT NULL UK.
+// Input `changes` should have same target table and same modifiable columns,
+// otherwise the behaviour is undefined.
+func GenPostgresInsertSQL(upsert bool, changes ...*RowChange) (string, []interface{}) {
+	if len(changes) == 0 {
+		log.L().DPanic("row changes is empty")
+		return "", nil
+	}
+
+	first := changes[0]
+
+	var (
+		buf strings.Builder
+		p   placeholders
+	)
+	buf.Grow(1024)
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(QuotePostgresTable(first.targetTable))
+	buf.WriteString(" (")
+
+	generatedColumns := generatedColumnsNameSet(first.targetTableInfo.GetColumns())
+	columns := make([]string, 0, len(first.sourceTableInfo.GetColumns()))
+	var skipColIdx []int
+	for i, col := range first.sourceTableInfo.GetColumns() {
+		if _, ok := generatedColumns[col.Name.L]; ok {
+			skipColIdx = append(skipColIdx, i)
+			continue
+		}
+		if len(columns) != 0 {
+			buf.WriteByte(',')
+		}
+		columns = append(columns, col.Name.O)
+		buf.WriteString(QuotePostgresName(col.Name.O))
+	}
+	buf.WriteString(") VALUES ")
+
+	args := make([]interface{}, 0, len(changes)*len(columns))
+	for i, change := range changes {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('(')
+		skip := 0 // used as index of skipColIdx
+		written := 0
+		for j, val := range change.postValues {
+			if skip < len(skipColIdx) && skipColIdx[skip] == j {
+				skip++
+				continue
+			}
+			if written > 0 {
+				buf.WriteByte(',')
+			}
+			written++
+			p.write(&buf)
+			args = append(args, val)
+		}
+		buf.WriteByte(')')
+	}
+
+	if !upsert {
+		return buf.String(), args
+	}
+	conflictColumns := first.conflictColumns()
+	if len(conflictColumns) == 0 {
+		return buf.String(), args
+	}
+	buf.WriteString(" ON CONFLICT (")
+	isConflictColumn := make(map[string]struct{}, len(conflictColumns))
+	for i, col := range conflictColumns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		isConflictColumn[col] = struct{}{}
+		buf.WriteString(QuotePostgresName(col))
+	}
+	buf.WriteString(") DO ")
+	writtenFirstCol := false
+	for _, col := range columns {
+		if _, ok := isConflictColumn[col]; ok {
+			continue
+		}
+		if writtenFirstCol {
+			buf.WriteByte(',')
+		} else {
+			buf.WriteString("UPDATE SET ")
+		}
+		writtenFirstCol = true
+		colName := QuotePostgresName(col)
+		buf.WriteString(colName + "=EXCLUDED." + colName)
+	}
+	// all the columns are the conflict columns, there is nothing to update.
+	if !writtenFirstCol {
+		buf.WriteString("NOTHING")
+	}
+	return buf.String(), args
+}
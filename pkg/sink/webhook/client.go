@@ -0,0 +1,122 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+const (
+	// ContentType is the content type of the request body,
+	// each line of the body is an encoded message.
+	ContentType = "application/x-ndjson"
+	// SignatureHeader carries the HMAC-SHA256 signature of the request body,
+	// the value is like `sha256=<hex encoded signature>`.
+	SignatureHeader = "X-TiCDC-Signature"
+	// signaturePrefix is the prefix of the signature header value.
+	signaturePrefix = "sha256="
+)
+
+// Client posts the messages to the webhook endpoint.
+type Client struct {
+	cfg        *Config
+	httpClient *http.Client
+}
+
+// NewClient creates a client by the given config.
+func NewClient(cfg *Config) *Client {
+	return &Client{
+		cfg: cfg,
+		httpClient: &http.Client{
+			Timeout: cfg.RequestTimeout,
+		},
+	}
+}
+
+// Post posts the messages by one request, the messages are delimited by the newline.
+// The request is succeeded only if the endpoint returns a 2xx status code.
+// ErrWebhookRequestRejected is returned if the status code is 4xx other than 408 and 429,
+// it can not be fixed by retrying, otherwise ErrWebhookRequestFailed is returned.
+func (c *Client) Post(ctx context.Context, messages [][]byte) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	body := encodeBody(messages)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookRequestFailed, err)
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if c.cfg.HMACSecret != "" {
+		req.Header.Set(SignatureHeader, signaturePrefix+Sign([]byte(c.cfg.HMACSecret), body))
+	}
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookRequestFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		// drain the body to reuse the connection.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("POST %s returns status %d: %s", c.cfg.Endpoint, resp.StatusCode, message)
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return cerror.ErrWebhookRequestRejected.GenWithStackByArgs(err.Error())
+	}
+	return cerror.WrapError(cerror.ErrWebhookRequestFailed, err)
+}
+
+func encodeBody(messages [][]byte) []byte {
+	size := 0
+	for _, m := range messages {
+		size += len(m) + 1
+	}
+	body := make([]byte, 0, size)
+	for _, m := range messages {
+		body = append(body, m...)
+		body = append(body, '\n')
+	}
+	return body
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the body,
+// the receiver can verify the request by the same secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsRetryableError returns true if the request can be retried.
+func IsRetryableError(err error) bool {
+	if !cerror.IsRetryableError(err) {
+		return false
+	}
+	return !cerror.ErrWebhookRequestRejected.Equal(err)
+}
@@ -0,0 +1,612 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package s3mock provides an in-process S3 compatible server backed by the local filesystem,
+// it's used by the unit tests to run the s3:// code paths end-to-end.
+package s3mock
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// The operations handled by the server, they are used to inject faults and count requests.
+const (
+	OpHeadBucket              = "HeadBucket"
+	OpListObjects             = "ListObjects"
+	OpDeleteObjects           = "DeleteObjects"
+	OpPutObject               = "PutObject"
+	OpGetObject               = "GetObject"
+	OpHeadObject              = "HeadObject"
+	OpDeleteObject            = "DeleteObject"
+	OpCreateMultipartUpload   = "CreateMultipartUpload"
+	OpUploadPart              = "UploadPart"
+	OpCompleteMultipartUpload = "CompleteMultipartUpload"
+	OpAbortMultipartUpload    = "AbortMultipartUpload"
+)
+
+const (
+	// Region is the region of all buckets.
+	Region = "us-east-1"
+
+	accessKey = "s3mock-access-key"
+	secretKey = "s3mock-secret-key"
+
+	uploadsDir = ".uploads"
+	xmlns      = "http://s3.amazonaws.com/doc/2006-03-01/"
+)
+
+type fault struct {
+	statusCode int
+	code       string
+	times      int
+}
+
+// Server is an S3 compatible server, each bucket is a directory under the root,
+// and each object is a file in the bucket directory named by the escaped key.
+type Server struct {
+	root   string
+	server *httptest.Server
+
+	mu       sync.Mutex
+	faults   map[string][]*fault
+	requests map[string]int
+	uploadID int
+}
+
+// NewServer starts a new server whose data is stored in a temporary directory,
+// the server is closed when the test finishes.
+func NewServer(t testing.TB, buckets ...string) *Server {
+	s := &Server{
+		root:     t.TempDir(),
+		faults:   make(map[string][]*fault),
+		requests: make(map[string]int),
+	}
+	for _, bucket := range buckets {
+		s.CreateBucket(t, bucket)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// Endpoint returns the http endpoint of the server.
+func (s *Server) Endpoint() string {
+	return s.server.URL
+}
+
+// URI returns the s3:// uri of the prefix in the bucket, which can be parsed by `storage.ParseBackend`.
+// More query parameters can be appended to the uri by `&`.
+func (s *Server) URI(bucket, prefix string) string {
+	return fmt.Sprintf("s3://%s/%s?endpoint=%s&access-key=%s&secret-access-key=%s&region=%s&force-path-style=true",
+		bucket, prefix, url.QueryEscape(s.server.URL), accessKey, secretKey, Region)
+}
+
+// CreateBucket creates a bucket.
+func (s *Server) CreateBucket(t testing.TB, bucket string) {
+	if err := os.MkdirAll(filepath.Join(s.root, bucket, uploadsDir), 0o755); err != nil {
+		t.Fatalf("create bucket %s failed: %v", bucket, err)
+	}
+}
+
+// InjectFault makes the next `times` requests of the operation fail with the status code and the error code.
+func (s *Server) InjectFault(op string, statusCode int, code string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[op] = append(s.faults[op], &fault{statusCode: statusCode, code: code, times: times})
+}
+
+// InjectInternalError makes the next `times` requests of the operation fail with 500 InternalError.
+func (s *Server) InjectInternalError(op string, times int) {
+	s.InjectFault(op, http.StatusInternalServerError, "InternalError", times)
+}
+
+// InjectThrottling makes the next `times` requests of the operation fail with 503 SlowDown.
+func (s *Server) InjectThrottling(op string, times int) {
+	s.InjectFault(op, http.StatusServiceUnavailable, "SlowDown", times)
+}
+
+// RequestCount returns the number of the requests of the operation, including the failed ones.
+func (s *Server) RequestCount(op string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[op]
+}
+
+// Object returns the content of the object, the second return value is false if it does not exist.
+func (s *Server) Object(bucket, key string) ([]byte, bool) {
+	data, err := os.ReadFile(s.objectPath(bucket, key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Keys returns the sorted keys of all objects in the bucket.
+func (s *Server) Keys(bucket string) []string {
+	keys, _ := s.listKeys(bucket)
+	return keys
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+	op := operation(r.Method, key, query)
+	if op == "" {
+		writeError(w, r, http.StatusNotImplemented, "NotImplemented", "unsupported request "+r.Method+" "+r.URL.String())
+		return
+	}
+	if f := s.onRequest(op); f != nil {
+		log.Info("s3mock: inject fault", zap.String("operation", op),
+			zap.Int("statusCode", f.statusCode), zap.String("code", f.code))
+		// drain the body, so the client will not get the broken pipe error.
+		_, _ = io.Copy(io.Discard, r.Body)
+		writeError(w, r, f.statusCode, f.code, "injected fault")
+		return
+	}
+	if _, err := os.Stat(filepath.Join(s.root, bucket)); bucket == "" || err != nil {
+		writeError(w, r, http.StatusNotFound, "NoSuchBucket", "the specified bucket does not exist")
+		return
+	}
+
+	switch op {
+	case OpHeadBucket:
+		w.Header().Set("X-Amz-Bucket-Region", Region)
+		w.WriteHeader(http.StatusOK)
+	case OpListObjects:
+		s.listObjects(w, r, bucket, query)
+	case OpDeleteObjects:
+		s.deleteObjects(w, r, bucket)
+	case OpPutObject:
+		s.putObject(w, r, bucket, key)
+	case OpGetObject, OpHeadObject:
+		s.getObject(w, r, bucket, key)
+	case OpDeleteObject:
+		if err := os.Remove(s.objectPath(bucket, key)); err != nil && !os.IsNotExist(err) {
+			writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case OpCreateMultipartUpload:
+		s.createMultipartUpload(w, r, bucket, key)
+	case OpUploadPart:
+		s.uploadPart(w, r, bucket, query)
+	case OpCompleteMultipartUpload:
+		s.completeMultipartUpload(w, r, bucket, key, query.Get("uploadId"))
+	case OpAbortMultipartUpload:
+		if err := os.RemoveAll(s.uploadPath(bucket, query.Get("uploadId"))); err != nil {
+			writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// operation returns the S3 operation of the request, an empty string is returned if it's not supported.
+func operation(method, key string, query url.Values) string {
+	if key == "" {
+		switch {
+		case method == http.MethodHead:
+			return OpHeadBucket
+		case method == http.MethodGet:
+			return OpListObjects
+		case method == http.MethodPost && query.Has("delete"):
+			return OpDeleteObjects
+		}
+		return ""
+	}
+	switch method {
+	case http.MethodPut:
+		if query.Has("uploadId") {
+			return OpUploadPart
+		}
+		return OpPutObject
+	case http.MethodGet:
+		return OpGetObject
+	case http.MethodHead:
+		return OpHeadObject
+	case http.MethodDelete:
+		if query.Has("uploadId") {
+			return OpAbortMultipartUpload
+		}
+		return OpDeleteObject
+	case http.MethodPost:
+		if query.Has("uploads") {
+			return OpCreateMultipartUpload
+		}
+		if query.Has("uploadId") {
+			return OpCompleteMultipartUpload
+		}
+	}
+	return ""
+}
+
+// onRequest counts the request and returns the fault to inject if any.
+func (s *Server) onRequest(op string) *fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[op]++
+	faults := s.faults[op]
+	if len(faults) == 0 {
+		return nil
+	}
+	f := faults[0]
+	f.times--
+	if f.times <= 0 {
+		s.faults[op] = faults[1:]
+	}
+	return f
+}
+
+func (s *Server) objectPath(bucket, key string) string {
+	return filepath.Join(s.root, bucket, url.QueryEscape(key))
+}
+
+func (s *Server) uploadPath(bucket, uploadID string) string {
+	return filepath.Join(s.root, bucket, uploadsDir, filepath.Base(uploadID))
+}
+
+func (s *Server) listKeys(bucket string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.root, bucket))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		key, err := url.QueryUnescape(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+type listObject struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+type listBucketResult struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Name        string
+	Prefix      string
+	Marker      string
+	MaxKeys     int
+	IsTruncated bool
+	Contents    []listObject
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request, bucket string, query url.Values) {
+	keys, err := s.listKeys(bucket)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	maxKeys := 1000
+	if v := query.Get("max-keys"); v != "" {
+		if maxKeys, err = strconv.Atoi(v); err != nil || maxKeys <= 0 {
+			writeError(w, r, http.StatusBadRequest, "InvalidArgument", "invalid max-keys "+v)
+			return
+		}
+	}
+	result := &listBucketResult{
+		Xmlns:   xmlns,
+		Name:    bucket,
+		Prefix:  query.Get("prefix"),
+		Marker:  query.Get("marker"),
+		MaxKeys: maxKeys,
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, result.Prefix) || key <= result.Marker {
+			continue
+		}
+		if len(result.Contents) == maxKeys {
+			result.IsTruncated = true
+			break
+		}
+		info, err := os.Stat(s.objectPath(bucket, key))
+		if err != nil {
+			// the object may be deleted concurrently.
+			continue
+		}
+		result.Contents = append(result.Contents, listObject{
+			Key:          key,
+			LastModified: info.ModTime().UTC().Format(time.RFC3339),
+			Size:         info.Size(),
+			StorageClass: "STANDARD",
+		})
+	}
+	writeXML(w, r, result)
+}
+
+type deleteRequest struct {
+	Objects []struct {
+		Key string
+	} `xml:"Object"`
+}
+
+type deletedObject struct {
+	Key string
+}
+
+type deleteResult struct {
+	XMLName xml.Name `xml:"DeleteResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Deleted []deletedObject
+}
+
+func (s *Server) deleteObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	var req deleteRequest
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "MalformedXML", err.Error())
+		return
+	}
+	result := &deleteResult{Xmlns: xmlns}
+	for _, object := range req.Objects {
+		if err := os.Remove(s.objectPath(bucket, object.Key)); err != nil && !os.IsNotExist(err) {
+			writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+			return
+		}
+		result.Deleted = append(result.Deleted, deletedObject{Key: object.Key})
+	}
+	writeXML(w, r, result)
+}
+
+func (s *Server) putObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if err := writeFileAtomic(s.objectPath(bucket, key), data); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	w.Header().Set("ETag", etag(data))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	data, err := os.ReadFile(s.objectPath(bucket, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			writeError(w, r, http.StatusNotFound, "NoSuchKey", "the specified key does not exist")
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	w.Header().Set("ETag", etag(data))
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	rangeHeader := r.Header.Get("Range")
+	if rangeHeader == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+		return
+	}
+	start, end, ok := parseRange(rangeHeader, int64(len(data)))
+	if !ok {
+		writeError(w, r, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "invalid range "+rangeHeader)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(end-start+1, 10))
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+	w.WriteHeader(http.StatusPartialContent)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(data[start : end+1])
+	}
+}
+
+// parseRange parses the `bytes=start-end` or `bytes=start-` range, the end is inclusive.
+func parseRange(header string, size int64) (int64, int64, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok {
+		return 0, 0, false
+	}
+	startStr, endStr, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start >= size {
+		return 0, 0, false
+	}
+	end := size - 1
+	if endStr != "" {
+		if end, err = strconv.ParseInt(endStr, 10, 64); err != nil || end < start {
+			return 0, 0, false
+		}
+		end = min(end, size-1)
+	}
+	return start, end, true
+}
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string
+	Key      string
+	UploadId string
+}
+
+func (s *Server) createMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	s.mu.Lock()
+	s.uploadID++
+	uploadID := strconv.Itoa(s.uploadID)
+	s.mu.Unlock()
+	if err := os.MkdirAll(s.uploadPath(bucket, uploadID), 0o755); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	writeXML(w, r, &initiateMultipartUploadResult{
+		Xmlns:    xmlns,
+		Bucket:   bucket,
+		Key:      key,
+		UploadId: uploadID,
+	})
+}
+
+func (s *Server) uploadPart(w http.ResponseWriter, r *http.Request, bucket string, query url.Values) {
+	dir := s.uploadPath(bucket, query.Get("uploadId"))
+	if _, err := os.Stat(dir); err != nil {
+		writeError(w, r, http.StatusNotFound, "NoSuchUpload", "the specified upload does not exist")
+		return
+	}
+	partNumber, err := strconv.Atoi(query.Get("partNumber"))
+	if err != nil || partNumber <= 0 {
+		writeError(w, r, http.StatusBadRequest, "InvalidArgument", "invalid part number "+query.Get("partNumber"))
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if err := writeFileAtomic(filepath.Join(dir, strconv.Itoa(partNumber)), data); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	w.Header().Set("ETag", etag(data))
+	w.WriteHeader(http.StatusOK)
+}
+
+type completeMultipartUploadRequest struct {
+	Parts []struct {
+		PartNumber int
+		ETag       string
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Bucket  string
+	Key     string
+	ETag    string
+}
+
+func (s *Server) completeMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key, uploadID string) {
+	var req completeMultipartUploadRequest
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "MalformedXML", err.Error())
+		return
+	}
+	dir := s.uploadPath(bucket, uploadID)
+	var data []byte
+	for i, part := range req.Parts {
+		if i > 0 && part.PartNumber <= req.Parts[i-1].PartNumber {
+			writeError(w, r, http.StatusBadRequest, "InvalidPartOrder", "the parts are not in ascending order")
+			return
+		}
+		content, err := os.ReadFile(filepath.Join(dir, strconv.Itoa(part.PartNumber)))
+		if err != nil || etag(content) != part.ETag {
+			writeError(w, r, http.StatusBadRequest, "InvalidPart", fmt.Sprintf("part %d not found", part.PartNumber))
+			return
+		}
+		data = append(data, content...)
+	}
+	if err := writeFileAtomic(s.objectPath(bucket, key), data); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	writeXML(w, r, &completeMultipartUploadResult{
+		Xmlns:  xmlns,
+		Bucket: bucket,
+		Key:    key,
+		ETag:   etag(data),
+	})
+}
+
+type errorResponse struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string
+	Message   string
+	Resource  string
+	RequestId string
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(statusCode)
+	// the response of the HEAD request has no body.
+	if r.Method == http.MethodHead {
+		return
+	}
+	_ = xml.NewEncoder(w).Encode(&errorResponse{
+		Code:      code,
+		Message:   message,
+		Resource:  r.URL.Path,
+		RequestId: "s3mock",
+	})
+}
+
+func writeXML(w http.ResponseWriter, r *http.Request, v interface{}) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
+
+// writeFileAtomic writes the file by renaming a temporary file,
+// so the readers never observe a partially written object.
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
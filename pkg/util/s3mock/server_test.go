@@ -0,0 +1,135 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3mock
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectOperations(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(t, "bucket")
+
+	store, err := util.GetExternalStorageWithDefaultTimeout(ctx, s.URI("bucket", "prefix"))
+	require.NoError(t, err)
+	defer store.Close()
+	require.Equal(t, 1, s.RequestCount(OpHeadObject))
+
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("dir/file-%d", i)
+		require.NoError(t, store.WriteFile(ctx, name, []byte(name)))
+	}
+	data, ok := s.Object("bucket", "prefix/dir/file-1")
+	require.True(t, ok)
+	require.Equal(t, "dir/file-1", string(data))
+
+	data, err = store.ReadFile(ctx, "dir/file-2")
+	require.NoError(t, err)
+	require.Equal(t, "dir/file-2", string(data))
+	exists, err := store.FileExists(ctx, "dir/file-5")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// seeking reopens the object with the range request.
+	reader, err := store.Open(ctx, "dir/file-3", nil)
+	require.NoError(t, err)
+	_, err = reader.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	data, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, "file-3", string(data))
+	require.Equal(t, 2, s.RequestCount(OpGetObject))
+
+	// list the objects by pages.
+	var names []string
+	err = store.WalkDir(ctx, &storage.WalkOption{SubDir: "dir", ListCount: 2}, func(path string, size int64) error {
+		require.Equal(t, int64(len(path)), size)
+		names = append(names, path)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"dir/file-0", "dir/file-1", "dir/file-2", "dir/file-3", "dir/file-4"}, names)
+	require.Equal(t, 3, s.RequestCount(OpListObjects))
+
+	require.NoError(t, store.DeleteFile(ctx, "dir/file-0"))
+	require.NoError(t, store.DeleteFiles(ctx, []string{"dir/file-1", "dir/file-2"}))
+	require.Equal(t, []string{"prefix/dir/file-3", "prefix/dir/file-4"}, s.Keys("bucket"))
+}
+
+func TestMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(t, "bucket")
+
+	store, err := util.GetExternalStorageWithDefaultTimeout(ctx, s.URI("bucket", ""))
+	require.NoError(t, err)
+	defer store.Close()
+
+	data := bytes.Repeat([]byte("0123456789"), 1<<20)
+	for _, concurrency := range []int{1, 2} {
+		name := fmt.Sprintf("file-%d", concurrency)
+		writer, err := store.Create(ctx, name, &storage.WriterOption{Concurrency: concurrency})
+		require.NoError(t, err)
+		_, err = writer.Write(ctx, data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close(ctx))
+
+		content, ok := s.Object("bucket", name)
+		require.True(t, ok)
+		require.Equal(t, data, content)
+	}
+	require.Equal(t, 2, s.RequestCount(OpCreateMultipartUpload))
+	require.Equal(t, 2, s.RequestCount(OpCompleteMultipartUpload))
+	require.Greater(t, s.RequestCount(OpUploadPart), 2)
+}
+
+func TestInjectFault(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(t, "bucket")
+
+	// the faults of HeadBucket and HeadObject are retried when the storage is created.
+	s.InjectInternalError(OpHeadBucket, 1)
+	s.InjectThrottling(OpHeadObject, 1)
+	store, err := util.GetExternalStorageWithDefaultTimeout(ctx, s.URI("bucket", ""))
+	require.NoError(t, err)
+	defer store.Close()
+	require.Equal(t, 2, s.RequestCount(OpHeadObject))
+
+	s.InjectThrottling(OpPutObject, 1)
+	s.InjectInternalError(OpPutObject, 1)
+	require.NoError(t, store.WriteFile(ctx, "file", []byte("data")))
+	require.Equal(t, 3, s.RequestCount(OpPutObject))
+	data, ok := s.Object("bucket", "file")
+	require.True(t, ok)
+	require.Equal(t, "data", string(data))
+
+	// the client errors are not retried.
+	s.InjectFault(OpGetObject, 403, "AccessDenied", 1)
+	_, err = store.ReadFile(ctx, "file")
+	require.ErrorContains(t, err, "AccessDenied")
+	data, err = store.ReadFile(ctx, "file")
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+
+	_, err = util.GetExternalStorageWithDefaultTimeout(ctx, s.URI("missing", ""))
+	require.ErrorContains(t, err, "status code: 404")
+}
@@ -74,7 +74,7 @@ kafka_groups=(
 	# G03
 	'canal_json_adapter_compatibility ddl_for_split_tables_with_merge_and_split'
 	# G04
-	'open_protocol_claim_check open_protocol_handle_key_only random_drop_message'
+	'open_protocol_claim_check open_protocol_handle_key_only random_drop_message maxwell_basic'
 	# G05
 	'move_table drop_many_tables checkpoint_race_ddl_crash'
 	# G06
@@ -110,7 +110,7 @@ pulsar_groups=(
 	# G03
 	'canal_json_adapter_compatibility ddl_for_split_tables_with_merge_and_split'
 	# G04
-	'open_protocol_claim_check open_protocol_handle_key_only'
+	'open_protocol_claim_check open_protocol_handle_key_only maxwell_basic'
 	# G05
 	'move_table drop_many_tables checkpoint_race_ddl_crash'
 	# G06